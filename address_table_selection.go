@@ -0,0 +1,180 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package solana
+
+import (
+	"fmt"
+
+	bin "github.com/gagliardetto/binary"
+)
+
+// maxExhaustiveAddressTables is the maximum number of candidate tables for
+// which every subset is tried; above it, tables are picked greedily.
+const maxExhaustiveAddressTables = 10
+
+// AddressTableSelection reports the address lookup tables picked by
+// NewTransactionWithAddressTablePool.
+type AddressTableSelection struct {
+	// Tables are the picked tables, a subset of the pool.
+	Tables map[PublicKey]PublicKeySlice
+
+	// Size is the size of the serialized transaction, signatures included.
+	Size int
+}
+
+// NewTransactionWithAddressTablePool creates a v0 transaction like
+// NewTransaction, picking from the pool the address lookup tables that make
+// the transaction the smallest. Signers and program IDs always stay static
+// accounts. Any TransactionAddressTables option is ignored.
+//
+// A table only pays off if it replaces at least two static accounts: every
+// looked up account saves 31 bytes, but every table used costs 34 bytes.
+// When more than a few tables qualify, they are picked greedily, adding the
+// table that shrinks the transaction the most until none does.
+func NewTransactionWithAddressTablePool(
+	instructions []Instruction,
+	recentBlockHash Hash,
+	pool map[PublicKey]PublicKeySlice,
+	opts ...TransactionOption,
+) (*Transaction, *AddressTableSelection, error) {
+	if len(instructions) == 0 {
+		return nil, nil, fmt.Errorf("requires at-least one instruction to create a transaction")
+	}
+	options := transactionOptions{}
+	for _, opt := range opts {
+		opt.apply(&options)
+	}
+
+	compile := func(tables map[PublicKey]PublicKeySlice) (*Transaction, int, error) {
+		txOpts := append(append([]TransactionOption(nil), opts...), TransactionAddressTables(tables))
+		tx, err := NewTransaction(instructions, recentBlockHash, txOpts...)
+		if err != nil {
+			return nil, 0, err
+		}
+		tx.Message.SetVersion(MessageVersionV0)
+		size, err := serializedTransactionSize(&tx.Message)
+		if err != nil {
+			return nil, 0, err
+		}
+		return tx, size, nil
+	}
+
+	candidates := addressTableCandidates(instructions, options.payer, pool)
+	subset := func(picked []PublicKey) map[PublicKey]PublicKeySlice {
+		tables := make(map[PublicKey]PublicKeySlice, len(picked))
+		for _, key := range picked {
+			tables[key] = pool[key]
+		}
+		return tables
+	}
+
+	best, bestSize, err := compile(nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	var bestPicked []PublicKey
+
+	if len(candidates) <= maxExhaustiveAddressTables {
+		for mask := 1; mask < 1<<len(candidates); mask++ {
+			var picked []PublicKey
+			for i, key := range candidates {
+				if mask&(1<<i) != 0 {
+					picked = append(picked, key)
+				}
+			}
+			tx, size, err := compile(subset(picked))
+			if err != nil {
+				continue
+			}
+			if size < bestSize || (size == bestSize && len(picked) < len(bestPicked)) {
+				best, bestSize, bestPicked = tx, size, picked
+			}
+		}
+	} else {
+		remaining := append([]PublicKey(nil), candidates...)
+		for len(remaining) > 0 {
+			improved := -1
+			for i, key := range remaining {
+				picked := append(append([]PublicKey(nil), bestPicked...), key)
+				tx, size, err := compile(subset(picked))
+				if err != nil || size >= bestSize {
+					continue
+				}
+				best, bestSize, improved = tx, size, i
+			}
+			if improved < 0 {
+				break
+			}
+			bestPicked = append(bestPicked, remaining[improved])
+			remaining = append(remaining[:improved], remaining[improved+1:]...)
+		}
+	}
+
+	return best, &AddressTableSelection{
+		Tables: subset(bestPicked),
+		Size:   bestSize,
+	}, nil
+}
+
+// addressTableCandidates returns, sorted, the tables of the pool that can
+// replace at least two static accounts of the instructions.
+func addressTableCandidates(instructions []Instruction, payer PublicKey, pool map[PublicKey]PublicKeySlice) PublicKeySlice {
+	eligible := make(map[PublicKey]bool)
+	for _, instruction := range instructions {
+		for _, account := range instruction.Accounts() {
+			if _, ok := eligible[account.PublicKey]; !ok {
+				eligible[account.PublicKey] = true
+			}
+			if account.IsSigner {
+				eligible[account.PublicKey] = false
+			}
+		}
+	}
+	for _, instruction := range instructions {
+		eligible[instruction.ProgramID()] = false
+	}
+	eligible[payer] = false
+
+	candidates := make(PublicKeySlice, 0, len(pool))
+	for tableKey, addresses := range pool {
+		if len(addresses) > 256 {
+			continue
+		}
+		found := make(map[PublicKey]struct{})
+		for _, address := range addresses {
+			if eligible[address] {
+				found[address] = struct{}{}
+			}
+		}
+		if len(found) >= 2 {
+			candidates = append(candidates, tableKey)
+		}
+	}
+	candidates.Sort()
+	return candidates
+}
+
+// serializedTransactionSize returns the size of the transaction
+// of the message, signatures included.
+func serializedTransactionSize(message *Message) (int, error) {
+	data, err := message.MarshalBinary()
+	if err != nil {
+		return 0, err
+	}
+	numSignatures := int(message.Header.NumRequiredSignatures)
+	var signatureCount []byte
+	bin.EncodeCompactU16Length(&signatureCount, numSignatures)
+	return len(signatureCount) + numSignatures*SignatureLength + len(data), nil
+}
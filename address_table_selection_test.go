@@ -0,0 +1,112 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package solana
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTestKeys(n int) PublicKeySlice {
+	keys := make(PublicKeySlice, n)
+	for i := range keys {
+		keys[i] = NewWallet().PublicKey()
+	}
+	return keys
+}
+
+func TestNewTransactionWithAddressTablePool(t *testing.T) {
+	payer := NewWallet().PublicKey()
+	programID := NewWallet().PublicKey()
+	accounts := newTestKeys(30)
+
+	metas := AccountMetaSlice{Meta(payer).WRITE().SIGNER()}
+	for i, account := range accounts {
+		if i%2 == 0 {
+			metas = append(metas, Meta(account).WRITE())
+		} else {
+			metas = append(metas, Meta(account))
+		}
+	}
+	instructions := []Instruction{NewInstruction(programID, metas, []byte{1, 2, 3})}
+
+	tableA := NewWallet().PublicKey()
+	tableB := NewWallet().PublicKey()
+	tableSmall := NewWallet().PublicKey()
+	tableSigners := NewWallet().PublicKey()
+	tableUnrelated := NewWallet().PublicKey()
+	pool := map[PublicKey]PublicKeySlice{
+		tableA:         append(newTestKeys(5), accounts[:20]...),
+		tableB:         accounts[18:],
+		tableSmall:     PublicKeySlice{accounts[25]},
+		tableSigners:   PublicKeySlice{payer, programID, accounts[29]},
+		tableUnrelated: newTestKeys(40),
+	}
+
+	tx, selection, err := NewTransactionWithAddressTablePool(instructions, Hash{}, pool, TransactionPayer(payer))
+	require.NoError(t, err)
+	require.Len(t, selection.Tables, 2)
+	require.Contains(t, selection.Tables, tableA)
+	require.Contains(t, selection.Tables, tableB)
+
+	require.Equal(t, MessageVersionV0, tx.Message.GetVersion())
+	require.Equal(t, PublicKeySlice{payer, programID}, PublicKeySlice(tx.Message.AccountKeys))
+	require.Equal(t, len(accounts), tx.Message.NumLookups())
+
+	data, err := tx.MarshalBinary()
+	require.NoError(t, err)
+	require.Equal(t, len(data)+SignatureLength, selection.Size)
+
+	plain, err := NewTransaction(instructions, Hash{}, TransactionPayer(payer))
+	require.NoError(t, err)
+	plain.Message.SetVersion(MessageVersionV0)
+	plainSize, err := serializedTransactionSize(&plain.Message)
+	require.NoError(t, err)
+	require.Less(t, selection.Size, plainSize)
+}
+
+func TestNewTransactionWithAddressTablePoolGreedy(t *testing.T) {
+	payer := NewWallet().PublicKey()
+	programID := NewWallet().PublicKey()
+	accounts := newTestKeys(36)
+
+	metas := AccountMetaSlice{Meta(payer).WRITE().SIGNER()}
+	for _, account := range accounts {
+		metas = append(metas, Meta(account).WRITE())
+	}
+	instructions := []Instruction{NewInstruction(programID, metas, nil)}
+
+	// 12 tables of 3 accounts each, and one table with all of them.
+	pool := make(map[PublicKey]PublicKeySlice)
+	for i := 0; i < 12; i++ {
+		pool[NewWallet().PublicKey()] = accounts[i*3 : i*3+3]
+	}
+	all := NewWallet().PublicKey()
+	pool[all] = accounts
+
+	tx, selection, err := NewTransactionWithAddressTablePool(instructions, Hash{}, pool, TransactionPayer(payer))
+	require.NoError(t, err)
+	require.Len(t, selection.Tables, 1)
+	require.Contains(t, selection.Tables, all)
+	require.Len(t, tx.Message.AddressTableLookups, 1)
+
+	// Nothing to look up: the transaction is still v0, without lookups.
+	tx, selection, err = NewTransactionWithAddressTablePool(instructions, Hash{}, nil, TransactionPayer(payer))
+	require.NoError(t, err)
+	require.Empty(t, selection.Tables)
+	require.Equal(t, MessageVersionV0, tx.Message.GetVersion())
+	require.Empty(t, tx.Message.AddressTableLookups)
+}
@@ -0,0 +1,140 @@
+// Copyright 2021 github.com/gagliardetto
+// This file has been modified by github.com/gagliardetto
+//
+// Copyright 2020 dfuse Platform Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"time"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// keygenGrindCmd represents the grind command
+var keygenGrindCmd = &cobra.Command{
+	Use:   "grind",
+	Short: "Grind for vanity keypairs",
+	Long: `Grind for vanity keypairs.
+
+Keys are generated until their public key matches one of the patterns, and
+each match is written as a solana-keygen compatible file named after its
+public key:
+
+    slnc keygen grind --starts-with Sun --ends-with xyz --ignore-case --count 2
+
+Every extra character makes the search about 58 times longer.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ignoreCase := viper.GetBool("keygen-grind-cmd-ignore-case")
+
+		var patterns []solana.GrindPattern
+		addPatterns := func(match solana.GrindMatch, values []string) {
+			for _, value := range values {
+				patterns = append(patterns, solana.GrindPattern{Match: match, Value: value, IgnoreCase: ignoreCase})
+			}
+		}
+		addPatterns(solana.GrindPrefix, viper.GetStringSlice("keygen-grind-cmd-starts-with"))
+		addPatterns(solana.GrindSuffix, viper.GetStringSlice("keygen-grind-cmd-ends-with"))
+		addPatterns(solana.GrindContains, viper.GetStringSlice("keygen-grind-cmd-contains"))
+		if len(patterns) == 0 {
+			return fmt.Errorf("specify at least one of --starts-with, --ends-with or --contains")
+		}
+		for _, pattern := range patterns {
+			if err := pattern.Validate(); err != nil {
+				return err
+			}
+		}
+
+		count := viper.GetInt("keygen-grind-cmd-count")
+		outDir := viper.GetString("keygen-grind-cmd-outdir")
+		opts := &solana.GrindOptions{
+			Workers: viper.GetInt("keygen-grind-cmd-workers"),
+		}
+		if !viper.GetBool("keygen-grind-cmd-quiet") {
+			opts.Progress = func(progress solana.GrindProgress) {
+				fmt.Fprintf(os.Stderr, "\r%d attempts, %.0f/s, ETA %s   ", progress.Attempts, progress.AttemptsPerSecond, formatGrindETA(progress.ETA))
+			}
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+
+		fmt.Fprintf(os.Stderr, "Searching for %d key(s), about %.0f attempts each.\n", count, solana.ExpectedGrindAttempts(patterns...))
+		for i := 0; i < count; i++ {
+			key, err := solana.GrindPrivateKey(ctx, patterns, opts)
+			if opts.Progress != nil {
+				fmt.Fprintln(os.Stderr)
+			}
+			if err != nil {
+				return fmt.Errorf("grind: %w", err)
+			}
+
+			filename := filepath.Join(outDir, key.PublicKey().String()+".json")
+			if err := writeKeygenFile(filename, key); err != nil {
+				return err
+			}
+			fmt.Printf("Wrote keypair %s to %s\n", key.PublicKey(), filename)
+		}
+		return nil
+	},
+}
+
+func formatGrindETA(eta time.Duration) string {
+	if eta <= 0 {
+		return "unknown"
+	}
+	if eta > 365*24*time.Hour {
+		return fmt.Sprintf("%.0f years", eta.Hours()/(365*24))
+	}
+	return eta.Round(time.Second).String()
+}
+
+// writeKeygenFile writes the key in the solana-keygen JSON format.
+func writeKeygenFile(filename string, key solana.PrivateKey) error {
+	values := make([]int, len(key))
+	for i, b := range key {
+		values[i] = int(b)
+	}
+	content, err := json.Marshal(values)
+	if err != nil {
+		return fmt.Errorf("encode keygen file: %w", err)
+	}
+	if err := os.WriteFile(filename, content, 0600); err != nil {
+		return fmt.Errorf("write keygen file: %w", err)
+	}
+	return nil
+}
+
+func init() {
+	keygenCmd.AddCommand(keygenGrindCmd)
+
+	keygenGrindCmd.Flags().StringSlice("starts-with", nil, "Public key prefix to grind for; can be repeated")
+	keygenGrindCmd.Flags().StringSlice("ends-with", nil, "Public key suffix to grind for; can be repeated")
+	keygenGrindCmd.Flags().StringSlice("contains", nil, "Substring of the public key to grind for; can be repeated")
+	keygenGrindCmd.Flags().Bool("ignore-case", false, "Match the patterns case-insensitively")
+	keygenGrindCmd.Flags().IntP("count", "n", 1, "Number of matching keypairs to find")
+	keygenGrindCmd.Flags().IntP("workers", "w", 0, "Number of worker goroutines (defaults to the number of CPUs)")
+	keygenGrindCmd.Flags().StringP("outdir", "o", ".", "Directory to write the keypair files to")
+	keygenGrindCmd.Flags().BoolP("quiet", "q", false, "Don't print progress")
+}
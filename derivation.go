@@ -0,0 +1,225 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package solana
+
+import (
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// HardenedKeyStart is the index of the first hardened child key.
+const HardenedKeyStart uint32 = 0x80000000
+
+// SolanaCoinType is the SLIP-0044 coin type of Solana.
+const SolanaCoinType uint32 = 501
+
+// DerivationPath is a SLIP-0010 derivation path. As ed25519 only supports
+// hardened derivation, every index must be hardened (>= HardenedKeyStart).
+type DerivationPath []uint32
+
+// ParseDerivationPath parses a path like "m/44'/501'/0'/0'". Indexes are
+// hardened with a ' or h suffix; as ed25519 only supports hardened
+// derivation, an index that isn't is an error.
+func ParseDerivationPath(path string) (DerivationPath, error) {
+	parts := strings.Split(strings.TrimSpace(path), "/")
+	if parts[0] != "m" {
+		return nil, fmt.Errorf("derivation path must start with m/: %q", path)
+	}
+	out := make(DerivationPath, 0, len(parts)-1)
+	for _, part := range parts[1:] {
+		hardened := strings.HasSuffix(part, "'") || strings.HasSuffix(part, "h") || strings.HasSuffix(part, "H")
+		if !hardened {
+			return nil, fmt.Errorf("derivation path index %q is not hardened: ed25519 only supports hardened derivation", part)
+		}
+		index, err := parseDerivationIndex(part[:len(part)-1])
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, index+HardenedKeyStart)
+	}
+	return out, nil
+}
+
+// MustParseDerivationPath is like ParseDerivationPath, but panics on error.
+func MustParseDerivationPath(path string) DerivationPath {
+	out, err := ParseDerivationPath(path)
+	if err != nil {
+		panic(err)
+	}
+	return out
+}
+
+func parseDerivationIndex(s string) (uint32, error) {
+	index, err := strconv.ParseUint(s, 10, 32)
+	if err != nil || uint32(index) >= HardenedKeyStart {
+		return 0, fmt.Errorf("invalid derivation path index: %q", s)
+	}
+	return uint32(index), nil
+}
+
+// SolanaDerivationPath returns the path m/44'/501'/<indexes'...>.
+//
+// Phantom and Solflare derive their n-th account at SolanaDerivationPath(n, 0),
+// that is m/44'/501'/n'/0'; Ledger at SolanaDerivationPath(n).
+func SolanaDerivationPath(indexes ...uint32) DerivationPath {
+	out := DerivationPath{44 + HardenedKeyStart, SolanaCoinType + HardenedKeyStart}
+	for _, index := range indexes {
+		out = append(out, index|HardenedKeyStart)
+	}
+	return out
+}
+
+func (path DerivationPath) String() string {
+	var b strings.Builder
+	b.WriteString("m")
+	for _, index := range path {
+		b.WriteString("/")
+		if index >= HardenedKeyStart {
+			b.WriteString(strconv.FormatUint(uint64(index-HardenedKeyStart), 10))
+			b.WriteString("'")
+		} else {
+			b.WriteString(strconv.FormatUint(uint64(index), 10))
+		}
+	}
+	return b.String()
+}
+
+// ParseKeygenURI parses the derivation of a solana-keygen "prompt:" key
+// source, e.g. "prompt://?key=0/0" (m/44'/501'/0'/0') or
+// "prompt://?full-path=m/44/501/0/0". The URI "prompt://", without any
+// derivation, returns a nil path: solana-keygen then uses the seed phrase
+// without derivation (see PrivateKeyFromMnemonic).
+func ParseKeygenURI(uri string) (DerivationPath, error) {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return nil, fmt.Errorf("invalid keygen URI %q: %w", uri, err)
+	}
+	if u.Scheme != "prompt" {
+		return nil, fmt.Errorf("unsupported keygen URI scheme: %q", u.Scheme)
+	}
+	query := u.Query()
+	key, hasKey := query["key"]
+	fullPath, hasFullPath := query["full-path"]
+	switch {
+	case hasKey && hasFullPath:
+		return nil, errors.New("keygen URI can't have both key and full-path")
+	case hasKey:
+		// Like solana-keygen, indexes are hardened, with or without a suffix.
+		var indexes []uint32
+		for _, part := range strings.Split(key[0], "/") {
+			index, err := parseDerivationIndex(strings.TrimSuffix(part, "'"))
+			if err != nil {
+				return nil, err
+			}
+			indexes = append(indexes, index)
+		}
+		return SolanaDerivationPath(indexes...), nil
+	case hasFullPath:
+		parts := strings.Split(fullPath[0], "/")
+		if parts[0] != "m" {
+			return nil, fmt.Errorf("derivation path must start with m/: %q", fullPath[0])
+		}
+		path := make(DerivationPath, 0, len(parts)-1)
+		for _, part := range parts[1:] {
+			index, err := parseDerivationIndex(strings.TrimSuffix(part, "'"))
+			if err != nil {
+				return nil, err
+			}
+			path = append(path, index+HardenedKeyStart)
+		}
+		return path, nil
+	default:
+		return nil, nil
+	}
+}
+
+// DeriveEd25519PrivateKey derives the private key at the path from the seed,
+// following SLIP-0010 for ed25519.
+func DeriveEd25519PrivateKey(seed []byte, path DerivationPath) (PrivateKey, error) {
+	mac := hmac.New(sha512.New, []byte("ed25519 seed"))
+	mac.Write(seed)
+	sum := mac.Sum(nil)
+	key, chainCode := sum[:32], sum[32:]
+
+	for _, index := range path {
+		if index < HardenedKeyStart {
+			return nil, fmt.Errorf("derivation path index %d is not hardened: ed25519 only supports hardened derivation", index)
+		}
+		data := make([]byte, 0, 1+32+4)
+		data = append(data, 0)
+		data = append(data, key...)
+		data = binary.BigEndian.AppendUint32(data, index)
+
+		mac := hmac.New(sha512.New, chainCode)
+		mac.Write(data)
+		sum := mac.Sum(nil)
+		key, chainCode = sum[:32], sum[32:]
+	}
+	return PrivateKey(ed25519.NewKeyFromSeed(key)), nil
+}
+
+// PrivateKeyFromMnemonic returns the private key of an English mnemonic and
+// optional passphrase, at the derivation path.
+//
+// With a nil path, the first 32 bytes of the seed are used as the key, like
+// solana-keygen does for a seed phrase without derivation.
+func PrivateKeyFromMnemonic(mnemonic string, passphrase string, path DerivationPath) (PrivateKey, error) {
+	if err := ValidateMnemonic(mnemonic); err != nil {
+		return nil, err
+	}
+	seed, err := MnemonicToSeed(mnemonic, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	if path == nil {
+		return PrivateKey(ed25519.NewKeyFromSeed(seed[:32])), nil
+	}
+	return DeriveEd25519PrivateKey(seed, path)
+}
+
+// PrivateKeysFromMnemonic returns count private keys of an English mnemonic
+// and optional passphrase, starting at the account index first, using the
+// Phantom and Solflare derivation m/44'/501'/<account>'/0'.
+func PrivateKeysFromMnemonic(mnemonic string, passphrase string, first uint32, count int) ([]PrivateKey, error) {
+	if count < 0 {
+		return nil, fmt.Errorf("invalid key count: %d", count)
+	}
+	if err := ValidateMnemonic(mnemonic); err != nil {
+		return nil, err
+	}
+	seed, err := MnemonicToSeed(mnemonic, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]PrivateKey, count)
+	for i := range out {
+		account := first + uint32(i)
+		if account >= HardenedKeyStart {
+			return nil, fmt.Errorf("account index %d out of range", account)
+		}
+		out[i], err = DeriveEd25519PrivateKey(seed, SolanaDerivationPath(account, 0))
+		if err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
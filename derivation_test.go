@@ -0,0 +1,177 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package solana
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnglishWordlist(t *testing.T) {
+	require.Equal(t, "abandon", EnglishWordlist.Word(0))
+	require.Equal(t, "zoo", EnglishWordlist.Word(2047))
+	sum := sha256.Sum256([]byte(englishWordlist))
+	require.Equal(t, "2f5eed53a4727b4bf8880d8f3f199efc90e58503646d9ff8eff3a2ed3b24dbda", hex.EncodeToString(sum[:]))
+}
+
+func TestMnemonicVectors(t *testing.T) {
+	// From the BIP39 test vectors, with the passphrase "TREZOR".
+	vectors := []struct {
+		entropy  string
+		mnemonic string
+		seed     string
+	}{
+		{
+			"00000000000000000000000000000000",
+			"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+			"c55257c360c07c72029aebc1b53c05ed0362ada38ead3e3e9efa3708e53495531f09a6987599d18264c1e1c92f2cf141630c7a3c4ab7c81b2f001698e7463b04",
+		},
+		{
+			"7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f",
+			"legal winner thank year wave sausage worth useful legal winner thank yellow",
+			"2e8905819b8723fe2c1d161860e5ee1830318dbf49a83bd451cfb8440c28bd6fa457fe1296106559a3c80937a1c1069be3a3a5bd381ee6260e8d9739fce1f607",
+		},
+		{
+			"80808080808080808080808080808080",
+			"letter advice cage absurd amount doctor acoustic avoid letter advice cage above",
+			"d71de856f81a8acc65e6fc851a38d4d7ec216fd0796d0a6827a3ad6ed5511a30fa280f12eb2e47ed2ac03b5c462a0358d18d69fe4f985ec81778c1b370b652a8",
+		},
+		{
+			"ffffffffffffffffffffffffffffffff",
+			"zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo wrong",
+			"ac27495480225222079d7be181583751e86f571027b0497b5b5d11218e0a8a13332572917f0f8e5a589620c6f15b11c61dee327651a14c34e18231052e48c069",
+		},
+	}
+	for _, vector := range vectors {
+		entropy, err := hex.DecodeString(vector.entropy)
+		require.NoError(t, err)
+
+		mnemonic, err := EnglishWordlist.MnemonicFromEntropy(entropy)
+		require.NoError(t, err)
+		require.Equal(t, vector.mnemonic, mnemonic)
+
+		got, err := EnglishWordlist.MnemonicToEntropy(mnemonic)
+		require.NoError(t, err)
+		require.Equal(t, entropy, got)
+
+		seed, err := MnemonicToSeed(mnemonic, "TREZOR")
+		require.NoError(t, err)
+		require.Equal(t, vector.seed, hex.EncodeToString(seed))
+	}
+}
+
+func TestNewMnemonic(t *testing.T) {
+	for _, bits := range []int{128, 160, 192, 224, 256} {
+		mnemonic, err := NewMnemonic(bits)
+		require.NoError(t, err)
+		require.Len(t, strings.Fields(mnemonic), bits*3/32)
+		require.NoError(t, ValidateMnemonic(mnemonic))
+	}
+	_, err := NewMnemonic(100)
+	require.Error(t, err)
+
+	// Bad checksum, unknown word, wrong length.
+	require.Error(t, ValidateMnemonic("abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon"))
+	require.Error(t, ValidateMnemonic("abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon solana"))
+	require.Error(t, ValidateMnemonic("abandon about"))
+}
+
+func TestDeriveEd25519PrivateKey(t *testing.T) {
+	// From the SLIP-0010 ed25519 test vector 1.
+	seed, err := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	require.NoError(t, err)
+	vectors := []struct {
+		path       string
+		privateKey string
+	}{
+		{"m", "2b4be7f19ee27bbf30c667b642d5f4aa69fd169872f8fc3059c08ebae2eb19e7"},
+		{"m/0'", "68e0fe46dfb67e368c75379acec591dad19df3cde26e63b93a8e704f1dade7a3"},
+		{"m/0'/1'", "b1d0bad404bf35da785a64ca1ac54b2617211d2777696fbffaf208f746ae84f2"},
+		{"m/0'/1'/2'", "92a5b23c0b8a99e37d07df3fb9966917f5d06e02ddbd909c7e184371463e9fc9"},
+		{"m/0'/1'/2'/2'", "30d1dc7e5fc04c31219ab25a27ae00b50f6fd66622f6e9c913253d6511d1e662"},
+		{"m/0'/1'/2'/2'/1000000000'", "8f94d394a8e8fd6b1bc2f3f49f5c47e385281d5c17e65324b0f62483e37e8793"},
+	}
+	for _, vector := range vectors {
+		path, err := ParseDerivationPath(vector.path)
+		require.NoError(t, err)
+		require.Equal(t, vector.path, path.String())
+
+		key, err := DeriveEd25519PrivateKey(seed, path)
+		require.NoError(t, err)
+		require.Equal(t, vector.privateKey, hex.EncodeToString(key[:32]))
+	}
+
+	_, err = ParseDerivationPath("m/44'/501'/0")
+	require.Error(t, err)
+	_, err = DeriveEd25519PrivateKey(seed, DerivationPath{0})
+	require.Error(t, err)
+}
+
+func TestParseKeygenURI(t *testing.T) {
+	path, err := ParseKeygenURI("prompt://?key=0/0")
+	require.NoError(t, err)
+	require.Equal(t, "m/44'/501'/0'/0'", path.String())
+
+	path, err = ParseKeygenURI("prompt://?key=3")
+	require.NoError(t, err)
+	require.Equal(t, SolanaDerivationPath(3), path)
+
+	path, err = ParseKeygenURI("prompt://?full-path=m/44/501/1/0")
+	require.NoError(t, err)
+	require.Equal(t, SolanaDerivationPath(1, 0), path)
+
+	path, err = ParseKeygenURI("prompt://")
+	require.NoError(t, err)
+	require.Nil(t, path)
+
+	_, err = ParseKeygenURI("file:///tmp/id.json")
+	require.Error(t, err)
+}
+
+func TestPrivateKeyFromMnemonic(t *testing.T) {
+	mnemonic := "legal winner thank year wave sausage worth useful legal winner thank yellow"
+
+	// Without derivation, the key is the first half of the seed.
+	seed, err := MnemonicToSeed(mnemonic, "")
+	require.NoError(t, err)
+	key, err := PrivateKeyFromMnemonic(mnemonic, "", nil)
+	require.NoError(t, err)
+	require.True(t, bytes.Equal(seed[:32], key[:32]))
+
+	keys, err := PrivateKeysFromMnemonic(mnemonic, "", 0, 3)
+	require.NoError(t, err)
+	require.Len(t, keys, 3)
+	for i, key := range keys {
+		derived, err := PrivateKeyFromMnemonic(mnemonic, "", MustParseDerivationPath("m/44'/501'/"+string(rune('0'+i))+"'/0'"))
+		require.NoError(t, err)
+		require.Equal(t, derived, key)
+		require.Equal(t, derived.PublicKey(), key.PublicKey())
+	}
+	require.NotEqual(t, keys[0], keys[1])
+	_, err = PrivateKeysFromMnemonic(mnemonic, "", 0, -1)
+	require.Error(t, err)
+
+	withPassphrase, err := PrivateKeyFromMnemonic(mnemonic, "passphrase", SolanaDerivationPath(0, 0))
+	require.NoError(t, err)
+	require.NotEqual(t, keys[0], withPassphrase)
+
+	_, err = PrivateKeyFromMnemonic("zoo zoo zoo", "", nil)
+	require.Error(t, err)
+}
@@ -0,0 +1,292 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package solana
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+
+	bin "github.com/gagliardetto/binary"
+)
+
+// The layout of the Instructions sysvar account (all integers are little-endian u16):
+//
+//	num_instructions
+//	offset of each instruction
+//	for each instruction:
+//	  num_accounts
+//	  for each account: flags (bit 0: signer, bit 1: writable), pubkey
+//	  program_id
+//	  data_len, data
+//	current_index
+const (
+	instructionsSysvarIsSigner   = 1 << 0
+	instructionsSysvarIsWritable = 1 << 1
+)
+
+// SerializeInstructionsSysvar serializes the instructions in the layout of the
+// Instructions sysvar account, as seen by the instruction at currentIndex.
+func SerializeInstructionsSysvar(instructions []Instruction, currentIndex uint16) ([]byte, error) {
+	if len(instructions) > math.MaxUint16 {
+		return nil, fmt.Errorf("too many instructions: %d", len(instructions))
+	}
+	data := make([]byte, 2+2*len(instructions))
+	binary.LittleEndian.PutUint16(data, uint16(len(instructions)))
+	for i, instruction := range instructions {
+		if len(data) > math.MaxUint16 {
+			return nil, errors.New("serialized instructions are too large")
+		}
+		binary.LittleEndian.PutUint16(data[2+2*i:], uint16(len(data)))
+
+		accounts := instruction.Accounts()
+		if len(accounts) > math.MaxUint16 {
+			return nil, fmt.Errorf("instruction %d has too many accounts: %d", i, len(accounts))
+		}
+		data = binary.LittleEndian.AppendUint16(data, uint16(len(accounts)))
+		for _, account := range accounts {
+			var flags byte
+			if account.IsSigner {
+				flags |= instructionsSysvarIsSigner
+			}
+			if account.IsWritable {
+				flags |= instructionsSysvarIsWritable
+			}
+			data = append(data, flags)
+			data = append(data, account.PublicKey[:]...)
+		}
+		programID := instruction.ProgramID()
+		data = append(data, programID[:]...)
+
+		instructionData, err := instruction.Data()
+		if err != nil {
+			return nil, fmt.Errorf("unable to encode instruction %d: %w", i, err)
+		}
+		if len(instructionData) > math.MaxUint16 {
+			return nil, fmt.Errorf("instruction %d data is too large: %d bytes", i, len(instructionData))
+		}
+		data = binary.LittleEndian.AppendUint16(data, uint16(len(instructionData)))
+		data = append(data, instructionData...)
+	}
+	return binary.LittleEndian.AppendUint16(data, currentIndex), nil
+}
+
+// InstructionsSysvarData returns the data of the Instructions sysvar account
+// while the instruction at currentIndex of the message is executed.
+//
+// As the runtime does, reserved accounts (the sysvars and the builtin
+// programs) are never writable, and neither are invoked programs, unless the
+// upgradeable loader is in the message.
+// The address tables of a versioned message with lookups must be set.
+func (m Message) InstructionsSysvarData(currentIndex uint16) ([]byte, error) {
+	if int(currentIndex) >= len(m.Instructions) {
+		return nil, fmt.Errorf("current instruction index %d out of range: %d instructions", currentIndex, len(m.Instructions))
+	}
+	metas, err := m.AccountMetaList()
+	if err != nil {
+		return nil, err
+	}
+	demotePrograms := !metas.GetKeys().Contains(BPFLoaderUpgradeableProgramID)
+
+	instructions := make([]Instruction, len(m.Instructions))
+	for i, compiled := range m.Instructions {
+		programID, err := m.Program(compiled.ProgramIDIndex)
+		if err != nil {
+			return nil, fmt.Errorf("instruction %d: %w", i, err)
+		}
+		accounts := make(AccountMetaSlice, len(compiled.Accounts))
+		for j, index := range compiled.Accounts {
+			if int(index) >= len(metas) {
+				return nil, fmt.Errorf("instruction %d: account index %d out of range", i, index)
+			}
+			meta := *metas[index]
+			if meta.IsWritable && (reservedAccountKeys.Contains(meta.PublicKey) || demotePrograms && m.isInvoked(index)) {
+				meta.IsWritable = false
+			}
+			accounts[j] = &meta
+		}
+		instructions[i] = &GenericInstruction{
+			AccountValues: accounts,
+			ProgID:        programID,
+			DataBytes:     compiled.Data,
+		}
+	}
+	return SerializeInstructionsSysvar(instructions, currentIndex)
+}
+
+// reservedAccountKeys are the accounts the runtime never lets a transaction
+// write to. The list includes the keys reserved behind features (the zk proof
+// programs and the secp256r1 precompile), as on a cluster where they are all
+// active.
+var reservedAccountKeys = PublicKeySlice{
+	AddressLookupTableProgramID,
+	BPFLoaderDeprecatedProgramID,
+	BPFLoaderProgramID,
+	BPFLoaderUpgradeableProgramID,
+	ComputeBudget,
+	ConfigProgramID,
+	Ed25519ProgramID,
+	FeatureProgramID,
+	LoaderV4ProgramID,
+	MustPublicKeyFromBase58("NativeLoader1111111111111111111111111111111"),
+	Secp256k1ProgramID,
+	MustPublicKeyFromBase58("Secp256r1SigVerify1111111111111111111111111"),
+	StakeProgramID,
+	SystemProgramID,
+	VoteProgramID,
+	MustPublicKeyFromBase58("ZkE1Gama1Proof11111111111111111111111111111"),
+	MustPublicKeyFromBase58("ZkTokenProof1111111111111111111111111111111"),
+	SysVarClockPubkey,
+	SysVarEpochRewardsPubkey,
+	SysVarEpochSchedulePubkey,
+	SysVarFeesPubkey,
+	SysVarInstructionsPubkey,
+	SysVarLastRestartSlotPubkey,
+	SysVarRecentBlockHashesPubkey,
+	SysVarRentPubkey,
+	SysVarRewardsPubkey,
+	SysVarSlotHashesPubkey,
+	SysVarSlotHistoryPubkey,
+	SysVarStakeHistoryPubkey,
+	SysVarOwnerPubkey,
+}
+
+func (m Message) isInvoked(accountIndex uint16) bool {
+	for _, compiled := range m.Instructions {
+		if compiled.ProgramIDIndex == accountIndex {
+			return true
+		}
+	}
+	return false
+}
+
+// SetInstructionsSysvarCurrentIndex sets the index of the executing instruction
+// in the data of an Instructions sysvar account.
+func SetInstructionsSysvarCurrentIndex(data []byte, currentIndex uint16) error {
+	if len(data) < 4 {
+		return errors.New("instructions sysvar data is too short")
+	}
+	binary.LittleEndian.PutUint16(data[len(data)-2:], currentIndex)
+	return nil
+}
+
+// InstructionsSysvarReader reads the data of the Instructions sysvar account,
+// like instruction introspection does on-chain.
+type InstructionsSysvarReader struct {
+	data []byte
+}
+
+// NewInstructionsSysvarReader checks the header of the data
+// of an Instructions sysvar account and returns a reader for it.
+func NewInstructionsSysvarReader(data []byte) (*InstructionsSysvarReader, error) {
+	if len(data) < 4 {
+		return nil, errors.New("instructions sysvar data is too short")
+	}
+	reader := &InstructionsSysvarReader{data: data}
+	if 2+2*reader.Len()+2 > len(data) {
+		return nil, fmt.Errorf("instructions sysvar data is too short for %d instructions", reader.Len())
+	}
+	return reader, nil
+}
+
+// Len returns the number of instructions.
+func (r *InstructionsSysvarReader) Len() int {
+	return int(binary.LittleEndian.Uint16(r.data))
+}
+
+// CurrentIndex returns the index of the executing instruction.
+func (r *InstructionsSysvarReader) CurrentIndex() uint16 {
+	return binary.LittleEndian.Uint16(r.data[len(r.data)-2:])
+}
+
+// InstructionAt returns the instruction at the given index.
+func (r *InstructionsSysvarReader) InstructionAt(index int) (*GenericInstruction, error) {
+	if index < 0 || index >= r.Len() {
+		return nil, fmt.Errorf("instruction index %d out of range: %d instructions", index, r.Len())
+	}
+	offset := int(binary.LittleEndian.Uint16(r.data[2+2*index:]))
+	if offset >= len(r.data)-2 {
+		return nil, fmt.Errorf("instruction %d offset %d out of range", index, offset)
+	}
+	instruction, err := decodeInstructionsSysvarInstruction(bin.NewBinDecoder(r.data[offset : len(r.data)-2]))
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode instruction %d: %w", index, err)
+	}
+	return instruction, nil
+}
+
+// InstructionRelative returns the instruction at the given offset
+// from the executing instruction; an offset of 0 returns the executing instruction.
+func (r *InstructionsSysvarReader) InstructionRelative(offset int) (*GenericInstruction, error) {
+	return r.InstructionAt(int(r.CurrentIndex()) + offset)
+}
+
+// Instructions returns all the instructions.
+func (r *InstructionsSysvarReader) Instructions() ([]*GenericInstruction, error) {
+	out := make([]*GenericInstruction, r.Len())
+	for i := range out {
+		instruction, err := r.InstructionAt(i)
+		if err != nil {
+			return nil, err
+		}
+		out[i] = instruction
+	}
+	return out, nil
+}
+
+func decodeInstructionsSysvarInstruction(dec *bin.Decoder) (*GenericInstruction, error) {
+	numAccounts, err := dec.ReadUint16(binary.LittleEndian)
+	if err != nil {
+		return nil, err
+	}
+	if int(numAccounts)*(1+PublicKeyLength) > dec.Remaining() {
+		return nil, fmt.Errorf("not enough data for %d accounts", numAccounts)
+	}
+	instruction := &GenericInstruction{
+		AccountValues: make(AccountMetaSlice, numAccounts),
+	}
+	for i := range instruction.AccountValues {
+		flags, err := dec.ReadUint8()
+		if err != nil {
+			return nil, err
+		}
+		pubkey, err := dec.ReadNBytes(PublicKeyLength)
+		if err != nil {
+			return nil, err
+		}
+		instruction.AccountValues[i] = &AccountMeta{
+			PublicKey:  PublicKeyFromBytes(pubkey),
+			IsSigner:   flags&instructionsSysvarIsSigner != 0,
+			IsWritable: flags&instructionsSysvarIsWritable != 0,
+		}
+	}
+	programID, err := dec.ReadNBytes(PublicKeyLength)
+	if err != nil {
+		return nil, err
+	}
+	instruction.ProgID = PublicKeyFromBytes(programID)
+
+	dataLen, err := dec.ReadUint16(binary.LittleEndian)
+	if err != nil {
+		return nil, err
+	}
+	data, err := dec.ReadNBytes(int(dataLen))
+	if err != nil {
+		return nil, err
+	}
+	instruction.DataBytes = append([]byte{}, data...)
+	return instruction, nil
+}
@@ -0,0 +1,122 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package solana
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSerializeInstructionsSysvar(t *testing.T) {
+	account := MustPublicKeyFromBase58("4wBqpZM9k69W87zdYXT2bRtLViWqTiJV3i2Kn9q7S6j")
+	program := MemoProgramID
+	instruction := NewInstruction(program, AccountMetaSlice{Meta(account).WRITE().SIGNER()}, []byte{0xaa, 0xbb})
+
+	data, err := SerializeInstructionsSysvar([]Instruction{instruction}, 0)
+	require.NoError(t, err)
+
+	expected := []byte{1, 0, 4, 0, 1, 0, 3}
+	expected = append(expected, account[:]...)
+	expected = append(expected, program[:]...)
+	expected = append(expected, 2, 0, 0xaa, 0xbb, 0, 0)
+	require.Equal(t, expected, data)
+
+	reader, err := NewInstructionsSysvarReader(data)
+	require.NoError(t, err)
+	require.Equal(t, 1, reader.Len())
+	require.Equal(t, uint16(0), reader.CurrentIndex())
+	got, err := reader.InstructionAt(0)
+	require.NoError(t, err)
+	require.Equal(t, instruction, got)
+
+	_, err = reader.InstructionAt(1)
+	require.Error(t, err)
+	_, err = reader.InstructionRelative(-1)
+	require.Error(t, err)
+}
+
+func TestMessageInstructionsSysvarData(t *testing.T) {
+	keys := newTestKeys(3)
+	payer, verified, vault := keys[0], keys[1], keys[2]
+	instructions := []Instruction{
+		NewInstruction(Ed25519ProgramID, nil, []byte{1, 2, 3}),
+		// A program passed as a writable account is demoted when it is invoked,
+		// and a reserved account always is.
+		NewInstruction(MemoProgramID, AccountMetaSlice{
+			Meta(payer).WRITE().SIGNER(),
+			Meta(vault).WRITE(),
+			Meta(Ed25519ProgramID).WRITE(),
+			Meta(SysVarInstructionsPubkey),
+			Meta(SysVarClockPubkey).WRITE(),
+		}, []byte("check")),
+		newTestTransfer(verified),
+	}
+	tx, err := NewTransaction(instructions, Hash{}, TransactionPayer(payer))
+	require.NoError(t, err)
+
+	data, err := tx.Message.InstructionsSysvarData(1)
+	require.NoError(t, err)
+
+	reader, err := NewInstructionsSysvarReader(data)
+	require.NoError(t, err)
+	require.Equal(t, 3, reader.Len())
+	require.Equal(t, uint16(1), reader.CurrentIndex())
+
+	current, err := reader.InstructionRelative(0)
+	require.NoError(t, err)
+	require.Equal(t, MemoProgramID, current.ProgramID())
+	require.Equal(t, []byte("check"), current.DataBytes)
+	require.Equal(t, AccountMetaSlice{
+		Meta(payer).WRITE().SIGNER(),
+		Meta(vault).WRITE(),
+		Meta(Ed25519ProgramID),
+		Meta(SysVarInstructionsPubkey),
+		Meta(SysVarClockPubkey),
+	}, current.AccountValues)
+
+	previous, err := reader.InstructionRelative(-1)
+	require.NoError(t, err)
+	require.Equal(t, Ed25519ProgramID, previous.ProgramID())
+	require.Equal(t, []byte{1, 2, 3}, previous.DataBytes)
+	require.Empty(t, previous.AccountValues)
+
+	next, err := reader.InstructionRelative(1)
+	require.NoError(t, err)
+	transferData, err := instructions[2].Data()
+	require.NoError(t, err)
+	require.Equal(t, instructions[2].ProgramID(), next.ProgramID())
+	require.Equal(t, transferData, next.DataBytes)
+	require.Equal(t, AccountMetaSlice(instructions[2].Accounts()).GetKeys(), next.AccountValues.GetKeys())
+
+	require.NoError(t, SetInstructionsSysvarCurrentIndex(data, 2))
+	require.Equal(t, uint16(2), reader.CurrentIndex())
+
+	_, err = tx.Message.InstructionsSysvarData(3)
+	require.Error(t, err)
+}
+
+func TestNewInstructionsSysvarReaderErrors(t *testing.T) {
+	_, err := NewInstructionsSysvarReader([]byte{1, 0})
+	require.Error(t, err)
+	_, err = NewInstructionsSysvarReader([]byte{3, 0, 0, 0})
+	require.Error(t, err)
+
+	// The instruction claims more accounts than there is data.
+	reader, err := NewInstructionsSysvarReader([]byte{1, 0, 4, 0, 9, 0, 0, 0})
+	require.NoError(t, err)
+	_, err = reader.InstructionAt(0)
+	require.Error(t, err)
+}
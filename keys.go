@@ -19,6 +19,7 @@ package solana
 
 import (
 	"bytes"
+	"context"
 	"crypto"
 	"crypto/ed25519"
 	crypto_rand "crypto/rand"
@@ -27,7 +28,13 @@ import (
 	"fmt"
 	"io/ioutil"
 	"math"
+	"runtime"
 	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+	"unicode"
 
 	"filippo.io/edwards25519"
 	"github.com/mr-tron/base58"
@@ -82,6 +89,269 @@ func NewRandomPrivateKey() (PrivateKey, error) {
 	return PrivateKey(priv), nil
 }
 
+// base58Alphabet is the alphabet of base58-encoded public keys.
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+// maxBase58PublicKeyLength is the length of the longest base58-encoded public key.
+const maxBase58PublicKeyLength = 44
+
+// GrindMatch is where a GrindPattern must appear in a base58 public key.
+type GrindMatch int
+
+const (
+	GrindPrefix GrindMatch = iota
+	GrindSuffix
+	GrindContains
+)
+
+func (m GrindMatch) String() string {
+	switch m {
+	case GrindPrefix:
+		return "prefix"
+	case GrindSuffix:
+		return "suffix"
+	case GrindContains:
+		return "contains"
+	default:
+		return fmt.Sprintf("GrindMatch(%d)", int(m))
+	}
+}
+
+// GrindPattern describes the vanity public key to grind for.
+type GrindPattern struct {
+	Match      GrindMatch
+	Value      string
+	IgnoreCase bool
+}
+
+// GrindPrefixPattern matches public keys starting with prefix.
+func GrindPrefixPattern(prefix string) GrindPattern {
+	return GrindPattern{Match: GrindPrefix, Value: prefix}
+}
+
+// GrindSuffixPattern matches public keys ending with suffix.
+func GrindSuffixPattern(suffix string) GrindPattern {
+	return GrindPattern{Match: GrindSuffix, Value: suffix}
+}
+
+// GrindContainsPattern matches public keys containing value.
+func GrindContainsPattern(value string) GrindPattern {
+	return GrindPattern{Match: GrindContains, Value: value}
+}
+
+// Validate checks that the pattern can match a base58 public key.
+func (p GrindPattern) Validate() error {
+	switch p.Match {
+	case GrindPrefix, GrindSuffix, GrindContains:
+	default:
+		return fmt.Errorf("invalid grind match: %s", p.Match)
+	}
+	if p.Value == "" {
+		return errors.New("grind pattern is empty")
+	}
+	if len(p.Value) > maxBase58PublicKeyLength {
+		return fmt.Errorf("grind pattern %q is longer than a public key", p.Value)
+	}
+	for i, r := range p.Value {
+		if base58Variants(r, p.IgnoreCase) == 0 {
+			return fmt.Errorf("invalid character %q at position %d of grind pattern %q: not in the base58 alphabet", r, i, p.Value)
+		}
+	}
+	return nil
+}
+
+// probability returns the approximate probability that a random public key
+// matches the pattern.
+func (p GrindPattern) probability() float64 {
+	prob := 1.0
+	for _, r := range p.Value {
+		prob *= float64(base58Variants(r, p.IgnoreCase)) / float64(len(base58Alphabet))
+	}
+	if p.Match == GrindContains {
+		prob *= float64(maxBase58PublicKeyLength - len(p.Value) + 1)
+	}
+	return math.Min(prob, 1)
+}
+
+func (p GrindPattern) matches(address, lowerAddress string) bool {
+	value := p.Value
+	if p.IgnoreCase {
+		address = lowerAddress
+		value = strings.ToLower(value)
+	}
+	switch p.Match {
+	case GrindPrefix:
+		return strings.HasPrefix(address, value)
+	case GrindSuffix:
+		return strings.HasSuffix(address, value)
+	default:
+		return strings.Contains(address, value)
+	}
+}
+
+// base58Variants returns how many characters of the base58 alphabet match r.
+func base58Variants(r rune, ignoreCase bool) int {
+	if !ignoreCase {
+		if strings.ContainsRune(base58Alphabet, r) {
+			return 1
+		}
+		return 0
+	}
+	count := 0
+	for _, c := range base58Alphabet {
+		if unicode.ToLower(c) == unicode.ToLower(r) {
+			count++
+		}
+	}
+	return count
+}
+
+// ExpectedGrindAttempts returns the estimated number of keys to generate
+// before one matches any of the patterns.
+func ExpectedGrindAttempts(patterns ...GrindPattern) float64 {
+	prob := 0.0
+	for _, pattern := range patterns {
+		prob += pattern.probability()
+	}
+	if prob == 0 {
+		return math.Inf(1)
+	}
+	return 1 / math.Min(prob, 1)
+}
+
+// GrindProgress is reported periodically while grinding.
+type GrindProgress struct {
+	Attempts          uint64
+	Elapsed           time.Duration
+	AttemptsPerSecond float64
+	// ExpectedAttempts is the estimated number of attempts needed for a match.
+	ExpectedAttempts float64
+	// ETA is the estimated time to a match from now. As every attempt is
+	// independent, it does not shrink with the attempts already made.
+	ETA time.Duration
+}
+
+// GrindOptions configures GrindPrivateKey.
+type GrindOptions struct {
+	// Workers is the number of goroutines generating keys;
+	// it defaults to runtime.NumCPU().
+	Workers int
+	// Progress, if set, is called every ProgressInterval (default one second).
+	Progress         func(GrindProgress)
+	ProgressInterval time.Duration
+}
+
+// GrindPrivateKey generates random private keys until the public key of one
+// of them matches any of the patterns, or the context is done.
+func GrindPrivateKey(ctx context.Context, patterns []GrindPattern, opts *GrindOptions) (PrivateKey, error) {
+	if len(patterns) == 0 {
+		return nil, errors.New("no grind pattern")
+	}
+	for _, pattern := range patterns {
+		if err := pattern.Validate(); err != nil {
+			return nil, err
+		}
+	}
+	if opts == nil {
+		opts = &GrindOptions{}
+	}
+	workers := opts.Workers
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	interval := opts.ProgressInterval
+	if interval <= 0 {
+		interval = time.Second
+	}
+	ignoreCase := false
+	for _, pattern := range patterns {
+		ignoreCase = ignoreCase || pattern.IgnoreCase
+	}
+
+	grindCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var attempts atomic.Uint64
+	found := make(chan PrivateKey, 1)
+	errs := make(chan error, workers)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for grindCtx.Err() == nil {
+				key, err := NewRandomPrivateKey()
+				if err != nil {
+					errs <- err
+					cancel()
+					return
+				}
+				attempts.Add(1)
+				address := key.PublicKey().String()
+				var lowerAddress string
+				if ignoreCase {
+					lowerAddress = strings.ToLower(address)
+				}
+				for _, pattern := range patterns {
+					if pattern.matches(address, lowerAddress) {
+						select {
+						case found <- key:
+						default:
+						}
+						cancel()
+						return
+					}
+				}
+			}
+		}()
+	}
+
+	if opts.Progress != nil {
+		expected := ExpectedGrindAttempts(patterns...)
+		start := time.Now()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+	loop:
+		for {
+			select {
+			case <-grindCtx.Done():
+				break loop
+			case now := <-ticker.C:
+				progress := GrindProgress{
+					Attempts:         attempts.Load(),
+					Elapsed:          now.Sub(start),
+					ExpectedAttempts: expected,
+				}
+				if secs := progress.Elapsed.Seconds(); secs > 0 {
+					progress.AttemptsPerSecond = float64(progress.Attempts) / secs
+				}
+				if progress.AttemptsPerSecond > 0 && !math.IsInf(expected, 1) {
+					eta := expected / progress.AttemptsPerSecond * float64(time.Second)
+					if eta >= math.MaxInt64 {
+						progress.ETA = math.MaxInt64
+					} else {
+						progress.ETA = time.Duration(eta)
+					}
+				}
+				opts.Progress(progress)
+			}
+		}
+	}
+	wg.Wait()
+
+	select {
+	case key := <-found:
+		return key, nil
+	default:
+	}
+	select {
+	case err := <-errs:
+		return nil, fmt.Errorf("generate key: %w", err)
+	default:
+	}
+	return nil, ctx.Err()
+}
+
 func (k PrivateKey) Sign(payload []byte) (Signature, error) {
 	p := ed25519.PrivateKey(k)
 	signData, err := p.Sign(crypto_rand.Reader, payload, crypto.Hash(0))
@@ -656,11 +926,18 @@ func MustFindAssociatedTokenAddress(
 	return token
 }
 
+// FindAssociatedTokenAddress returns the associated token account address
+// of the wallet for the mint, and its bump seed. The programID is the token
+// program that owns the mint, either TokenProgramID or Token2022ProgramID:
+// the same wallet and mint have a different address for each program.
 func FindAssociatedTokenAddress(
 	wallet PublicKey,
 	mint PublicKey,
 	programID PublicKey,
 ) (PublicKey, uint8, error) {
+	if programID.IsZero() {
+		return PublicKey{}, 0, errors.New("token program ID is not set")
+	}
 	return findAssociatedTokenAddressAndBumpSeed(
 		wallet,
 		mint,
@@ -18,11 +18,14 @@
 package solana
 
 import (
+	"context"
 	"encoding/binary"
 	"encoding/hex"
 	"errors"
 	"flag"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -618,3 +621,60 @@ func TestFindTokenMetadataAddress(t *testing.T) {
 	assert.Equal(t, metadataPDA, MustPublicKeyFromBase58("GfihrEYCPrvUyrMyMQPdhGEStxa9nKEK2Wfn9iK4AZq2"))
 	assert.Equal(t, bumpSeed, uint8(0xfd))
 }
+
+func TestGrindPatternValidate(t *testing.T) {
+	require.NoError(t, GrindPrefixPattern("Sun").Validate())
+	for _, invalid := range []string{"", "0", "O", "I", "l", "Sol0na", "ab-c"} {
+		require.Error(t, GrindPrefixPattern(invalid).Validate(), invalid)
+	}
+	// Case-insensitive patterns only need one case in the alphabet.
+	require.NoError(t, GrindPattern{Match: GrindSuffix, Value: "lol", IgnoreCase: true}.Validate())
+	require.Error(t, GrindPattern{Match: GrindSuffix, Value: "0", IgnoreCase: true}.Validate())
+	require.Error(t, GrindPattern{Match: GrindMatch(7), Value: "a"}.Validate())
+}
+
+func TestExpectedGrindAttempts(t *testing.T) {
+	require.InDelta(t, 58.0, ExpectedGrindAttempts(GrindPrefixPattern("A")), 1e-9)
+	require.InDelta(t, 58.0*58, ExpectedGrindAttempts(GrindSuffixPattern("AB")), 1e-9)
+	require.InDelta(t, 29.0, ExpectedGrindAttempts(GrindPattern{Match: GrindPrefix, Value: "a", IgnoreCase: true}), 1e-9)
+	// "l" only matches "L".
+	require.InDelta(t, 58.0, ExpectedGrindAttempts(GrindPattern{Match: GrindPrefix, Value: "l", IgnoreCase: true}), 1e-9)
+	require.InDelta(t, 29.0, ExpectedGrindAttempts(GrindPrefixPattern("A"), GrindPrefixPattern("B")), 1e-9)
+	require.InDelta(t, 58.0/44, ExpectedGrindAttempts(GrindContainsPattern("A")), 1e-9)
+}
+
+func TestGrindPrivateKey(t *testing.T) {
+	key, err := GrindPrivateKey(context.Background(), []GrindPattern{GrindPrefixPattern("A")}, &GrindOptions{Workers: 2})
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(key.PublicKey().String(), "A"))
+
+	key, err = GrindPrivateKey(context.Background(), []GrindPattern{{Match: GrindSuffix, Value: "z", IgnoreCase: true}}, nil)
+	require.NoError(t, err)
+	require.True(t, strings.HasSuffix(strings.ToLower(key.PublicKey().String()), "z"))
+
+	_, err = GrindPrivateKey(context.Background(), []GrindPattern{GrindPrefixPattern("0")}, nil)
+	require.Error(t, err)
+	_, err = GrindPrivateKey(context.Background(), nil, nil)
+	require.Error(t, err)
+}
+
+func TestGrindPrivateKeyCancel(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	var reports []GrindProgress
+	_, err := GrindPrivateKey(ctx, []GrindPattern{GrindPrefixPattern("Sooooooooooo")}, &GrindOptions{
+		Workers:          2,
+		ProgressInterval: 10 * time.Millisecond,
+		Progress: func(progress GrindProgress) {
+			reports = append(reports, progress)
+		},
+	})
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.NotEmpty(t, reports)
+	last := reports[len(reports)-1]
+	require.NotZero(t, last.Attempts)
+	require.Greater(t, last.AttemptsPerSecond, 0.0)
+	require.Equal(t, ExpectedGrindAttempts(GrindPrefixPattern("Sooooooooooo")), last.ExpectedAttempts)
+	require.Greater(t, last.ETA, time.Hour)
+}
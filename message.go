@@ -943,6 +943,75 @@ func (m Message) DecodeInstructions() ([]Instruction, error) {
 	return ins, nil
 }
 
+// Decompile is the inverse of NewTransaction: it returns the instructions of
+// the message, with the signer and writable flags of their accounts resolved
+// from the message header and, for accounts loaded from address tables, from
+// the lookups. The address tables must be set on a message with lookups.
+//
+// The instructions can be edited and compiled again with NewTransaction,
+// using the first account key as payer and the same address tables.
+// Signers not used by any instruction, other than the payer, are not kept.
+func (m Message) Decompile() ([]Instruction, error) {
+	metas, err := m.accountMetasByIndex()
+	if err != nil {
+		return nil, err
+	}
+	out := make([]Instruction, len(m.Instructions))
+	for i, compiled := range m.Instructions {
+		programID, err := m.Program(compiled.ProgramIDIndex)
+		if err != nil {
+			return nil, fmt.Errorf("instruction %d: %w", i, err)
+		}
+		accounts := make(AccountMetaSlice, len(compiled.Accounts))
+		for j, index := range compiled.Accounts {
+			if int(index) >= len(metas) {
+				return nil, fmt.Errorf("instruction %d: account index %d out of range", i, index)
+			}
+			// Copy the meta, so that editing an instruction doesn't affect the others.
+			meta := metas[index]
+			accounts[j] = &meta
+		}
+		out[i] = &GenericInstruction{
+			AccountValues: accounts,
+			ProgID:        programID,
+			DataBytes:     append([]byte{}, compiled.Data...),
+		}
+	}
+	return out, nil
+}
+
+// accountMetasByIndex returns the account metas of all the message's keys,
+// with flags resolved from the position of each key.
+func (m Message) accountMetasByIndex() ([]AccountMeta, error) {
+	if err := m.checkPreconditions(); err != nil {
+		return nil, err
+	}
+	keys, err := m.GetAllKeys()
+	if err != nil {
+		return nil, err
+	}
+	h := m.Header
+	numStatic := m.numStaticAccounts()
+	numSigners := int(h.NumRequiredSignatures)
+	if numSigners > numStatic || int(h.NumReadonlySignedAccounts) > numSigners || int(h.NumReadonlyUnsignedAccounts) > numStatic-numSigners {
+		return nil, fmt.Errorf("invalid message header for %d account keys", numStatic)
+	}
+	out := make([]AccountMeta, len(keys))
+	for i, key := range keys {
+		out[i] = AccountMeta{PublicKey: key}
+		switch {
+		case i < numSigners:
+			out[i].IsSigner = true
+			out[i].IsWritable = i < numSigners-int(h.NumReadonlySignedAccounts)
+		case i < numStatic:
+			out[i].IsWritable = i < numStatic-int(h.NumReadonlyUnsignedAccounts)
+		default:
+			out[i].IsWritable = m.isWritableInLookups(i)
+		}
+	}
+	return out, nil
+}
+
 // CheckInstructionEquivalence is the fallback equivalence checking logic
 // which doesn't give as good errors because it compares compiled instruction
 // data.
@@ -0,0 +1,143 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package solana
+
+import (
+	"testing"
+
+	bin "github.com/gagliardetto/binary"
+	"github.com/stretchr/testify/require"
+)
+
+func requireSameInstructions(t *testing.T, expected []Instruction, got []Instruction) {
+	t.Helper()
+	require.Len(t, got, len(expected))
+	for i := range expected {
+		require.Equal(t, expected[i].ProgramID(), got[i].ProgramID(), "instruction %d", i)
+		require.Len(t, got[i].Accounts(), len(expected[i].Accounts()), "instruction %d", i)
+		for j, account := range expected[i].Accounts() {
+			require.Equal(t, *account, *got[i].Accounts()[j], "instruction %d, account %d", i, j)
+		}
+		expectedData, err := expected[i].Data()
+		require.NoError(t, err)
+		gotData, err := got[i].Data()
+		require.NoError(t, err)
+		require.Equal(t, expectedData, gotData, "instruction %d", i)
+	}
+}
+
+func TestMessageDecompileLegacy(t *testing.T) {
+	keys := newTestKeys(5)
+	payer, cosigner, readonlySigner, writable, readonly := keys[0], keys[1], keys[2], keys[3], keys[4]
+	instructions := []Instruction{
+		NewInstruction(MemoProgramID, AccountMetaSlice{
+			Meta(cosigner).WRITE().SIGNER(),
+			Meta(readonlySigner).SIGNER(),
+			Meta(readonly),
+		}, []byte("first")),
+		NewInstruction(SystemProgramID, AccountMetaSlice{
+			Meta(payer).WRITE().SIGNER(),
+			Meta(writable).WRITE(),
+			Meta(readonly),
+		}, []byte{2, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0}),
+	}
+	hash := Hash(newTestKeys(1)[0])
+	tx, err := NewTransaction(instructions, hash, TransactionPayer(payer))
+	require.NoError(t, err)
+
+	decompiled, err := tx.Message.Decompile()
+	require.NoError(t, err)
+	requireSameInstructions(t, instructions, decompiled)
+
+	recompiled, err := NewTransaction(decompiled, hash, TransactionPayer(tx.Message.AccountKeys[0]))
+	require.NoError(t, err)
+	expected, err := tx.Message.MarshalBinary()
+	require.NoError(t, err)
+	got, err := recompiled.Message.MarshalBinary()
+	require.NoError(t, err)
+	require.Equal(t, expected, got)
+
+	// Editing a decompiled instruction doesn't affect the others.
+	decompiled[0].Accounts()[2].IsWritable = true
+	require.False(t, decompiled[1].Accounts()[2].IsWritable)
+}
+
+func TestMessageDecompileV0(t *testing.T) {
+	keys := newTestKeys(5)
+	payer, writable, readonly, tableWritable, tableReadonly := keys[0], keys[1], keys[2], keys[3], keys[4]
+	table := newTestKeys(1)[0]
+	tables := map[PublicKey]PublicKeySlice{
+		table: {tableReadonly, tableWritable},
+	}
+	instructions := []Instruction{
+		NewInstruction(MemoProgramID, AccountMetaSlice{
+			Meta(payer).WRITE().SIGNER(),
+			Meta(tableWritable).WRITE(),
+			Meta(tableReadonly),
+		}, []byte("first")),
+		NewInstruction(SystemProgramID, AccountMetaSlice{
+			Meta(payer).WRITE().SIGNER(),
+			Meta(writable).WRITE(),
+			Meta(readonly),
+			Meta(tableReadonly),
+		}, []byte{2, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0}),
+	}
+	hash := Hash(newTestKeys(1)[0])
+	tx, err := NewTransaction(instructions, hash, TransactionPayer(payer), TransactionAddressTables(tables))
+	require.NoError(t, err)
+	require.True(t, tx.Message.IsVersioned())
+	require.Equal(t, 2, tx.Message.NumLookups())
+
+	// Decode the transaction as if it were fetched.
+	data, err := tx.MarshalBinary()
+	require.NoError(t, err)
+	fetched, err := TransactionFromDecoder(bin.NewBinDecoder(data))
+	require.NoError(t, err)
+
+	_, err = fetched.Message.Decompile()
+	require.Error(t, err)
+
+	require.NoError(t, fetched.Message.SetAddressTables(tables))
+	decompiled, err := fetched.Message.Decompile()
+	require.NoError(t, err)
+	requireSameInstructions(t, instructions, decompiled)
+
+	// The result is the same once the lookups are resolved.
+	require.NoError(t, fetched.Message.ResolveLookups())
+	resolved, err := fetched.Message.Decompile()
+	require.NoError(t, err)
+	require.Equal(t, decompiled, resolved)
+
+	unedited, err := NewTransaction(decompiled, fetched.Message.RecentBlockhash, TransactionPayer(fetched.Message.AccountKeys[0]), TransactionAddressTables(tables))
+	require.NoError(t, err)
+	expected, err := tx.Message.MarshalBinary()
+	require.NoError(t, err)
+	got, err := unedited.Message.MarshalBinary()
+	require.NoError(t, err)
+	require.Equal(t, expected, got)
+
+	// Add a compute budget instruction, and compile again.
+	setComputeUnitLimit := NewInstruction(ComputeBudget, nil, []byte{2, 0x40, 0x0d, 0x03, 0x00})
+	edited := append([]Instruction{setComputeUnitLimit}, decompiled...)
+	recompiled, err := NewTransaction(edited, fetched.Message.RecentBlockhash, TransactionPayer(fetched.Message.AccountKeys[0]), TransactionAddressTables(tables))
+	require.NoError(t, err)
+	require.Equal(t, 2, recompiled.Message.NumLookups())
+	// The compute budget program is one more read-only account.
+	require.Equal(t, tx.Message.Header.NumReadonlyUnsignedAccounts+1, recompiled.Message.Header.NumReadonlyUnsignedAccounts)
+
+	again, err := recompiled.Message.Decompile()
+	require.NoError(t, err)
+	requireSameInstructions(t, edited, again)
+}
@@ -0,0 +1,184 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package solana
+
+import (
+	"crypto/pbkdf2"
+	crypto_rand "crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Wordlist is a BIP39 wordlist of 2048 words. Only the English wordlist is
+// embedded in the package; the wordlists of the other languages can be loaded
+// with NewWordlist.
+type Wordlist struct {
+	words []string
+	index map[string]int
+}
+
+// EnglishWordlist is the BIP39 English wordlist, the one used by default.
+var EnglishWordlist = mustNewWordlist(strings.Fields(englishWordlist))
+
+// NewWordlist creates a wordlist from the 2048 words, in BIP39 order,
+// e.g. one of the other languages of the BIP39 wordlists, which are not
+// embedded in the package.
+// Mnemonics of the wordlist have their words separated by spaces.
+func NewWordlist(words []string) (*Wordlist, error) {
+	if len(words) != 2048 {
+		return nil, fmt.Errorf("wordlist must have 2048 words, got %d", len(words))
+	}
+	wl := &Wordlist{
+		words: make([]string, len(words)),
+		index: make(map[string]int, len(words)),
+	}
+	for i, word := range words {
+		word = norm.NFKD.String(word)
+		if _, ok := wl.index[word]; ok {
+			return nil, fmt.Errorf("duplicate word in wordlist: %q", word)
+		}
+		wl.words[i] = word
+		wl.index[word] = i
+	}
+	return wl, nil
+}
+
+func mustNewWordlist(words []string) *Wordlist {
+	wl, err := NewWordlist(words)
+	if err != nil {
+		panic(err)
+	}
+	return wl
+}
+
+// Word returns the word at the index.
+func (wl *Wordlist) Word(index int) string {
+	return wl.words[index]
+}
+
+// NewMnemonic generates a random mnemonic with entropyBits bits of entropy:
+// 128 (12 words), 160, 192, 224 or 256 (24 words).
+func (wl *Wordlist) NewMnemonic(entropyBits int) (string, error) {
+	if err := validateEntropyBits(entropyBits); err != nil {
+		return "", err
+	}
+	entropy := make([]byte, entropyBits/8)
+	if _, err := crypto_rand.Read(entropy); err != nil {
+		return "", fmt.Errorf("unable to generate entropy: %w", err)
+	}
+	return wl.MnemonicFromEntropy(entropy)
+}
+
+// MnemonicFromEntropy returns the mnemonic of the entropy.
+func (wl *Wordlist) MnemonicFromEntropy(entropy []byte) (string, error) {
+	if err := validateEntropyBits(len(entropy) * 8); err != nil {
+		return "", err
+	}
+	// The entropy is followed by a checksum of len(entropy)/4 bits, the
+	// first bits of its hash, and the result is split in 11-bit indexes.
+	checksum := sha256.Sum256(entropy)
+	data := append(append([]byte(nil), entropy...), checksum[0])
+	numWords := (len(entropy)*8 + len(entropy)/4) / 11
+	words := make([]string, numWords)
+	for i := range words {
+		words[i] = wl.words[readBits(data, i*11, 11)]
+	}
+	return strings.Join(words, " "), nil
+}
+
+// MnemonicToEntropy returns the entropy of the mnemonic,
+// after checking its words and checksum.
+func (wl *Wordlist) MnemonicToEntropy(mnemonic string) ([]byte, error) {
+	words := strings.Fields(norm.NFKD.String(mnemonic))
+	switch len(words) {
+	case 12, 15, 18, 21, 24:
+	default:
+		return nil, fmt.Errorf("mnemonic must have 12, 15, 18, 21 or 24 words, got %d", len(words))
+	}
+	numBits := len(words) * 11
+	checksumBits := numBits / 33
+	data := make([]byte, (numBits+7)/8)
+	for i, word := range words {
+		index, ok := wl.index[word]
+		if !ok {
+			return nil, fmt.Errorf("word %d is not in the wordlist: %q", i+1, word)
+		}
+		writeBits(data, i*11, 11, index)
+	}
+	entropy := data[:(numBits-checksumBits)/8]
+	checksum := sha256.Sum256(entropy)
+	if readBits(checksum[:], 0, checksumBits) != readBits(data, len(entropy)*8, checksumBits) {
+		return nil, errors.New("invalid mnemonic checksum")
+	}
+	return entropy, nil
+}
+
+// ValidateMnemonic checks the words and the checksum of the mnemonic.
+func (wl *Wordlist) ValidateMnemonic(mnemonic string) error {
+	_, err := wl.MnemonicToEntropy(mnemonic)
+	return err
+}
+
+// NewMnemonic generates a random English mnemonic with entropyBits bits of
+// entropy: 128 (12 words), 160, 192, 224 or 256 (24 words).
+func NewMnemonic(entropyBits int) (string, error) {
+	return EnglishWordlist.NewMnemonic(entropyBits)
+}
+
+// ValidateMnemonic checks the words and the checksum of an English mnemonic.
+func ValidateMnemonic(mnemonic string) error {
+	return EnglishWordlist.ValidateMnemonic(mnemonic)
+}
+
+// MnemonicToSeed returns the BIP39 seed of the mnemonic and the optional
+// passphrase. The mnemonic is not validated; see ValidateMnemonic.
+func MnemonicToSeed(mnemonic string, passphrase string) ([]byte, error) {
+	mnemonic = strings.Join(strings.Fields(norm.NFKD.String(mnemonic)), " ")
+	salt := "mnemonic" + norm.NFKD.String(passphrase)
+	return pbkdf2.Key(sha512.New, mnemonic, []byte(salt), 2048, 64)
+}
+
+func validateEntropyBits(entropyBits int) error {
+	if entropyBits < 128 || entropyBits > 256 || entropyBits%32 != 0 {
+		return fmt.Errorf("entropy must be 128, 160, 192, 224 or 256 bits, got %d", entropyBits)
+	}
+	return nil
+}
+
+// readBits reads n bits (at most 16) of data, starting at bit offset,
+// most significant bit first.
+func readBits(data []byte, offset int, n int) int {
+	out := 0
+	for i := offset; i < offset+n; i++ {
+		out = out<<1 | int(data[i/8]>>(7-i%8)&1)
+	}
+	return out
+}
+
+// writeBits writes the n lowest bits of value to data, starting at bit
+// offset, most significant bit first.
+func writeBits(data []byte, offset int, n int, value int) {
+	for i := 0; i < n; i++ {
+		if value>>(n-1-i)&1 == 1 {
+			bit := offset + i
+			data[bit/8] |= 1 << (7 - bit%8)
+		}
+	}
+}
@@ -33,11 +33,20 @@ var (
 	BPFLoaderProgramID            = MustPublicKeyFromBase58("BPFLoader2111111111111111111111111111111111")
 	BPFLoaderUpgradeableProgramID = MustPublicKeyFromBase58("BPFLoaderUpgradeab1e11111111111111111111111")
 
+	// Deploys, retracts, and executes programs on the chain; the successor of the upgradeable loader.
+	LoaderV4ProgramID = MustPublicKeyFromBase58("LoaderV411111111111111111111111111111111111")
+
 	// Verify secp256k1 public key recovery operations (ecrecover).
 	Secp256k1ProgramID = MustPublicKeyFromBase58("KeccakSecp256k11111111111111111111111111111")
 
+	// Verify ed25519 signatures; programs check them through the Instructions sysvar.
+	Ed25519ProgramID = MustPublicKeyFromBase58("Ed25519SigVerify111111111111111111111111111")
+
 	FeatureProgramID = MustPublicKeyFromBase58("Feature111111111111111111111111111111111111")
 
+	// Create and manage address lookup tables, used by versioned transactions.
+	AddressLookupTableProgramID = MustPublicKeyFromBase58("AddressLookupTab1e1111111111111111111111111")
+
 	ComputeBudget = MustPublicKeyFromBase58("ComputeBudget111111111111111111111111111111")
 )
 
@@ -69,6 +78,10 @@ var (
 	// and know they were approved by zero or more addresses
 	// by inspecting the transaction log from a trusted provider.
 	MemoProgramID = MustPublicKeyFromBase58("MemoSq4gqABAXKb96qnH8TysNcWxMyWCqXgDLGmfcHr")
+
+	// The first version of the Memo program, which only validates the memo
+	// and ignores the accounts provided.
+	MemoV1ProgramID = MustPublicKeyFromBase58("Memo1UhkJRfHyvLMcVucJwxXeuD728EqVDDwQDxFMNo")
 )
 
 var (
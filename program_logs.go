@@ -0,0 +1,332 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package solana
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/gagliardetto/solana-go/text"
+	"github.com/gagliardetto/treeout"
+)
+
+// ProgramInvocation is a program invocation parsed from the logs of a transaction,
+// with the invocations it made through CPI.
+type ProgramInvocation struct {
+	ProgramID PublicKey
+	// Depth of the invocation: 1 for the instructions of the transaction.
+	Depth int
+	// Index of the instruction of the transaction this invocation belongs to.
+	InstructionIndex int
+
+	// Messages of "Program log:" lines, and any other line logged by the program.
+	Logs []string
+	// Data of the "Program data:" lines; each entry holds the fields of one line.
+	Data [][][]byte
+	// Data of the last "Program return:" line, if any.
+	ReturnData []byte
+
+	ComputeUnitsConsumed uint64
+	ComputeUnitsLimit    uint64
+
+	// Success and Err are unset if the logs end before the invocation does.
+	Success bool
+	// Err is the failure reason of a failed invocation.
+	Err string
+
+	Invocations []*ProgramInvocation
+}
+
+// Failed tells whether the invocation logged a failure.
+func (inv *ProgramInvocation) Failed() bool {
+	return inv.Err != ""
+}
+
+// Completed tells whether the logs contain the end of the invocation.
+func (inv *ProgramInvocation) Completed() bool {
+	return inv.Success || inv.Failed()
+}
+
+// ProgramLogs is the invocation tree parsed from the log messages of a transaction.
+type ProgramLogs struct {
+	// Instructions holds one invocation for each executed instruction of the transaction.
+	Instructions []*ProgramInvocation
+	// Truncated is set if the runtime truncated the logs;
+	// the invocations still running at that point are not completed.
+	Truncated bool
+}
+
+// ComputeUnitsConsumed returns the compute units consumed by all the instructions.
+func (logs *ProgramLogs) ComputeUnitsConsumed() (total uint64) {
+	for _, inv := range logs.Instructions {
+		total += inv.ComputeUnitsConsumed
+	}
+	return total
+}
+
+// Failure returns the deepest failed invocation, if any.
+func (logs *ProgramLogs) Failure() *ProgramInvocation {
+	var failure *ProgramInvocation
+	var visit func(invs []*ProgramInvocation)
+	visit = func(invs []*ProgramInvocation) {
+		for _, inv := range invs {
+			if inv.Failed() {
+				failure = inv
+				visit(inv.Invocations)
+				return
+			}
+		}
+	}
+	visit(logs.Instructions)
+	return failure
+}
+
+const (
+	logTruncated        = "Log truncated"
+	programLogPrefix    = "Program log: "
+	programDataPrefix   = "Program data: "
+	programReturnPrefix = "Program return: "
+	programPrefix       = "Program "
+)
+
+// ParseProgramLogs parses the log messages of a transaction,
+// as found in the transaction metadata or in the result of a simulation.
+func ParseProgramLogs(logs []string) (*ProgramLogs, error) {
+	out := &ProgramLogs{}
+	var stack []*ProgramInvocation
+	current := func() *ProgramInvocation {
+		if len(stack) == 0 {
+			return nil
+		}
+		return stack[len(stack)-1]
+	}
+
+	for i, line := range logs {
+		if line == logTruncated {
+			out.Truncated = true
+			break
+		}
+		inv := current()
+		switch {
+		case strings.HasPrefix(line, programLogPrefix):
+			if inv == nil {
+				return nil, fmt.Errorf("log %d: program log outside of an invocation: %q", i, line)
+			}
+			inv.Logs = append(inv.Logs, strings.TrimPrefix(line, programLogPrefix))
+
+		case strings.HasPrefix(line, programDataPrefix):
+			if inv == nil {
+				return nil, fmt.Errorf("log %d: program data outside of an invocation: %q", i, line)
+			}
+			var fields [][]byte
+			for _, field := range strings.Fields(strings.TrimPrefix(line, programDataPrefix)) {
+				data, err := base64.StdEncoding.DecodeString(field)
+				if err != nil {
+					return nil, fmt.Errorf("log %d: invalid program data: %w", i, err)
+				}
+				fields = append(fields, data)
+			}
+			inv.Data = append(inv.Data, fields)
+
+		case strings.HasPrefix(line, programReturnPrefix):
+			if inv == nil {
+				return nil, fmt.Errorf("log %d: program return outside of an invocation: %q", i, line)
+			}
+			parts := strings.Fields(strings.TrimPrefix(line, programReturnPrefix))
+			if len(parts) == 0 || len(parts) > 2 {
+				return nil, fmt.Errorf("log %d: invalid program return: %q", i, line)
+			}
+			inv.ReturnData = []byte{}
+			if len(parts) == 2 {
+				data, err := base64.StdEncoding.DecodeString(parts[1])
+				if err != nil {
+					return nil, fmt.Errorf("log %d: invalid program return data: %w", i, err)
+				}
+				inv.ReturnData = data
+			}
+
+		default:
+			programID, rest, ok := parseProgramLogLine(line)
+			if !ok {
+				// Lines logged by builtin programs, or by the runtime.
+				if inv != nil {
+					inv.Logs = append(inv.Logs, line)
+				}
+				continue
+			}
+			if depth, ok := parseInvoke(rest); ok {
+				if depth != len(stack)+1 {
+					return nil, fmt.Errorf("log %d: invocation of %s at depth %d, expected depth %d", i, programID, depth, len(stack)+1)
+				}
+				child := &ProgramInvocation{
+					ProgramID: programID,
+					Depth:     depth,
+				}
+				if inv == nil {
+					child.InstructionIndex = len(out.Instructions)
+					out.Instructions = append(out.Instructions, child)
+				} else {
+					child.InstructionIndex = inv.InstructionIndex
+					inv.Invocations = append(inv.Invocations, child)
+				}
+				stack = append(stack, child)
+				continue
+			}
+			if inv == nil || !inv.ProgramID.Equals(programID) {
+				return nil, fmt.Errorf("log %d: unexpected log of program %s: %q", i, programID, line)
+			}
+			switch {
+			case rest == "success":
+				inv.Success = true
+				stack = stack[:len(stack)-1]
+			case strings.HasPrefix(rest, "failed: "):
+				inv.Err = strings.TrimPrefix(rest, "failed: ")
+				stack = stack[:len(stack)-1]
+			case strings.HasPrefix(rest, "consumed "):
+				consumed, limit, err := parseConsumed(rest)
+				if err != nil {
+					return nil, fmt.Errorf("log %d: %w", i, err)
+				}
+				inv.ComputeUnitsConsumed = consumed
+				inv.ComputeUnitsLimit = limit
+			default:
+				inv.Logs = append(inv.Logs, line)
+			}
+		}
+	}
+	return out, nil
+}
+
+// parseProgramLogLine splits a "Program <program ID> <rest>" line.
+func parseProgramLogLine(line string) (PublicKey, string, bool) {
+	if !strings.HasPrefix(line, programPrefix) {
+		return PublicKey{}, "", false
+	}
+	id, rest, ok := strings.Cut(strings.TrimPrefix(line, programPrefix), " ")
+	if !ok {
+		return PublicKey{}, "", false
+	}
+	programID, err := PublicKeyFromBase58(id)
+	if err != nil {
+		return PublicKey{}, "", false
+	}
+	return programID, rest, true
+}
+
+// parseInvoke parses "invoke [<depth>]".
+func parseInvoke(rest string) (int, bool) {
+	if !strings.HasPrefix(rest, "invoke [") || !strings.HasSuffix(rest, "]") {
+		return 0, false
+	}
+	depth, err := strconv.Atoi(rest[len("invoke [") : len(rest)-1])
+	if err != nil || depth < 1 {
+		return 0, false
+	}
+	return depth, true
+}
+
+// parseConsumed parses "consumed <consumed> of <limit> compute units".
+func parseConsumed(rest string) (consumed uint64, limit uint64, err error) {
+	var consumedStr, limitStr string
+	if _, err := fmt.Sscanf(rest, "consumed %s of %s compute units", &consumedStr, &limitStr); err != nil {
+		return 0, 0, fmt.Errorf("invalid compute units log: %q", rest)
+	}
+	if consumed, err = strconv.ParseUint(consumedStr, 10, 64); err != nil {
+		return 0, 0, fmt.Errorf("invalid consumed compute units: %w", err)
+	}
+	if limit, err = strconv.ParseUint(limitStr, 10, 64); err != nil {
+		return 0, 0, fmt.Errorf("invalid compute units limit: %w", err)
+	}
+	return consumed, limit, nil
+}
+
+func (logs *ProgramLogs) EncodeToTree(parent treeout.Branches) {
+	title := fmt.Sprintf("ProgramLogs[instructions=%v, computeUnits=%v]", len(logs.Instructions), logs.ComputeUnitsConsumed())
+	if logs.Truncated {
+		title += " " + text.RedBG("truncated")
+	}
+	parent.Child(title).ParentFunc(func(logsBranch treeout.Branches) {
+		for _, inv := range logs.Instructions {
+			inv.EncodeToTree(logsBranch)
+		}
+	})
+}
+
+func (inv *ProgramInvocation) EncodeToTree(parent treeout.Branches) {
+	var status string
+	switch {
+	case inv.Success:
+		status = text.Lime("success")
+	case inv.Failed():
+		status = text.Red("failed: " + inv.Err)
+	default:
+		status = text.Yellow("incomplete")
+	}
+	title := text.Sf("[%v] %s %s %s", inv.InstructionIndex, text.IndigoBG("Program"), text.ColorizeBG(inv.ProgramID.String()), status)
+
+	parent.Child(title).ParentFunc(func(invBranch treeout.Branches) {
+		invBranch.Child(text.Sf("Depth: %v", inv.Depth))
+		if inv.ComputeUnitsConsumed > 0 || inv.ComputeUnitsLimit > 0 {
+			invBranch.Child(text.Sf("ComputeUnits: %v of %v", inv.ComputeUnitsConsumed, inv.ComputeUnitsLimit))
+		}
+		if len(inv.Logs) > 0 {
+			invBranch.Child(text.Sf("Logs[len=%v]", len(inv.Logs))).ParentFunc(func(logsBranch treeout.Branches) {
+				for _, log := range inv.Logs {
+					logsBranch.Child(log)
+				}
+			})
+		}
+		if len(inv.Data) > 0 {
+			invBranch.Child(text.Sf("Data[len=%v]", len(inv.Data))).ParentFunc(func(dataBranch treeout.Branches) {
+				for _, fields := range inv.Data {
+					encoded := make([]string, len(fields))
+					for i, field := range fields {
+						encoded[i] = base64.StdEncoding.EncodeToString(field)
+					}
+					dataBranch.Child(strings.Join(encoded, " "))
+				}
+			})
+		}
+		if inv.ReturnData != nil {
+			invBranch.Child("ReturnData: " + base64.StdEncoding.EncodeToString(inv.ReturnData))
+		}
+		if len(inv.Invocations) > 0 {
+			invBranch.Child(text.Sf("Invocations[len=%v]", len(inv.Invocations))).ParentFunc(func(invocationsBranch treeout.Branches) {
+				for _, child := range inv.Invocations {
+					child.EncodeToTree(invocationsBranch)
+				}
+			})
+		}
+	})
+}
+
+func (logs *ProgramLogs) EncodeTree(encoder *text.TreeEncoder) (int, error) {
+	logs.EncodeToTree(encoder)
+	return encoder.WriteString(encoder.Tree.String())
+}
+
+// String returns a human-readable invocation tree.
+// To disable colors, set "github.com/gagliardetto/solana-go/text".DisableColors = true
+func (logs *ProgramLogs) String() string {
+	buf := new(bytes.Buffer)
+	_, err := logs.EncodeTree(text.NewTreeEncoder(buf, ""))
+	if err != nil {
+		panic(err)
+	}
+	return buf.String()
+}
@@ -0,0 +1,153 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package solana
+
+import (
+	"testing"
+
+	"github.com/gagliardetto/solana-go/text"
+	"github.com/stretchr/testify/require"
+)
+
+var testProgramLogs = []string{
+	"Program ComputeBudget111111111111111111111111111111 invoke [1]",
+	"Program ComputeBudget111111111111111111111111111111 success",
+	"Program JUP6LkbZbjS1jKKwapdHNy74zcZ3tLUZoi5QNyVTaV4 invoke [1]",
+	"Program log: Instruction: Route",
+	"Program TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA invoke [2]",
+	"Program log: Instruction: Transfer",
+	"Program TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA consumed 4645 of 180000 compute units",
+	"Program TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA success",
+	"Program data: AQID BAU=",
+	"Program return: JUP6LkbZbjS1jKKwapdHNy74zcZ3tLUZoi5QNyVTaV4 KgAAAAAAAAA=",
+	"Program JUP6LkbZbjS1jKKwapdHNy74zcZ3tLUZoi5QNyVTaV4 consumed 25000 of 199850 compute units",
+	"Program JUP6LkbZbjS1jKKwapdHNy74zcZ3tLUZoi5QNyVTaV4 success",
+	"Program 11111111111111111111111111111111 invoke [1]",
+	"Transfer: insufficient lamports 100, need 200",
+	"Program 11111111111111111111111111111111 failed: custom program error: 0x1",
+}
+
+func TestParseProgramLogs(t *testing.T) {
+	logs, err := ParseProgramLogs(testProgramLogs)
+	require.NoError(t, err)
+	require.False(t, logs.Truncated)
+	require.Len(t, logs.Instructions, 3)
+
+	budget := logs.Instructions[0]
+	require.Equal(t, ComputeBudget, budget.ProgramID)
+	require.True(t, budget.Success)
+	require.Equal(t, 1, budget.Depth)
+
+	route := logs.Instructions[1]
+	require.Equal(t, MPK("JUP6LkbZbjS1jKKwapdHNy74zcZ3tLUZoi5QNyVTaV4"), route.ProgramID)
+	require.Equal(t, 1, route.InstructionIndex)
+	require.Equal(t, []string{"Instruction: Route"}, route.Logs)
+	require.Equal(t, [][][]byte{{{1, 2, 3}, {4, 5}}}, route.Data)
+	require.Equal(t, []byte{42, 0, 0, 0, 0, 0, 0, 0}, route.ReturnData)
+	require.Equal(t, uint64(25000), route.ComputeUnitsConsumed)
+	require.Equal(t, uint64(199850), route.ComputeUnitsLimit)
+	require.True(t, route.Success)
+	require.True(t, route.Completed())
+
+	require.Len(t, route.Invocations, 1)
+	transfer := route.Invocations[0]
+	require.Equal(t, TokenProgramID, transfer.ProgramID)
+	require.Equal(t, 2, transfer.Depth)
+	require.Equal(t, 1, transfer.InstructionIndex)
+	require.Equal(t, []string{"Instruction: Transfer"}, transfer.Logs)
+	require.Equal(t, uint64(4645), transfer.ComputeUnitsConsumed)
+	require.True(t, transfer.Success)
+
+	failed := logs.Instructions[2]
+	require.Equal(t, SystemProgramID, failed.ProgramID)
+	require.False(t, failed.Success)
+	require.True(t, failed.Failed())
+	require.Equal(t, "custom program error: 0x1", failed.Err)
+	require.Equal(t, []string{"Transfer: insufficient lamports 100, need 200"}, failed.Logs)
+
+	require.Equal(t, uint64(25000), logs.ComputeUnitsConsumed())
+	require.Equal(t, failed, logs.Failure())
+}
+
+func TestParseProgramLogsTruncated(t *testing.T) {
+	logs, err := ParseProgramLogs([]string{
+		"Program JUP6LkbZbjS1jKKwapdHNy74zcZ3tLUZoi5QNyVTaV4 invoke [1]",
+		"Program TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA invoke [2]",
+		"Program log: Instruction: Transfer",
+		"Log truncated",
+	})
+	require.NoError(t, err)
+	require.True(t, logs.Truncated)
+	require.Len(t, logs.Instructions, 1)
+	require.False(t, logs.Instructions[0].Completed())
+	require.False(t, logs.Instructions[0].Invocations[0].Completed())
+	require.Nil(t, logs.Failure())
+}
+
+func TestParseProgramLogsNestedFailure(t *testing.T) {
+	logs, err := ParseProgramLogs([]string{
+		"Program JUP6LkbZbjS1jKKwapdHNy74zcZ3tLUZoi5QNyVTaV4 invoke [1]",
+		"Program TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA invoke [2]",
+		"Program log: Error: insufficient funds",
+		"Program TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA consumed 3000 of 190000 compute units",
+		"Program TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA failed: custom program error: 0x1",
+		"Program JUP6LkbZbjS1jKKwapdHNy74zcZ3tLUZoi5QNyVTaV4 consumed 10000 of 200000 compute units",
+		"Program JUP6LkbZbjS1jKKwapdHNy74zcZ3tLUZoi5QNyVTaV4 failed: custom program error: 0x1",
+	})
+	require.NoError(t, err)
+	failure := logs.Failure()
+	require.NotNil(t, failure)
+	require.Equal(t, TokenProgramID, failure.ProgramID)
+	require.Equal(t, 2, failure.Depth)
+}
+
+func TestParseProgramLogsErrors(t *testing.T) {
+	for _, logs := range [][]string{
+		{"Program log: outside"},
+		{"Program 11111111111111111111111111111111 invoke [2]"},
+		{"Program 11111111111111111111111111111111 success"},
+		{
+			"Program 11111111111111111111111111111111 invoke [1]",
+			"Program TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA success",
+		},
+		{
+			"Program 11111111111111111111111111111111 invoke [1]",
+			"Program data: not-base64!",
+		},
+		{
+			"Program 11111111111111111111111111111111 invoke [1]",
+			"Program 11111111111111111111111111111111 consumed many of 200000 compute units",
+		},
+	} {
+		_, err := ParseProgramLogs(logs)
+		require.Error(t, err, logs)
+	}
+}
+
+func TestProgramLogsString(t *testing.T) {
+	text.DisableColors = true
+	defer func() { text.DisableColors = false }()
+
+	logs, err := ParseProgramLogs(testProgramLogs)
+	require.NoError(t, err)
+	out := logs.String()
+	require.Contains(t, out, "ProgramLogs[instructions=3, computeUnits=25000]")
+	require.Contains(t, out, "[1] Program JUP6LkbZbjS1jKKwapdHNy74zcZ3tLUZoi5QNyVTaV4 success")
+	require.Contains(t, out, "ComputeUnits: 4645 of 180000")
+	require.Contains(t, out, "Instruction: Transfer")
+	require.Contains(t, out, "AQID BAU=")
+	require.Contains(t, out, "ReturnData: KgAAAAAAAAA=")
+	require.Contains(t, out, "failed: custom program error: 0x1")
+}
@@ -0,0 +1,144 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package addresslookuptable
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	ag_binary "github.com/gagliardetto/binary"
+	ag_solanago "github.com/gagliardetto/solana-go"
+	ag_format "github.com/gagliardetto/solana-go/text/format"
+	ag_treeout "github.com/gagliardetto/treeout"
+)
+
+// Close an address lookup table account.
+type CloseLookupTable struct {
+	// [0] = [WRITE] LookupTableAccount
+	// ··········· Address lookup table account to close
+	//
+	// [1] = [SIGNER] AuthorityAccount
+	// ··········· Current authority
+	//
+	// [2] = [WRITE] RecipientAccount
+	// ··········· Recipient of closed account lamports
+	ag_solanago.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+// NewCloseLookupTableInstructionBuilder creates a new `CloseLookupTable` instruction builder.
+func NewCloseLookupTableInstructionBuilder() *CloseLookupTable {
+	nd := &CloseLookupTable{
+		AccountMetaSlice: make(ag_solanago.AccountMetaSlice, 3),
+	}
+	return nd
+}
+
+// Address lookup table account to close
+func (inst *CloseLookupTable) SetLookupTableAccount(lookupTableAccount ag_solanago.PublicKey) *CloseLookupTable {
+	inst.AccountMetaSlice[0] = ag_solanago.Meta(lookupTableAccount).WRITE()
+	return inst
+}
+
+func (inst *CloseLookupTable) GetLookupTableAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[0]
+}
+
+// Current authority
+func (inst *CloseLookupTable) SetAuthorityAccount(authorityAccount ag_solanago.PublicKey) *CloseLookupTable {
+	inst.AccountMetaSlice[1] = ag_solanago.Meta(authorityAccount).SIGNER()
+	return inst
+}
+
+func (inst *CloseLookupTable) GetAuthorityAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[1]
+}
+
+// Recipient of closed account lamports
+func (inst *CloseLookupTable) SetRecipientAccount(recipientAccount ag_solanago.PublicKey) *CloseLookupTable {
+	inst.AccountMetaSlice[2] = ag_solanago.Meta(recipientAccount).WRITE()
+	return inst
+}
+
+func (inst *CloseLookupTable) GetRecipientAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[2]
+}
+
+func (inst CloseLookupTable) Build() *Instruction {
+	return &Instruction{BaseVariant: ag_binary.BaseVariant{
+		Impl:   inst,
+		TypeID: ag_binary.TypeIDFromUint32(Instruction_CloseLookupTable, binary.LittleEndian),
+	}}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (inst CloseLookupTable) ValidateAndBuild() (*Instruction, error) {
+	if err := inst.Validate(); err != nil {
+		return nil, err
+	}
+	return inst.Build(), nil
+}
+
+func (inst *CloseLookupTable) Validate() error {
+	// Check whether all (required) accounts are set:
+	for accIndex, acc := range inst.AccountMetaSlice {
+		if acc == nil {
+			return fmt.Errorf("ins.AccountMetaSlice[%v] is not set", accIndex)
+		}
+	}
+	return nil
+}
+
+func (inst *CloseLookupTable) EncodeToTree(parent ag_treeout.Branches) {
+	parent.Child(ag_format.Program(ProgramName, ProgramID)).
+		//
+		ParentFunc(func(programBranch ag_treeout.Branches) {
+			programBranch.Child(ag_format.Instruction("CloseLookupTable")).
+				//
+				ParentFunc(func(instructionBranch ag_treeout.Branches) {
+
+					// Parameters of the instruction:
+					instructionBranch.Child("Params").ParentFunc(func(paramsBranch ag_treeout.Branches) {})
+
+					// Accounts of the instruction:
+					instructionBranch.Child("Accounts").ParentFunc(func(accountsBranch ag_treeout.Branches) {
+						accountsBranch.Child(ag_format.Meta("LookupTable", inst.AccountMetaSlice.Get(0)))
+						accountsBranch.Child(ag_format.Meta("  Authority", inst.AccountMetaSlice.Get(1)))
+						accountsBranch.Child(ag_format.Meta("  Recipient", inst.AccountMetaSlice.Get(2)))
+					})
+				})
+		})
+}
+
+func (inst CloseLookupTable) MarshalWithEncoder(encoder *ag_binary.Encoder) error {
+	return nil
+}
+
+func (inst *CloseLookupTable) UnmarshalWithDecoder(decoder *ag_binary.Decoder) error {
+	return nil
+}
+
+// NewCloseLookupTableInstruction declares a new CloseLookupTable instruction with the provided parameters and accounts.
+func NewCloseLookupTableInstruction(
+	// Accounts:
+	lookupTableAccount ag_solanago.PublicKey,
+	authorityAccount ag_solanago.PublicKey,
+	recipientAccount ag_solanago.PublicKey) *CloseLookupTable {
+	return NewCloseLookupTableInstructionBuilder().
+		SetLookupTableAccount(lookupTableAccount).
+		SetAuthorityAccount(authorityAccount).
+		SetRecipientAccount(recipientAccount)
+}
@@ -0,0 +1,219 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package addresslookuptable
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	ag_binary "github.com/gagliardetto/binary"
+	ag_solanago "github.com/gagliardetto/solana-go"
+	ag_format "github.com/gagliardetto/solana-go/text/format"
+	ag_treeout "github.com/gagliardetto/treeout"
+)
+
+// Extend an address lookup table with new addresses.
+//
+// The payer and system program accounts are only required
+// if the table needs to be funded for the extra space.
+type ExtendLookupTable struct {
+	// Addresses to append to the table
+	NewAddresses []ag_solanago.PublicKey
+
+	// [0] = [WRITE] LookupTableAccount
+	// ··········· Address lookup table account to extend
+	//
+	// [1] = [SIGNER] AuthorityAccount
+	// ··········· Current authority
+	//
+	// [2] = [WRITE, SIGNER] PayerAccount
+	// ··········· Account that will fund the table reallocation (optional)
+	//
+	// [3] = [] SystemProgramAccount
+	// ··········· System program for CPI (optional)
+	ag_solanago.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+// NewExtendLookupTableInstructionBuilder creates a new `ExtendLookupTable` instruction builder.
+func NewExtendLookupTableInstructionBuilder() *ExtendLookupTable {
+	nd := &ExtendLookupTable{
+		AccountMetaSlice: make(ag_solanago.AccountMetaSlice, 2),
+	}
+	return nd
+}
+
+// Addresses to append to the table
+func (inst *ExtendLookupTable) SetNewAddresses(newAddresses []ag_solanago.PublicKey) *ExtendLookupTable {
+	inst.NewAddresses = newAddresses
+	return inst
+}
+
+// Address lookup table account to extend
+func (inst *ExtendLookupTable) SetLookupTableAccount(lookupTableAccount ag_solanago.PublicKey) *ExtendLookupTable {
+	inst.AccountMetaSlice[0] = ag_solanago.Meta(lookupTableAccount).WRITE()
+	return inst
+}
+
+func (inst *ExtendLookupTable) GetLookupTableAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[0]
+}
+
+// Current authority
+func (inst *ExtendLookupTable) SetAuthorityAccount(authorityAccount ag_solanago.PublicKey) *ExtendLookupTable {
+	inst.AccountMetaSlice[1] = ag_solanago.Meta(authorityAccount).SIGNER()
+	return inst
+}
+
+func (inst *ExtendLookupTable) GetAuthorityAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[1]
+}
+
+// Account that will fund the table reallocation;
+// also sets the system program account, which is required along with the payer.
+func (inst *ExtendLookupTable) SetPayerAccount(payerAccount ag_solanago.PublicKey) *ExtendLookupTable {
+	for len(inst.AccountMetaSlice) <= 3 {
+		inst.AccountMetaSlice = append(inst.AccountMetaSlice, nil)
+	}
+	inst.AccountMetaSlice[2] = ag_solanago.Meta(payerAccount).WRITE().SIGNER()
+	if inst.AccountMetaSlice[3] == nil {
+		inst.AccountMetaSlice[3] = ag_solanago.Meta(ag_solanago.SystemProgramID)
+	}
+	return inst
+}
+
+func (inst *ExtendLookupTable) GetPayerAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(2)
+}
+
+// System program for CPI
+func (inst *ExtendLookupTable) SetSystemProgramAccount(systemProgramAccount ag_solanago.PublicKey) *ExtendLookupTable {
+	for len(inst.AccountMetaSlice) <= 3 {
+		inst.AccountMetaSlice = append(inst.AccountMetaSlice, nil)
+	}
+	inst.AccountMetaSlice[3] = ag_solanago.Meta(systemProgramAccount)
+	return inst
+}
+
+func (inst *ExtendLookupTable) GetSystemProgramAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(3)
+}
+
+func (inst ExtendLookupTable) Build() *Instruction {
+	return &Instruction{BaseVariant: ag_binary.BaseVariant{
+		Impl:   inst,
+		TypeID: ag_binary.TypeIDFromUint32(Instruction_ExtendLookupTable, binary.LittleEndian),
+	}}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (inst ExtendLookupTable) ValidateAndBuild() (*Instruction, error) {
+	if err := inst.Validate(); err != nil {
+		return nil, err
+	}
+	return inst.Build(), nil
+}
+
+func (inst *ExtendLookupTable) Validate() error {
+	// Check whether all (required) parameters are set:
+	{
+		if len(inst.NewAddresses) == 0 {
+			return errors.New("NewAddresses parameter is not set")
+		}
+	}
+
+	// Check whether all (required) accounts are set:
+	for accIndex, acc := range inst.AccountMetaSlice[:2] {
+		if acc == nil {
+			return fmt.Errorf("ins.AccountMetaSlice[%v] is not set", accIndex)
+		}
+	}
+	return nil
+}
+
+func (inst *ExtendLookupTable) EncodeToTree(parent ag_treeout.Branches) {
+	parent.Child(ag_format.Program(ProgramName, ProgramID)).
+		//
+		ParentFunc(func(programBranch ag_treeout.Branches) {
+			programBranch.Child(ag_format.Instruction("ExtendLookupTable")).
+				//
+				ParentFunc(func(instructionBranch ag_treeout.Branches) {
+
+					// Parameters of the instruction:
+					instructionBranch.Child("Params").ParentFunc(func(paramsBranch ag_treeout.Branches) {
+						paramsBranch.Child(ag_format.Param("NewAddresses", inst.NewAddresses))
+					})
+
+					// Accounts of the instruction:
+					instructionBranch.Child("Accounts").ParentFunc(func(accountsBranch ag_treeout.Branches) {
+						accountsBranch.Child(ag_format.Meta("  LookupTable", inst.AccountMetaSlice.Get(0)))
+						accountsBranch.Child(ag_format.Meta("    Authority", inst.AccountMetaSlice.Get(1)))
+						accountsBranch.Child(ag_format.Meta("        Payer", inst.AccountMetaSlice.Get(2)))
+						accountsBranch.Child(ag_format.Meta("SystemProgram", inst.AccountMetaSlice.Get(3)))
+					})
+				})
+		})
+}
+
+func (inst ExtendLookupTable) MarshalWithEncoder(encoder *ag_binary.Encoder) error {
+	// Serialize `NewAddresses` param:
+	{
+		err := encoder.WriteUint64(uint64(len(inst.NewAddresses)), binary.LittleEndian)
+		if err != nil {
+			return err
+		}
+		for _, v := range inst.NewAddresses {
+			if err = encoder.Encode(v); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (inst *ExtendLookupTable) UnmarshalWithDecoder(decoder *ag_binary.Decoder) error {
+	// Deserialize `NewAddresses` param:
+	{
+		length, err := decoder.ReadUint64(binary.LittleEndian)
+		if err != nil {
+			return err
+		}
+		if length > uint64(decoder.Remaining()/32) {
+			return fmt.Errorf("invalid NewAddresses length: %d", length)
+		}
+		inst.NewAddresses = make([]ag_solanago.PublicKey, length)
+		for i := range inst.NewAddresses {
+			if err = decoder.Decode(&inst.NewAddresses[i]); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// NewExtendLookupTableInstruction declares a new ExtendLookupTable instruction with the provided parameters and accounts.
+func NewExtendLookupTableInstruction(
+	// Parameters:
+	newAddresses []ag_solanago.PublicKey,
+	// Accounts:
+	lookupTableAccount ag_solanago.PublicKey,
+	authorityAccount ag_solanago.PublicKey) *ExtendLookupTable {
+	return NewExtendLookupTableInstructionBuilder().
+		SetNewAddresses(newAddresses).
+		SetLookupTableAccount(lookupTableAccount).
+		SetAuthorityAccount(authorityAccount)
+}
@@ -2,6 +2,7 @@ package addresslookuptable
 
 import (
 	"context"
+	"encoding/binary"
 	"fmt"
 	"math"
 
@@ -16,6 +17,20 @@ const (
 	LOOKUP_TABLE_MAX_ADDRESSES = 256
 )
 
+// DeriveLookupTableAddress derives the address of the lookup table
+// created by the authority at the provided recent slot.
+func DeriveLookupTableAddress(authority solana.PublicKey, recentSlot uint64) (solana.PublicKey, uint8, error) {
+	slot := make([]byte, 8)
+	binary.LittleEndian.PutUint64(slot, recentSlot)
+	return solana.FindProgramAddress(
+		[][]byte{
+			authority[:],
+			slot,
+		},
+		ProgramID,
+	)
+}
+
 // DecodeAddressLookupTableState decodes the given account bytes into a AddressLookupTableState.
 func DecodeAddressLookupTableState(data []byte) (*AddressLookupTableState, error) {
 	decoder := bin.NewBinDecoder(data)
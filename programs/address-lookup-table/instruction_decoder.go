@@ -0,0 +1,21 @@
+package addresslookuptable
+
+import (
+	"github.com/gagliardetto/solana-go"
+)
+
+var (
+	DecodeCloseLookupTable      = decode[*CloseLookupTable]()
+	DecodeCreateLookupTable     = decode[*CreateLookupTable]()
+	DecodeDeactivateLookupTable = decode[*DeactivateLookupTable]()
+	DecodeExtendLookupTable     = decode[*ExtendLookupTable]()
+	DecodeFreezeLookupTable     = decode[*FreezeLookupTable]()
+)
+
+func decode[T any]() func(*solana.Message, int) (T, error) {
+	return solana.DecodeInstructionType[*Instruction, T](
+		ProgramID,
+		InstructionImplDef,
+		DecodeInstruction,
+	)
+}
@@ -0,0 +1,168 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Create and manage address lookup tables, used by versioned transactions
+// to load more accounts than fit in a single transaction.
+
+package addresslookuptable
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+
+	ag_spew "github.com/davecgh/go-spew/spew"
+	ag_binary "github.com/gagliardetto/binary"
+	ag_solanago "github.com/gagliardetto/solana-go"
+	ag_text "github.com/gagliardetto/solana-go/text"
+	ag_treeout "github.com/gagliardetto/treeout"
+)
+
+var ProgramID ag_solanago.PublicKey = ag_solanago.AddressLookupTableProgramID
+
+func SetProgramID(pubkey ag_solanago.PublicKey) {
+	ProgramID = pubkey
+	ag_solanago.RegisterInstructionDecoder(ProgramID, registryDecodeInstruction)
+}
+
+const ProgramName = "AddressLookupTable"
+
+func init() {
+	ag_solanago.RegisterInstructionDecoder(ProgramID, registryDecodeInstruction)
+}
+
+const (
+	// Create an address lookup table
+	Instruction_CreateLookupTable uint32 = iota
+
+	// Permanently freeze an address lookup table, making it immutable
+	Instruction_FreezeLookupTable
+
+	// Extend an address lookup table with new addresses
+	Instruction_ExtendLookupTable
+
+	// Deactivate an address lookup table, making it unusable and
+	// eligible for closure after a short period of time
+	Instruction_DeactivateLookupTable
+
+	// Close an address lookup table account
+	Instruction_CloseLookupTable
+)
+
+// InstructionIDToName returns the name of the instruction given its ID.
+func InstructionIDToName(id uint32) string {
+	switch id {
+	case Instruction_CreateLookupTable:
+		return "CreateLookupTable"
+	case Instruction_FreezeLookupTable:
+		return "FreezeLookupTable"
+	case Instruction_ExtendLookupTable:
+		return "ExtendLookupTable"
+	case Instruction_DeactivateLookupTable:
+		return "DeactivateLookupTable"
+	case Instruction_CloseLookupTable:
+		return "CloseLookupTable"
+	default:
+		return ""
+	}
+}
+
+type Instruction struct {
+	ag_binary.BaseVariant
+}
+
+func (inst *Instruction) EncodeToTree(parent ag_treeout.Branches) {
+	if enToTree, ok := inst.Impl.(ag_text.EncodableToTree); ok {
+		enToTree.EncodeToTree(parent)
+	} else {
+		parent.Child(ag_spew.Sdump(inst))
+	}
+}
+
+var InstructionImplDef = ag_binary.NewVariantDefinition(
+	ag_binary.Uint32TypeIDEncoding,
+	[]ag_binary.VariantType{
+		{Name: "CreateLookupTable", Type: (*CreateLookupTable)(nil)},
+		{Name: "FreezeLookupTable", Type: (*FreezeLookupTable)(nil)},
+		{Name: "ExtendLookupTable", Type: (*ExtendLookupTable)(nil)},
+		{Name: "DeactivateLookupTable", Type: (*DeactivateLookupTable)(nil)},
+		{Name: "CloseLookupTable", Type: (*CloseLookupTable)(nil)},
+	},
+)
+
+func (inst *Instruction) ProgramID() ag_solanago.PublicKey {
+	return ProgramID
+}
+
+func (inst *Instruction) Accounts() (out []*ag_solanago.AccountMeta) {
+	return inst.Impl.(ag_solanago.AccountsGettable).GetAccounts()
+}
+
+func (inst *Instruction) Data() ([]byte, error) {
+	buf := new(bytes.Buffer)
+	if err := ag_binary.NewBinEncoder(buf).Encode(inst); err != nil {
+		return nil, fmt.Errorf("unable to encode instruction: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func (a *Instruction) AssertEquivalent(in ag_solanago.Instruction) error {
+	b, ok := in.(*Instruction)
+	if !ok {
+		return fmt.Errorf("expected %T, but got %T", a, in)
+	}
+	equiv, ok := a.BaseVariant.Impl.(ag_solanago.EquivalenceAssertable[interface{}])
+	if !ok {
+		return ag_solanago.CheckInstructionEquivalence(a, b)
+	}
+	return equiv.AssertEquivalent(b.BaseVariant.Impl)
+}
+
+func (inst *Instruction) TextEncode(encoder *ag_text.Encoder, option *ag_text.Option) error {
+	return encoder.Encode(inst.Impl, option)
+}
+
+func (inst *Instruction) UnmarshalWithDecoder(decoder *ag_binary.Decoder) error {
+	return inst.BaseVariant.UnmarshalBinaryVariant(decoder, InstructionImplDef)
+}
+
+func (inst Instruction) MarshalWithEncoder(encoder *ag_binary.Encoder) error {
+	err := encoder.WriteUint32(inst.TypeID.Uint32(), binary.LittleEndian)
+	if err != nil {
+		return fmt.Errorf("unable to write variant type: %w", err)
+	}
+	return encoder.Encode(inst.Impl)
+}
+
+func registryDecodeInstruction(accounts []*ag_solanago.AccountMeta, data []byte) (interface{}, error) {
+	inst, err := DecodeInstruction(accounts, data)
+	if err != nil {
+		return nil, err
+	}
+	return inst, nil
+}
+
+func DecodeInstruction(accounts []*ag_solanago.AccountMeta, data []byte) (*Instruction, error) {
+	inst := new(Instruction)
+	if err := ag_binary.NewBinDecoder(data).Decode(inst); err != nil {
+		return nil, fmt.Errorf("unable to decode instruction: %w", err)
+	}
+	if v, ok := inst.Impl.(ag_solanago.AccountsSettable); ok {
+		err := v.SetAccounts(accounts)
+		if err != nil {
+			return nil, fmt.Errorf("unable to set accounts for instruction: %w", err)
+		}
+	}
+	return inst, nil
+}
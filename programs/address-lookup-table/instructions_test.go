@@ -0,0 +1,102 @@
+package addresslookuptable
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeriveLookupTableAddress(t *testing.T) {
+	authority := solana.MPK("9FRhPDoDk9JrpCqc4r51qTWgdBTxM892TdjexeErQUNs")
+	recentSlot := uint64(154742572)
+
+	address, bump, err := DeriveLookupTableAddress(authority, recentSlot)
+	require.NoError(t, err)
+
+	slot := make([]byte, 8)
+	binary.LittleEndian.PutUint64(slot, recentSlot)
+	expected, err := solana.CreateProgramAddress([][]byte{authority[:], slot, {bump}}, ProgramID)
+	require.NoError(t, err)
+	require.Equal(t, expected, address)
+}
+
+func TestCreateLookupTable(t *testing.T) {
+	authority := solana.NewWallet().PublicKey()
+	payer := solana.NewWallet().PublicKey()
+
+	builder, address, err := NewCreateLookupTableInstructionFromSlot(42, authority, payer)
+	require.NoError(t, err)
+	inst, err := builder.ValidateAndBuild()
+	require.NoError(t, err)
+
+	_, bump, err := DeriveLookupTableAddress(authority, 42)
+	require.NoError(t, err)
+
+	data, err := inst.Data()
+	require.NoError(t, err)
+	require.Equal(t, []byte{0, 0, 0, 0, 42, 0, 0, 0, 0, 0, 0, 0, bump}, data)
+
+	accounts := inst.Accounts()
+	require.Len(t, accounts, 4)
+	require.Equal(t, address, accounts[0].PublicKey)
+	require.True(t, accounts[0].IsWritable)
+	require.False(t, accounts[1].IsSigner)
+	require.True(t, accounts[2].IsSigner)
+	require.Equal(t, solana.SystemProgramID, accounts[3].PublicKey)
+}
+
+func TestExtendLookupTable(t *testing.T) {
+	table := solana.NewWallet().PublicKey()
+	authority := solana.NewWallet().PublicKey()
+	payer := solana.NewWallet().PublicKey()
+	addresses := []solana.PublicKey{
+		solana.SystemProgramID,
+		solana.TokenProgramID,
+	}
+
+	inst := NewExtendLookupTableInstruction(addresses, table, authority).Build()
+	require.Len(t, inst.Accounts(), 2)
+
+	inst, err := NewExtendLookupTableInstruction(addresses, table, authority).
+		SetPayerAccount(payer).
+		ValidateAndBuild()
+	require.NoError(t, err)
+	accounts := inst.Accounts()
+	require.Len(t, accounts, 4)
+	require.Equal(t, solana.SystemProgramID, accounts[3].PublicKey)
+
+	data, err := inst.Data()
+	require.NoError(t, err)
+	require.Len(t, data, 4+8+2*32)
+	require.Equal(t, uint32(Instruction_ExtendLookupTable), binary.LittleEndian.Uint32(data[0:4]))
+	require.Equal(t, uint64(2), binary.LittleEndian.Uint64(data[4:12]))
+
+	decoded, err := DecodeInstruction(accounts, data)
+	require.NoError(t, err)
+	extend, ok := decoded.Impl.(*ExtendLookupTable)
+	require.True(t, ok)
+	require.Equal(t, addresses, extend.NewAddresses)
+	require.Equal(t, payer, extend.GetPayerAccount().PublicKey)
+}
+
+func TestInstructionTree(t *testing.T) {
+	table := solana.NewWallet().PublicKey()
+	authority := solana.NewWallet().PublicKey()
+	recipient := solana.NewWallet().PublicKey()
+
+	tx, err := solana.NewTransaction(
+		[]solana.Instruction{
+			NewDeactivateLookupTableInstruction(table, authority).Build(),
+			NewCloseLookupTableInstruction(table, authority, recipient).Build(),
+		},
+		solana.Hash{},
+		solana.TransactionPayer(authority),
+	)
+	require.NoError(t, err)
+
+	out := tx.String()
+	require.Contains(t, out, "DeactivateLookupTable")
+	require.Contains(t, out, "CloseLookupTable")
+}
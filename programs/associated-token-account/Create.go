@@ -53,7 +53,10 @@ type Create struct {
 	// [6] = [] SysVarRent
 	// ··········· SysVarRentPubkey
 	solana.AccountMetaSlice `bin:"-" borsh_skip:"true"`
-	Idempotent              bool `bin:"-" borsh_skip:"true"`
+
+	// Deprecated: use CreateIdempotent instead; when set, the instruction
+	// is encoded as CreateIdempotent.
+	Idempotent bool `bin:"-" borsh_skip:"true"`
 }
 
 // NewCreateInstructionBuilder creates a new `Create` instruction builder.
@@ -138,7 +141,7 @@ func (inst Create) Build() *Instruction {
 
 	return &Instruction{BaseVariant: bin.BaseVariant{
 		Impl:   inst,
-		TypeID: bin.NoTypeIDDefaultID,
+		TypeID: bin.TypeIDFromUint8(Instruction_Create),
 	}}
 }
 
@@ -162,6 +165,9 @@ func (inst *Create) Validate() error {
 	if inst.Mint.IsZero() {
 		return errors.New("Mint not set")
 	}
+	if inst.TokenProgramID.IsZero() {
+		return errors.New("TokenProgramID not set")
+	}
 	_, _, err := solana.FindAssociatedTokenAddress(
 		inst.Wallet,
 		inst.Mint,
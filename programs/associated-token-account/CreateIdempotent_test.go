@@ -0,0 +1,90 @@
+package associatedtokenaccount
+
+import (
+	"testing"
+
+	solana "github.com/gagliardetto/solana-go"
+	"github.com/test-go/testify/assert"
+	"github.com/test-go/testify/require"
+)
+
+func TestCreateIdempotentInstructionData(t *testing.T) {
+	t.Parallel()
+	wallet, err := solana.NewRandomPrivateKey()
+	require.NoError(t, err)
+
+	c, err := NewCreateIdempotentInstruction(
+		wallet.PublicKey(),
+		wallet.PublicKey(),
+		solana.MPK("G8iheDY9bGix5qCXEitCExLcgZzZrEemngk9cbTR3CQs"),
+		solana.TokenProgramID,
+	).ValidateAndBuild()
+	require.NoError(t, err)
+
+	data, err := c.Data()
+	require.NoError(t, err)
+	assert.Equal(t, []byte{1}, data)
+
+	decoded, err := DecodeInstruction(c.Accounts(), data)
+	require.NoError(t, err)
+	require.IsType(t, &CreateIdempotent{}, decoded.Impl)
+	require.NoError(t, c.AssertEquivalent(decoded))
+}
+
+func TestDecodeCreateWithoutData(t *testing.T) {
+	t.Parallel()
+	wallet, err := solana.NewRandomPrivateKey()
+	require.NoError(t, err)
+
+	c := NewCreateInstruction(
+		wallet.PublicKey(),
+		wallet.PublicKey(),
+		solana.MPK("G8iheDY9bGix5qCXEitCExLcgZzZrEemngk9cbTR3CQs"),
+		solana.TokenProgramID,
+		false,
+	).Build()
+
+	decoded, err := DecodeInstruction(c.Accounts(), nil)
+	require.NoError(t, err)
+	require.IsType(t, &Create{}, decoded.Impl)
+	require.NoError(t, c.AssertEquivalent(decoded))
+}
+
+func TestCreateToken2022Address(t *testing.T) {
+	t.Parallel()
+	wallet, err := solana.NewRandomPrivateKey()
+	require.NoError(t, err)
+	mint := solana.MPK("G8iheDY9bGix5qCXEitCExLcgZzZrEemngk9cbTR3CQs")
+
+	classic, _, err := solana.FindAssociatedTokenAddress(wallet.PublicKey(), mint, solana.TokenProgramID)
+	require.NoError(t, err)
+	token2022, _, err := solana.FindAssociatedTokenAddress(wallet.PublicKey(), mint, solana.Token2022ProgramID)
+	require.NoError(t, err)
+	assert.NotEqual(t, classic, token2022)
+
+	c, err := NewCreateIdempotentInstruction(
+		wallet.PublicKey(),
+		wallet.PublicKey(),
+		mint,
+		solana.Token2022ProgramID,
+	).ValidateAndBuild()
+	require.NoError(t, err)
+	assert.Equal(t, token2022, c.Accounts()[1].PublicKey)
+	assert.Equal(t, solana.Token2022ProgramID, c.Accounts()[5].PublicKey)
+}
+
+func TestCreateWithoutTokenProgram(t *testing.T) {
+	t.Parallel()
+	wallet, err := solana.NewRandomPrivateKey()
+	require.NoError(t, err)
+	mint := solana.MPK("G8iheDY9bGix5qCXEitCExLcgZzZrEemngk9cbTR3CQs")
+
+	_, _, err = solana.FindAssociatedTokenAddress(wallet.PublicKey(), mint, solana.PublicKey{})
+	require.Error(t, err)
+
+	_, err = NewCreateInstruction(wallet.PublicKey(), wallet.PublicKey(), mint, solana.PublicKey{}, false).ValidateAndBuild()
+	require.Error(t, err)
+
+	_, err = NewCreateIdempotentInstruction(wallet.PublicKey(), wallet.PublicKey(), mint, solana.PublicKey{}).ValidateAndBuild()
+	require.Error(t, err)
+}
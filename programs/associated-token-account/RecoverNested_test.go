@@ -0,0 +1,48 @@
+package associatedtokenaccount
+
+import (
+	"testing"
+
+	solana "github.com/gagliardetto/solana-go"
+	"github.com/test-go/testify/assert"
+	"github.com/test-go/testify/require"
+)
+
+func TestRecoverNestedInstruction(t *testing.T) {
+	t.Parallel()
+	wallet, err := solana.NewRandomPrivateKey()
+	require.NoError(t, err)
+	ownerMint := solana.MPK("G8iheDY9bGix5qCXEitCExLcgZzZrEemngk9cbTR3CQs")
+	nestedMint := solana.MPK("EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v")
+
+	inst, err := NewRecoverNestedInstruction(
+		wallet.PublicKey(),
+		ownerMint,
+		nestedMint,
+		solana.Token2022ProgramID,
+	).ValidateAndBuild()
+	require.NoError(t, err)
+
+	data, err := inst.Data()
+	require.NoError(t, err)
+	assert.Equal(t, []byte{2}, data)
+
+	ownerATA, _, err := solana.FindAssociatedTokenAddress(wallet.PublicKey(), ownerMint, solana.Token2022ProgramID)
+	require.NoError(t, err)
+	nestedATA, _, err := solana.FindAssociatedTokenAddress(ownerATA, nestedMint, solana.Token2022ProgramID)
+	require.NoError(t, err)
+	destinationATA, _, err := solana.FindAssociatedTokenAddress(wallet.PublicKey(), nestedMint, solana.Token2022ProgramID)
+	require.NoError(t, err)
+
+	accounts := inst.Accounts()
+	require.Len(t, accounts, 7)
+	assert.Equal(t, nestedATA, accounts[0].PublicKey)
+	assert.Equal(t, destinationATA, accounts[2].PublicKey)
+	assert.Equal(t, ownerATA, accounts[3].PublicKey)
+	assert.True(t, accounts[5].IsSigner)
+
+	decoded, err := DecodeInstruction(accounts, data)
+	require.NoError(t, err)
+	require.IsType(t, &RecoverNested{}, decoded.Impl)
+	require.NoError(t, inst.AssertEquivalent(decoded))
+}
@@ -5,7 +5,9 @@ import (
 )
 
 var (
-	DecodeCreate = decode[*Create]()
+	DecodeCreate           = decode[*Create]()
+	DecodeCreateIdempotent = decode[*CreateIdempotent]()
+	DecodeRecoverNested    = decode[*RecoverNested]()
 )
 
 func decode[T any]() func(*solana.Message, int) (T, error) {
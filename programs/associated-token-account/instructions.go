@@ -51,12 +51,46 @@ func (inst *Instruction) EncodeToTree(parent treeout.Branches) {
 	}
 }
 
+const (
+	// Creates an associated token account for the given wallet address and token mint.
+	// Fails if the account already exists.
+	Instruction_Create uint8 = iota
+
+	// Creates an associated token account for the given wallet address and token mint,
+	// if it doesn't already exist.
+	Instruction_CreateIdempotent
+
+	// Transfers from and closes a nested associated token account: an
+	// associated token account owned by an associated token account.
+	Instruction_RecoverNested
+)
+
+// InstructionIDToName returns the name of the instruction given its ID.
+func InstructionIDToName(id uint8) string {
+	switch id {
+	case Instruction_Create:
+		return "Create"
+	case Instruction_CreateIdempotent:
+		return "CreateIdempotent"
+	case Instruction_RecoverNested:
+		return "RecoverNested"
+	default:
+		return ""
+	}
+}
+
 var InstructionImplDef = bin.NewVariantDefinition(
-	bin.NoTypeIDEncoding, // NOTE: the associated-token-account program has no ID encoding.
+	bin.Uint8TypeIDEncoding,
 	[]bin.VariantType{
 		{
 			"Create", (*Create)(nil),
 		},
+		{
+			"CreateIdempotent", (*CreateIdempotent)(nil),
+		},
+		{
+			"RecoverNested", (*RecoverNested)(nil),
+		},
 	},
 )
 
@@ -93,10 +127,23 @@ func (inst *Instruction) TextEncode(encoder *text.Encoder, option *text.Option)
 }
 
 func (inst *Instruction) UnmarshalWithDecoder(decoder *bin.Decoder) error {
+	// NOTE: the original Create instruction has no data.
+	if !decoder.HasRemaining() {
+		inst.TypeID = bin.TypeIDFromUint8(Instruction_Create)
+		inst.Impl = new(Create)
+		return nil
+	}
 	return inst.BaseVariant.UnmarshalBinaryVariant(decoder, InstructionImplDef)
 }
 
 func (inst Instruction) MarshalWithEncoder(encoder *bin.Encoder) error {
+	// NOTE: Create is encoded without its ID, like the original instruction.
+	if inst.TypeID.Uint8() != Instruction_Create {
+		err := encoder.WriteUint8(inst.TypeID.Uint8())
+		if err != nil {
+			return fmt.Errorf("unable to write variant type: %w", err)
+		}
+	}
 	return encoder.Encode(inst.Impl)
 }
 
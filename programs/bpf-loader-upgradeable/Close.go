@@ -0,0 +1,163 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bpfloaderupgradeable
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	ag_binary "github.com/gagliardetto/binary"
+	ag_solanago "github.com/gagliardetto/solana-go"
+	ag_format "github.com/gagliardetto/solana-go/text/format"
+	ag_treeout "github.com/gagliardetto/treeout"
+)
+
+// Closes an account owned by the upgradeable loader of all lamports and
+// withdraws all the lamports.
+type Close struct {
+	// [0] = [WRITE] CloseAccount
+	// ··········· The account to close; if closing a program, must be the ProgramData account
+	//
+	// [1] = [WRITE] RecipientAccount
+	// ··········· The account to deposit the closed account's lamports
+	//
+	// [2] = [SIGNER] AuthorityAccount
+	// ··········· The account's authority; required unless closing an Uninitialized account (optional)
+	//
+	// [3] = [WRITE] ProgramAccount
+	// ··········· The associated Program account, if closing a ProgramData account (optional)
+	ag_solanago.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+// NewCloseInstructionBuilder creates a new `Close` instruction builder.
+func NewCloseInstructionBuilder() *Close {
+	nd := &Close{
+		AccountMetaSlice: make(ag_solanago.AccountMetaSlice, 2),
+	}
+	return nd
+}
+
+// The account to close; if closing a program, must be the ProgramData account
+func (inst *Close) SetCloseAccount(closeAccount ag_solanago.PublicKey) *Close {
+	inst.AccountMetaSlice[0] = ag_solanago.Meta(closeAccount).WRITE()
+	return inst
+}
+
+func (inst *Close) GetCloseAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[0]
+}
+
+// The account to deposit the closed account's lamports
+func (inst *Close) SetRecipientAccount(recipientAccount ag_solanago.PublicKey) *Close {
+	inst.AccountMetaSlice[1] = ag_solanago.Meta(recipientAccount).WRITE()
+	return inst
+}
+
+func (inst *Close) GetRecipientAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[1]
+}
+
+// The account's authority; required unless closing an Uninitialized account
+func (inst *Close) SetAuthorityAccount(authorityAccount ag_solanago.PublicKey) *Close {
+	if len(inst.AccountMetaSlice) <= 2 {
+		inst.AccountMetaSlice = append(inst.AccountMetaSlice, nil)
+	}
+	inst.AccountMetaSlice[2] = ag_solanago.Meta(authorityAccount).SIGNER()
+	return inst
+}
+
+func (inst *Close) GetAuthorityAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(2)
+}
+
+// The associated Program account, if closing a ProgramData account
+func (inst *Close) SetProgramAccount(programAccount ag_solanago.PublicKey) *Close {
+	if len(inst.AccountMetaSlice) <= 3 {
+		inst.AccountMetaSlice = append(inst.AccountMetaSlice, nil)
+	}
+	inst.AccountMetaSlice[3] = ag_solanago.Meta(programAccount).WRITE()
+	return inst
+}
+
+func (inst *Close) GetProgramAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(3)
+}
+
+func (inst Close) Build() *Instruction {
+	return &Instruction{BaseVariant: ag_binary.BaseVariant{
+		Impl:   inst,
+		TypeID: ag_binary.TypeIDFromUint32(Instruction_Close, binary.LittleEndian),
+	}}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (inst Close) ValidateAndBuild() (*Instruction, error) {
+	if err := inst.Validate(); err != nil {
+		return nil, err
+	}
+	return inst.Build(), nil
+}
+
+func (inst *Close) Validate() error {
+	// Check whether all (required) accounts are set:
+	for accIndex, acc := range inst.AccountMetaSlice[:2] {
+		if acc == nil {
+			return fmt.Errorf("ins.AccountMetaSlice[%v] is not set", accIndex)
+		}
+	}
+	return nil
+}
+
+func (inst *Close) EncodeToTree(parent ag_treeout.Branches) {
+	parent.Child(ag_format.Program(ProgramName, ProgramID)).
+		//
+		ParentFunc(func(programBranch ag_treeout.Branches) {
+			programBranch.Child(ag_format.Instruction("Close")).
+				//
+				ParentFunc(func(instructionBranch ag_treeout.Branches) {
+
+					// Parameters of the instruction:
+					instructionBranch.Child("Params").ParentFunc(func(paramsBranch ag_treeout.Branches) {})
+
+					// Accounts of the instruction:
+					instructionBranch.Child("Accounts").ParentFunc(func(accountsBranch ag_treeout.Branches) {
+						accountsBranch.Child(ag_format.Meta("    Close", inst.AccountMetaSlice.Get(0)))
+						accountsBranch.Child(ag_format.Meta("Recipient", inst.AccountMetaSlice.Get(1)))
+						accountsBranch.Child(ag_format.Meta("Authority", inst.AccountMetaSlice.Get(2)))
+						accountsBranch.Child(ag_format.Meta("  Program", inst.AccountMetaSlice.Get(3)))
+					})
+				})
+		})
+}
+
+func (inst Close) MarshalWithEncoder(encoder *ag_binary.Encoder) error {
+	return nil
+}
+
+func (inst *Close) UnmarshalWithDecoder(decoder *ag_binary.Decoder) error {
+	return nil
+}
+
+// NewCloseInstruction declares a new Close instruction with the provided parameters and accounts.
+func NewCloseInstruction(
+	// Accounts:
+	closeAccount ag_solanago.PublicKey,
+	recipientAccount ag_solanago.PublicKey) *Close {
+	return NewCloseInstructionBuilder().
+		SetCloseAccount(closeAccount).
+		SetRecipientAccount(recipientAccount)
+}
@@ -0,0 +1,274 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bpfloaderupgradeable
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	ag_binary "github.com/gagliardetto/binary"
+	ag_solanago "github.com/gagliardetto/solana-go"
+	ag_format "github.com/gagliardetto/solana-go/text/format"
+	ag_treeout "github.com/gagliardetto/treeout"
+)
+
+// Deploy an executable program.
+//
+// A program consists of a Program and ProgramData account pair.
+//   - The Program account's address will serve as the program id for any
+//     instructions that execute this program.
+//   - The ProgramData account will remain mutable by the loader only and
+//     holds the program data and authority information. The ProgramData
+//     account's address is derived from the Program account's address and
+//     created by the DeployWithMaxDataLen instruction.
+//
+// The ProgramData address is derived from the Program's address as
+// follows: see FindProgramDataAddress.
+//
+// The DeployWithMaxDataLen instruction does not require the ProgramData
+// account be a signer and therefore MUST be included within the same
+// Transaction as the system program's CreateAccount instruction that
+// creates the Program account. Otherwise another party may initialize the
+// account.
+type DeployWithMaxDataLen struct {
+	// Maximum length that the program can be upgraded to
+	MaxDataLen *uint64
+
+	// [0] = [WRITE, SIGNER] PayerAccount
+	// ··········· The payer account that will pay to create the ProgramData account
+	//
+	// [1] = [WRITE] ProgramDataAccount
+	// ··········· The uninitialized ProgramData account
+	//
+	// [2] = [WRITE] ProgramAccount
+	// ··········· The uninitialized Program account
+	//
+	// [3] = [WRITE] BufferAccount
+	// ··········· The Buffer account where the program data has been written; the buffer account's authority must match the program's authority
+	//
+	// [4] = [] $(SysVarRentPubkey)
+	// ··········· Rent sysvar
+	//
+	// [5] = [] $(SysVarClockPubkey)
+	// ··········· Clock sysvar
+	//
+	// [6] = [] $(SystemProgramID)
+	// ··········· System program
+	//
+	// [7] = [SIGNER] AuthorityAccount
+	// ··········· The program's authority
+	ag_solanago.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+// NewDeployWithMaxDataLenInstructionBuilder creates a new `DeployWithMaxDataLen` instruction builder.
+func NewDeployWithMaxDataLenInstructionBuilder() *DeployWithMaxDataLen {
+	nd := &DeployWithMaxDataLen{
+		AccountMetaSlice: make(ag_solanago.AccountMetaSlice, 8),
+	}
+	nd.AccountMetaSlice[4] = ag_solanago.Meta(ag_solanago.SysVarRentPubkey)
+	nd.AccountMetaSlice[5] = ag_solanago.Meta(ag_solanago.SysVarClockPubkey)
+	nd.AccountMetaSlice[6] = ag_solanago.Meta(ag_solanago.SystemProgramID)
+	return nd
+}
+
+// Maximum length that the program can be upgraded to
+func (inst *DeployWithMaxDataLen) SetMaxDataLen(maxDataLen uint64) *DeployWithMaxDataLen {
+	inst.MaxDataLen = &maxDataLen
+	return inst
+}
+
+// The payer account that will pay to create the ProgramData account
+func (inst *DeployWithMaxDataLen) SetPayerAccount(payerAccount ag_solanago.PublicKey) *DeployWithMaxDataLen {
+	inst.AccountMetaSlice[0] = ag_solanago.Meta(payerAccount).WRITE().SIGNER()
+	return inst
+}
+
+func (inst *DeployWithMaxDataLen) GetPayerAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[0]
+}
+
+// The uninitialized ProgramData account
+func (inst *DeployWithMaxDataLen) SetProgramDataAccount(programDataAccount ag_solanago.PublicKey) *DeployWithMaxDataLen {
+	inst.AccountMetaSlice[1] = ag_solanago.Meta(programDataAccount).WRITE()
+	return inst
+}
+
+func (inst *DeployWithMaxDataLen) GetProgramDataAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[1]
+}
+
+// The uninitialized Program account
+func (inst *DeployWithMaxDataLen) SetProgramAccount(programAccount ag_solanago.PublicKey) *DeployWithMaxDataLen {
+	inst.AccountMetaSlice[2] = ag_solanago.Meta(programAccount).WRITE()
+	return inst
+}
+
+func (inst *DeployWithMaxDataLen) GetProgramAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[2]
+}
+
+// The Buffer account where the program data has been written; the buffer account's authority must match the program's authority
+func (inst *DeployWithMaxDataLen) SetBufferAccount(bufferAccount ag_solanago.PublicKey) *DeployWithMaxDataLen {
+	inst.AccountMetaSlice[3] = ag_solanago.Meta(bufferAccount).WRITE()
+	return inst
+}
+
+func (inst *DeployWithMaxDataLen) GetBufferAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[3]
+}
+
+// Rent sysvar
+func (inst *DeployWithMaxDataLen) SetSysVarRentPubkeyAccount(sysVarRentPubkeyAccount ag_solanago.PublicKey) *DeployWithMaxDataLen {
+	inst.AccountMetaSlice[4] = ag_solanago.Meta(sysVarRentPubkeyAccount)
+	return inst
+}
+
+func (inst *DeployWithMaxDataLen) GetSysVarRentPubkeyAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[4]
+}
+
+// Clock sysvar
+func (inst *DeployWithMaxDataLen) SetSysVarClockPubkeyAccount(sysVarClockPubkeyAccount ag_solanago.PublicKey) *DeployWithMaxDataLen {
+	inst.AccountMetaSlice[5] = ag_solanago.Meta(sysVarClockPubkeyAccount)
+	return inst
+}
+
+func (inst *DeployWithMaxDataLen) GetSysVarClockPubkeyAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[5]
+}
+
+// System program
+func (inst *DeployWithMaxDataLen) SetSystemProgramAccount(systemProgramAccount ag_solanago.PublicKey) *DeployWithMaxDataLen {
+	inst.AccountMetaSlice[6] = ag_solanago.Meta(systemProgramAccount)
+	return inst
+}
+
+func (inst *DeployWithMaxDataLen) GetSystemProgramAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[6]
+}
+
+// The program's authority
+func (inst *DeployWithMaxDataLen) SetAuthorityAccount(authorityAccount ag_solanago.PublicKey) *DeployWithMaxDataLen {
+	inst.AccountMetaSlice[7] = ag_solanago.Meta(authorityAccount).SIGNER()
+	return inst
+}
+
+func (inst *DeployWithMaxDataLen) GetAuthorityAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[7]
+}
+
+func (inst DeployWithMaxDataLen) Build() *Instruction {
+	return &Instruction{BaseVariant: ag_binary.BaseVariant{
+		Impl:   inst,
+		TypeID: ag_binary.TypeIDFromUint32(Instruction_DeployWithMaxDataLen, binary.LittleEndian),
+	}}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (inst DeployWithMaxDataLen) ValidateAndBuild() (*Instruction, error) {
+	if err := inst.Validate(); err != nil {
+		return nil, err
+	}
+	return inst.Build(), nil
+}
+
+func (inst *DeployWithMaxDataLen) Validate() error {
+	// Check whether all (required) parameters are set:
+	{
+		if inst.MaxDataLen == nil {
+			return errors.New("MaxDataLen parameter is not set")
+		}
+	}
+
+	// Check whether all (required) accounts are set:
+	for accIndex, acc := range inst.AccountMetaSlice {
+		if acc == nil {
+			return fmt.Errorf("ins.AccountMetaSlice[%v] is not set", accIndex)
+		}
+	}
+	return nil
+}
+
+func (inst *DeployWithMaxDataLen) EncodeToTree(parent ag_treeout.Branches) {
+	parent.Child(ag_format.Program(ProgramName, ProgramID)).
+		//
+		ParentFunc(func(programBranch ag_treeout.Branches) {
+			programBranch.Child(ag_format.Instruction("DeployWithMaxDataLen")).
+				//
+				ParentFunc(func(instructionBranch ag_treeout.Branches) {
+
+					// Parameters of the instruction:
+					instructionBranch.Child("Params").ParentFunc(func(paramsBranch ag_treeout.Branches) {
+						paramsBranch.Child(ag_format.Param("MaxDataLen", *inst.MaxDataLen))
+					})
+
+					// Accounts of the instruction:
+					instructionBranch.Child("Accounts").ParentFunc(func(accountsBranch ag_treeout.Branches) {
+						accountsBranch.Child(ag_format.Meta("        Payer", inst.AccountMetaSlice.Get(0)))
+						accountsBranch.Child(ag_format.Meta("  ProgramData", inst.AccountMetaSlice.Get(1)))
+						accountsBranch.Child(ag_format.Meta("      Program", inst.AccountMetaSlice.Get(2)))
+						accountsBranch.Child(ag_format.Meta("       Buffer", inst.AccountMetaSlice.Get(3)))
+						accountsBranch.Child(ag_format.Meta("   SysVarRent", inst.AccountMetaSlice.Get(4)))
+						accountsBranch.Child(ag_format.Meta("  SysVarClock", inst.AccountMetaSlice.Get(5)))
+						accountsBranch.Child(ag_format.Meta("SystemProgram", inst.AccountMetaSlice.Get(6)))
+						accountsBranch.Child(ag_format.Meta("    Authority", inst.AccountMetaSlice.Get(7)))
+					})
+				})
+		})
+}
+
+func (inst DeployWithMaxDataLen) MarshalWithEncoder(encoder *ag_binary.Encoder) error {
+	// Serialize `MaxDataLen` param:
+	{
+		err := encoder.Encode(*inst.MaxDataLen)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (inst *DeployWithMaxDataLen) UnmarshalWithDecoder(decoder *ag_binary.Decoder) error {
+	// Deserialize `MaxDataLen` param:
+	{
+		err := decoder.Decode(&inst.MaxDataLen)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// NewDeployWithMaxDataLenInstruction declares a new DeployWithMaxDataLen instruction with the provided parameters and accounts.
+func NewDeployWithMaxDataLenInstruction(
+	// Parameters:
+	maxDataLen uint64,
+	// Accounts:
+	payerAccount ag_solanago.PublicKey,
+	programDataAccount ag_solanago.PublicKey,
+	programAccount ag_solanago.PublicKey,
+	bufferAccount ag_solanago.PublicKey,
+	authorityAccount ag_solanago.PublicKey) *DeployWithMaxDataLen {
+	return NewDeployWithMaxDataLenInstructionBuilder().
+		SetMaxDataLen(maxDataLen).
+		SetPayerAccount(payerAccount).
+		SetProgramDataAccount(programDataAccount).
+		SetProgramAccount(programAccount).
+		SetBufferAccount(bufferAccount).
+		SetAuthorityAccount(authorityAccount)
+}
@@ -0,0 +1,199 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bpfloaderupgradeable
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	ag_binary "github.com/gagliardetto/binary"
+	ag_solanago "github.com/gagliardetto/solana-go"
+	ag_format "github.com/gagliardetto/solana-go/text/format"
+	ag_treeout "github.com/gagliardetto/treeout"
+)
+
+// Extend a program's ProgramData account by the specified number of bytes.
+// Only upgradeable programs can be extended.
+//
+// The payer may need to transfer lamports to the ProgramData account for it
+// to remain rent-exempt after the extension.
+type ExtendProgram struct {
+	// Number of bytes to extend the program data
+	AdditionalBytes *uint32
+
+	// [0] = [WRITE] ProgramDataAccount
+	// ··········· The ProgramData account
+	//
+	// [1] = [WRITE] ProgramAccount
+	// ··········· The ProgramData account's associated Program account
+	//
+	// [2] = [] $(SystemProgramID)
+	// ··········· System program, used to transfer lamports from the payer
+	//
+	// [3] = [WRITE, SIGNER] PayerAccount
+	// ··········· The payer account, which funds the extension
+	ag_solanago.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+// NewExtendProgramInstructionBuilder creates a new `ExtendProgram` instruction builder.
+func NewExtendProgramInstructionBuilder() *ExtendProgram {
+	nd := &ExtendProgram{
+		AccountMetaSlice: make(ag_solanago.AccountMetaSlice, 4),
+	}
+	nd.AccountMetaSlice[2] = ag_solanago.Meta(ag_solanago.SystemProgramID)
+	return nd
+}
+
+// Number of bytes to extend the program data
+func (inst *ExtendProgram) SetAdditionalBytes(additionalBytes uint32) *ExtendProgram {
+	inst.AdditionalBytes = &additionalBytes
+	return inst
+}
+
+// The ProgramData account
+func (inst *ExtendProgram) SetProgramDataAccount(programDataAccount ag_solanago.PublicKey) *ExtendProgram {
+	inst.AccountMetaSlice[0] = ag_solanago.Meta(programDataAccount).WRITE()
+	return inst
+}
+
+func (inst *ExtendProgram) GetProgramDataAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[0]
+}
+
+// The ProgramData account's associated Program account
+func (inst *ExtendProgram) SetProgramAccount(programAccount ag_solanago.PublicKey) *ExtendProgram {
+	inst.AccountMetaSlice[1] = ag_solanago.Meta(programAccount).WRITE()
+	return inst
+}
+
+func (inst *ExtendProgram) GetProgramAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[1]
+}
+
+// System program, used to transfer lamports from the payer
+func (inst *ExtendProgram) SetSystemProgramAccount(systemProgramAccount ag_solanago.PublicKey) *ExtendProgram {
+	inst.AccountMetaSlice[2] = ag_solanago.Meta(systemProgramAccount)
+	return inst
+}
+
+func (inst *ExtendProgram) GetSystemProgramAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[2]
+}
+
+// The payer account, which funds the extension
+func (inst *ExtendProgram) SetPayerAccount(payerAccount ag_solanago.PublicKey) *ExtendProgram {
+	inst.AccountMetaSlice[3] = ag_solanago.Meta(payerAccount).WRITE().SIGNER()
+	return inst
+}
+
+func (inst *ExtendProgram) GetPayerAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[3]
+}
+
+func (inst ExtendProgram) Build() *Instruction {
+	return &Instruction{BaseVariant: ag_binary.BaseVariant{
+		Impl:   inst,
+		TypeID: ag_binary.TypeIDFromUint32(Instruction_ExtendProgram, binary.LittleEndian),
+	}}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (inst ExtendProgram) ValidateAndBuild() (*Instruction, error) {
+	if err := inst.Validate(); err != nil {
+		return nil, err
+	}
+	return inst.Build(), nil
+}
+
+func (inst *ExtendProgram) Validate() error {
+	// Check whether all (required) parameters are set:
+	{
+		if inst.AdditionalBytes == nil {
+			return errors.New("AdditionalBytes parameter is not set")
+		}
+	}
+
+	// Check whether all (required) accounts are set:
+	for accIndex, acc := range inst.AccountMetaSlice {
+		if acc == nil {
+			return fmt.Errorf("ins.AccountMetaSlice[%v] is not set", accIndex)
+		}
+	}
+	return nil
+}
+
+func (inst *ExtendProgram) EncodeToTree(parent ag_treeout.Branches) {
+	parent.Child(ag_format.Program(ProgramName, ProgramID)).
+		//
+		ParentFunc(func(programBranch ag_treeout.Branches) {
+			programBranch.Child(ag_format.Instruction("ExtendProgram")).
+				//
+				ParentFunc(func(instructionBranch ag_treeout.Branches) {
+
+					// Parameters of the instruction:
+					instructionBranch.Child("Params").ParentFunc(func(paramsBranch ag_treeout.Branches) {
+						paramsBranch.Child(ag_format.Param("AdditionalBytes", *inst.AdditionalBytes))
+					})
+
+					// Accounts of the instruction:
+					instructionBranch.Child("Accounts").ParentFunc(func(accountsBranch ag_treeout.Branches) {
+						accountsBranch.Child(ag_format.Meta("  ProgramData", inst.AccountMetaSlice.Get(0)))
+						accountsBranch.Child(ag_format.Meta("      Program", inst.AccountMetaSlice.Get(1)))
+						accountsBranch.Child(ag_format.Meta("SystemProgram", inst.AccountMetaSlice.Get(2)))
+						accountsBranch.Child(ag_format.Meta("        Payer", inst.AccountMetaSlice.Get(3)))
+					})
+				})
+		})
+}
+
+func (inst ExtendProgram) MarshalWithEncoder(encoder *ag_binary.Encoder) error {
+	// Serialize `AdditionalBytes` param:
+	{
+		err := encoder.Encode(*inst.AdditionalBytes)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (inst *ExtendProgram) UnmarshalWithDecoder(decoder *ag_binary.Decoder) error {
+	// Deserialize `AdditionalBytes` param:
+	{
+		err := decoder.Decode(&inst.AdditionalBytes)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// NewExtendProgramInstruction declares a new ExtendProgram instruction with the provided parameters and accounts.
+func NewExtendProgramInstruction(
+	// Parameters:
+	additionalBytes uint32,
+	// Accounts:
+	programDataAccount ag_solanago.PublicKey,
+	programAccount ag_solanago.PublicKey,
+	payerAccount ag_solanago.PublicKey) *ExtendProgram {
+	return NewExtendProgramInstructionBuilder().
+		SetAdditionalBytes(additionalBytes).
+		SetProgramDataAccount(programDataAccount).
+		SetProgramAccount(programAccount).
+		SetPayerAccount(payerAccount)
+}
@@ -0,0 +1,214 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bpfloaderupgradeable
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	ag_binary "github.com/gagliardetto/binary"
+	ag_solanago "github.com/gagliardetto/solana-go"
+	ag_format "github.com/gagliardetto/solana-go/text/format"
+	ag_treeout "github.com/gagliardetto/treeout"
+)
+
+// Extend a program's ProgramData account by the specified number of bytes.
+//
+// This instruction differs from ExtendProgram in that the authority is a
+// required signer.
+type ExtendProgramChecked struct {
+	// Number of bytes to extend the program data
+	AdditionalBytes *uint32
+
+	// [0] = [WRITE] ProgramDataAccount
+	// ··········· The ProgramData account
+	//
+	// [1] = [WRITE] ProgramAccount
+	// ··········· The ProgramData account's associated Program account
+	//
+	// [2] = [SIGNER] AuthorityAccount
+	// ··········· The program's authority
+	//
+	// [3] = [] $(SystemProgramID)
+	// ··········· System program, used to transfer lamports from the payer
+	//
+	// [4] = [WRITE, SIGNER] PayerAccount
+	// ··········· The payer account, which funds the extension
+	ag_solanago.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+// NewExtendProgramCheckedInstructionBuilder creates a new `ExtendProgramChecked` instruction builder.
+func NewExtendProgramCheckedInstructionBuilder() *ExtendProgramChecked {
+	nd := &ExtendProgramChecked{
+		AccountMetaSlice: make(ag_solanago.AccountMetaSlice, 5),
+	}
+	nd.AccountMetaSlice[3] = ag_solanago.Meta(ag_solanago.SystemProgramID)
+	return nd
+}
+
+// Number of bytes to extend the program data
+func (inst *ExtendProgramChecked) SetAdditionalBytes(additionalBytes uint32) *ExtendProgramChecked {
+	inst.AdditionalBytes = &additionalBytes
+	return inst
+}
+
+// The ProgramData account
+func (inst *ExtendProgramChecked) SetProgramDataAccount(programDataAccount ag_solanago.PublicKey) *ExtendProgramChecked {
+	inst.AccountMetaSlice[0] = ag_solanago.Meta(programDataAccount).WRITE()
+	return inst
+}
+
+func (inst *ExtendProgramChecked) GetProgramDataAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[0]
+}
+
+// The ProgramData account's associated Program account
+func (inst *ExtendProgramChecked) SetProgramAccount(programAccount ag_solanago.PublicKey) *ExtendProgramChecked {
+	inst.AccountMetaSlice[1] = ag_solanago.Meta(programAccount).WRITE()
+	return inst
+}
+
+func (inst *ExtendProgramChecked) GetProgramAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[1]
+}
+
+// The program's authority
+func (inst *ExtendProgramChecked) SetAuthorityAccount(authorityAccount ag_solanago.PublicKey) *ExtendProgramChecked {
+	inst.AccountMetaSlice[2] = ag_solanago.Meta(authorityAccount).SIGNER()
+	return inst
+}
+
+func (inst *ExtendProgramChecked) GetAuthorityAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[2]
+}
+
+// System program, used to transfer lamports from the payer
+func (inst *ExtendProgramChecked) SetSystemProgramAccount(systemProgramAccount ag_solanago.PublicKey) *ExtendProgramChecked {
+	inst.AccountMetaSlice[3] = ag_solanago.Meta(systemProgramAccount)
+	return inst
+}
+
+func (inst *ExtendProgramChecked) GetSystemProgramAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[3]
+}
+
+// The payer account, which funds the extension
+func (inst *ExtendProgramChecked) SetPayerAccount(payerAccount ag_solanago.PublicKey) *ExtendProgramChecked {
+	inst.AccountMetaSlice[4] = ag_solanago.Meta(payerAccount).WRITE().SIGNER()
+	return inst
+}
+
+func (inst *ExtendProgramChecked) GetPayerAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[4]
+}
+
+func (inst ExtendProgramChecked) Build() *Instruction {
+	return &Instruction{BaseVariant: ag_binary.BaseVariant{
+		Impl:   inst,
+		TypeID: ag_binary.TypeIDFromUint32(Instruction_ExtendProgramChecked, binary.LittleEndian),
+	}}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (inst ExtendProgramChecked) ValidateAndBuild() (*Instruction, error) {
+	if err := inst.Validate(); err != nil {
+		return nil, err
+	}
+	return inst.Build(), nil
+}
+
+func (inst *ExtendProgramChecked) Validate() error {
+	// Check whether all (required) parameters are set:
+	{
+		if inst.AdditionalBytes == nil {
+			return errors.New("AdditionalBytes parameter is not set")
+		}
+	}
+
+	// Check whether all (required) accounts are set:
+	for accIndex, acc := range inst.AccountMetaSlice {
+		if acc == nil {
+			return fmt.Errorf("ins.AccountMetaSlice[%v] is not set", accIndex)
+		}
+	}
+	return nil
+}
+
+func (inst *ExtendProgramChecked) EncodeToTree(parent ag_treeout.Branches) {
+	parent.Child(ag_format.Program(ProgramName, ProgramID)).
+		//
+		ParentFunc(func(programBranch ag_treeout.Branches) {
+			programBranch.Child(ag_format.Instruction("ExtendProgramChecked")).
+				//
+				ParentFunc(func(instructionBranch ag_treeout.Branches) {
+
+					// Parameters of the instruction:
+					instructionBranch.Child("Params").ParentFunc(func(paramsBranch ag_treeout.Branches) {
+						paramsBranch.Child(ag_format.Param("AdditionalBytes", *inst.AdditionalBytes))
+					})
+
+					// Accounts of the instruction:
+					instructionBranch.Child("Accounts").ParentFunc(func(accountsBranch ag_treeout.Branches) {
+						accountsBranch.Child(ag_format.Meta("  ProgramData", inst.AccountMetaSlice.Get(0)))
+						accountsBranch.Child(ag_format.Meta("      Program", inst.AccountMetaSlice.Get(1)))
+						accountsBranch.Child(ag_format.Meta("    Authority", inst.AccountMetaSlice.Get(2)))
+						accountsBranch.Child(ag_format.Meta("SystemProgram", inst.AccountMetaSlice.Get(3)))
+						accountsBranch.Child(ag_format.Meta("        Payer", inst.AccountMetaSlice.Get(4)))
+					})
+				})
+		})
+}
+
+func (inst ExtendProgramChecked) MarshalWithEncoder(encoder *ag_binary.Encoder) error {
+	// Serialize `AdditionalBytes` param:
+	{
+		err := encoder.Encode(*inst.AdditionalBytes)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (inst *ExtendProgramChecked) UnmarshalWithDecoder(decoder *ag_binary.Decoder) error {
+	// Deserialize `AdditionalBytes` param:
+	{
+		err := decoder.Decode(&inst.AdditionalBytes)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// NewExtendProgramCheckedInstruction declares a new ExtendProgramChecked instruction with the provided parameters and accounts.
+func NewExtendProgramCheckedInstruction(
+	// Parameters:
+	additionalBytes uint32,
+	// Accounts:
+	programDataAccount ag_solanago.PublicKey,
+	programAccount ag_solanago.PublicKey,
+	authorityAccount ag_solanago.PublicKey,
+	payerAccount ag_solanago.PublicKey) *ExtendProgramChecked {
+	return NewExtendProgramCheckedInstructionBuilder().
+		SetAdditionalBytes(additionalBytes).
+		SetProgramDataAccount(programDataAccount).
+		SetProgramAccount(programAccount).
+		SetAuthorityAccount(authorityAccount).
+		SetPayerAccount(payerAccount)
+}
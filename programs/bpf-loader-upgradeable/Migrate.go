@@ -0,0 +1,144 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bpfloaderupgradeable
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	ag_binary "github.com/gagliardetto/binary"
+	ag_solanago "github.com/gagliardetto/solana-go"
+	ag_format "github.com/gagliardetto/solana-go/text/format"
+	ag_treeout "github.com/gagliardetto/treeout"
+)
+
+// Migrate the program to loader-v4.
+type Migrate struct {
+	// [0] = [WRITE] ProgramDataAccount
+	// ··········· The ProgramData account
+	//
+	// [1] = [WRITE] ProgramAccount
+	// ··········· The Program account
+	//
+	// [2] = [SIGNER] AuthorityAccount
+	// ··········· The current authority
+	ag_solanago.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+// NewMigrateInstructionBuilder creates a new `Migrate` instruction builder.
+func NewMigrateInstructionBuilder() *Migrate {
+	nd := &Migrate{
+		AccountMetaSlice: make(ag_solanago.AccountMetaSlice, 3),
+	}
+	return nd
+}
+
+// The ProgramData account
+func (inst *Migrate) SetProgramDataAccount(programDataAccount ag_solanago.PublicKey) *Migrate {
+	inst.AccountMetaSlice[0] = ag_solanago.Meta(programDataAccount).WRITE()
+	return inst
+}
+
+func (inst *Migrate) GetProgramDataAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[0]
+}
+
+// The Program account
+func (inst *Migrate) SetProgramAccount(programAccount ag_solanago.PublicKey) *Migrate {
+	inst.AccountMetaSlice[1] = ag_solanago.Meta(programAccount).WRITE()
+	return inst
+}
+
+func (inst *Migrate) GetProgramAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[1]
+}
+
+// The current authority
+func (inst *Migrate) SetAuthorityAccount(authorityAccount ag_solanago.PublicKey) *Migrate {
+	inst.AccountMetaSlice[2] = ag_solanago.Meta(authorityAccount).SIGNER()
+	return inst
+}
+
+func (inst *Migrate) GetAuthorityAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[2]
+}
+
+func (inst Migrate) Build() *Instruction {
+	return &Instruction{BaseVariant: ag_binary.BaseVariant{
+		Impl:   inst,
+		TypeID: ag_binary.TypeIDFromUint32(Instruction_Migrate, binary.LittleEndian),
+	}}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (inst Migrate) ValidateAndBuild() (*Instruction, error) {
+	if err := inst.Validate(); err != nil {
+		return nil, err
+	}
+	return inst.Build(), nil
+}
+
+func (inst *Migrate) Validate() error {
+	// Check whether all (required) accounts are set:
+	for accIndex, acc := range inst.AccountMetaSlice {
+		if acc == nil {
+			return fmt.Errorf("ins.AccountMetaSlice[%v] is not set", accIndex)
+		}
+	}
+	return nil
+}
+
+func (inst *Migrate) EncodeToTree(parent ag_treeout.Branches) {
+	parent.Child(ag_format.Program(ProgramName, ProgramID)).
+		//
+		ParentFunc(func(programBranch ag_treeout.Branches) {
+			programBranch.Child(ag_format.Instruction("Migrate")).
+				//
+				ParentFunc(func(instructionBranch ag_treeout.Branches) {
+
+					// Parameters of the instruction:
+					instructionBranch.Child("Params").ParentFunc(func(paramsBranch ag_treeout.Branches) {})
+
+					// Accounts of the instruction:
+					instructionBranch.Child("Accounts").ParentFunc(func(accountsBranch ag_treeout.Branches) {
+						accountsBranch.Child(ag_format.Meta("ProgramData", inst.AccountMetaSlice.Get(0)))
+						accountsBranch.Child(ag_format.Meta("    Program", inst.AccountMetaSlice.Get(1)))
+						accountsBranch.Child(ag_format.Meta("  Authority", inst.AccountMetaSlice.Get(2)))
+					})
+				})
+		})
+}
+
+func (inst Migrate) MarshalWithEncoder(encoder *ag_binary.Encoder) error {
+	return nil
+}
+
+func (inst *Migrate) UnmarshalWithDecoder(decoder *ag_binary.Decoder) error {
+	return nil
+}
+
+// NewMigrateInstruction declares a new Migrate instruction with the provided parameters and accounts.
+func NewMigrateInstruction(
+	// Accounts:
+	programDataAccount ag_solanago.PublicKey,
+	programAccount ag_solanago.PublicKey,
+	authorityAccount ag_solanago.PublicKey) *Migrate {
+	return NewMigrateInstructionBuilder().
+		SetProgramDataAccount(programDataAccount).
+		SetProgramAccount(programAccount).
+		SetAuthorityAccount(authorityAccount)
+}
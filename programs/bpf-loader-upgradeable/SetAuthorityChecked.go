@@ -0,0 +1,148 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bpfloaderupgradeable
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	ag_binary "github.com/gagliardetto/binary"
+	ag_solanago "github.com/gagliardetto/solana-go"
+	ag_format "github.com/gagliardetto/solana-go/text/format"
+	ag_treeout "github.com/gagliardetto/treeout"
+)
+
+// Set a new authority that is allowed to write the buffer or upgrade the
+// program.
+//
+// This instruction differs from SetAuthority in that the new authority is a
+// required signer.
+type SetAuthorityChecked struct {
+	// [0] = [WRITE] BufferOrProgramDataAccount
+	// ··········· The Buffer or ProgramData account to change the authority of
+	//
+	// [1] = [SIGNER] CurrentAuthorityAccount
+	// ··········· The current authority
+	//
+	// [2] = [SIGNER] NewAuthorityAccount
+	// ··········· The new authority
+	ag_solanago.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+// NewSetAuthorityCheckedInstructionBuilder creates a new `SetAuthorityChecked` instruction builder.
+func NewSetAuthorityCheckedInstructionBuilder() *SetAuthorityChecked {
+	nd := &SetAuthorityChecked{
+		AccountMetaSlice: make(ag_solanago.AccountMetaSlice, 3),
+	}
+	return nd
+}
+
+// The Buffer or ProgramData account to change the authority of
+func (inst *SetAuthorityChecked) SetBufferOrProgramDataAccount(bufferOrProgramDataAccount ag_solanago.PublicKey) *SetAuthorityChecked {
+	inst.AccountMetaSlice[0] = ag_solanago.Meta(bufferOrProgramDataAccount).WRITE()
+	return inst
+}
+
+func (inst *SetAuthorityChecked) GetBufferOrProgramDataAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[0]
+}
+
+// The current authority
+func (inst *SetAuthorityChecked) SetCurrentAuthorityAccount(currentAuthorityAccount ag_solanago.PublicKey) *SetAuthorityChecked {
+	inst.AccountMetaSlice[1] = ag_solanago.Meta(currentAuthorityAccount).SIGNER()
+	return inst
+}
+
+func (inst *SetAuthorityChecked) GetCurrentAuthorityAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[1]
+}
+
+// The new authority
+func (inst *SetAuthorityChecked) SetNewAuthorityAccount(newAuthorityAccount ag_solanago.PublicKey) *SetAuthorityChecked {
+	inst.AccountMetaSlice[2] = ag_solanago.Meta(newAuthorityAccount).SIGNER()
+	return inst
+}
+
+func (inst *SetAuthorityChecked) GetNewAuthorityAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[2]
+}
+
+func (inst SetAuthorityChecked) Build() *Instruction {
+	return &Instruction{BaseVariant: ag_binary.BaseVariant{
+		Impl:   inst,
+		TypeID: ag_binary.TypeIDFromUint32(Instruction_SetAuthorityChecked, binary.LittleEndian),
+	}}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (inst SetAuthorityChecked) ValidateAndBuild() (*Instruction, error) {
+	if err := inst.Validate(); err != nil {
+		return nil, err
+	}
+	return inst.Build(), nil
+}
+
+func (inst *SetAuthorityChecked) Validate() error {
+	// Check whether all (required) accounts are set:
+	for accIndex, acc := range inst.AccountMetaSlice {
+		if acc == nil {
+			return fmt.Errorf("ins.AccountMetaSlice[%v] is not set", accIndex)
+		}
+	}
+	return nil
+}
+
+func (inst *SetAuthorityChecked) EncodeToTree(parent ag_treeout.Branches) {
+	parent.Child(ag_format.Program(ProgramName, ProgramID)).
+		//
+		ParentFunc(func(programBranch ag_treeout.Branches) {
+			programBranch.Child(ag_format.Instruction("SetAuthorityChecked")).
+				//
+				ParentFunc(func(instructionBranch ag_treeout.Branches) {
+
+					// Parameters of the instruction:
+					instructionBranch.Child("Params").ParentFunc(func(paramsBranch ag_treeout.Branches) {})
+
+					// Accounts of the instruction:
+					instructionBranch.Child("Accounts").ParentFunc(func(accountsBranch ag_treeout.Branches) {
+						accountsBranch.Child(ag_format.Meta("BufferOrProgramData", inst.AccountMetaSlice.Get(0)))
+						accountsBranch.Child(ag_format.Meta("   CurrentAuthority", inst.AccountMetaSlice.Get(1)))
+						accountsBranch.Child(ag_format.Meta("       NewAuthority", inst.AccountMetaSlice.Get(2)))
+					})
+				})
+		})
+}
+
+func (inst SetAuthorityChecked) MarshalWithEncoder(encoder *ag_binary.Encoder) error {
+	return nil
+}
+
+func (inst *SetAuthorityChecked) UnmarshalWithDecoder(decoder *ag_binary.Decoder) error {
+	return nil
+}
+
+// NewSetAuthorityCheckedInstruction declares a new SetAuthorityChecked instruction with the provided parameters and accounts.
+func NewSetAuthorityCheckedInstruction(
+	// Accounts:
+	bufferOrProgramDataAccount ag_solanago.PublicKey,
+	currentAuthorityAccount ag_solanago.PublicKey,
+	newAuthorityAccount ag_solanago.PublicKey) *SetAuthorityChecked {
+	return NewSetAuthorityCheckedInstructionBuilder().
+		SetBufferOrProgramDataAccount(bufferOrProgramDataAccount).
+		SetCurrentAuthorityAccount(currentAuthorityAccount).
+		SetNewAuthorityAccount(newAuthorityAccount)
+}
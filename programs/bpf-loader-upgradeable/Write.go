@@ -0,0 +1,204 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bpfloaderupgradeable
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	ag_binary "github.com/gagliardetto/binary"
+	ag_solanago "github.com/gagliardetto/solana-go"
+	ag_format "github.com/gagliardetto/solana-go/text/format"
+	ag_treeout "github.com/gagliardetto/treeout"
+)
+
+// Write program data into a Buffer account.
+type Write struct {
+	// Offset at which to write the given bytes
+	Offset *uint32
+
+	// Serialized program data
+	Bytes []byte
+
+	// [0] = [WRITE] BufferAccount
+	// ··········· Buffer account to write program data to
+	//
+	// [1] = [SIGNER] BufferAuthorityAccount
+	// ··········· Buffer authority
+	ag_solanago.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+// NewWriteInstructionBuilder creates a new `Write` instruction builder.
+func NewWriteInstructionBuilder() *Write {
+	nd := &Write{
+		AccountMetaSlice: make(ag_solanago.AccountMetaSlice, 2),
+	}
+	return nd
+}
+
+// Offset at which to write the given bytes
+func (inst *Write) SetOffset(offset uint32) *Write {
+	inst.Offset = &offset
+	return inst
+}
+
+// Serialized program data
+func (inst *Write) SetBytes(bytes []byte) *Write {
+	inst.Bytes = bytes
+	return inst
+}
+
+// Buffer account to write program data to
+func (inst *Write) SetBufferAccount(bufferAccount ag_solanago.PublicKey) *Write {
+	inst.AccountMetaSlice[0] = ag_solanago.Meta(bufferAccount).WRITE()
+	return inst
+}
+
+func (inst *Write) GetBufferAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[0]
+}
+
+// Buffer authority
+func (inst *Write) SetBufferAuthorityAccount(bufferAuthorityAccount ag_solanago.PublicKey) *Write {
+	inst.AccountMetaSlice[1] = ag_solanago.Meta(bufferAuthorityAccount).SIGNER()
+	return inst
+}
+
+func (inst *Write) GetBufferAuthorityAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[1]
+}
+
+func (inst Write) Build() *Instruction {
+	return &Instruction{BaseVariant: ag_binary.BaseVariant{
+		Impl:   inst,
+		TypeID: ag_binary.TypeIDFromUint32(Instruction_Write, binary.LittleEndian),
+	}}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (inst Write) ValidateAndBuild() (*Instruction, error) {
+	if err := inst.Validate(); err != nil {
+		return nil, err
+	}
+	return inst.Build(), nil
+}
+
+func (inst *Write) Validate() error {
+	// Check whether all (required) parameters are set:
+	{
+		if inst.Offset == nil {
+			return errors.New("Offset parameter is not set")
+		}
+		if inst.Bytes == nil {
+			return errors.New("Bytes parameter is not set")
+		}
+	}
+
+	// Check whether all (required) accounts are set:
+	for accIndex, acc := range inst.AccountMetaSlice {
+		if acc == nil {
+			return fmt.Errorf("ins.AccountMetaSlice[%v] is not set", accIndex)
+		}
+	}
+	return nil
+}
+
+func (inst *Write) EncodeToTree(parent ag_treeout.Branches) {
+	parent.Child(ag_format.Program(ProgramName, ProgramID)).
+		//
+		ParentFunc(func(programBranch ag_treeout.Branches) {
+			programBranch.Child(ag_format.Instruction("Write")).
+				//
+				ParentFunc(func(instructionBranch ag_treeout.Branches) {
+
+					// Parameters of the instruction:
+					instructionBranch.Child("Params").ParentFunc(func(paramsBranch ag_treeout.Branches) {
+						paramsBranch.Child(ag_format.Param("Offset", *inst.Offset))
+						paramsBranch.Child(ag_format.Param(" Bytes", inst.Bytes))
+					})
+
+					// Accounts of the instruction:
+					instructionBranch.Child("Accounts").ParentFunc(func(accountsBranch ag_treeout.Branches) {
+						accountsBranch.Child(ag_format.Meta("         Buffer", inst.AccountMetaSlice.Get(0)))
+						accountsBranch.Child(ag_format.Meta("BufferAuthority", inst.AccountMetaSlice.Get(1)))
+					})
+				})
+		})
+}
+
+func (inst Write) MarshalWithEncoder(encoder *ag_binary.Encoder) error {
+	// Serialize `Offset` param:
+	{
+		err := encoder.Encode(*inst.Offset)
+		if err != nil {
+			return err
+		}
+	}
+	// Serialize `Bytes` param:
+	{
+		err := encoder.WriteUint64(uint64(len(inst.Bytes)), binary.LittleEndian)
+		if err != nil {
+			return err
+		}
+		err = encoder.WriteBytes(inst.Bytes, false)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (inst *Write) UnmarshalWithDecoder(decoder *ag_binary.Decoder) error {
+	// Deserialize `Offset` param:
+	{
+		err := decoder.Decode(&inst.Offset)
+		if err != nil {
+			return err
+		}
+	}
+	// Deserialize `Bytes` param:
+	{
+		length, err := decoder.ReadUint64(binary.LittleEndian)
+		if err != nil {
+			return err
+		}
+		if length > uint64(decoder.Remaining()) {
+			return fmt.Errorf("invalid Bytes length: %d", length)
+		}
+		inst.Bytes, err = decoder.ReadNBytes(int(length))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// NewWriteInstruction declares a new Write instruction with the provided parameters and accounts.
+func NewWriteInstruction(
+	// Parameters:
+	offset uint32,
+	bytes []byte,
+	// Accounts:
+	bufferAccount ag_solanago.PublicKey,
+	bufferAuthorityAccount ag_solanago.PublicKey) *Write {
+	return NewWriteInstructionBuilder().
+		SetOffset(offset).
+		SetBytes(bytes).
+		SetBufferAccount(bufferAccount).
+		SetBufferAuthorityAccount(bufferAuthorityAccount)
+}
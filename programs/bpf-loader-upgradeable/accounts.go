@@ -0,0 +1,193 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bpfloaderupgradeable
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	ag_binary "github.com/gagliardetto/binary"
+	ag_solanago "github.com/gagliardetto/solana-go"
+)
+
+const (
+	// Size in bytes of the metadata of a Buffer account;
+	// the buffered program data follows it.
+	BufferMetadataSize = 37
+
+	// Size in bytes of a Program account.
+	ProgramSize = 36
+
+	// Size in bytes of the metadata of a ProgramData account;
+	// the program data follows it.
+	ProgramDataMetadataSize = 45
+)
+
+// BufferSize returns the size of a Buffer account holding programLen bytes.
+func BufferSize(programLen int) int {
+	return BufferMetadataSize + programLen
+}
+
+// ProgramDataSize returns the size of a ProgramData account holding programLen bytes.
+func ProgramDataSize(programLen int) int {
+	return ProgramDataMetadataSize + programLen
+}
+
+// FindProgramDataAddress returns the address of the ProgramData account of a program.
+func FindProgramDataAddress(program ag_solanago.PublicKey) (ag_solanago.PublicKey, uint8, error) {
+	return ag_solanago.FindProgramAddress([][]byte{program[:]}, ProgramID)
+}
+
+type UpgradeableLoaderStateType uint32
+
+const (
+	UpgradeableLoaderStateUninitialized UpgradeableLoaderStateType = iota
+	UpgradeableLoaderStateBuffer
+	UpgradeableLoaderStateProgram
+	UpgradeableLoaderStateProgramData
+)
+
+func (t UpgradeableLoaderStateType) String() string {
+	switch t {
+	case UpgradeableLoaderStateUninitialized:
+		return "Uninitialized"
+	case UpgradeableLoaderStateBuffer:
+		return "Buffer"
+	case UpgradeableLoaderStateProgram:
+		return "Program"
+	case UpgradeableLoaderStateProgramData:
+		return "ProgramData"
+	default:
+		return fmt.Sprintf("Unknown(%d)", uint32(t))
+	}
+}
+
+// UpgradeableLoaderState is the state of an account owned by the upgradeable loader.
+type UpgradeableLoaderState struct {
+	Type UpgradeableLoaderStateType
+
+	// Authority of a Buffer, or upgrade authority of a ProgramData account;
+	// nil if the buffer or program is immutable.
+	AuthorityAddress *ag_solanago.PublicKey
+
+	// Set when Type is UpgradeableLoaderStateProgram.
+	ProgramDataAddress ag_solanago.PublicKey
+
+	// Slot that the program was last modified;
+	// set when Type is UpgradeableLoaderStateProgramData.
+	Slot uint64
+
+	// The data following the metadata of a Buffer or ProgramData account,
+	// i.e. the (buffered) program.
+	Data []byte
+}
+
+// DecodeUpgradeableLoaderState decodes the data of an account owned by the upgradeable loader.
+func DecodeUpgradeableLoaderState(data []byte) (*UpgradeableLoaderState, error) {
+	state := new(UpgradeableLoaderState)
+	if err := state.UnmarshalWithDecoder(ag_binary.NewBinDecoder(data)); err != nil {
+		return nil, fmt.Errorf("unable to decode upgradeable loader state: %w", err)
+	}
+	return state, nil
+}
+
+func (obj *UpgradeableLoaderState) UnmarshalWithDecoder(decoder *ag_binary.Decoder) (err error) {
+	typ, err := decoder.ReadUint32(binary.LittleEndian)
+	if err != nil {
+		return err
+	}
+	*obj = UpgradeableLoaderState{Type: UpgradeableLoaderStateType(typ)}
+	switch obj.Type {
+	case UpgradeableLoaderStateUninitialized:
+		return nil
+	case UpgradeableLoaderStateBuffer:
+		if obj.AuthorityAddress, err = readAuthority(decoder); err != nil {
+			return err
+		}
+	case UpgradeableLoaderStateProgram:
+		v, err := decoder.ReadNBytes(32)
+		if err != nil {
+			return err
+		}
+		obj.ProgramDataAddress = ag_solanago.PublicKeyFromBytes(v)
+		return nil
+	case UpgradeableLoaderStateProgramData:
+		if obj.Slot, err = decoder.ReadUint64(binary.LittleEndian); err != nil {
+			return err
+		}
+		if obj.AuthorityAddress, err = readAuthority(decoder); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("invalid upgradeable loader state type: %d", typ)
+	}
+	obj.Data, err = decoder.ReadNBytes(decoder.Remaining())
+	return err
+}
+
+// MarshalWithEncoder encodes the state, followed by the Data of
+// Buffer and ProgramData accounts.
+func (obj UpgradeableLoaderState) MarshalWithEncoder(encoder *ag_binary.Encoder) (err error) {
+	if err = encoder.WriteUint32(uint32(obj.Type), binary.LittleEndian); err != nil {
+		return err
+	}
+	switch obj.Type {
+	case UpgradeableLoaderStateUninitialized:
+		return nil
+	case UpgradeableLoaderStateBuffer:
+		if err = writeAuthority(encoder, obj.AuthorityAddress); err != nil {
+			return err
+		}
+	case UpgradeableLoaderStateProgram:
+		return encoder.WriteBytes(obj.ProgramDataAddress[:], false)
+	case UpgradeableLoaderStateProgramData:
+		if err = encoder.WriteUint64(obj.Slot, binary.LittleEndian); err != nil {
+			return err
+		}
+		if err = writeAuthority(encoder, obj.AuthorityAddress); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("invalid upgradeable loader state type: %d", obj.Type)
+	}
+	return encoder.WriteBytes(obj.Data, false)
+}
+
+// The authority is an Option<Pubkey> that always takes 33 bytes
+// of the account metadata, even when not set.
+func readAuthority(decoder *ag_binary.Decoder) (*ag_solanago.PublicKey, error) {
+	ok, err := decoder.ReadOption()
+	if err != nil {
+		return nil, err
+	}
+	v, err := decoder.ReadNBytes(32)
+	if err != nil || !ok {
+		return nil, err
+	}
+	return ag_solanago.PublicKeyFromBytes(v).ToPointer(), nil
+}
+
+func writeAuthority(encoder *ag_binary.Encoder, authority *ag_solanago.PublicKey) error {
+	if authority == nil {
+		if err := encoder.WriteOption(false); err != nil {
+			return err
+		}
+		return encoder.WriteBytes(make([]byte, 32), false)
+	}
+	if err := encoder.WriteOption(true); err != nil {
+		return err
+	}
+	return encoder.WriteBytes(authority[:], false)
+}
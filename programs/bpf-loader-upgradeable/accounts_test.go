@@ -0,0 +1,166 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bpfloaderupgradeable
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	ag_binary "github.com/gagliardetto/binary"
+	ag_solanago "github.com/gagliardetto/solana-go"
+	bpfloader "github.com/gagliardetto/solana-go/programs/bpf-loader"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpgradeableLoaderState(t *testing.T) {
+	authority := ag_solanago.NewWallet().PublicKey()
+
+	{
+		state := UpgradeableLoaderState{
+			Type:             UpgradeableLoaderStateProgramData,
+			Slot:             123456,
+			AuthorityAddress: &authority,
+			Data:             []byte{0x7f, 'E', 'L', 'F'},
+		}
+		buf := new(bytes.Buffer)
+		require.NoError(t, ag_binary.NewBinEncoder(buf).Encode(state))
+		data := buf.Bytes()
+		require.Len(t, data, ProgramDataSize(4))
+		require.Equal(t, uint32(3), binary.LittleEndian.Uint32(data[0:4]))
+		require.Equal(t, uint64(123456), binary.LittleEndian.Uint64(data[4:12]))
+		require.Equal(t, byte(1), data[12])
+		require.Equal(t, authority[:], data[13:45])
+
+		got, err := DecodeUpgradeableLoaderState(data)
+		require.NoError(t, err)
+		require.Equal(t, &state, got)
+	}
+	{
+		// An immutable buffer still reserves the space of the authority.
+		state := UpgradeableLoaderState{
+			Type: UpgradeableLoaderStateBuffer,
+			Data: []byte{1, 2, 3},
+		}
+		buf := new(bytes.Buffer)
+		require.NoError(t, ag_binary.NewBinEncoder(buf).Encode(state))
+		require.Len(t, buf.Bytes(), BufferSize(3))
+
+		got, err := DecodeUpgradeableLoaderState(buf.Bytes())
+		require.NoError(t, err)
+		require.Equal(t, &state, got)
+		require.Nil(t, got.AuthorityAddress)
+	}
+	{
+		programData, _, err := FindProgramDataAddress(ag_solanago.NewWallet().PublicKey())
+		require.NoError(t, err)
+		state := UpgradeableLoaderState{
+			Type:               UpgradeableLoaderStateProgram,
+			ProgramDataAddress: programData,
+		}
+		buf := new(bytes.Buffer)
+		require.NoError(t, ag_binary.NewBinEncoder(buf).Encode(state))
+		require.Len(t, buf.Bytes(), ProgramSize)
+
+		got, err := DecodeUpgradeableLoaderState(buf.Bytes())
+		require.NoError(t, err)
+		require.Equal(t, &state, got)
+	}
+
+	_, err := DecodeUpgradeableLoaderState([]byte{4, 0, 0, 0})
+	require.Error(t, err)
+}
+
+func TestInstructionData(t *testing.T) {
+	buffer := ag_solanago.NewWallet().PublicKey()
+	authority := ag_solanago.NewWallet().PublicKey()
+
+	inst, err := NewWriteInstruction(16, []byte{0xaa, 0xbb}, buffer, authority).ValidateAndBuild()
+	require.NoError(t, err)
+	data, err := inst.Data()
+	require.NoError(t, err)
+	require.Equal(t, []byte{1, 0, 0, 0, 16, 0, 0, 0, 2, 0, 0, 0, 0, 0, 0, 0, 0xaa, 0xbb}, data)
+
+	decoded, err := DecodeInstruction(inst.Accounts(), data)
+	require.NoError(t, err)
+	write, ok := decoded.Impl.(*Write)
+	require.True(t, ok)
+	require.Equal(t, []byte{0xaa, 0xbb}, write.Bytes)
+	require.Equal(t, authority, write.GetBufferAuthorityAccount().PublicKey)
+
+	// Omitting the new authority makes the buffer immutable.
+	inst, err = NewSetAuthorityInstruction(buffer, authority).ValidateAndBuild()
+	require.NoError(t, err)
+	require.Len(t, inst.Accounts(), 2)
+	data, err = inst.Data()
+	require.NoError(t, err)
+	require.Equal(t, []byte{4, 0, 0, 0}, data)
+
+	data, err = NewExtendProgramInstruction(1024, buffer, authority, authority).Build().Data()
+	require.NoError(t, err)
+	require.Equal(t, []byte{6, 0, 0, 0, 0, 4, 0, 0}, data)
+}
+
+func TestDeployProgram(t *testing.T) {
+	payer := ag_solanago.NewWallet().PublicKey()
+	buffer := ag_solanago.NewWallet().PublicKey()
+	program := ag_solanago.NewWallet().PublicKey()
+	programData := bytes.Repeat([]byte{0x42}, 5000)
+
+	initialBuilder, writeBuilders, finalBuilder, err := DeployProgram(
+		payer, programData, 0, 1_000_000, 2_000_000, buffer, program, payer,
+	)
+	require.NoError(t, err)
+
+	initial, err := initialBuilder.Build()
+	require.NoError(t, err)
+	require.Len(t, initial.Message.Instructions, 2)
+
+	var written []byte
+	for _, builder := range writeBuilders {
+		tx, err := builder.Build()
+		require.NoError(t, err)
+		tx.Signatures = make([]ag_solanago.Signature, tx.Message.Header.NumRequiredSignatures)
+		serialized, err := tx.MarshalBinary()
+		require.NoError(t, err)
+		require.LessOrEqual(t, len(serialized), bpfloader.PACKET_DATA_SIZE)
+
+		inst, err := DecodeInstruction(nil, tx.Message.Instructions[0].Data)
+		require.NoError(t, err)
+		write := inst.Impl.(*Write)
+		require.Equal(t, uint32(len(written)), *write.Offset)
+		written = append(written, write.Bytes...)
+	}
+	require.Equal(t, programData, written)
+
+	final, err := finalBuilder.Build()
+	require.NoError(t, err)
+	require.Len(t, final.Message.Instructions, 2)
+	inst, err := DecodeInstruction(nil, final.Message.Instructions[1].Data)
+	require.NoError(t, err)
+	require.Equal(t, uint64(len(programData)), *inst.Impl.(*DeployWithMaxDataLen).MaxDataLen)
+
+	_, _, _, err = DeployProgram(payer, programData, 10, 0, 0, buffer, program, payer)
+	require.Error(t, err)
+
+	_, writeBuilders, finalBuilder, err = UpgradeProgram(payer, programData, 1_000_000, buffer, program, payer)
+	require.NoError(t, err)
+	require.NotEmpty(t, writeBuilders)
+	final, err = finalBuilder.Build()
+	require.NoError(t, err)
+	programDataAddress, _, err := FindProgramDataAddress(program)
+	require.NoError(t, err)
+	require.Contains(t, final.Message.AccountKeys, programDataAddress)
+}
@@ -0,0 +1,198 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bpfloaderupgradeable
+
+import (
+	"fmt"
+
+	ag_solanago "github.com/gagliardetto/solana-go"
+	bpfloader "github.com/gagliardetto/solana-go/programs/bpf-loader"
+	"github.com/gagliardetto/solana-go/programs/system"
+)
+
+// writeBuffer returns the transaction that creates and initializes the buffer,
+// and the transactions that write the program data into it.
+func writeBuffer(
+	payerPubkey ag_solanago.PublicKey,
+	programData []byte,
+	bufferMinimumBalance uint64,
+	bufferPubkey ag_solanago.PublicKey,
+	authorityPubkey ag_solanago.PublicKey,
+) (
+	initialBuilder *ag_solanago.TransactionBuilder,
+	writeBuilders []*ag_solanago.TransactionBuilder,
+	err error,
+) {
+	if len(programData) == 0 {
+		err = fmt.Errorf("program data is empty")
+		return
+	}
+	initialBuilder = ag_solanago.NewTransactionBuilder().
+		AddInstruction(
+			system.NewCreateAccountInstruction(
+				bufferMinimumBalance,
+				uint64(BufferSize(len(programData))),
+				ProgramID,
+				payerPubkey,
+				bufferPubkey,
+			).Build(),
+		).
+		AddInstruction(
+			NewInitializeBufferInstruction(bufferPubkey, authorityPubkey).Build(),
+		).
+		SetFeePayer(payerPubkey)
+
+	createBuilder := func(offset int, chunk []byte) *ag_solanago.TransactionBuilder {
+		return ag_solanago.NewTransactionBuilder().
+			AddInstruction(
+				NewWriteInstruction(uint32(offset), chunk, bufferPubkey, authorityPubkey).Build(),
+			).
+			SetFeePayer(payerPubkey)
+	}
+
+	chunkSize, err := bpfloader.CalculateMaxChunkSize(createBuilder)
+	if err != nil {
+		return
+	}
+	writeBuilders = []*ag_solanago.TransactionBuilder{}
+	for i := 0; i < len(programData); i += chunkSize {
+		end := i + chunkSize
+		if end > len(programData) {
+			end = len(programData)
+		}
+		writeBuilders = append(
+			writeBuilders,
+			createBuilder(i, programData[i:end]),
+		)
+	}
+	return
+}
+
+// DeployProgram plans the deployment of a new program through a buffer account:
+//   - initialBuilder creates the buffer account and initializes it with the authority;
+//   - writeBuilders write the program data into the buffer, one chunk per transaction;
+//   - finalBuilder creates the program account and deploys the buffer to it.
+//
+// The buffer, program and authority accounts must sign along with the payer
+// (the buffer only signs the initial transaction, the program only the final one).
+// If maxDataLen is zero, the program can't be upgraded to a larger size
+// than programData without first extending it.
+func DeployProgram(
+	payerPubkey ag_solanago.PublicKey,
+	programData []byte,
+	maxDataLen int,
+	bufferMinimumBalance uint64,
+	programMinimumBalance uint64,
+	bufferPubkey ag_solanago.PublicKey,
+	programPubkey ag_solanago.PublicKey,
+	authorityPubkey ag_solanago.PublicKey,
+) (
+	initialBuilder *ag_solanago.TransactionBuilder,
+	writeBuilders []*ag_solanago.TransactionBuilder,
+	finalBuilder *ag_solanago.TransactionBuilder,
+	err error,
+) {
+	if maxDataLen == 0 {
+		maxDataLen = len(programData)
+	}
+	if maxDataLen < len(programData) {
+		err = fmt.Errorf("max data length %d is smaller than the program (%d bytes)", maxDataLen, len(programData))
+		return
+	}
+	programDataPubkey, _, err := FindProgramDataAddress(programPubkey)
+	if err != nil {
+		return
+	}
+	initialBuilder, writeBuilders, err = writeBuffer(
+		payerPubkey,
+		programData,
+		bufferMinimumBalance,
+		bufferPubkey,
+		authorityPubkey,
+	)
+	if err != nil {
+		return
+	}
+	finalBuilder = ag_solanago.NewTransactionBuilder().
+		AddInstruction(
+			system.NewCreateAccountInstruction(
+				programMinimumBalance,
+				ProgramSize,
+				ProgramID,
+				payerPubkey,
+				programPubkey,
+			).Build(),
+		).
+		AddInstruction(
+			NewDeployWithMaxDataLenInstruction(
+				uint64(maxDataLen),
+				payerPubkey,
+				programDataPubkey,
+				programPubkey,
+				bufferPubkey,
+				authorityPubkey,
+			).Build(),
+		).
+		SetFeePayer(payerPubkey)
+	return
+}
+
+// UpgradeProgram plans the upgrade of an existing program through a buffer account:
+//   - initialBuilder creates the buffer account and initializes it with the authority;
+//   - writeBuilders write the program data into the buffer, one chunk per transaction;
+//   - finalBuilder upgrades the program with the buffer; the lamports of the buffer
+//     are refunded to the payer.
+//
+// The authority must be the program's upgrade authority.
+func UpgradeProgram(
+	payerPubkey ag_solanago.PublicKey,
+	programData []byte,
+	bufferMinimumBalance uint64,
+	bufferPubkey ag_solanago.PublicKey,
+	programPubkey ag_solanago.PublicKey,
+	authorityPubkey ag_solanago.PublicKey,
+) (
+	initialBuilder *ag_solanago.TransactionBuilder,
+	writeBuilders []*ag_solanago.TransactionBuilder,
+	finalBuilder *ag_solanago.TransactionBuilder,
+	err error,
+) {
+	programDataPubkey, _, err := FindProgramDataAddress(programPubkey)
+	if err != nil {
+		return
+	}
+	initialBuilder, writeBuilders, err = writeBuffer(
+		payerPubkey,
+		programData,
+		bufferMinimumBalance,
+		bufferPubkey,
+		authorityPubkey,
+	)
+	if err != nil {
+		return
+	}
+	finalBuilder = ag_solanago.NewTransactionBuilder().
+		AddInstruction(
+			NewUpgradeInstruction(
+				programDataPubkey,
+				programPubkey,
+				bufferPubkey,
+				payerPubkey,
+				authorityPubkey,
+			).Build(),
+		).
+		SetFeePayer(payerPubkey)
+	return
+}
@@ -0,0 +1,26 @@
+package bpfloaderupgradeable
+
+import (
+	"github.com/gagliardetto/solana-go"
+)
+
+var (
+	DecodeClose                = decode[*Close]()
+	DecodeDeployWithMaxDataLen = decode[*DeployWithMaxDataLen]()
+	DecodeExtendProgram        = decode[*ExtendProgram]()
+	DecodeExtendProgramChecked = decode[*ExtendProgramChecked]()
+	DecodeInitializeBuffer     = decode[*InitializeBuffer]()
+	DecodeMigrate              = decode[*Migrate]()
+	DecodeSetAuthority         = decode[*SetAuthority]()
+	DecodeSetAuthorityChecked  = decode[*SetAuthorityChecked]()
+	DecodeUpgrade              = decode[*Upgrade]()
+	DecodeWrite                = decode[*Write]()
+)
+
+func decode[T any]() func(*solana.Message, int) (T, error) {
+	return solana.DecodeInstructionType[*Instruction, T](
+		ProgramID,
+		InstructionImplDef,
+		DecodeInstruction,
+	)
+}
@@ -0,0 +1,196 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Deploy, upgrade and manage programs owned by the upgradeable BPF loader.
+
+package bpfloaderupgradeable
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+
+	ag_spew "github.com/davecgh/go-spew/spew"
+	ag_binary "github.com/gagliardetto/binary"
+	ag_solanago "github.com/gagliardetto/solana-go"
+	ag_text "github.com/gagliardetto/solana-go/text"
+	ag_treeout "github.com/gagliardetto/treeout"
+)
+
+var ProgramID ag_solanago.PublicKey = ag_solanago.BPFLoaderUpgradeableProgramID
+
+func SetProgramID(pubkey ag_solanago.PublicKey) {
+	ProgramID = pubkey
+	ag_solanago.RegisterInstructionDecoder(ProgramID, registryDecodeInstruction)
+}
+
+const ProgramName = "BPFLoaderUpgradeable"
+
+func init() {
+	ag_solanago.RegisterInstructionDecoder(ProgramID, registryDecodeInstruction)
+}
+
+const (
+	// Initialize a Buffer account
+	Instruction_InitializeBuffer uint32 = iota
+
+	// Write program data into a Buffer account
+	Instruction_Write
+
+	// Deploy an executable program
+	Instruction_DeployWithMaxDataLen
+
+	// Upgrade a program
+	Instruction_Upgrade
+
+	// Set a new authority that is allowed to write the buffer or upgrade the program
+	Instruction_SetAuthority
+
+	// Closes an account owned by the upgradeable loader of all lamports and withdraws all the lamports
+	Instruction_Close
+
+	// Extend a program's ProgramData account by the specified number of bytes
+	Instruction_ExtendProgram
+
+	// Set a new authority that is allowed to write the buffer or upgrade the program, requiring the new authority to sign
+	Instruction_SetAuthorityChecked
+
+	// Migrate the program to loader-v4
+	Instruction_Migrate
+
+	// Extend a program's ProgramData account by the specified number of bytes, requiring the authority to sign
+	Instruction_ExtendProgramChecked
+)
+
+// InstructionIDToName returns the name of the instruction given its ID.
+func InstructionIDToName(id uint32) string {
+	switch id {
+	case Instruction_InitializeBuffer:
+		return "InitializeBuffer"
+	case Instruction_Write:
+		return "Write"
+	case Instruction_DeployWithMaxDataLen:
+		return "DeployWithMaxDataLen"
+	case Instruction_Upgrade:
+		return "Upgrade"
+	case Instruction_SetAuthority:
+		return "SetAuthority"
+	case Instruction_Close:
+		return "Close"
+	case Instruction_ExtendProgram:
+		return "ExtendProgram"
+	case Instruction_SetAuthorityChecked:
+		return "SetAuthorityChecked"
+	case Instruction_Migrate:
+		return "Migrate"
+	case Instruction_ExtendProgramChecked:
+		return "ExtendProgramChecked"
+	default:
+		return ""
+	}
+}
+
+type Instruction struct {
+	ag_binary.BaseVariant
+}
+
+func (inst *Instruction) EncodeToTree(parent ag_treeout.Branches) {
+	if enToTree, ok := inst.Impl.(ag_text.EncodableToTree); ok {
+		enToTree.EncodeToTree(parent)
+	} else {
+		parent.Child(ag_spew.Sdump(inst))
+	}
+}
+
+var InstructionImplDef = ag_binary.NewVariantDefinition(
+	ag_binary.Uint32TypeIDEncoding,
+	[]ag_binary.VariantType{
+		{Name: "InitializeBuffer", Type: (*InitializeBuffer)(nil)},
+		{Name: "Write", Type: (*Write)(nil)},
+		{Name: "DeployWithMaxDataLen", Type: (*DeployWithMaxDataLen)(nil)},
+		{Name: "Upgrade", Type: (*Upgrade)(nil)},
+		{Name: "SetAuthority", Type: (*SetAuthority)(nil)},
+		{Name: "Close", Type: (*Close)(nil)},
+		{Name: "ExtendProgram", Type: (*ExtendProgram)(nil)},
+		{Name: "SetAuthorityChecked", Type: (*SetAuthorityChecked)(nil)},
+		{Name: "Migrate", Type: (*Migrate)(nil)},
+		{Name: "ExtendProgramChecked", Type: (*ExtendProgramChecked)(nil)},
+	},
+)
+
+func (inst *Instruction) ProgramID() ag_solanago.PublicKey {
+	return ProgramID
+}
+
+func (inst *Instruction) Accounts() (out []*ag_solanago.AccountMeta) {
+	return inst.Impl.(ag_solanago.AccountsGettable).GetAccounts()
+}
+
+func (inst *Instruction) Data() ([]byte, error) {
+	buf := new(bytes.Buffer)
+	if err := ag_binary.NewBinEncoder(buf).Encode(inst); err != nil {
+		return nil, fmt.Errorf("unable to encode instruction: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func (a *Instruction) AssertEquivalent(in ag_solanago.Instruction) error {
+	b, ok := in.(*Instruction)
+	if !ok {
+		return fmt.Errorf("expected %T, but got %T", a, in)
+	}
+	equiv, ok := a.BaseVariant.Impl.(ag_solanago.EquivalenceAssertable[interface{}])
+	if !ok {
+		return ag_solanago.CheckInstructionEquivalence(a, b)
+	}
+	return equiv.AssertEquivalent(b.BaseVariant.Impl)
+}
+
+func (inst *Instruction) TextEncode(encoder *ag_text.Encoder, option *ag_text.Option) error {
+	return encoder.Encode(inst.Impl, option)
+}
+
+func (inst *Instruction) UnmarshalWithDecoder(decoder *ag_binary.Decoder) error {
+	return inst.BaseVariant.UnmarshalBinaryVariant(decoder, InstructionImplDef)
+}
+
+func (inst Instruction) MarshalWithEncoder(encoder *ag_binary.Encoder) error {
+	err := encoder.WriteUint32(inst.TypeID.Uint32(), binary.LittleEndian)
+	if err != nil {
+		return fmt.Errorf("unable to write variant type: %w", err)
+	}
+	return encoder.Encode(inst.Impl)
+}
+
+func registryDecodeInstruction(accounts []*ag_solanago.AccountMeta, data []byte) (interface{}, error) {
+	inst, err := DecodeInstruction(accounts, data)
+	if err != nil {
+		return nil, err
+	}
+	return inst, nil
+}
+
+func DecodeInstruction(accounts []*ag_solanago.AccountMeta, data []byte) (*Instruction, error) {
+	inst := new(Instruction)
+	if err := ag_binary.NewBinDecoder(data).Decode(inst); err != nil {
+		return nil, fmt.Errorf("unable to decode instruction: %w", err)
+	}
+	if v, ok := inst.Impl.(ag_solanago.AccountsSettable); ok {
+		err := v.SetAccounts(accounts)
+		if err != nil {
+			return nil, fmt.Errorf("unable to set accounts for instruction: %w", err)
+		}
+	}
+	return inst, nil
+}
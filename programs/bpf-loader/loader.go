@@ -13,8 +13,11 @@ const (
 	PACKET_DATA_SIZE int = 1280 - 40 - 8
 )
 
+// CalculateMaxChunkSize returns the largest chunk of program data that fits
+// in the transaction returned by createBuilder, once signed.
+//
 // https://github.com/solana-labs/solana/blob/v1.7.15/cli/src/program.rs#L1683
-func calculateMaxChunkSize(
+func CalculateMaxChunkSize(
 	createBuilder func(offset int, data []byte) *solana.TransactionBuilder,
 ) (size int, err error) {
 	transaction, err := createBuilder(0, []byte{}).Build()
@@ -166,7 +169,7 @@ func load(
 			SetFeePayer(payerPubkey)
 	}
 
-	chunkSize, err := calculateMaxChunkSize(createBuilder)
+	chunkSize, err := CalculateMaxChunkSize(createBuilder)
 	if err != nil {
 		return
 	}
@@ -0,0 +1,238 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"errors"
+	"fmt"
+
+	ag_binary "github.com/gagliardetto/binary"
+	ag_solanago "github.com/gagliardetto/solana-go"
+	ag_format "github.com/gagliardetto/solana-go/text/format"
+	ag_treeout "github.com/gagliardetto/treeout"
+)
+
+// Store configuration data in a config account.
+//
+// An uninitialized config account must sign; afterwards, the signers
+// listed in the keys currently stored in the account must sign,
+// along with the signers listed in the new keys.
+type Store struct {
+	// Keys of the configuration.
+	Keys ConfigKeys
+
+	// Serialized configuration data; the rest of the instruction data.
+	Data []byte
+
+	// [0] = [WRITE] ConfigAccount
+	// ··········· Config account to store the data in;
+	// ··········· also [SIGNER] if it is not initialized yet.
+	//
+	// [1...] = [SIGNER] Signers
+	// ··········· Signers of the configuration, other than the config account.
+	ag_solanago.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+// NewStoreInstructionBuilder creates a new `Store` instruction builder.
+func NewStoreInstructionBuilder() *Store {
+	nd := &Store{
+		AccountMetaSlice: make(ag_solanago.AccountMetaSlice, 1),
+	}
+	return nd
+}
+
+// SetKeys sets the "keys" parameter.
+// Keys of the configuration.
+func (inst *Store) SetKeys(keys ...ConfigKey) *Store {
+	inst.Keys = keys
+	return inst
+}
+
+// SetData sets the "data" parameter.
+// Serialized configuration data.
+func (inst *Store) SetData(data []byte) *Store {
+	inst.Data = data
+	return inst
+}
+
+// SetConfigAccount sets the "configAccount" account.
+// Config account to store the data in; isSigner must be true
+// if it is not initialized yet.
+func (inst *Store) SetConfigAccount(configAccount ag_solanago.PublicKey, isSigner bool) *Store {
+	inst.AccountMetaSlice[0] = ag_solanago.Meta(configAccount).WRITE()
+	if isSigner {
+		inst.AccountMetaSlice[0].SIGNER()
+	}
+	return inst
+}
+
+// GetConfigAccount gets the "configAccount" account.
+// Config account to store the data in.
+func (inst *Store) GetConfigAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(0)
+}
+
+// AddSignerAccounts appends the "signers" accounts.
+// Signers of the configuration, other than the config account.
+func (inst *Store) AddSignerAccounts(signers ...ag_solanago.PublicKey) *Store {
+	for _, signer := range signers {
+		inst.AccountMetaSlice = append(inst.AccountMetaSlice, ag_solanago.Meta(signer).SIGNER())
+	}
+	return inst
+}
+
+// GetSignerAccounts gets the "signers" accounts.
+// Signers of the configuration, other than the config account.
+func (inst *Store) GetSignerAccounts() ag_solanago.AccountMetaSlice {
+	if len(inst.AccountMetaSlice) < 2 {
+		return nil
+	}
+	return inst.AccountMetaSlice[1:]
+}
+
+func (inst Store) Build() *Instruction {
+	return &Instruction{BaseVariant: ag_binary.BaseVariant{
+		Impl:   inst,
+		TypeID: ag_binary.NoTypeIDDefaultID,
+	}}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (inst Store) ValidateAndBuild() (*Instruction, error) {
+	if err := inst.Validate(); err != nil {
+		return nil, err
+	}
+	return inst.Build(), nil
+}
+
+func (inst *Store) Validate() error {
+	// Check whether all (required) parameters are set:
+	{
+		if inst.Data == nil {
+			return errors.New("Data parameter is not set")
+		}
+	}
+
+	// Check whether all (required) accounts are set:
+	{
+		for i, acc := range inst.AccountMetaSlice {
+			if acc == nil {
+				return fmt.Errorf("ins.AccountMetaSlice[%v] is not set", i)
+			}
+			if i > 0 && !acc.IsSigner {
+				return fmt.Errorf("accounts.Signers[%v] is not a signer", i-1)
+			}
+		}
+	}
+
+	// Check whether all the signers of the keys are provided:
+	{
+		for _, signer := range inst.Keys.Signers() {
+			found := false
+			for _, acc := range inst.AccountMetaSlice {
+				if acc.PublicKey.Equals(signer) && acc.IsSigner {
+					found = true
+					break
+				}
+			}
+			if !found {
+				return fmt.Errorf("signer %s of the keys is not a signer account", signer)
+			}
+		}
+	}
+	return nil
+}
+
+func (inst *Store) EncodeToTree(parent ag_treeout.Branches) {
+	parent.Child(ag_format.Program(ProgramName, ProgramID)).
+		//
+		ParentFunc(func(programBranch ag_treeout.Branches) {
+			programBranch.Child(ag_format.Instruction("Store")).
+				//
+				ParentFunc(func(instructionBranch ag_treeout.Branches) {
+
+					// Parameters of the instruction:
+					instructionBranch.Child("Params").ParentFunc(func(paramsBranch ag_treeout.Branches) {
+						keysBranch := paramsBranch.Child(fmt.Sprintf("Keys[len=%v]", len(inst.Keys)))
+						for i, key := range inst.Keys {
+							keysBranch.Child(ag_format.Param(fmt.Sprintf("[%v]", i), fmt.Sprintf("%s (signer: %v)", key.PublicKey, key.IsSigner)))
+						}
+						paramsBranch.Child(ag_format.Param("Data", inst.Data))
+					})
+
+					// Accounts of the instruction:
+					instructionBranch.Child("Accounts").ParentFunc(func(accountsBranch ag_treeout.Branches) {
+						accountsBranch.Child(ag_format.Meta("config", inst.AccountMetaSlice.Get(0)))
+						signers := inst.GetSignerAccounts()
+						signersBranch := accountsBranch.Child(fmt.Sprintf("signers[len=%v]", len(signers)))
+						for i, v := range signers {
+							signersBranch.Child(ag_format.Meta(fmt.Sprintf("[%v]", i), v))
+						}
+					})
+				})
+		})
+}
+
+func (obj Store) MarshalWithEncoder(encoder *ag_binary.Encoder) (err error) {
+	// Serialize `Keys` param:
+	err = obj.Keys.MarshalWithEncoder(encoder)
+	if err != nil {
+		return err
+	}
+	// Serialize `Data` param:
+	err = encoder.WriteBytes(obj.Data, false)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+func (obj *Store) UnmarshalWithDecoder(decoder *ag_binary.Decoder) (err error) {
+	// Deserialize `Keys`:
+	err = obj.Keys.UnmarshalWithDecoder(decoder)
+	if err != nil {
+		return err
+	}
+	// Deserialize `Data`:
+	obj.Data, err = decoder.ReadNBytes(decoder.Remaining())
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// NewStoreInstruction declares a new Store instruction with the provided
+// keys and data; the signers of the keys, other than the config account,
+// are added as signer accounts.
+func NewStoreInstruction(
+	// Parameters:
+	keys ConfigKeys,
+	data []byte,
+	// Accounts:
+	configAccount ag_solanago.PublicKey,
+	isConfigSigner bool,
+) *Store {
+	inst := NewStoreInstructionBuilder().
+		SetKeys(keys...).
+		SetData(data).
+		SetConfigAccount(configAccount, isConfigSigner)
+	for _, signer := range keys.Signers() {
+		if !signer.Equals(configAccount) {
+			inst.AddSignerAccounts(signer)
+		}
+	}
+	return inst
+}
@@ -0,0 +1,121 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+
+	ag_binary "github.com/gagliardetto/binary"
+	ag_solanago "github.com/gagliardetto/solana-go"
+)
+
+// ConfigKey is a public key that is either the type of the stored
+// configuration, or a signer permitted to modify it.
+type ConfigKey struct {
+	PublicKey ag_solanago.PublicKey
+	IsSigner  bool
+}
+
+// ConfigKeys is the header of every config account, and the first
+// part of the data of the Store instruction.
+type ConfigKeys []ConfigKey
+
+// Size returns the serialized size of the keys, in bytes.
+func (keys ConfigKeys) Size() int {
+	var prefix []byte
+	ag_binary.EncodeCompactU16Length(&prefix, len(keys))
+	return len(prefix) + len(keys)*33
+}
+
+// Signers returns the keys that must sign modifications of the config.
+func (keys ConfigKeys) Signers() []ag_solanago.PublicKey {
+	var out []ag_solanago.PublicKey
+	for _, key := range keys {
+		if key.IsSigner {
+			out = append(out, key.PublicKey)
+		}
+	}
+	return out
+}
+
+func (keys ConfigKeys) MarshalWithEncoder(encoder *ag_binary.Encoder) (err error) {
+	if err = encoder.WriteCompactU16(len(keys)); err != nil {
+		return err
+	}
+	for _, key := range keys {
+		if err = encoder.WriteBytes(key.PublicKey[:], false); err != nil {
+			return err
+		}
+		if err = encoder.WriteBool(key.IsSigner); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (keys *ConfigKeys) UnmarshalWithDecoder(decoder *ag_binary.Decoder) error {
+	count, err := decoder.ReadCompactU16()
+	if err != nil {
+		return err
+	}
+	if count*33 > decoder.Remaining() {
+		return fmt.Errorf("config keys count %d exceeds remaining data", count)
+	}
+	*keys = make(ConfigKeys, count)
+	for i := range *keys {
+		v, err := decoder.ReadNBytes(32)
+		if err != nil {
+			return err
+		}
+		(*keys)[i].PublicKey = ag_solanago.PublicKeyFromBytes(v)
+		if (*keys)[i].IsSigner, err = decoder.ReadBool(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ConfigState is the content of an account owned by the config program.
+type ConfigState struct {
+	Keys ConfigKeys
+
+	// The serialized configuration data, followed by
+	// the zero padding up to the size of the account.
+	Data []byte
+}
+
+// DecodeConfigState decodes the data of an account owned by the config program.
+func DecodeConfigState(data []byte) (*ConfigState, error) {
+	state := new(ConfigState)
+	if err := state.UnmarshalWithDecoder(ag_binary.NewBinDecoder(data)); err != nil {
+		return nil, fmt.Errorf("unable to decode config state: %w", err)
+	}
+	return state, nil
+}
+
+func (obj *ConfigState) UnmarshalWithDecoder(decoder *ag_binary.Decoder) (err error) {
+	if err = obj.Keys.UnmarshalWithDecoder(decoder); err != nil {
+		return err
+	}
+	obj.Data, err = decoder.ReadNBytes(decoder.Remaining())
+	return err
+}
+
+func (obj ConfigState) MarshalWithEncoder(encoder *ag_binary.Encoder) (err error) {
+	if err = obj.Keys.MarshalWithEncoder(encoder); err != nil {
+		return err
+	}
+	return encoder.WriteBytes(obj.Data, false)
+}
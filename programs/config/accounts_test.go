@@ -0,0 +1,137 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	ag_binary "github.com/gagliardetto/binary"
+	ag_solanago "github.com/gagliardetto/solana-go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigState(t *testing.T) {
+	signer := ag_solanago.NewWallet().PublicKey()
+	state := ConfigState{
+		Keys: ConfigKeys{
+			{PublicKey: ValidatorInfoKey},
+			{PublicKey: signer, IsSigner: true},
+		},
+		Data: []byte{1, 2, 3},
+	}
+	buf := new(bytes.Buffer)
+	require.NoError(t, ag_binary.NewBinEncoder(buf).Encode(state))
+	data := buf.Bytes()
+	require.Len(t, data, state.Keys.Size()+3)
+	require.Equal(t, 67, state.Keys.Size())
+	require.Equal(t, byte(2), data[0])
+	require.Equal(t, ValidatorInfoKey[:], data[1:33])
+	require.Equal(t, byte(0), data[33])
+	require.Equal(t, signer[:], data[34:66])
+	require.Equal(t, byte(1), data[66])
+
+	got, err := DecodeConfigState(data)
+	require.NoError(t, err)
+	require.Equal(t, &state, got)
+	require.Equal(t, []ag_solanago.PublicKey{signer}, got.Keys.Signers())
+
+	_, err = DecodeConfigState(data[:40])
+	require.Error(t, err)
+}
+
+func TestValidatorInfo(t *testing.T) {
+	identity := ag_solanago.NewWallet().PublicKey()
+	info := ValidatorInfo{
+		Name:            "Example Validator",
+		Website:         "https://example.com",
+		KeybaseUsername: "example",
+	}
+
+	// The first store into a new account is signed by the account itself.
+	infoAccount := ag_solanago.NewWallet().PublicKey()
+	inst, err := NewStoreValidatorInfoInstruction(info, identity, infoAccount, true)
+	require.NoError(t, err)
+	built, err := inst.ValidateAndBuild()
+	require.NoError(t, err)
+
+	accounts := built.Accounts()
+	require.Len(t, accounts, 2)
+	require.Equal(t, infoAccount, accounts[0].PublicKey)
+	require.True(t, accounts[0].IsWritable)
+	require.True(t, accounts[0].IsSigner)
+	require.Equal(t, identity, accounts[1].PublicKey)
+	require.True(t, accounts[1].IsSigner)
+
+	// Updates are signed by the identity only.
+	update, err := NewStoreValidatorInfoInstruction(info, identity, infoAccount, false)
+	require.NoError(t, err)
+	require.False(t, update.GetConfigAccount().IsSigner)
+
+	data, err := built.Data()
+	require.NoError(t, err)
+	require.Contains(t, string(data), `{"name":"Example Validator","website":"https://example.com","keybaseUsername":"example"}`)
+
+	decoded, err := DecodeInstruction(accounts, data)
+	require.NoError(t, err)
+	store := decoded.Impl.(*Store)
+	require.Equal(t, ValidatorInfoKeys(identity), store.Keys)
+	require.Equal(t, infoAccount, store.GetConfigAccount().PublicKey)
+
+	// The account data is the instruction data, zero padded.
+	accountData := make([]byte, ValidatorInfoAccountSize())
+	copy(accountData, data)
+	got, err := DecodeValidatorInfo(accountData)
+	require.NoError(t, err)
+	require.Equal(t, identity, got.Identity)
+	require.Equal(t, info, got.Info)
+
+	create := NewCreateValidatorInfoAccountInstruction(1_000_000, identity, infoAccount)
+	require.Equal(t, uint64(ValidatorInfoAccountSize()), *create.Space)
+	require.Equal(t, ProgramID, *create.Owner)
+	require.Equal(t, infoAccount, create.GetNewAccount().PublicKey)
+	require.True(t, create.GetNewAccount().IsSigner)
+
+	_, err = NewStoreValidatorInfoInstruction(ValidatorInfo{Name: strings.Repeat("x", MaxValidatorInfo)}, identity, infoAccount, true)
+	require.Error(t, err)
+	_, err = NewStoreValidatorInfoInstruction(ValidatorInfo{}, identity, infoAccount, true)
+	require.Error(t, err)
+
+	// Not a validator info account.
+	_, err = DecodeValidatorInfo(data[1+33:])
+	require.Error(t, err)
+}
+
+func TestStore_Validate(t *testing.T) {
+	config := ag_solanago.NewWallet().PublicKey()
+	signer := ag_solanago.NewWallet().PublicKey()
+	keys := ConfigKeys{{PublicKey: signer, IsSigner: true}}
+
+	// The config account may be one of the signers.
+	inst, err := NewStoreInstruction(ConfigKeys{{PublicKey: config, IsSigner: true}}, []byte{}, config, true).ValidateAndBuild()
+	require.NoError(t, err)
+	require.Len(t, inst.Accounts(), 1)
+
+	_, err = NewStoreInstructionBuilder().
+		SetKeys(keys...).
+		SetData([]byte{}).
+		SetConfigAccount(config, true).
+		ValidateAndBuild()
+	require.Error(t, err)
+
+	_, err = NewStoreInstruction(keys, nil, config, true).ValidateAndBuild()
+	require.Error(t, err)
+}
@@ -0,0 +1,17 @@
+package config
+
+import (
+	"github.com/gagliardetto/solana-go"
+)
+
+var (
+	DecodeStore = decode[*Store]()
+)
+
+func decode[T any]() func(*solana.Message, int) (T, error) {
+	return solana.DecodeInstructionType[*Instruction, T](
+		ProgramID,
+		InstructionImplDef,
+		DecodeInstruction,
+	)
+}
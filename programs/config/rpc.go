@@ -0,0 +1,97 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"context"
+	"fmt"
+
+	ag_solanago "github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+)
+
+// FetchValidatorInfos returns the validator infos published on the cluster;
+// accounts whose info can't be decoded are skipped.
+func FetchValidatorInfos(ctx context.Context, rpcCli *rpc.Client) (out []*ValidatorInfoAccount, err error) {
+	resp, err := rpcCli.GetProgramAccountsWithOpts(
+		ctx,
+		ProgramID,
+		&rpc.GetProgramAccountsOpts{
+			Filters: []rpc.RPCFilter{
+				{
+					// The first key, after the compact-u16 keys count.
+					Memcmp: &rpc.RPCFilterMemcmp{
+						Offset: 1,
+						Bytes:  ag_solanago.Base58(ValidatorInfoKey[:]),
+					},
+				},
+			},
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil {
+		return nil, fmt.Errorf("resp empty... program account not found")
+	}
+
+	for _, keyedAcct := range resp {
+		info, err := DecodeValidatorInfo(keyedAcct.Account.Data.GetBinary())
+		if err != nil {
+			continue
+		}
+		info.Pubkey = keyedAcct.Pubkey
+		out = append(out, info)
+	}
+	return
+}
+
+// FetchValidatorInfoAccount returns the validator info account of the provided
+// identity, or rpc.ErrNotFound if the identity has not published any info.
+func FetchValidatorInfoAccount(ctx context.Context, rpcCli *rpc.Client, identity ag_solanago.PublicKey) (*ValidatorInfoAccount, error) {
+	resp, err := rpcCli.GetProgramAccountsWithOpts(
+		ctx,
+		ProgramID,
+		&rpc.GetProgramAccountsOpts{
+			Filters: []rpc.RPCFilter{
+				{
+					Memcmp: &rpc.RPCFilterMemcmp{
+						Offset: 1,
+						Bytes:  ag_solanago.Base58(ValidatorInfoKey[:]),
+					},
+				},
+				{
+					// The second key: the identity, after the first key and its signer flag.
+					Memcmp: &rpc.RPCFilterMemcmp{
+						Offset: 1 + ag_solanago.PublicKeyLength + 1,
+						Bytes:  ag_solanago.Base58(identity[:]),
+					},
+				},
+			},
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	for _, keyedAcct := range resp {
+		info, err := DecodeValidatorInfo(keyedAcct.Account.Data.GetBinary())
+		if err != nil || !info.Identity.Equals(identity) {
+			continue
+		}
+		info.Pubkey = keyedAcct.Pubkey
+		return info, nil
+	}
+	return nil, rpc.ErrNotFound
+}
@@ -0,0 +1,65 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	ag_solanago "github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFetchValidatorInfoAccount(t *testing.T) {
+	identity := ag_solanago.NewWallet().PublicKey()
+	infoAccount := ag_solanago.NewWallet().PublicKey()
+	info := ValidatorInfo{Name: "Example Validator"}
+
+	inst, err := NewStoreValidatorInfoInstruction(info, identity, infoAccount, true)
+	require.NoError(t, err)
+	data, err := inst.Build().Data()
+	require.NoError(t, err)
+	accountData := make([]byte, ValidatorInfoAccountSize())
+	copy(accountData, data)
+
+	var requestBody string
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		body, _ := io.ReadAll(req.Body)
+		requestBody = string(body)
+		fmt.Fprintf(rw,
+			`{"jsonrpc":"2.0","id":0,"result":[{"pubkey":%q,"account":{"lamports":1,"owner":%q,"data":[%q,"base64"],"executable":false,"rentEpoch":0}}]}`,
+			infoAccount, ProgramID, base64.StdEncoding.EncodeToString(accountData),
+		)
+	}))
+	defer server.Close()
+	rpcCli := rpc.New(server.URL)
+
+	got, err := FetchValidatorInfoAccount(context.Background(), rpcCli, identity)
+	require.NoError(t, err)
+	require.Equal(t, infoAccount, got.Pubkey)
+	require.Equal(t, identity, got.Identity)
+	require.Equal(t, info, got.Info)
+	require.Contains(t, requestBody, identity.String())
+
+	// The returned account doesn't belong to another identity.
+	_, err = FetchValidatorInfoAccount(context.Background(), rpcCli, ag_solanago.NewWallet().PublicKey())
+	require.ErrorIs(t, err, rpc.ErrNotFound)
+}
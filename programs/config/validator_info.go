@@ -0,0 +1,147 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	ag_binary "github.com/gagliardetto/binary"
+	ag_solanago "github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/programs/system"
+)
+
+// ValidatorInfoKey is the first key of the config accounts
+// holding validator info, identifying their type.
+var ValidatorInfoKey = ag_solanago.MustPublicKeyFromBase58("Va1idator1nfo111111111111111111111111111111")
+
+// Maximum size of the serialized validator info.
+const MaxValidatorInfo = 576
+
+// ValidatorInfo is the information a validator publishes about itself.
+type ValidatorInfo struct {
+	Name            string `json:"name"`
+	Website         string `json:"website,omitempty"`
+	Details         string `json:"details,omitempty"`
+	KeybaseUsername string `json:"keybaseUsername,omitempty"`
+	IconURL         string `json:"iconUrl,omitempty"`
+}
+
+// ValidatorInfoAccount is a config account holding validator info.
+type ValidatorInfoAccount struct {
+	// Address of the config account; zero if decoded from data only.
+	Pubkey ag_solanago.PublicKey
+
+	// Identity of the validator, which signed the info.
+	Identity ag_solanago.PublicKey
+
+	Info ValidatorInfo
+}
+
+// ValidatorInfoKeys returns the config keys of the validator info of the provided identity.
+func ValidatorInfoKeys(identity ag_solanago.PublicKey) ConfigKeys {
+	return ConfigKeys{
+		{PublicKey: ValidatorInfoKey, IsSigner: false},
+		{PublicKey: identity, IsSigner: true},
+	}
+}
+
+// ValidatorInfoAccountSize is the size of a validator info config account.
+func ValidatorInfoAccountSize() int {
+	return ValidatorInfoKeys(ag_solanago.PublicKey{}).Size() + MaxValidatorInfo
+}
+
+// MarshalValidatorInfo serializes the info as the config data of a validator info account.
+func MarshalValidatorInfo(info ValidatorInfo) ([]byte, error) {
+	if info.Name == "" {
+		return nil, fmt.Errorf("validator name is not set")
+	}
+	js, err := json.Marshal(info)
+	if err != nil {
+		return nil, err
+	}
+	buf := new(bytes.Buffer)
+	if err := ag_binary.NewBinEncoder(buf).WriteRustString(string(js)); err != nil {
+		return nil, err
+	}
+	if buf.Len() > MaxValidatorInfo {
+		return nil, fmt.Errorf("validator info is %d bytes, exceeding the maximum of %d", buf.Len(), MaxValidatorInfo)
+	}
+	return buf.Bytes(), nil
+}
+
+// DecodeValidatorInfo decodes the data of a validator info config account.
+func DecodeValidatorInfo(data []byte) (*ValidatorInfoAccount, error) {
+	state, err := DecodeConfigState(data)
+	if err != nil {
+		return nil, err
+	}
+	if len(state.Keys) < 2 || !state.Keys[0].PublicKey.Equals(ValidatorInfoKey) {
+		return nil, fmt.Errorf("not a validator info account")
+	}
+	if !state.Keys[1].IsSigner {
+		return nil, fmt.Errorf("validator identity %s is not a signer", state.Keys[1].PublicKey)
+	}
+	js, err := ag_binary.NewBinDecoder(state.Data).ReadRustString()
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode validator info: %w", err)
+	}
+	out := &ValidatorInfoAccount{
+		Identity: state.Keys[1].PublicKey,
+	}
+	if err := json.Unmarshal([]byte(js), &out.Info); err != nil {
+		return nil, fmt.Errorf("unable to parse validator info: %w", err)
+	}
+	return out, nil
+}
+
+// NewCreateValidatorInfoAccountInstruction creates a validator info account at
+// the address of a new keypair, which must sign; lamports must make the
+// account rent-exempt for ValidatorInfoAccountSize bytes.
+// Like the Solana CLI, publish the first info in the same transaction with
+// NewStoreValidatorInfoInstruction, with isConfigSigner set.
+func NewCreateValidatorInfoAccountInstruction(
+	lamports uint64,
+	payer ag_solanago.PublicKey,
+	infoAccount ag_solanago.PublicKey,
+) *system.CreateAccount {
+	return system.NewCreateAccountInstruction(
+		lamports,
+		uint64(ValidatorInfoAccountSize()),
+		ProgramID,
+		payer,
+		infoAccount,
+	)
+}
+
+// NewStoreValidatorInfoInstruction publishes the info of the provided identity
+// into its validator info account.
+//
+// A new account holds no keys yet, so the first store must be signed by the
+// info account itself: set isConfigSigner. Later updates are signed by the
+// identity only; find the account of an identity with FetchValidatorInfoAccount.
+func NewStoreValidatorInfoInstruction(
+	info ValidatorInfo,
+	identity ag_solanago.PublicKey,
+	infoAccount ag_solanago.PublicKey,
+	isConfigSigner bool,
+) (*Store, error) {
+	data, err := MarshalValidatorInfo(info)
+	if err != nil {
+		return nil, err
+	}
+	return NewStoreInstruction(ValidatorInfoKeys(identity), data, infoAccount, isConfigSigner), nil
+}
@@ -0,0 +1,111 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Decode feature-gate accounts, and activate runtime features.
+
+package feature
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+
+	ag_binary "github.com/gagliardetto/binary"
+	ag_solanago "github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/programs/system"
+)
+
+var ProgramID ag_solanago.PublicKey = ag_solanago.FeatureProgramID
+
+func SetProgramID(pubkey ag_solanago.PublicKey) {
+	ProgramID = pubkey
+}
+
+const ProgramName = "Feature"
+
+// Size in bytes of a feature account.
+const FeatureSize = 9
+
+// Feature is the state of a feature account.
+type Feature struct {
+	// Slot in which the feature was activated;
+	// nil if the activation is pending.
+	ActivatedAt *uint64
+}
+
+// DecodeFeature decodes the data of a feature account.
+func DecodeFeature(data []byte) (*Feature, error) {
+	feature := new(Feature)
+	if err := feature.UnmarshalWithDecoder(ag_binary.NewBinDecoder(data)); err != nil {
+		return nil, fmt.Errorf("unable to decode feature: %w", err)
+	}
+	return feature, nil
+}
+
+func (obj *Feature) UnmarshalWithDecoder(decoder *ag_binary.Decoder) error {
+	ok, err := decoder.ReadOption()
+	if err != nil {
+		return err
+	}
+	if !ok {
+		obj.ActivatedAt = nil
+		return nil
+	}
+	slot, err := decoder.ReadUint64(binary.LittleEndian)
+	if err != nil {
+		return err
+	}
+	obj.ActivatedAt = &slot
+	return nil
+}
+
+func (obj Feature) MarshalWithEncoder(encoder *ag_binary.Encoder) error {
+	if err := encoder.WriteOption(obj.ActivatedAt != nil); err != nil {
+		return err
+	}
+	var slot uint64
+	if obj.ActivatedAt != nil {
+		slot = *obj.ActivatedAt
+	}
+	// The slot is always present, to keep the account size fixed.
+	return encoder.WriteUint64(slot, binary.LittleEndian)
+}
+
+// MarshalBinary returns the data of the feature account.
+func (obj Feature) MarshalBinary() ([]byte, error) {
+	buf := new(bytes.Buffer)
+	if err := ag_binary.NewBinEncoder(buf).Encode(obj); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// NewActivateInstructions returns the instructions that request the
+// activation of a feature: they fund the feature account with lamports
+// (which must make it rent-exempt for FeatureSize bytes), allocate it and
+// assign it to the feature program. The runtime activates the feature at
+// the start of the next epoch.
+//
+// The feature account must sign, along with the funding account.
+func NewActivateInstructions(
+	featureID ag_solanago.PublicKey,
+	fundingAccount ag_solanago.PublicKey,
+	lamports uint64,
+) []ag_solanago.Instruction {
+	return []ag_solanago.Instruction{
+		system.NewTransferInstruction(lamports, fundingAccount, featureID).Build(),
+		system.NewAllocateInstruction(FeatureSize, featureID).Build(),
+		system.NewAssignInstruction(ProgramID, featureID).Build(),
+	}
+}
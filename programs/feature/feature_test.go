@@ -0,0 +1,115 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package feature
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	ag_solanago "github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/programs/system"
+	"github.com/gagliardetto/solana-go/rpc"
+	ag_text "github.com/gagliardetto/solana-go/text"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFeature(t *testing.T) {
+	slot := uint64(250_000_000)
+	data, err := Feature{ActivatedAt: &slot}.MarshalBinary()
+	require.NoError(t, err)
+	require.Equal(t, []byte{1, 0x80, 0xb2, 0xe6, 0x0e, 0, 0, 0, 0}, data)
+
+	got, err := DecodeFeature(data)
+	require.NoError(t, err)
+	require.Equal(t, slot, *got.ActivatedAt)
+
+	data, err = Feature{}.MarshalBinary()
+	require.NoError(t, err)
+	require.Equal(t, make([]byte, FeatureSize), data)
+	got, err = DecodeFeature(data)
+	require.NoError(t, err)
+	require.Nil(t, got.ActivatedAt)
+
+	_, err = DecodeFeature([]byte{1, 2})
+	require.Error(t, err)
+}
+
+func TestNewActivateInstructions(t *testing.T) {
+	featureID := ag_solanago.NewWallet().PublicKey()
+	payer := ag_solanago.NewWallet().PublicKey()
+
+	instructions := NewActivateInstructions(featureID, payer, 953_520)
+	require.Len(t, instructions, 3)
+
+	tx, err := ag_solanago.NewTransaction(instructions, ag_solanago.Hash{}, ag_solanago.TransactionPayer(payer))
+	require.NoError(t, err)
+	require.Equal(t, uint8(2), tx.Message.Header.NumRequiredSignatures)
+
+	allocate, err := system.DecodeInstruction(nil, mustData(t, instructions[1]))
+	require.NoError(t, err)
+	require.Equal(t, uint64(FeatureSize), *allocate.Impl.(*system.Allocate).Space)
+	assign, err := system.DecodeInstruction(nil, mustData(t, instructions[2]))
+	require.NoError(t, err)
+	require.Equal(t, ProgramID, *assign.Impl.(*system.Assign).Owner)
+}
+
+func mustData(t *testing.T, inst ag_solanago.Instruction) []byte {
+	data, err := inst.Data()
+	require.NoError(t, err)
+	return data
+}
+
+func TestGetFeatureStatusReport(t *testing.T) {
+	active := ag_solanago.NewWallet().PublicKey()
+	pending := ag_solanago.NewWallet().PublicKey()
+	inactive := ag_solanago.NewWallet().PublicKey()
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		account := func(data []byte) string {
+			return fmt.Sprintf(
+				`{"lamports":953520,"owner":%q,"data":[%q,"base64"],"executable":false,"rentEpoch":0}`,
+				ProgramID, base64.StdEncoding.EncodeToString(data),
+			)
+		}
+		fmt.Fprintf(rw,
+			`{"jsonrpc":"2.0","id":0,"result":{"context":{"slot":1},"value":[%s,%s,null]}}`,
+			account([]byte{1, 42, 0, 0, 0, 0, 0, 0, 0}),
+			account(make([]byte, FeatureSize)),
+		)
+	}))
+	defer server.Close()
+
+	report, err := GetFeatureStatusReport(context.Background(), rpc.New(server.URL), active, pending, inactive)
+	require.NoError(t, err)
+	require.Len(t, report, 3)
+	require.Equal(t, FeatureStatusActive, report[0].Status)
+	require.Equal(t, uint64(42), *report[0].ActivatedAt)
+	require.Equal(t, FeatureStatusPending, report[1].Status)
+	require.Equal(t, FeatureStatusInactive, report[2].Status)
+	require.Len(t, report.Filter(FeatureStatusPending), 1)
+
+	ag_text.DisableColors = true
+	defer func() { ag_text.DisableColors = false }()
+	out := report.String()
+	require.True(t, strings.Contains(out, "Features[len=3]"), out)
+	require.True(t, strings.Contains(out, active.String()+" (activated at slot 42)"), out)
+	require.True(t, strings.Contains(out, "Pending[len=1]"), out)
+	require.True(t, strings.Contains(out, "Inactive[len=1]"), out)
+}
@@ -0,0 +1,163 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package feature
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	ag_solanago "github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+	ag_text "github.com/gagliardetto/solana-go/text"
+	ag_treeout "github.com/gagliardetto/treeout"
+)
+
+// FeatureStatus is the activation status of a feature on a cluster.
+type FeatureStatus int
+
+const (
+	// The activation of the feature hasn't been requested.
+	FeatureStatusInactive FeatureStatus = iota
+	// The feature will be activated at the start of the next epoch.
+	FeatureStatusPending
+	// The feature is active.
+	FeatureStatusActive
+)
+
+func (s FeatureStatus) String() string {
+	switch s {
+	case FeatureStatusInactive:
+		return "Inactive"
+	case FeatureStatusPending:
+		return "Pending"
+	case FeatureStatusActive:
+		return "Active"
+	default:
+		return fmt.Sprintf("Unknown(%d)", int(s))
+	}
+}
+
+// FeatureInfo is the activation status of a feature.
+type FeatureInfo struct {
+	ID     ag_solanago.PublicKey
+	Status FeatureStatus
+
+	// Slot in which the feature was activated, if active.
+	ActivatedAt *uint64
+}
+
+// NewFeatureInfo returns the activation status of the feature
+// from its account, which is nil if it doesn't exist.
+func NewFeatureInfo(featureID ag_solanago.PublicKey, account *rpc.Account) (*FeatureInfo, error) {
+	info := &FeatureInfo{
+		ID:     featureID,
+		Status: FeatureStatusInactive,
+	}
+	if account == nil || !account.Owner.Equals(ProgramID) {
+		return info, nil
+	}
+	feature, err := DecodeFeature(account.Data.GetBinary())
+	if err != nil {
+		return nil, fmt.Errorf("feature %s: %w", featureID, err)
+	}
+	info.ActivatedAt = feature.ActivatedAt
+	if feature.ActivatedAt != nil {
+		info.Status = FeatureStatusActive
+	} else {
+		info.Status = FeatureStatusPending
+	}
+	return info, nil
+}
+
+// FeatureStatusReport is the activation status of a list of features.
+type FeatureStatusReport []*FeatureInfo
+
+// Filter returns the features of the report with the provided status.
+func (report FeatureStatusReport) Filter(status FeatureStatus) FeatureStatusReport {
+	var out FeatureStatusReport
+	for _, info := range report {
+		if info.Status == status {
+			out = append(out, info)
+		}
+	}
+	return out
+}
+
+func (report FeatureStatusReport) EncodeToTree(parent ag_treeout.Branches) {
+	parent.Child(fmt.Sprintf("Features[len=%v]", len(report))).ParentFunc(func(featuresBranch ag_treeout.Branches) {
+		for _, status := range []FeatureStatus{FeatureStatusActive, FeatureStatusPending, FeatureStatusInactive} {
+			infos := report.Filter(status)
+			featuresBranch.Child(fmt.Sprintf("%s[len=%v]", ag_text.Bold(status.String()), len(infos))).ParentFunc(func(statusBranch ag_treeout.Branches) {
+				for _, info := range infos {
+					line := ag_text.ColorizeBG(info.ID.String())
+					if info.ActivatedAt != nil {
+						line += fmt.Sprintf(" (activated at slot %v)", *info.ActivatedAt)
+					}
+					statusBranch.Child(line)
+				}
+			})
+		}
+	})
+}
+
+func (report FeatureStatusReport) EncodeTree(encoder *ag_text.TreeEncoder) (int, error) {
+	report.EncodeToTree(encoder)
+	return encoder.WriteString(encoder.Tree.String())
+}
+
+// String returns a human-readable tree of the features, grouped by status.
+// To disable colors, set "github.com/gagliardetto/solana-go/text".DisableColors = true
+func (report FeatureStatusReport) String() string {
+	buf := new(bytes.Buffer)
+	_, err := report.EncodeTree(ag_text.NewTreeEncoder(buf, ""))
+	if err != nil {
+		panic(err)
+	}
+	return buf.String()
+}
+
+// Maximum number of accounts per getMultipleAccounts request.
+const maxAccountsPerRequest = 100
+
+// GetFeatureStatusReport fetches the activation status of the provided features.
+func GetFeatureStatusReport(
+	ctx context.Context,
+	rpcCli *rpc.Client,
+	featureIDs ...ag_solanago.PublicKey,
+) (FeatureStatusReport, error) {
+	report := make(FeatureStatusReport, 0, len(featureIDs))
+	for start := 0; start < len(featureIDs); start += maxAccountsPerRequest {
+		end := start + maxAccountsPerRequest
+		if end > len(featureIDs) {
+			end = len(featureIDs)
+		}
+		resp, err := rpcCli.GetMultipleAccounts(ctx, featureIDs[start:end]...)
+		if err != nil {
+			return nil, err
+		}
+		if len(resp.Value) != end-start {
+			return nil, fmt.Errorf("expected %d accounts, got %d", end-start, len(resp.Value))
+		}
+		for i, account := range resp.Value {
+			info, err := NewFeatureInfo(featureIDs[start+i], account)
+			if err != nil {
+				return nil, err
+			}
+			report = append(report, info)
+		}
+	}
+	return report, nil
+}
@@ -0,0 +1,238 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loaderv4
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	ag_binary "github.com/gagliardetto/binary"
+	ag_solanago "github.com/gagliardetto/solana-go"
+	ag_format "github.com/gagliardetto/solana-go/text/format"
+	ag_treeout "github.com/gagliardetto/treeout"
+)
+
+// Copy ELF data into an undeployed program account.
+type Copy struct {
+	// Offset at which to write
+	DestinationOffset *uint32
+
+	// Offset at which to read
+	SourceOffset *uint32
+
+	// Amount of bytes to copy
+	Length *uint32
+
+	// [0] = [WRITE] ProgramAccount
+	// ··········· The program account to write to
+	//
+	// [1] = [SIGNER] AuthorityAccount
+	// ··········· The authority of the program
+	//
+	// [2] = [] SourceProgramAccount
+	// ··········· The program account to copy from
+	ag_solanago.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+// NewCopyInstructionBuilder creates a new `Copy` instruction builder.
+func NewCopyInstructionBuilder() *Copy {
+	nd := &Copy{
+		AccountMetaSlice: make(ag_solanago.AccountMetaSlice, 3),
+	}
+	return nd
+}
+
+// Offset at which to write
+func (inst *Copy) SetDestinationOffset(destinationOffset uint32) *Copy {
+	inst.DestinationOffset = &destinationOffset
+	return inst
+}
+
+// Offset at which to read
+func (inst *Copy) SetSourceOffset(sourceOffset uint32) *Copy {
+	inst.SourceOffset = &sourceOffset
+	return inst
+}
+
+// Amount of bytes to copy
+func (inst *Copy) SetLength(length uint32) *Copy {
+	inst.Length = &length
+	return inst
+}
+
+// The program account to write to
+func (inst *Copy) SetProgramAccount(programAccount ag_solanago.PublicKey) *Copy {
+	inst.AccountMetaSlice[0] = ag_solanago.Meta(programAccount).WRITE()
+	return inst
+}
+
+func (inst *Copy) GetProgramAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[0]
+}
+
+// The authority of the program
+func (inst *Copy) SetAuthorityAccount(authorityAccount ag_solanago.PublicKey) *Copy {
+	inst.AccountMetaSlice[1] = ag_solanago.Meta(authorityAccount).SIGNER()
+	return inst
+}
+
+func (inst *Copy) GetAuthorityAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[1]
+}
+
+// The program account to copy from
+func (inst *Copy) SetSourceProgramAccount(sourceProgramAccount ag_solanago.PublicKey) *Copy {
+	inst.AccountMetaSlice[2] = ag_solanago.Meta(sourceProgramAccount)
+	return inst
+}
+
+func (inst *Copy) GetSourceProgramAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[2]
+}
+
+func (inst Copy) Build() *Instruction {
+	return &Instruction{BaseVariant: ag_binary.BaseVariant{
+		Impl:   inst,
+		TypeID: ag_binary.TypeIDFromUint32(Instruction_Copy, binary.LittleEndian),
+	}}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (inst Copy) ValidateAndBuild() (*Instruction, error) {
+	if err := inst.Validate(); err != nil {
+		return nil, err
+	}
+	return inst.Build(), nil
+}
+
+func (inst *Copy) Validate() error {
+	// Check whether all (required) parameters are set:
+	{
+		if inst.DestinationOffset == nil {
+			return errors.New("DestinationOffset parameter is not set")
+		}
+		if inst.SourceOffset == nil {
+			return errors.New("SourceOffset parameter is not set")
+		}
+		if inst.Length == nil {
+			return errors.New("Length parameter is not set")
+		}
+	}
+
+	// Check whether all (required) accounts are set:
+	for accIndex, acc := range inst.AccountMetaSlice {
+		if acc == nil {
+			return fmt.Errorf("ins.AccountMetaSlice[%v] is not set", accIndex)
+		}
+	}
+	return nil
+}
+
+func (inst *Copy) EncodeToTree(parent ag_treeout.Branches) {
+	parent.Child(ag_format.Program(ProgramName, ProgramID)).
+		//
+		ParentFunc(func(programBranch ag_treeout.Branches) {
+			programBranch.Child(ag_format.Instruction("Copy")).
+				//
+				ParentFunc(func(instructionBranch ag_treeout.Branches) {
+
+					// Parameters of the instruction:
+					instructionBranch.Child("Params").ParentFunc(func(paramsBranch ag_treeout.Branches) {
+						paramsBranch.Child(ag_format.Param("DestinationOffset", *inst.DestinationOffset))
+						paramsBranch.Child(ag_format.Param("     SourceOffset", *inst.SourceOffset))
+						paramsBranch.Child(ag_format.Param("           Length", *inst.Length))
+					})
+
+					// Accounts of the instruction:
+					instructionBranch.Child("Accounts").ParentFunc(func(accountsBranch ag_treeout.Branches) {
+						accountsBranch.Child(ag_format.Meta("      Program", inst.AccountMetaSlice.Get(0)))
+						accountsBranch.Child(ag_format.Meta("    Authority", inst.AccountMetaSlice.Get(1)))
+						accountsBranch.Child(ag_format.Meta("SourceProgram", inst.AccountMetaSlice.Get(2)))
+					})
+				})
+		})
+}
+
+func (inst Copy) MarshalWithEncoder(encoder *ag_binary.Encoder) error {
+	// Serialize `DestinationOffset` param:
+	{
+		err := encoder.Encode(*inst.DestinationOffset)
+		if err != nil {
+			return err
+		}
+	}
+	// Serialize `SourceOffset` param:
+	{
+		err := encoder.Encode(*inst.SourceOffset)
+		if err != nil {
+			return err
+		}
+	}
+	// Serialize `Length` param:
+	{
+		err := encoder.Encode(*inst.Length)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (inst *Copy) UnmarshalWithDecoder(decoder *ag_binary.Decoder) error {
+	// Deserialize `DestinationOffset` param:
+	{
+		err := decoder.Decode(&inst.DestinationOffset)
+		if err != nil {
+			return err
+		}
+	}
+	// Deserialize `SourceOffset` param:
+	{
+		err := decoder.Decode(&inst.SourceOffset)
+		if err != nil {
+			return err
+		}
+	}
+	// Deserialize `Length` param:
+	{
+		err := decoder.Decode(&inst.Length)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// NewCopyInstruction declares a new Copy instruction with the provided parameters and accounts.
+func NewCopyInstruction(
+	// Parameters:
+	destinationOffset uint32,
+	sourceOffset uint32,
+	length uint32,
+	// Accounts:
+	programAccount ag_solanago.PublicKey,
+	authorityAccount ag_solanago.PublicKey,
+	sourceProgramAccount ag_solanago.PublicKey) *Copy {
+	return NewCopyInstructionBuilder().
+		SetDestinationOffset(destinationOffset).
+		SetSourceOffset(sourceOffset).
+		SetLength(length).
+		SetProgramAccount(programAccount).
+		SetAuthorityAccount(authorityAccount).
+		SetSourceProgramAccount(sourceProgramAccount)
+}
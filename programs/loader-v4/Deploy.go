@@ -0,0 +1,128 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loaderv4
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	ag_binary "github.com/gagliardetto/binary"
+	ag_solanago "github.com/gagliardetto/solana-go"
+	ag_format "github.com/gagliardetto/solana-go/text/format"
+	ag_treeout "github.com/gagliardetto/treeout"
+)
+
+// Deploy a program account.
+type Deploy struct {
+	// [0] = [WRITE] ProgramAccount
+	// ··········· The program account to deploy
+	//
+	// [1] = [SIGNER] AuthorityAccount
+	// ··········· The authority of the program
+	ag_solanago.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+// NewDeployInstructionBuilder creates a new `Deploy` instruction builder.
+func NewDeployInstructionBuilder() *Deploy {
+	nd := &Deploy{
+		AccountMetaSlice: make(ag_solanago.AccountMetaSlice, 2),
+	}
+	return nd
+}
+
+// The program account to deploy
+func (inst *Deploy) SetProgramAccount(programAccount ag_solanago.PublicKey) *Deploy {
+	inst.AccountMetaSlice[0] = ag_solanago.Meta(programAccount).WRITE()
+	return inst
+}
+
+func (inst *Deploy) GetProgramAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[0]
+}
+
+// The authority of the program
+func (inst *Deploy) SetAuthorityAccount(authorityAccount ag_solanago.PublicKey) *Deploy {
+	inst.AccountMetaSlice[1] = ag_solanago.Meta(authorityAccount).SIGNER()
+	return inst
+}
+
+func (inst *Deploy) GetAuthorityAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[1]
+}
+
+func (inst Deploy) Build() *Instruction {
+	return &Instruction{BaseVariant: ag_binary.BaseVariant{
+		Impl:   inst,
+		TypeID: ag_binary.TypeIDFromUint32(Instruction_Deploy, binary.LittleEndian),
+	}}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (inst Deploy) ValidateAndBuild() (*Instruction, error) {
+	if err := inst.Validate(); err != nil {
+		return nil, err
+	}
+	return inst.Build(), nil
+}
+
+func (inst *Deploy) Validate() error {
+	// Check whether all (required) accounts are set:
+	for accIndex, acc := range inst.AccountMetaSlice {
+		if acc == nil {
+			return fmt.Errorf("ins.AccountMetaSlice[%v] is not set", accIndex)
+		}
+	}
+	return nil
+}
+
+func (inst *Deploy) EncodeToTree(parent ag_treeout.Branches) {
+	parent.Child(ag_format.Program(ProgramName, ProgramID)).
+		//
+		ParentFunc(func(programBranch ag_treeout.Branches) {
+			programBranch.Child(ag_format.Instruction("Deploy")).
+				//
+				ParentFunc(func(instructionBranch ag_treeout.Branches) {
+
+					// Parameters of the instruction:
+					instructionBranch.Child("Params").ParentFunc(func(paramsBranch ag_treeout.Branches) {})
+
+					// Accounts of the instruction:
+					instructionBranch.Child("Accounts").ParentFunc(func(accountsBranch ag_treeout.Branches) {
+						accountsBranch.Child(ag_format.Meta("  Program", inst.AccountMetaSlice.Get(0)))
+						accountsBranch.Child(ag_format.Meta("Authority", inst.AccountMetaSlice.Get(1)))
+					})
+				})
+		})
+}
+
+func (inst Deploy) MarshalWithEncoder(encoder *ag_binary.Encoder) error {
+	return nil
+}
+
+func (inst *Deploy) UnmarshalWithDecoder(decoder *ag_binary.Decoder) error {
+	return nil
+}
+
+// NewDeployInstruction declares a new Deploy instruction with the provided parameters and accounts.
+func NewDeployInstruction(
+	// Accounts:
+	programAccount ag_solanago.PublicKey,
+	authorityAccount ag_solanago.PublicKey) *Deploy {
+	return NewDeployInstructionBuilder().
+		SetProgramAccount(programAccount).
+		SetAuthorityAccount(authorityAccount)
+}
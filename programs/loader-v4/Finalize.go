@@ -0,0 +1,144 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loaderv4
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	ag_binary "github.com/gagliardetto/binary"
+	ag_solanago "github.com/gagliardetto/solana-go"
+	ag_format "github.com/gagliardetto/solana-go/text/format"
+	ag_treeout "github.com/gagliardetto/treeout"
+)
+
+// Finalizes the program account, rendering it immutable.
+type Finalize struct {
+	// [0] = [WRITE] ProgramAccount
+	// ··········· The program account to finalize
+	//
+	// [1] = [SIGNER] AuthorityAccount
+	// ··········· The authority of the program
+	//
+	// [2] = [] NextVersionAccount
+	// ··········· The program account of the next version
+	ag_solanago.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+// NewFinalizeInstructionBuilder creates a new `Finalize` instruction builder.
+func NewFinalizeInstructionBuilder() *Finalize {
+	nd := &Finalize{
+		AccountMetaSlice: make(ag_solanago.AccountMetaSlice, 3),
+	}
+	return nd
+}
+
+// The program account to finalize
+func (inst *Finalize) SetProgramAccount(programAccount ag_solanago.PublicKey) *Finalize {
+	inst.AccountMetaSlice[0] = ag_solanago.Meta(programAccount).WRITE()
+	return inst
+}
+
+func (inst *Finalize) GetProgramAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[0]
+}
+
+// The authority of the program
+func (inst *Finalize) SetAuthorityAccount(authorityAccount ag_solanago.PublicKey) *Finalize {
+	inst.AccountMetaSlice[1] = ag_solanago.Meta(authorityAccount).SIGNER()
+	return inst
+}
+
+func (inst *Finalize) GetAuthorityAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[1]
+}
+
+// The program account of the next version
+func (inst *Finalize) SetNextVersionAccount(nextVersionAccount ag_solanago.PublicKey) *Finalize {
+	inst.AccountMetaSlice[2] = ag_solanago.Meta(nextVersionAccount)
+	return inst
+}
+
+func (inst *Finalize) GetNextVersionAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[2]
+}
+
+func (inst Finalize) Build() *Instruction {
+	return &Instruction{BaseVariant: ag_binary.BaseVariant{
+		Impl:   inst,
+		TypeID: ag_binary.TypeIDFromUint32(Instruction_Finalize, binary.LittleEndian),
+	}}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (inst Finalize) ValidateAndBuild() (*Instruction, error) {
+	if err := inst.Validate(); err != nil {
+		return nil, err
+	}
+	return inst.Build(), nil
+}
+
+func (inst *Finalize) Validate() error {
+	// Check whether all (required) accounts are set:
+	for accIndex, acc := range inst.AccountMetaSlice {
+		if acc == nil {
+			return fmt.Errorf("ins.AccountMetaSlice[%v] is not set", accIndex)
+		}
+	}
+	return nil
+}
+
+func (inst *Finalize) EncodeToTree(parent ag_treeout.Branches) {
+	parent.Child(ag_format.Program(ProgramName, ProgramID)).
+		//
+		ParentFunc(func(programBranch ag_treeout.Branches) {
+			programBranch.Child(ag_format.Instruction("Finalize")).
+				//
+				ParentFunc(func(instructionBranch ag_treeout.Branches) {
+
+					// Parameters of the instruction:
+					instructionBranch.Child("Params").ParentFunc(func(paramsBranch ag_treeout.Branches) {})
+
+					// Accounts of the instruction:
+					instructionBranch.Child("Accounts").ParentFunc(func(accountsBranch ag_treeout.Branches) {
+						accountsBranch.Child(ag_format.Meta("    Program", inst.AccountMetaSlice.Get(0)))
+						accountsBranch.Child(ag_format.Meta("  Authority", inst.AccountMetaSlice.Get(1)))
+						accountsBranch.Child(ag_format.Meta("NextVersion", inst.AccountMetaSlice.Get(2)))
+					})
+				})
+		})
+}
+
+func (inst Finalize) MarshalWithEncoder(encoder *ag_binary.Encoder) error {
+	return nil
+}
+
+func (inst *Finalize) UnmarshalWithDecoder(decoder *ag_binary.Decoder) error {
+	return nil
+}
+
+// NewFinalizeInstruction declares a new Finalize instruction with the provided parameters and accounts.
+func NewFinalizeInstruction(
+	// Accounts:
+	programAccount ag_solanago.PublicKey,
+	authorityAccount ag_solanago.PublicKey,
+	nextVersionAccount ag_solanago.PublicKey) *Finalize {
+	return NewFinalizeInstructionBuilder().
+		SetProgramAccount(programAccount).
+		SetAuthorityAccount(authorityAccount).
+		SetNextVersionAccount(nextVersionAccount)
+}
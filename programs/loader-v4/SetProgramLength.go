@@ -0,0 +1,186 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loaderv4
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	ag_binary "github.com/gagliardetto/binary"
+	ag_solanago "github.com/gagliardetto/solana-go"
+	ag_format "github.com/gagliardetto/solana-go/text/format"
+	ag_treeout "github.com/gagliardetto/treeout"
+)
+
+// Changes the size of an undeployed program account.
+//
+// A program account is automatically initialized when its size is first increased.
+// In this initial truncate, this sets the authority needed for subsequent operations.
+// Decreasing to size zero closes the program account and resets it into an uninitialized state.
+// Closing the program requires a recipient account.
+// Providing a recipient account without closing the program account is possible as well.
+type SetProgramLength struct {
+	// New size of the program data, in bytes
+	NewSize *uint32
+
+	// [0] = [WRITE] ProgramAccount
+	// ··········· The program account to change the size of
+	//
+	// [1] = [SIGNER] AuthorityAccount
+	// ··········· The authority of the program
+	//
+	// [2] = [WRITE] RecipientAccount
+	// ··········· The recipient account of the lamports in excess
+	ag_solanago.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+// NewSetProgramLengthInstructionBuilder creates a new `SetProgramLength` instruction builder.
+func NewSetProgramLengthInstructionBuilder() *SetProgramLength {
+	nd := &SetProgramLength{
+		AccountMetaSlice: make(ag_solanago.AccountMetaSlice, 3),
+	}
+	return nd
+}
+
+// New size of the program data, in bytes
+func (inst *SetProgramLength) SetNewSize(newSize uint32) *SetProgramLength {
+	inst.NewSize = &newSize
+	return inst
+}
+
+// The program account to change the size of
+func (inst *SetProgramLength) SetProgramAccount(programAccount ag_solanago.PublicKey) *SetProgramLength {
+	inst.AccountMetaSlice[0] = ag_solanago.Meta(programAccount).WRITE()
+	return inst
+}
+
+func (inst *SetProgramLength) GetProgramAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[0]
+}
+
+// The authority of the program
+func (inst *SetProgramLength) SetAuthorityAccount(authorityAccount ag_solanago.PublicKey) *SetProgramLength {
+	inst.AccountMetaSlice[1] = ag_solanago.Meta(authorityAccount).SIGNER()
+	return inst
+}
+
+func (inst *SetProgramLength) GetAuthorityAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[1]
+}
+
+// The recipient account of the lamports in excess
+func (inst *SetProgramLength) SetRecipientAccount(recipientAccount ag_solanago.PublicKey) *SetProgramLength {
+	inst.AccountMetaSlice[2] = ag_solanago.Meta(recipientAccount).WRITE()
+	return inst
+}
+
+func (inst *SetProgramLength) GetRecipientAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[2]
+}
+
+func (inst SetProgramLength) Build() *Instruction {
+	return &Instruction{BaseVariant: ag_binary.BaseVariant{
+		Impl:   inst,
+		TypeID: ag_binary.TypeIDFromUint32(Instruction_SetProgramLength, binary.LittleEndian),
+	}}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (inst SetProgramLength) ValidateAndBuild() (*Instruction, error) {
+	if err := inst.Validate(); err != nil {
+		return nil, err
+	}
+	return inst.Build(), nil
+}
+
+func (inst *SetProgramLength) Validate() error {
+	// Check whether all (required) parameters are set:
+	{
+		if inst.NewSize == nil {
+			return errors.New("NewSize parameter is not set")
+		}
+	}
+
+	// Check whether all (required) accounts are set:
+	for accIndex, acc := range inst.AccountMetaSlice {
+		if acc == nil {
+			return fmt.Errorf("ins.AccountMetaSlice[%v] is not set", accIndex)
+		}
+	}
+	return nil
+}
+
+func (inst *SetProgramLength) EncodeToTree(parent ag_treeout.Branches) {
+	parent.Child(ag_format.Program(ProgramName, ProgramID)).
+		//
+		ParentFunc(func(programBranch ag_treeout.Branches) {
+			programBranch.Child(ag_format.Instruction("SetProgramLength")).
+				//
+				ParentFunc(func(instructionBranch ag_treeout.Branches) {
+
+					// Parameters of the instruction:
+					instructionBranch.Child("Params").ParentFunc(func(paramsBranch ag_treeout.Branches) {
+						paramsBranch.Child(ag_format.Param("NewSize", *inst.NewSize))
+					})
+
+					// Accounts of the instruction:
+					instructionBranch.Child("Accounts").ParentFunc(func(accountsBranch ag_treeout.Branches) {
+						accountsBranch.Child(ag_format.Meta("  Program", inst.AccountMetaSlice.Get(0)))
+						accountsBranch.Child(ag_format.Meta("Authority", inst.AccountMetaSlice.Get(1)))
+						accountsBranch.Child(ag_format.Meta("Recipient", inst.AccountMetaSlice.Get(2)))
+					})
+				})
+		})
+}
+
+func (inst SetProgramLength) MarshalWithEncoder(encoder *ag_binary.Encoder) error {
+	// Serialize `NewSize` param:
+	{
+		err := encoder.Encode(*inst.NewSize)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (inst *SetProgramLength) UnmarshalWithDecoder(decoder *ag_binary.Decoder) error {
+	// Deserialize `NewSize` param:
+	{
+		err := decoder.Decode(&inst.NewSize)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// NewSetProgramLengthInstruction declares a new SetProgramLength instruction with the provided parameters and accounts.
+func NewSetProgramLengthInstruction(
+	// Parameters:
+	newSize uint32,
+	// Accounts:
+	programAccount ag_solanago.PublicKey,
+	authorityAccount ag_solanago.PublicKey,
+	recipientAccount ag_solanago.PublicKey) *SetProgramLength {
+	return NewSetProgramLengthInstructionBuilder().
+		SetNewSize(newSize).
+		SetProgramAccount(programAccount).
+		SetAuthorityAccount(authorityAccount).
+		SetRecipientAccount(recipientAccount)
+}
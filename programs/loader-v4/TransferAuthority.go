@@ -0,0 +1,144 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loaderv4
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	ag_binary "github.com/gagliardetto/binary"
+	ag_solanago "github.com/gagliardetto/solana-go"
+	ag_format "github.com/gagliardetto/solana-go/text/format"
+	ag_treeout "github.com/gagliardetto/treeout"
+)
+
+// Transfers the authority over a program account.
+type TransferAuthority struct {
+	// [0] = [WRITE] ProgramAccount
+	// ··········· The program account to change the authority of
+	//
+	// [1] = [SIGNER] AuthorityAccount
+	// ··········· The current authority of the program
+	//
+	// [2] = [SIGNER] NewAuthorityAccount
+	// ··········· The new authority of the program
+	ag_solanago.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+// NewTransferAuthorityInstructionBuilder creates a new `TransferAuthority` instruction builder.
+func NewTransferAuthorityInstructionBuilder() *TransferAuthority {
+	nd := &TransferAuthority{
+		AccountMetaSlice: make(ag_solanago.AccountMetaSlice, 3),
+	}
+	return nd
+}
+
+// The program account to change the authority of
+func (inst *TransferAuthority) SetProgramAccount(programAccount ag_solanago.PublicKey) *TransferAuthority {
+	inst.AccountMetaSlice[0] = ag_solanago.Meta(programAccount).WRITE()
+	return inst
+}
+
+func (inst *TransferAuthority) GetProgramAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[0]
+}
+
+// The current authority of the program
+func (inst *TransferAuthority) SetAuthorityAccount(authorityAccount ag_solanago.PublicKey) *TransferAuthority {
+	inst.AccountMetaSlice[1] = ag_solanago.Meta(authorityAccount).SIGNER()
+	return inst
+}
+
+func (inst *TransferAuthority) GetAuthorityAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[1]
+}
+
+// The new authority of the program
+func (inst *TransferAuthority) SetNewAuthorityAccount(newAuthorityAccount ag_solanago.PublicKey) *TransferAuthority {
+	inst.AccountMetaSlice[2] = ag_solanago.Meta(newAuthorityAccount).SIGNER()
+	return inst
+}
+
+func (inst *TransferAuthority) GetNewAuthorityAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[2]
+}
+
+func (inst TransferAuthority) Build() *Instruction {
+	return &Instruction{BaseVariant: ag_binary.BaseVariant{
+		Impl:   inst,
+		TypeID: ag_binary.TypeIDFromUint32(Instruction_TransferAuthority, binary.LittleEndian),
+	}}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (inst TransferAuthority) ValidateAndBuild() (*Instruction, error) {
+	if err := inst.Validate(); err != nil {
+		return nil, err
+	}
+	return inst.Build(), nil
+}
+
+func (inst *TransferAuthority) Validate() error {
+	// Check whether all (required) accounts are set:
+	for accIndex, acc := range inst.AccountMetaSlice {
+		if acc == nil {
+			return fmt.Errorf("ins.AccountMetaSlice[%v] is not set", accIndex)
+		}
+	}
+	return nil
+}
+
+func (inst *TransferAuthority) EncodeToTree(parent ag_treeout.Branches) {
+	parent.Child(ag_format.Program(ProgramName, ProgramID)).
+		//
+		ParentFunc(func(programBranch ag_treeout.Branches) {
+			programBranch.Child(ag_format.Instruction("TransferAuthority")).
+				//
+				ParentFunc(func(instructionBranch ag_treeout.Branches) {
+
+					// Parameters of the instruction:
+					instructionBranch.Child("Params").ParentFunc(func(paramsBranch ag_treeout.Branches) {})
+
+					// Accounts of the instruction:
+					instructionBranch.Child("Accounts").ParentFunc(func(accountsBranch ag_treeout.Branches) {
+						accountsBranch.Child(ag_format.Meta("     Program", inst.AccountMetaSlice.Get(0)))
+						accountsBranch.Child(ag_format.Meta("   Authority", inst.AccountMetaSlice.Get(1)))
+						accountsBranch.Child(ag_format.Meta("NewAuthority", inst.AccountMetaSlice.Get(2)))
+					})
+				})
+		})
+}
+
+func (inst TransferAuthority) MarshalWithEncoder(encoder *ag_binary.Encoder) error {
+	return nil
+}
+
+func (inst *TransferAuthority) UnmarshalWithDecoder(decoder *ag_binary.Decoder) error {
+	return nil
+}
+
+// NewTransferAuthorityInstruction declares a new TransferAuthority instruction with the provided parameters and accounts.
+func NewTransferAuthorityInstruction(
+	// Accounts:
+	programAccount ag_solanago.PublicKey,
+	authorityAccount ag_solanago.PublicKey,
+	newAuthorityAccount ag_solanago.PublicKey) *TransferAuthority {
+	return NewTransferAuthorityInstructionBuilder().
+		SetProgramAccount(programAccount).
+		SetAuthorityAccount(authorityAccount).
+		SetNewAuthorityAccount(newAuthorityAccount)
+}
@@ -0,0 +1,142 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loaderv4
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	ag_binary "github.com/gagliardetto/binary"
+	ag_solanago "github.com/gagliardetto/solana-go"
+)
+
+// Size in bytes of the header of a program account;
+// the program data follows it.
+const ProgramDataOffset = 48
+
+// ProgramDataSize returns the size of a program account holding programLen bytes.
+func ProgramDataSize(programLen int) int {
+	return ProgramDataOffset + programLen
+}
+
+// LoaderV4Status is the deployment status of a program account.
+type LoaderV4Status uint64
+
+const (
+	// Program is in maintenance.
+	LoaderV4StatusRetracted LoaderV4Status = iota
+	// Program is ready to be executed.
+	LoaderV4StatusDeployed
+	// Same as Deployed, but can not be retracted anymore.
+	LoaderV4StatusFinalized
+)
+
+func (s LoaderV4Status) String() string {
+	switch s {
+	case LoaderV4StatusRetracted:
+		return "Retracted"
+	case LoaderV4StatusDeployed:
+		return "Deployed"
+	case LoaderV4StatusFinalized:
+		return "Finalized"
+	default:
+		return fmt.Sprintf("Unknown(%d)", uint64(s))
+	}
+}
+
+// LoaderV4State is the state of a program account owned by the loader-v4 program.
+type LoaderV4State struct {
+	// Slot in which the program was last deployed, retracted or initialized.
+	Slot uint64
+
+	// Address of the signer which can send program management instructions
+	// if the program is not finalized, or the address of the next version
+	// of the program otherwise.
+	AuthorityAddressOrNextVersion ag_solanago.PublicKey
+
+	// Deployment status.
+	Status LoaderV4Status
+
+	// The program data following the header.
+	Data []byte
+}
+
+// IsFinalized returns true if the program can't be modified anymore.
+func (obj *LoaderV4State) IsFinalized() bool {
+	return obj.Status == LoaderV4StatusFinalized
+}
+
+// Authority returns the authority of the program,
+// and false if the program is finalized.
+func (obj *LoaderV4State) Authority() (ag_solanago.PublicKey, bool) {
+	if obj.IsFinalized() {
+		return ag_solanago.PublicKey{}, false
+	}
+	return obj.AuthorityAddressOrNextVersion, true
+}
+
+// NextVersion returns the address of the next version of the program,
+// and false if the program is not finalized.
+func (obj *LoaderV4State) NextVersion() (ag_solanago.PublicKey, bool) {
+	if !obj.IsFinalized() {
+		return ag_solanago.PublicKey{}, false
+	}
+	return obj.AuthorityAddressOrNextVersion, true
+}
+
+// DecodeLoaderV4State decodes the data of a program account owned by the loader-v4 program.
+func DecodeLoaderV4State(data []byte) (*LoaderV4State, error) {
+	state := new(LoaderV4State)
+	if err := state.UnmarshalWithDecoder(ag_binary.NewBinDecoder(data)); err != nil {
+		return nil, fmt.Errorf("unable to decode loader-v4 state: %w", err)
+	}
+	return state, nil
+}
+
+func (obj *LoaderV4State) UnmarshalWithDecoder(decoder *ag_binary.Decoder) (err error) {
+	if obj.Slot, err = decoder.ReadUint64(binary.LittleEndian); err != nil {
+		return err
+	}
+	{
+		v, err := decoder.ReadNBytes(32)
+		if err != nil {
+			return err
+		}
+		obj.AuthorityAddressOrNextVersion = ag_solanago.PublicKeyFromBytes(v)
+	}
+	status, err := decoder.ReadUint64(binary.LittleEndian)
+	if err != nil {
+		return err
+	}
+	obj.Status = LoaderV4Status(status)
+	if obj.Status > LoaderV4StatusFinalized {
+		return fmt.Errorf("invalid loader-v4 status: %d", status)
+	}
+	obj.Data, err = decoder.ReadNBytes(decoder.Remaining())
+	return err
+}
+
+func (obj LoaderV4State) MarshalWithEncoder(encoder *ag_binary.Encoder) (err error) {
+	if err = encoder.WriteUint64(obj.Slot, binary.LittleEndian); err != nil {
+		return err
+	}
+	if err = encoder.WriteBytes(obj.AuthorityAddressOrNextVersion[:], false); err != nil {
+		return err
+	}
+	if err = encoder.WriteUint64(uint64(obj.Status), binary.LittleEndian); err != nil {
+		return err
+	}
+	return encoder.WriteBytes(obj.Data, false)
+}
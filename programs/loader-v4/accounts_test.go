@@ -0,0 +1,153 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loaderv4
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	ag_binary "github.com/gagliardetto/binary"
+	ag_solanago "github.com/gagliardetto/solana-go"
+	bpfloader "github.com/gagliardetto/solana-go/programs/bpf-loader"
+	"github.com/gagliardetto/solana-go/programs/system"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoaderV4State(t *testing.T) {
+	authority := ag_solanago.NewWallet().PublicKey()
+
+	state := LoaderV4State{
+		Slot:                          123456,
+		AuthorityAddressOrNextVersion: authority,
+		Status:                        LoaderV4StatusDeployed,
+		Data:                          []byte{0x7f, 'E', 'L', 'F'},
+	}
+	buf := new(bytes.Buffer)
+	require.NoError(t, ag_binary.NewBinEncoder(buf).Encode(state))
+	data := buf.Bytes()
+	require.Len(t, data, ProgramDataSize(4))
+	require.Equal(t, uint64(123456), binary.LittleEndian.Uint64(data[0:8]))
+	require.Equal(t, authority[:], data[8:40])
+	require.Equal(t, uint64(1), binary.LittleEndian.Uint64(data[40:48]))
+
+	got, err := DecodeLoaderV4State(data)
+	require.NoError(t, err)
+	require.Equal(t, &state, got)
+	require.Equal(t, "Deployed", got.Status.String())
+
+	gotAuthority, ok := got.Authority()
+	require.True(t, ok)
+	require.Equal(t, authority, gotAuthority)
+	_, ok = got.NextVersion()
+	require.False(t, ok)
+
+	got.Status = LoaderV4StatusFinalized
+	_, ok = got.Authority()
+	require.False(t, ok)
+	nextVersion, ok := got.NextVersion()
+	require.True(t, ok)
+	require.Equal(t, authority, nextVersion)
+
+	// Invalid status.
+	binary.LittleEndian.PutUint64(data[40:48], 3)
+	_, err = DecodeLoaderV4State(data)
+	require.Error(t, err)
+
+	// Truncated header.
+	_, err = DecodeLoaderV4State(data[:ProgramDataOffset-1])
+	require.Error(t, err)
+}
+
+func TestInstructionData(t *testing.T) {
+	program := ag_solanago.NewWallet().PublicKey()
+	authority := ag_solanago.NewWallet().PublicKey()
+
+	inst, err := NewWriteInstruction(16, []byte{0xaa, 0xbb}, program, authority).ValidateAndBuild()
+	require.NoError(t, err)
+	data, err := inst.Data()
+	require.NoError(t, err)
+	require.Equal(t, []byte{0, 0, 0, 0, 16, 0, 0, 0, 2, 0, 0, 0, 0, 0, 0, 0, 0xaa, 0xbb}, data)
+
+	decoded, err := DecodeInstruction(inst.Accounts(), data)
+	require.NoError(t, err)
+	write, ok := decoded.Impl.(*Write)
+	require.True(t, ok)
+	require.Equal(t, []byte{0xaa, 0xbb}, write.Bytes)
+	require.Equal(t, authority, write.GetAuthorityAccount().PublicKey)
+
+	data, err = NewSetProgramLengthInstruction(1024, program, authority, authority).Build().Data()
+	require.NoError(t, err)
+	require.Equal(t, []byte{2, 0, 0, 0, 0, 4, 0, 0}, data)
+
+	data, err = NewRetractInstruction(program, authority).Build().Data()
+	require.NoError(t, err)
+	require.Equal(t, []byte{4, 0, 0, 0}, data)
+}
+
+func TestDeployProgram(t *testing.T) {
+	payer := ag_solanago.NewWallet().PublicKey()
+	program := ag_solanago.NewWallet().PublicKey()
+	programData := bytes.Repeat([]byte{0x42}, 5000)
+
+	initialBuilder, writeBuilders, finalBuilder, err := DeployProgram(
+		payer, programData, 1_000_000, program, payer,
+	)
+	require.NoError(t, err)
+
+	initial, err := initialBuilder.Build()
+	require.NoError(t, err)
+	require.Len(t, initial.Message.Instructions, 2)
+	inst, err := DecodeInstruction(nil, initial.Message.Instructions[1].Data)
+	require.NoError(t, err)
+	require.Equal(t, uint32(len(programData)), *inst.Impl.(*SetProgramLength).NewSize)
+
+	var written []byte
+	for _, builder := range writeBuilders {
+		tx, err := builder.Build()
+		require.NoError(t, err)
+		tx.Signatures = make([]ag_solanago.Signature, tx.Message.Header.NumRequiredSignatures)
+		serialized, err := tx.MarshalBinary()
+		require.NoError(t, err)
+		require.LessOrEqual(t, len(serialized), bpfloader.PACKET_DATA_SIZE)
+
+		inst, err := DecodeInstruction(nil, tx.Message.Instructions[0].Data)
+		require.NoError(t, err)
+		write := inst.Impl.(*Write)
+		require.Equal(t, uint32(len(written)), *write.Offset)
+		written = append(written, write.Bytes...)
+	}
+	require.Equal(t, programData, written)
+
+	final, err := finalBuilder.Build()
+	require.NoError(t, err)
+	require.Len(t, final.Message.Instructions, 1)
+	inst, err = DecodeInstruction(nil, final.Message.Instructions[0].Data)
+	require.NoError(t, err)
+	require.IsType(t, &Deploy{}, inst.Impl)
+
+	_, _, _, err = DeployProgram(payer, nil, 0, program, payer)
+	require.Error(t, err)
+
+	initialBuilder, _, _, err = RedeployProgram(payer, programData, 500, program, payer)
+	require.NoError(t, err)
+	initial, err = initialBuilder.Build()
+	require.NoError(t, err)
+	require.Len(t, initial.Message.Instructions, 3)
+	inst, err = DecodeInstruction(nil, initial.Message.Instructions[0].Data)
+	require.NoError(t, err)
+	require.IsType(t, &Retract{}, inst.Impl)
+	require.Equal(t, system.ProgramID, initial.Message.AccountKeys[initial.Message.Instructions[1].ProgramIDIndex])
+}
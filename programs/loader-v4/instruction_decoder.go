@@ -0,0 +1,23 @@
+package loaderv4
+
+import (
+	"github.com/gagliardetto/solana-go"
+)
+
+var (
+	DecodeCopy              = decode[*Copy]()
+	DecodeDeploy            = decode[*Deploy]()
+	DecodeFinalize          = decode[*Finalize]()
+	DecodeRetract           = decode[*Retract]()
+	DecodeSetProgramLength  = decode[*SetProgramLength]()
+	DecodeTransferAuthority = decode[*TransferAuthority]()
+	DecodeWrite             = decode[*Write]()
+)
+
+func decode[T any]() func(*solana.Message, int) (T, error) {
+	return solana.DecodeInstructionType[*Instruction, T](
+		ProgramID,
+		InstructionImplDef,
+		DecodeInstruction,
+	)
+}
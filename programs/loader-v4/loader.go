@@ -0,0 +1,173 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loaderv4
+
+import (
+	"fmt"
+
+	ag_solanago "github.com/gagliardetto/solana-go"
+	bpfloader "github.com/gagliardetto/solana-go/programs/bpf-loader"
+	"github.com/gagliardetto/solana-go/programs/system"
+)
+
+// writeProgram returns the transactions that write the program data
+// into the program account, one chunk per transaction.
+func writeProgram(
+	payerPubkey ag_solanago.PublicKey,
+	programData []byte,
+	programPubkey ag_solanago.PublicKey,
+	authorityPubkey ag_solanago.PublicKey,
+) (writeBuilders []*ag_solanago.TransactionBuilder, err error) {
+	createBuilder := func(offset int, chunk []byte) *ag_solanago.TransactionBuilder {
+		return ag_solanago.NewTransactionBuilder().
+			AddInstruction(
+				NewWriteInstruction(uint32(offset), chunk, programPubkey, authorityPubkey).Build(),
+			).
+			SetFeePayer(payerPubkey)
+	}
+
+	chunkSize, err := bpfloader.CalculateMaxChunkSize(createBuilder)
+	if err != nil {
+		return
+	}
+	writeBuilders = []*ag_solanago.TransactionBuilder{}
+	for i := 0; i < len(programData); i += chunkSize {
+		end := i + chunkSize
+		if end > len(programData) {
+			end = len(programData)
+		}
+		writeBuilders = append(
+			writeBuilders,
+			createBuilder(i, programData[i:end]),
+		)
+	}
+	return
+}
+
+// DeployProgram plans the deployment of a new program:
+//   - initialBuilder creates the program account, and sets its length
+//     (which also sets its authority);
+//   - writeBuilders write the program data, one chunk per transaction;
+//   - finalBuilder deploys the program.
+//
+// minimumBalance must be the rent-exempt balance of ProgramDataSize(len(programData))
+// bytes. The program account must sign the initial transaction, and the
+// authority all of them, along with the payer.
+func DeployProgram(
+	payerPubkey ag_solanago.PublicKey,
+	programData []byte,
+	minimumBalance uint64,
+	programPubkey ag_solanago.PublicKey,
+	authorityPubkey ag_solanago.PublicKey,
+) (
+	initialBuilder *ag_solanago.TransactionBuilder,
+	writeBuilders []*ag_solanago.TransactionBuilder,
+	finalBuilder *ag_solanago.TransactionBuilder,
+	err error,
+) {
+	if len(programData) == 0 {
+		err = fmt.Errorf("program data is empty")
+		return
+	}
+	initialBuilder = ag_solanago.NewTransactionBuilder().
+		AddInstruction(
+			system.NewCreateAccountInstruction(
+				minimumBalance,
+				0,
+				ProgramID,
+				payerPubkey,
+				programPubkey,
+			).Build(),
+		).
+		AddInstruction(
+			NewSetProgramLengthInstruction(
+				uint32(len(programData)),
+				programPubkey,
+				authorityPubkey,
+				payerPubkey,
+			).Build(),
+		).
+		SetFeePayer(payerPubkey)
+
+	writeBuilders, err = writeProgram(payerPubkey, programData, programPubkey, authorityPubkey)
+	if err != nil {
+		return
+	}
+
+	finalBuilder = ag_solanago.NewTransactionBuilder().
+		AddInstruction(
+			NewDeployInstruction(programPubkey, authorityPubkey).Build(),
+		).
+		SetFeePayer(payerPubkey)
+	return
+}
+
+// RedeployProgram plans the deployment of a new version of an existing,
+// deployed program:
+//   - initialBuilder retracts the program, funds the program account with
+//     additionalLamports (if not zero) and resizes it; lamports in excess
+//     are refunded to the payer;
+//   - writeBuilders write the program data, one chunk per transaction;
+//   - finalBuilder deploys the program.
+//
+// The program can only be retracted once the deployment cooldown
+// since its last deployment has passed.
+func RedeployProgram(
+	payerPubkey ag_solanago.PublicKey,
+	programData []byte,
+	additionalLamports uint64,
+	programPubkey ag_solanago.PublicKey,
+	authorityPubkey ag_solanago.PublicKey,
+) (
+	initialBuilder *ag_solanago.TransactionBuilder,
+	writeBuilders []*ag_solanago.TransactionBuilder,
+	finalBuilder *ag_solanago.TransactionBuilder,
+	err error,
+) {
+	if len(programData) == 0 {
+		err = fmt.Errorf("program data is empty")
+		return
+	}
+	initialBuilder = ag_solanago.NewTransactionBuilder().
+		AddInstruction(
+			NewRetractInstruction(programPubkey, authorityPubkey).Build(),
+		).
+		SetFeePayer(payerPubkey)
+	if additionalLamports > 0 {
+		initialBuilder.AddInstruction(
+			system.NewTransferInstruction(additionalLamports, payerPubkey, programPubkey).Build(),
+		)
+	}
+	initialBuilder.AddInstruction(
+		NewSetProgramLengthInstruction(
+			uint32(len(programData)),
+			programPubkey,
+			authorityPubkey,
+			payerPubkey,
+		).Build(),
+	)
+
+	writeBuilders, err = writeProgram(payerPubkey, programData, programPubkey, authorityPubkey)
+	if err != nil {
+		return
+	}
+
+	finalBuilder = ag_solanago.NewTransactionBuilder().
+		AddInstruction(
+			NewDeployInstruction(programPubkey, authorityPubkey).Build(),
+		).
+		SetFeePayer(payerPubkey)
+	return
+}
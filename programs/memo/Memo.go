@@ -0,0 +1,192 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memo
+
+import (
+	"errors"
+	"fmt"
+	"unicode/utf8"
+
+	ag_binary "github.com/gagliardetto/binary"
+	ag_solanago "github.com/gagliardetto/solana-go"
+	ag_format "github.com/gagliardetto/solana-go/text/format"
+	ag_treeout "github.com/gagliardetto/treeout"
+)
+
+// Memo logs a UTF-8 message, and verifies that all the accounts
+// provided are signers of the transaction.
+type Memo struct {
+	// The memo message; the whole instruction data.
+	Message []byte
+
+	// [0...] = [SIGNER] signers
+	// ··········· Accounts that must sign the transaction.
+	Signers ag_solanago.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+
+	// The program the memo is sent to; ProgramID if unset.
+	programID ag_solanago.PublicKey
+}
+
+func (obj *Memo) SetAccounts(accounts []*ag_solanago.AccountMeta) error {
+	obj.Signers = accounts
+	return nil
+}
+
+func (slice Memo) GetAccounts() (accounts []*ag_solanago.AccountMeta) {
+	return slice.Signers
+}
+
+// NewMemoInstructionBuilder creates a new `Memo` instruction builder.
+func NewMemoInstructionBuilder() *Memo {
+	nd := &Memo{
+		Signers: make(ag_solanago.AccountMetaSlice, 0),
+	}
+	return nd
+}
+
+// SetMessage sets the "message" parameter.
+// The memo message.
+func (inst *Memo) SetMessage(message []byte) *Memo {
+	inst.Message = message
+	return inst
+}
+
+// SetProgramID sets the program the memo is sent to,
+// e.g. MemoV1ProgramID.
+func (inst *Memo) SetProgramID(programID ag_solanago.PublicKey) *Memo {
+	inst.programID = programID
+	return inst
+}
+
+// GetProgramID gets the program the memo is sent to.
+func (inst Memo) GetProgramID() ag_solanago.PublicKey {
+	if inst.programID.IsZero() {
+		return ProgramID
+	}
+	return inst.programID
+}
+
+// AddSigners appends the "signers" accounts.
+// Accounts that must sign the transaction.
+func (inst *Memo) AddSigners(signers ...ag_solanago.PublicKey) *Memo {
+	for _, signer := range signers {
+		inst.Signers = append(inst.Signers, ag_solanago.Meta(signer).SIGNER())
+	}
+	return inst
+}
+
+// GetSigners gets the "signers" accounts.
+// Accounts that must sign the transaction.
+func (inst *Memo) GetSigners() ag_solanago.AccountMetaSlice {
+	return inst.Signers
+}
+
+func (inst Memo) Build() *Instruction {
+	return &Instruction{BaseVariant: ag_binary.BaseVariant{
+		Impl:   inst,
+		TypeID: ag_binary.NoTypeIDDefaultID,
+	}}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (inst Memo) ValidateAndBuild() (*Instruction, error) {
+	if err := inst.Validate(); err != nil {
+		return nil, err
+	}
+	return inst.Build(), nil
+}
+
+func (inst *Memo) Validate() error {
+	// Check whether all (required) parameters are set:
+	{
+		if inst.Message == nil {
+			return errors.New("Message parameter is not set")
+		}
+		if !utf8.Valid(inst.Message) {
+			return errors.New("Message parameter is not valid UTF-8")
+		}
+	}
+
+	// Check whether all accounts are signers:
+	{
+		for i, signer := range inst.Signers {
+			if signer == nil {
+				return fmt.Errorf("accounts.Signers[%v] is not set", i)
+			}
+			if !signer.IsSigner {
+				return fmt.Errorf("accounts.Signers[%v] is not a signer", i)
+			}
+		}
+	}
+	return nil
+}
+
+func (inst *Memo) EncodeToTree(parent ag_treeout.Branches) {
+	parent.Child(ag_format.Program(ProgramName, inst.GetProgramID())).
+		//
+		ParentFunc(func(programBranch ag_treeout.Branches) {
+			programBranch.Child(ag_format.Instruction("Memo")).
+				//
+				ParentFunc(func(instructionBranch ag_treeout.Branches) {
+
+					// Parameters of the instruction:
+					instructionBranch.Child("Params").ParentFunc(func(paramsBranch ag_treeout.Branches) {
+						paramsBranch.Child(ag_format.Param("Message", string(inst.Message)))
+					})
+
+					// Accounts of the instruction:
+					instructionBranch.Child("Accounts").ParentFunc(func(accountsBranch ag_treeout.Branches) {
+						signersBranch := accountsBranch.Child(fmt.Sprintf("signers[len=%v]", len(inst.Signers)))
+						for i, v := range inst.Signers {
+							signersBranch.Child(ag_format.Meta(fmt.Sprintf("[%v]", i), v))
+						}
+					})
+				})
+		})
+}
+
+func (obj Memo) MarshalWithEncoder(encoder *ag_binary.Encoder) (err error) {
+	// Serialize `Message` param:
+	err = encoder.WriteBytes(obj.Message, false)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+func (obj *Memo) UnmarshalWithDecoder(decoder *ag_binary.Decoder) (err error) {
+	// Deserialize `Message`:
+	obj.Message, err = decoder.ReadNBytes(decoder.Remaining())
+	if err != nil {
+		return err
+	}
+	if !utf8.Valid(obj.Message) {
+		return errors.New("memo is not valid UTF-8")
+	}
+	return nil
+}
+
+// NewMemoInstruction declares a new Memo instruction with the provided message and signers.
+func NewMemoInstruction(
+	// Parameters:
+	message []byte,
+	// Accounts:
+	signers ...ag_solanago.PublicKey,
+) *Memo {
+	return NewMemoInstructionBuilder().
+		SetMessage(message).
+		AddSigners(signers...)
+}
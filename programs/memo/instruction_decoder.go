@@ -0,0 +1,17 @@
+package memo
+
+import (
+	"github.com/gagliardetto/solana-go"
+)
+
+var (
+	DecodeMemo = decode[*Memo]()
+)
+
+func decode[T any]() func(*solana.Message, int) (T, error) {
+	return solana.DecodeInstructionType[*Instruction, T](
+		ProgramID,
+		InstructionImplDef,
+		DecodeInstruction,
+	)
+}
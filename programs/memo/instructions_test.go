@@ -0,0 +1,86 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memo
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemo(t *testing.T) {
+	signer := solana.NewWallet().PublicKey()
+
+	inst, err := NewMemoInstruction([]byte("invoice #42 ✓"), signer).ValidateAndBuild()
+	require.NoError(t, err)
+	require.Equal(t, solana.MemoProgramID, inst.ProgramID())
+
+	data, err := inst.Data()
+	require.NoError(t, err)
+	require.Equal(t, []byte("invoice #42 ✓"), data)
+
+	accounts := inst.Accounts()
+	require.Len(t, accounts, 1)
+	require.Equal(t, signer, accounts[0].PublicKey)
+	require.True(t, accounts[0].IsSigner)
+	require.False(t, accounts[0].IsWritable)
+
+	decoded, err := DecodeInstruction(accounts, data)
+	require.NoError(t, err)
+	memo, ok := decoded.Impl.(*Memo)
+	require.True(t, ok)
+	require.Equal(t, "invoice #42 ✓", string(memo.Message))
+	require.Len(t, memo.GetSigners(), 1)
+}
+
+func TestMemo_InvalidUTF8(t *testing.T) {
+	_, err := NewMemoInstruction([]byte{0xf0, 0x9f, 0x90}).ValidateAndBuild()
+	require.Error(t, err)
+
+	_, err = DecodeInstruction(nil, []byte{0xf0, 0x9f, 0x90})
+	require.Error(t, err)
+
+	_, err = NewMemoInstruction([]byte("hello")).
+		SetMessage(nil).
+		ValidateAndBuild()
+	require.Error(t, err)
+}
+
+func TestMemo_Transaction(t *testing.T) {
+	payer := solana.NewWallet().PublicKey()
+
+	tx, err := solana.NewTransaction(
+		[]solana.Instruction{
+			NewMemoInstruction([]byte("payment ref 7f3a"), payer).Build(),
+			NewMemoInstruction([]byte("legacy memo")).SetProgramID(solana.MemoV1ProgramID).Build(),
+		},
+		solana.Hash{},
+		solana.TransactionPayer(payer),
+	)
+	require.NoError(t, err)
+	require.Contains(t, tx.Message.AccountKeys, solana.MemoV1ProgramID)
+
+	// Decoded through the registry, for both program versions:
+	out := tx.String()
+	require.True(t, strings.Contains(out, "payment ref 7f3a"), out)
+	require.True(t, strings.Contains(out, "legacy memo"), out)
+	require.True(t, strings.Contains(out, solana.MemoV1ProgramID.String()), out)
+
+	got, err := DecodeMemo(&tx.Message, 0)
+	require.NoError(t, err)
+	require.Equal(t, "payment ref 7f3a", string(got.Message))
+}
@@ -0,0 +1,215 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stake
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	ag_binary "github.com/gagliardetto/binary"
+	ag_solanago "github.com/gagliardetto/solana-go"
+	ag_format "github.com/gagliardetto/solana-go/text/format"
+	ag_treeout "github.com/gagliardetto/treeout"
+)
+
+// Authorize a key to manage stake or withdrawal.
+//
+// This instruction behaves like Authorize with the additional requirement
+// that the new stake or withdraw authority must also be a signer.
+type AuthorizeChecked struct {
+	// Type of authority to change
+	StakeAuthorize *StakeAuthorize
+
+	// [0] = [WRITE] StakeAccount
+	// ··········· Stake account to be updated
+	//
+	// [1] = [] $(SysVarClockPubkey)
+	// ··········· Clock sysvar
+	//
+	// [2] = [SIGNER] AuthorityAccount
+	// ··········· The stake or withdraw authority
+	//
+	// [3] = [SIGNER] NewAuthorityAccount
+	// ··········· The new stake or withdraw authority
+	//
+	// [4] = [SIGNER] LockupAuthorityAccount
+	// ··········· Lockup authority, if updating the withdrawer before lockup expiration (optional)
+	ag_solanago.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+// NewAuthorizeCheckedInstructionBuilder creates a new `AuthorizeChecked` instruction builder.
+func NewAuthorizeCheckedInstructionBuilder() *AuthorizeChecked {
+	nd := &AuthorizeChecked{
+		AccountMetaSlice: make(ag_solanago.AccountMetaSlice, 4),
+	}
+	nd.AccountMetaSlice[1] = ag_solanago.Meta(ag_solanago.SysVarClockPubkey)
+	return nd
+}
+
+// Type of authority to change
+func (inst *AuthorizeChecked) SetStakeAuthorize(stakeAuthorize StakeAuthorize) *AuthorizeChecked {
+	inst.StakeAuthorize = &stakeAuthorize
+	return inst
+}
+
+// Stake account to be updated
+func (inst *AuthorizeChecked) SetStakeAccount(stakeAccount ag_solanago.PublicKey) *AuthorizeChecked {
+	inst.AccountMetaSlice[0] = ag_solanago.Meta(stakeAccount).WRITE()
+	return inst
+}
+
+func (inst *AuthorizeChecked) GetStakeAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[0]
+}
+
+// Clock sysvar
+func (inst *AuthorizeChecked) SetSysVarClockPubkeyAccount(sysVarClockPubkeyAccount ag_solanago.PublicKey) *AuthorizeChecked {
+	inst.AccountMetaSlice[1] = ag_solanago.Meta(sysVarClockPubkeyAccount)
+	return inst
+}
+
+func (inst *AuthorizeChecked) GetSysVarClockPubkeyAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[1]
+}
+
+// The stake or withdraw authority
+func (inst *AuthorizeChecked) SetAuthorityAccount(authorityAccount ag_solanago.PublicKey) *AuthorizeChecked {
+	inst.AccountMetaSlice[2] = ag_solanago.Meta(authorityAccount).SIGNER()
+	return inst
+}
+
+func (inst *AuthorizeChecked) GetAuthorityAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[2]
+}
+
+// The new stake or withdraw authority
+func (inst *AuthorizeChecked) SetNewAuthorityAccount(newAuthorityAccount ag_solanago.PublicKey) *AuthorizeChecked {
+	inst.AccountMetaSlice[3] = ag_solanago.Meta(newAuthorityAccount).SIGNER()
+	return inst
+}
+
+func (inst *AuthorizeChecked) GetNewAuthorityAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[3]
+}
+
+// Lockup authority, if updating the withdrawer before lockup expiration
+func (inst *AuthorizeChecked) SetLockupAuthorityAccount(lockupAuthorityAccount ag_solanago.PublicKey) *AuthorizeChecked {
+	if len(inst.AccountMetaSlice) <= 4 {
+		inst.AccountMetaSlice = append(inst.AccountMetaSlice, nil)
+	}
+	inst.AccountMetaSlice[4] = ag_solanago.Meta(lockupAuthorityAccount).SIGNER()
+	return inst
+}
+
+func (inst *AuthorizeChecked) GetLockupAuthorityAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(4)
+}
+
+func (inst AuthorizeChecked) Build() *Instruction {
+	return &Instruction{BaseVariant: ag_binary.BaseVariant{
+		Impl:   inst,
+		TypeID: ag_binary.TypeIDFromUint32(Instruction_AuthorizeChecked, binary.LittleEndian),
+	}}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (inst AuthorizeChecked) ValidateAndBuild() (*Instruction, error) {
+	if err := inst.Validate(); err != nil {
+		return nil, err
+	}
+	return inst.Build(), nil
+}
+
+func (inst *AuthorizeChecked) Validate() error {
+	// Check whether all (required) parameters are set:
+	{
+		if inst.StakeAuthorize == nil {
+			return errors.New("StakeAuthorize parameter is not set")
+		}
+	}
+
+	// Check whether all (required) accounts are set:
+	for accIndex, acc := range inst.AccountMetaSlice[:4] {
+		if acc == nil {
+			return fmt.Errorf("ins.AccountMetaSlice[%v] is not set", accIndex)
+		}
+	}
+	return nil
+}
+
+func (inst *AuthorizeChecked) EncodeToTree(parent ag_treeout.Branches) {
+	parent.Child(ag_format.Program(ProgramName, ProgramID)).
+		//
+		ParentFunc(func(programBranch ag_treeout.Branches) {
+			programBranch.Child(ag_format.Instruction("AuthorizeChecked")).
+				//
+				ParentFunc(func(instructionBranch ag_treeout.Branches) {
+
+					// Parameters of the instruction:
+					instructionBranch.Child("Params").ParentFunc(func(paramsBranch ag_treeout.Branches) {
+						paramsBranch.Child(ag_format.Param("StakeAuthorize", *inst.StakeAuthorize))
+					})
+
+					// Accounts of the instruction:
+					instructionBranch.Child("Accounts").ParentFunc(func(accountsBranch ag_treeout.Branches) {
+						accountsBranch.Child(ag_format.Meta("          Stake", inst.AccountMetaSlice.Get(0)))
+						accountsBranch.Child(ag_format.Meta("    SysVarClock", inst.AccountMetaSlice.Get(1)))
+						accountsBranch.Child(ag_format.Meta("      Authority", inst.AccountMetaSlice.Get(2)))
+						accountsBranch.Child(ag_format.Meta("   NewAuthority", inst.AccountMetaSlice.Get(3)))
+						accountsBranch.Child(ag_format.Meta("LockupAuthority", inst.AccountMetaSlice.Get(4)))
+					})
+				})
+		})
+}
+
+func (inst AuthorizeChecked) MarshalWithEncoder(encoder *ag_binary.Encoder) error {
+	// Serialize `StakeAuthorize` param:
+	{
+		err := encoder.Encode(*inst.StakeAuthorize)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (inst *AuthorizeChecked) UnmarshalWithDecoder(decoder *ag_binary.Decoder) error {
+	// Deserialize `StakeAuthorize` param:
+	{
+		err := decoder.Decode(&inst.StakeAuthorize)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// NewAuthorizeCheckedInstruction declares a new AuthorizeChecked instruction with the provided parameters and accounts.
+func NewAuthorizeCheckedInstruction(
+	// Parameters:
+	stakeAuthorize StakeAuthorize,
+	// Accounts:
+	stakeAccount ag_solanago.PublicKey,
+	authorityAccount ag_solanago.PublicKey,
+	newAuthorityAccount ag_solanago.PublicKey) *AuthorizeChecked {
+	return NewAuthorizeCheckedInstructionBuilder().
+		SetStakeAuthorize(stakeAuthorize).
+		SetStakeAccount(stakeAccount).
+		SetAuthorityAccount(authorityAccount).
+		SetNewAuthorityAccount(newAuthorityAccount)
+}
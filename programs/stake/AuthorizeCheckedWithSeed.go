@@ -0,0 +1,274 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stake
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	ag_binary "github.com/gagliardetto/binary"
+	ag_solanago "github.com/gagliardetto/solana-go"
+	ag_format "github.com/gagliardetto/solana-go/text/format"
+	ag_treeout "github.com/gagliardetto/treeout"
+)
+
+// Authorize a key to manage stake or withdrawal with a derived key.
+//
+// This instruction behaves like AuthorizeWithSeed with the additional requirement
+// that the new stake or withdraw authority must also be a signer.
+type AuthorizeCheckedWithSeed struct {
+	// Type of authority to change
+	StakeAuthorize *StakeAuthorize
+
+	// Seed used to derive the current authority
+	AuthoritySeed *string
+
+	// Owner program used to derive the current authority
+	AuthorityOwner *ag_solanago.PublicKey
+
+	// [0] = [WRITE] StakeAccount
+	// ··········· Stake account to be updated
+	//
+	// [1] = [SIGNER] AuthorityBaseAccount
+	// ··········· Base key of stake or withdraw authority
+	//
+	// [2] = [] $(SysVarClockPubkey)
+	// ··········· Clock sysvar
+	//
+	// [3] = [SIGNER] NewAuthorityAccount
+	// ··········· The new stake or withdraw authority
+	//
+	// [4] = [SIGNER] LockupAuthorityAccount
+	// ··········· Lockup authority, if updating the withdrawer before lockup expiration (optional)
+	ag_solanago.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+// NewAuthorizeCheckedWithSeedInstructionBuilder creates a new `AuthorizeCheckedWithSeed` instruction builder.
+func NewAuthorizeCheckedWithSeedInstructionBuilder() *AuthorizeCheckedWithSeed {
+	nd := &AuthorizeCheckedWithSeed{
+		AccountMetaSlice: make(ag_solanago.AccountMetaSlice, 4),
+	}
+	nd.AccountMetaSlice[2] = ag_solanago.Meta(ag_solanago.SysVarClockPubkey)
+	return nd
+}
+
+// Type of authority to change
+func (inst *AuthorizeCheckedWithSeed) SetStakeAuthorize(stakeAuthorize StakeAuthorize) *AuthorizeCheckedWithSeed {
+	inst.StakeAuthorize = &stakeAuthorize
+	return inst
+}
+
+// Seed used to derive the current authority
+func (inst *AuthorizeCheckedWithSeed) SetAuthoritySeed(authoritySeed string) *AuthorizeCheckedWithSeed {
+	inst.AuthoritySeed = &authoritySeed
+	return inst
+}
+
+// Owner program used to derive the current authority
+func (inst *AuthorizeCheckedWithSeed) SetAuthorityOwner(authorityOwner ag_solanago.PublicKey) *AuthorizeCheckedWithSeed {
+	inst.AuthorityOwner = &authorityOwner
+	return inst
+}
+
+// Stake account to be updated
+func (inst *AuthorizeCheckedWithSeed) SetStakeAccount(stakeAccount ag_solanago.PublicKey) *AuthorizeCheckedWithSeed {
+	inst.AccountMetaSlice[0] = ag_solanago.Meta(stakeAccount).WRITE()
+	return inst
+}
+
+func (inst *AuthorizeCheckedWithSeed) GetStakeAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[0]
+}
+
+// Base key of stake or withdraw authority
+func (inst *AuthorizeCheckedWithSeed) SetAuthorityBaseAccount(authorityBaseAccount ag_solanago.PublicKey) *AuthorizeCheckedWithSeed {
+	inst.AccountMetaSlice[1] = ag_solanago.Meta(authorityBaseAccount).SIGNER()
+	return inst
+}
+
+func (inst *AuthorizeCheckedWithSeed) GetAuthorityBaseAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[1]
+}
+
+// Clock sysvar
+func (inst *AuthorizeCheckedWithSeed) SetSysVarClockPubkeyAccount(sysVarClockPubkeyAccount ag_solanago.PublicKey) *AuthorizeCheckedWithSeed {
+	inst.AccountMetaSlice[2] = ag_solanago.Meta(sysVarClockPubkeyAccount)
+	return inst
+}
+
+func (inst *AuthorizeCheckedWithSeed) GetSysVarClockPubkeyAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[2]
+}
+
+// The new stake or withdraw authority
+func (inst *AuthorizeCheckedWithSeed) SetNewAuthorityAccount(newAuthorityAccount ag_solanago.PublicKey) *AuthorizeCheckedWithSeed {
+	inst.AccountMetaSlice[3] = ag_solanago.Meta(newAuthorityAccount).SIGNER()
+	return inst
+}
+
+func (inst *AuthorizeCheckedWithSeed) GetNewAuthorityAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[3]
+}
+
+// Lockup authority, if updating the withdrawer before lockup expiration
+func (inst *AuthorizeCheckedWithSeed) SetLockupAuthorityAccount(lockupAuthorityAccount ag_solanago.PublicKey) *AuthorizeCheckedWithSeed {
+	if len(inst.AccountMetaSlice) <= 4 {
+		inst.AccountMetaSlice = append(inst.AccountMetaSlice, nil)
+	}
+	inst.AccountMetaSlice[4] = ag_solanago.Meta(lockupAuthorityAccount).SIGNER()
+	return inst
+}
+
+func (inst *AuthorizeCheckedWithSeed) GetLockupAuthorityAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(4)
+}
+
+func (inst AuthorizeCheckedWithSeed) Build() *Instruction {
+	return &Instruction{BaseVariant: ag_binary.BaseVariant{
+		Impl:   inst,
+		TypeID: ag_binary.TypeIDFromUint32(Instruction_AuthorizeCheckedWithSeed, binary.LittleEndian),
+	}}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (inst AuthorizeCheckedWithSeed) ValidateAndBuild() (*Instruction, error) {
+	if err := inst.Validate(); err != nil {
+		return nil, err
+	}
+	return inst.Build(), nil
+}
+
+func (inst *AuthorizeCheckedWithSeed) Validate() error {
+	// Check whether all (required) parameters are set:
+	{
+		if inst.StakeAuthorize == nil {
+			return errors.New("StakeAuthorize parameter is not set")
+		}
+		if inst.AuthoritySeed == nil {
+			return errors.New("AuthoritySeed parameter is not set")
+		}
+		if inst.AuthorityOwner == nil {
+			return errors.New("AuthorityOwner parameter is not set")
+		}
+	}
+
+	// Check whether all (required) accounts are set:
+	for accIndex, acc := range inst.AccountMetaSlice[:4] {
+		if acc == nil {
+			return fmt.Errorf("ins.AccountMetaSlice[%v] is not set", accIndex)
+		}
+	}
+	return nil
+}
+
+func (inst *AuthorizeCheckedWithSeed) EncodeToTree(parent ag_treeout.Branches) {
+	parent.Child(ag_format.Program(ProgramName, ProgramID)).
+		//
+		ParentFunc(func(programBranch ag_treeout.Branches) {
+			programBranch.Child(ag_format.Instruction("AuthorizeCheckedWithSeed")).
+				//
+				ParentFunc(func(instructionBranch ag_treeout.Branches) {
+
+					// Parameters of the instruction:
+					instructionBranch.Child("Params").ParentFunc(func(paramsBranch ag_treeout.Branches) {
+						paramsBranch.Child(ag_format.Param("StakeAuthorize", *inst.StakeAuthorize))
+						paramsBranch.Child(ag_format.Param(" AuthoritySeed", *inst.AuthoritySeed))
+						paramsBranch.Child(ag_format.Param("AuthorityOwner", *inst.AuthorityOwner))
+					})
+
+					// Accounts of the instruction:
+					instructionBranch.Child("Accounts").ParentFunc(func(accountsBranch ag_treeout.Branches) {
+						accountsBranch.Child(ag_format.Meta("          Stake", inst.AccountMetaSlice.Get(0)))
+						accountsBranch.Child(ag_format.Meta("  AuthorityBase", inst.AccountMetaSlice.Get(1)))
+						accountsBranch.Child(ag_format.Meta("    SysVarClock", inst.AccountMetaSlice.Get(2)))
+						accountsBranch.Child(ag_format.Meta("   NewAuthority", inst.AccountMetaSlice.Get(3)))
+						accountsBranch.Child(ag_format.Meta("LockupAuthority", inst.AccountMetaSlice.Get(4)))
+					})
+				})
+		})
+}
+
+func (inst AuthorizeCheckedWithSeed) MarshalWithEncoder(encoder *ag_binary.Encoder) error {
+	// Serialize `StakeAuthorize` param:
+	{
+		err := encoder.Encode(*inst.StakeAuthorize)
+		if err != nil {
+			return err
+		}
+	}
+	// Serialize `AuthoritySeed` param:
+	{
+		err := encoder.WriteRustString(*inst.AuthoritySeed)
+		if err != nil {
+			return err
+		}
+	}
+	// Serialize `AuthorityOwner` param:
+	{
+		err := encoder.Encode(*inst.AuthorityOwner)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (inst *AuthorizeCheckedWithSeed) UnmarshalWithDecoder(decoder *ag_binary.Decoder) error {
+	// Deserialize `StakeAuthorize` param:
+	{
+		err := decoder.Decode(&inst.StakeAuthorize)
+		if err != nil {
+			return err
+		}
+	}
+	// Deserialize `AuthoritySeed` param:
+	{
+		v, err := decoder.ReadRustString()
+		if err != nil {
+			return err
+		}
+		inst.AuthoritySeed = &v
+	}
+	// Deserialize `AuthorityOwner` param:
+	{
+		err := decoder.Decode(&inst.AuthorityOwner)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// NewAuthorizeCheckedWithSeedInstruction declares a new AuthorizeCheckedWithSeed instruction with the provided parameters and accounts.
+func NewAuthorizeCheckedWithSeedInstruction(
+	// Parameters:
+	stakeAuthorize StakeAuthorize,
+	authoritySeed string,
+	authorityOwner ag_solanago.PublicKey,
+	// Accounts:
+	stakeAccount ag_solanago.PublicKey,
+	authorityBaseAccount ag_solanago.PublicKey,
+	newAuthorityAccount ag_solanago.PublicKey) *AuthorizeCheckedWithSeed {
+	return NewAuthorizeCheckedWithSeedInstructionBuilder().
+		SetStakeAuthorize(stakeAuthorize).
+		SetAuthoritySeed(authoritySeed).
+		SetAuthorityOwner(authorityOwner).
+		SetStakeAccount(stakeAccount).
+		SetAuthorityBaseAccount(authorityBaseAccount).
+		SetNewAuthorityAccount(newAuthorityAccount)
+}
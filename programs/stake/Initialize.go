@@ -0,0 +1,193 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stake
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	ag_binary "github.com/gagliardetto/binary"
+	ag_solanago "github.com/gagliardetto/solana-go"
+	ag_format "github.com/gagliardetto/solana-go/text/format"
+	ag_treeout "github.com/gagliardetto/treeout"
+)
+
+// Initializes a stake account with lockup and authorization information.
+// This instruction is usually paired with system.CreateAccount.
+type Initialize struct {
+	// Authorities of the new stake account
+	Authorized *Authorized
+
+	// Lockup of the new stake account
+	Lockup *Lockup
+
+	// [0] = [WRITE] StakeAccount
+	// ··········· Uninitialized stake account
+	//
+	// [1] = [] $(SysVarRentPubkey)
+	// ··········· Rent sysvar
+	ag_solanago.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+// NewInitializeInstructionBuilder creates a new `Initialize` instruction builder.
+func NewInitializeInstructionBuilder() *Initialize {
+	nd := &Initialize{
+		AccountMetaSlice: make(ag_solanago.AccountMetaSlice, 2),
+	}
+	nd.AccountMetaSlice[1] = ag_solanago.Meta(ag_solanago.SysVarRentPubkey)
+	return nd
+}
+
+// Authorities of the new stake account
+func (inst *Initialize) SetAuthorized(authorized Authorized) *Initialize {
+	inst.Authorized = &authorized
+	return inst
+}
+
+// Lockup of the new stake account
+func (inst *Initialize) SetLockup(lockup Lockup) *Initialize {
+	inst.Lockup = &lockup
+	return inst
+}
+
+// Uninitialized stake account
+func (inst *Initialize) SetStakeAccount(stakeAccount ag_solanago.PublicKey) *Initialize {
+	inst.AccountMetaSlice[0] = ag_solanago.Meta(stakeAccount).WRITE()
+	return inst
+}
+
+func (inst *Initialize) GetStakeAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[0]
+}
+
+// Rent sysvar
+func (inst *Initialize) SetSysVarRentPubkeyAccount(sysVarRentPubkeyAccount ag_solanago.PublicKey) *Initialize {
+	inst.AccountMetaSlice[1] = ag_solanago.Meta(sysVarRentPubkeyAccount)
+	return inst
+}
+
+func (inst *Initialize) GetSysVarRentPubkeyAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[1]
+}
+
+func (inst Initialize) Build() *Instruction {
+	return &Instruction{BaseVariant: ag_binary.BaseVariant{
+		Impl:   inst,
+		TypeID: ag_binary.TypeIDFromUint32(Instruction_Initialize, binary.LittleEndian),
+	}}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (inst Initialize) ValidateAndBuild() (*Instruction, error) {
+	if err := inst.Validate(); err != nil {
+		return nil, err
+	}
+	return inst.Build(), nil
+}
+
+func (inst *Initialize) Validate() error {
+	// Check whether all (required) parameters are set:
+	{
+		if inst.Authorized == nil {
+			return errors.New("Authorized parameter is not set")
+		}
+		if inst.Lockup == nil {
+			return errors.New("Lockup parameter is not set")
+		}
+	}
+
+	// Check whether all (required) accounts are set:
+	for accIndex, acc := range inst.AccountMetaSlice {
+		if acc == nil {
+			return fmt.Errorf("ins.AccountMetaSlice[%v] is not set", accIndex)
+		}
+	}
+	return nil
+}
+
+func (inst *Initialize) EncodeToTree(parent ag_treeout.Branches) {
+	parent.Child(ag_format.Program(ProgramName, ProgramID)).
+		//
+		ParentFunc(func(programBranch ag_treeout.Branches) {
+			programBranch.Child(ag_format.Instruction("Initialize")).
+				//
+				ParentFunc(func(instructionBranch ag_treeout.Branches) {
+
+					// Parameters of the instruction:
+					instructionBranch.Child("Params").ParentFunc(func(paramsBranch ag_treeout.Branches) {
+						paramsBranch.Child(ag_format.Param("Authorized", *inst.Authorized))
+						paramsBranch.Child(ag_format.Param("    Lockup", *inst.Lockup))
+					})
+
+					// Accounts of the instruction:
+					instructionBranch.Child("Accounts").ParentFunc(func(accountsBranch ag_treeout.Branches) {
+						accountsBranch.Child(ag_format.Meta("     Stake", inst.AccountMetaSlice.Get(0)))
+						accountsBranch.Child(ag_format.Meta("SysVarRent", inst.AccountMetaSlice.Get(1)))
+					})
+				})
+		})
+}
+
+func (inst Initialize) MarshalWithEncoder(encoder *ag_binary.Encoder) error {
+	// Serialize `Authorized` param:
+	{
+		err := encoder.Encode(*inst.Authorized)
+		if err != nil {
+			return err
+		}
+	}
+	// Serialize `Lockup` param:
+	{
+		err := encoder.Encode(*inst.Lockup)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (inst *Initialize) UnmarshalWithDecoder(decoder *ag_binary.Decoder) error {
+	// Deserialize `Authorized` param:
+	{
+		err := decoder.Decode(&inst.Authorized)
+		if err != nil {
+			return err
+		}
+	}
+	// Deserialize `Lockup` param:
+	{
+		err := decoder.Decode(&inst.Lockup)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// NewInitializeInstruction declares a new Initialize instruction with the provided parameters and accounts.
+func NewInitializeInstruction(
+	// Parameters:
+	authorized Authorized,
+	lockup Lockup,
+	// Accounts:
+	stakeAccount ag_solanago.PublicKey) *Initialize {
+	return NewInitializeInstructionBuilder().
+		SetAuthorized(authorized).
+		SetLockup(lockup).
+		SetStakeAccount(stakeAccount)
+}
@@ -0,0 +1,162 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stake
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	ag_binary "github.com/gagliardetto/binary"
+	ag_solanago "github.com/gagliardetto/solana-go"
+	ag_format "github.com/gagliardetto/solana-go/text/format"
+	ag_treeout "github.com/gagliardetto/treeout"
+)
+
+// Initialize a stake with authorization information.
+//
+// This instruction is similar to Initialize except that the withdraw
+// authority must be a signer, and no lockup is applied to the account.
+type InitializeChecked struct {
+	// [0] = [WRITE] StakeAccount
+	// ··········· Uninitialized stake account
+	//
+	// [1] = [] $(SysVarRentPubkey)
+	// ··········· Rent sysvar
+	//
+	// [2] = [] StakeAuthorityAccount
+	// ··········· The stake authority
+	//
+	// [3] = [SIGNER] WithdrawAuthorityAccount
+	// ··········· The withdraw authority
+	ag_solanago.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+// NewInitializeCheckedInstructionBuilder creates a new `InitializeChecked` instruction builder.
+func NewInitializeCheckedInstructionBuilder() *InitializeChecked {
+	nd := &InitializeChecked{
+		AccountMetaSlice: make(ag_solanago.AccountMetaSlice, 4),
+	}
+	nd.AccountMetaSlice[1] = ag_solanago.Meta(ag_solanago.SysVarRentPubkey)
+	return nd
+}
+
+// Uninitialized stake account
+func (inst *InitializeChecked) SetStakeAccount(stakeAccount ag_solanago.PublicKey) *InitializeChecked {
+	inst.AccountMetaSlice[0] = ag_solanago.Meta(stakeAccount).WRITE()
+	return inst
+}
+
+func (inst *InitializeChecked) GetStakeAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[0]
+}
+
+// Rent sysvar
+func (inst *InitializeChecked) SetSysVarRentPubkeyAccount(sysVarRentPubkeyAccount ag_solanago.PublicKey) *InitializeChecked {
+	inst.AccountMetaSlice[1] = ag_solanago.Meta(sysVarRentPubkeyAccount)
+	return inst
+}
+
+func (inst *InitializeChecked) GetSysVarRentPubkeyAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[1]
+}
+
+// The stake authority
+func (inst *InitializeChecked) SetStakeAuthorityAccount(stakeAuthorityAccount ag_solanago.PublicKey) *InitializeChecked {
+	inst.AccountMetaSlice[2] = ag_solanago.Meta(stakeAuthorityAccount)
+	return inst
+}
+
+func (inst *InitializeChecked) GetStakeAuthorityAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[2]
+}
+
+// The withdraw authority
+func (inst *InitializeChecked) SetWithdrawAuthorityAccount(withdrawAuthorityAccount ag_solanago.PublicKey) *InitializeChecked {
+	inst.AccountMetaSlice[3] = ag_solanago.Meta(withdrawAuthorityAccount).SIGNER()
+	return inst
+}
+
+func (inst *InitializeChecked) GetWithdrawAuthorityAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[3]
+}
+
+func (inst InitializeChecked) Build() *Instruction {
+	return &Instruction{BaseVariant: ag_binary.BaseVariant{
+		Impl:   inst,
+		TypeID: ag_binary.TypeIDFromUint32(Instruction_InitializeChecked, binary.LittleEndian),
+	}}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (inst InitializeChecked) ValidateAndBuild() (*Instruction, error) {
+	if err := inst.Validate(); err != nil {
+		return nil, err
+	}
+	return inst.Build(), nil
+}
+
+func (inst *InitializeChecked) Validate() error {
+	// Check whether all (required) accounts are set:
+	for accIndex, acc := range inst.AccountMetaSlice {
+		if acc == nil {
+			return fmt.Errorf("ins.AccountMetaSlice[%v] is not set", accIndex)
+		}
+	}
+	return nil
+}
+
+func (inst *InitializeChecked) EncodeToTree(parent ag_treeout.Branches) {
+	parent.Child(ag_format.Program(ProgramName, ProgramID)).
+		//
+		ParentFunc(func(programBranch ag_treeout.Branches) {
+			programBranch.Child(ag_format.Instruction("InitializeChecked")).
+				//
+				ParentFunc(func(instructionBranch ag_treeout.Branches) {
+
+					// Parameters of the instruction:
+					instructionBranch.Child("Params").ParentFunc(func(paramsBranch ag_treeout.Branches) {})
+
+					// Accounts of the instruction:
+					instructionBranch.Child("Accounts").ParentFunc(func(accountsBranch ag_treeout.Branches) {
+						accountsBranch.Child(ag_format.Meta("            Stake", inst.AccountMetaSlice.Get(0)))
+						accountsBranch.Child(ag_format.Meta("       SysVarRent", inst.AccountMetaSlice.Get(1)))
+						accountsBranch.Child(ag_format.Meta("   StakeAuthority", inst.AccountMetaSlice.Get(2)))
+						accountsBranch.Child(ag_format.Meta("WithdrawAuthority", inst.AccountMetaSlice.Get(3)))
+					})
+				})
+		})
+}
+
+func (inst InitializeChecked) MarshalWithEncoder(encoder *ag_binary.Encoder) error {
+	return nil
+}
+
+func (inst *InitializeChecked) UnmarshalWithDecoder(decoder *ag_binary.Decoder) error {
+	return nil
+}
+
+// NewInitializeCheckedInstruction declares a new InitializeChecked instruction with the provided parameters and accounts.
+func NewInitializeCheckedInstruction(
+	// Accounts:
+	stakeAccount ag_solanago.PublicKey,
+	stakeAuthorityAccount ag_solanago.PublicKey,
+	withdrawAuthorityAccount ag_solanago.PublicKey) *InitializeChecked {
+	return NewInitializeCheckedInstructionBuilder().
+		SetStakeAccount(stakeAccount).
+		SetStakeAuthorityAccount(stakeAuthorityAccount).
+		SetWithdrawAuthorityAccount(withdrawAuthorityAccount)
+}
@@ -0,0 +1,177 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stake
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	ag_binary "github.com/gagliardetto/binary"
+	ag_solanago "github.com/gagliardetto/solana-go"
+	ag_format "github.com/gagliardetto/solana-go/text/format"
+	ag_treeout "github.com/gagliardetto/treeout"
+)
+
+// Merge two stake accounts.
+//
+// Both accounts must have identical lockup and authority keys. The source
+// account is drained and closed after the merge.
+type Merge struct {
+	// [0] = [WRITE] DestinationStakeAccount
+	// ··········· Destination stake account for the merge
+	//
+	// [1] = [WRITE] SourceStakeAccount
+	// ··········· Source stake account to merge into the destination stake account
+	//
+	// [2] = [] $(SysVarClockPubkey)
+	// ··········· Clock sysvar
+	//
+	// [3] = [] $(SysVarStakeHistoryPubkey)
+	// ··········· Stake history sysvar that carries stake warmup/cooldown history
+	//
+	// [4] = [SIGNER] StakeAuthorityAccount
+	// ··········· Stake authority
+	ag_solanago.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+// NewMergeInstructionBuilder creates a new `Merge` instruction builder.
+func NewMergeInstructionBuilder() *Merge {
+	nd := &Merge{
+		AccountMetaSlice: make(ag_solanago.AccountMetaSlice, 5),
+	}
+	nd.AccountMetaSlice[2] = ag_solanago.Meta(ag_solanago.SysVarClockPubkey)
+	nd.AccountMetaSlice[3] = ag_solanago.Meta(ag_solanago.SysVarStakeHistoryPubkey)
+	return nd
+}
+
+// Destination stake account for the merge
+func (inst *Merge) SetDestinationStakeAccount(destinationStakeAccount ag_solanago.PublicKey) *Merge {
+	inst.AccountMetaSlice[0] = ag_solanago.Meta(destinationStakeAccount).WRITE()
+	return inst
+}
+
+func (inst *Merge) GetDestinationStakeAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[0]
+}
+
+// Source stake account to merge into the destination stake account
+func (inst *Merge) SetSourceStakeAccount(sourceStakeAccount ag_solanago.PublicKey) *Merge {
+	inst.AccountMetaSlice[1] = ag_solanago.Meta(sourceStakeAccount).WRITE()
+	return inst
+}
+
+func (inst *Merge) GetSourceStakeAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[1]
+}
+
+// Clock sysvar
+func (inst *Merge) SetSysVarClockPubkeyAccount(sysVarClockPubkeyAccount ag_solanago.PublicKey) *Merge {
+	inst.AccountMetaSlice[2] = ag_solanago.Meta(sysVarClockPubkeyAccount)
+	return inst
+}
+
+func (inst *Merge) GetSysVarClockPubkeyAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[2]
+}
+
+// Stake history sysvar that carries stake warmup/cooldown history
+func (inst *Merge) SetSysVarStakeHistoryPubkeyAccount(sysVarStakeHistoryPubkeyAccount ag_solanago.PublicKey) *Merge {
+	inst.AccountMetaSlice[3] = ag_solanago.Meta(sysVarStakeHistoryPubkeyAccount)
+	return inst
+}
+
+func (inst *Merge) GetSysVarStakeHistoryPubkeyAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[3]
+}
+
+// Stake authority
+func (inst *Merge) SetStakeAuthorityAccount(stakeAuthorityAccount ag_solanago.PublicKey) *Merge {
+	inst.AccountMetaSlice[4] = ag_solanago.Meta(stakeAuthorityAccount).SIGNER()
+	return inst
+}
+
+func (inst *Merge) GetStakeAuthorityAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[4]
+}
+
+func (inst Merge) Build() *Instruction {
+	return &Instruction{BaseVariant: ag_binary.BaseVariant{
+		Impl:   inst,
+		TypeID: ag_binary.TypeIDFromUint32(Instruction_Merge, binary.LittleEndian),
+	}}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (inst Merge) ValidateAndBuild() (*Instruction, error) {
+	if err := inst.Validate(); err != nil {
+		return nil, err
+	}
+	return inst.Build(), nil
+}
+
+func (inst *Merge) Validate() error {
+	// Check whether all (required) accounts are set:
+	for accIndex, acc := range inst.AccountMetaSlice {
+		if acc == nil {
+			return fmt.Errorf("ins.AccountMetaSlice[%v] is not set", accIndex)
+		}
+	}
+	return nil
+}
+
+func (inst *Merge) EncodeToTree(parent ag_treeout.Branches) {
+	parent.Child(ag_format.Program(ProgramName, ProgramID)).
+		//
+		ParentFunc(func(programBranch ag_treeout.Branches) {
+			programBranch.Child(ag_format.Instruction("Merge")).
+				//
+				ParentFunc(func(instructionBranch ag_treeout.Branches) {
+
+					// Parameters of the instruction:
+					instructionBranch.Child("Params").ParentFunc(func(paramsBranch ag_treeout.Branches) {})
+
+					// Accounts of the instruction:
+					instructionBranch.Child("Accounts").ParentFunc(func(accountsBranch ag_treeout.Branches) {
+						accountsBranch.Child(ag_format.Meta("  DestinationStake", inst.AccountMetaSlice.Get(0)))
+						accountsBranch.Child(ag_format.Meta("       SourceStake", inst.AccountMetaSlice.Get(1)))
+						accountsBranch.Child(ag_format.Meta("       SysVarClock", inst.AccountMetaSlice.Get(2)))
+						accountsBranch.Child(ag_format.Meta("SysVarStakeHistory", inst.AccountMetaSlice.Get(3)))
+						accountsBranch.Child(ag_format.Meta("    StakeAuthority", inst.AccountMetaSlice.Get(4)))
+					})
+				})
+		})
+}
+
+func (inst Merge) MarshalWithEncoder(encoder *ag_binary.Encoder) error {
+	return nil
+}
+
+func (inst *Merge) UnmarshalWithDecoder(decoder *ag_binary.Decoder) error {
+	return nil
+}
+
+// NewMergeInstruction declares a new Merge instruction with the provided parameters and accounts.
+func NewMergeInstruction(
+	// Accounts:
+	destinationStakeAccount ag_solanago.PublicKey,
+	sourceStakeAccount ag_solanago.PublicKey,
+	stakeAuthorityAccount ag_solanago.PublicKey) *Merge {
+	return NewMergeInstructionBuilder().
+		SetDestinationStakeAccount(destinationStakeAccount).
+		SetSourceStakeAccount(sourceStakeAccount).
+		SetStakeAuthorityAccount(stakeAuthorityAccount)
+}
@@ -0,0 +1,177 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stake
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	ag_binary "github.com/gagliardetto/binary"
+	ag_solanago "github.com/gagliardetto/solana-go"
+	ag_format "github.com/gagliardetto/solana-go/text/format"
+	ag_treeout "github.com/gagliardetto/treeout"
+)
+
+// Redelegate activated stake to another vote account.
+//
+// Deprecated: the instruction is disabled on all clusters.
+type Redelegate struct {
+	// [0] = [WRITE] StakeAccount
+	// ··········· Delegated stake account to be redelegated
+	//
+	// [1] = [WRITE] UninitializedStakeAccount
+	// ··········· Uninitialized stake account that will hold the redelegated stake
+	//
+	// [2] = [] VoteAccount
+	// ··········· Vote account to which this stake will be re-delegated
+	//
+	// [3] = [] $(StakeConfigPubkey)
+	// ··········· Unused account, formerly the stake config
+	//
+	// [4] = [SIGNER] StakeAuthorityAccount
+	// ··········· Stake authority
+	ag_solanago.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+// NewRedelegateInstructionBuilder creates a new `Redelegate` instruction builder.
+func NewRedelegateInstructionBuilder() *Redelegate {
+	nd := &Redelegate{
+		AccountMetaSlice: make(ag_solanago.AccountMetaSlice, 5),
+	}
+	nd.AccountMetaSlice[3] = ag_solanago.Meta(StakeConfigPubkey)
+	return nd
+}
+
+// Delegated stake account to be redelegated
+func (inst *Redelegate) SetStakeAccount(stakeAccount ag_solanago.PublicKey) *Redelegate {
+	inst.AccountMetaSlice[0] = ag_solanago.Meta(stakeAccount).WRITE()
+	return inst
+}
+
+func (inst *Redelegate) GetStakeAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[0]
+}
+
+// Uninitialized stake account that will hold the redelegated stake
+func (inst *Redelegate) SetUninitializedStakeAccount(uninitializedStakeAccount ag_solanago.PublicKey) *Redelegate {
+	inst.AccountMetaSlice[1] = ag_solanago.Meta(uninitializedStakeAccount).WRITE()
+	return inst
+}
+
+func (inst *Redelegate) GetUninitializedStakeAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[1]
+}
+
+// Vote account to which this stake will be re-delegated
+func (inst *Redelegate) SetVoteAccount(voteAccount ag_solanago.PublicKey) *Redelegate {
+	inst.AccountMetaSlice[2] = ag_solanago.Meta(voteAccount)
+	return inst
+}
+
+func (inst *Redelegate) GetVoteAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[2]
+}
+
+// Unused account, formerly the stake config
+func (inst *Redelegate) SetStakeConfigAccount(stakeConfigAccount ag_solanago.PublicKey) *Redelegate {
+	inst.AccountMetaSlice[3] = ag_solanago.Meta(stakeConfigAccount)
+	return inst
+}
+
+func (inst *Redelegate) GetStakeConfigAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[3]
+}
+
+// Stake authority
+func (inst *Redelegate) SetStakeAuthorityAccount(stakeAuthorityAccount ag_solanago.PublicKey) *Redelegate {
+	inst.AccountMetaSlice[4] = ag_solanago.Meta(stakeAuthorityAccount).SIGNER()
+	return inst
+}
+
+func (inst *Redelegate) GetStakeAuthorityAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[4]
+}
+
+func (inst Redelegate) Build() *Instruction {
+	return &Instruction{BaseVariant: ag_binary.BaseVariant{
+		Impl:   inst,
+		TypeID: ag_binary.TypeIDFromUint32(Instruction_Redelegate, binary.LittleEndian),
+	}}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (inst Redelegate) ValidateAndBuild() (*Instruction, error) {
+	if err := inst.Validate(); err != nil {
+		return nil, err
+	}
+	return inst.Build(), nil
+}
+
+func (inst *Redelegate) Validate() error {
+	// Check whether all (required) accounts are set:
+	for accIndex, acc := range inst.AccountMetaSlice {
+		if acc == nil {
+			return fmt.Errorf("ins.AccountMetaSlice[%v] is not set", accIndex)
+		}
+	}
+	return nil
+}
+
+func (inst *Redelegate) EncodeToTree(parent ag_treeout.Branches) {
+	parent.Child(ag_format.Program(ProgramName, ProgramID)).
+		//
+		ParentFunc(func(programBranch ag_treeout.Branches) {
+			programBranch.Child(ag_format.Instruction("Redelegate")).
+				//
+				ParentFunc(func(instructionBranch ag_treeout.Branches) {
+
+					// Parameters of the instruction:
+					instructionBranch.Child("Params").ParentFunc(func(paramsBranch ag_treeout.Branches) {})
+
+					// Accounts of the instruction:
+					instructionBranch.Child("Accounts").ParentFunc(func(accountsBranch ag_treeout.Branches) {
+						accountsBranch.Child(ag_format.Meta("             Stake", inst.AccountMetaSlice.Get(0)))
+						accountsBranch.Child(ag_format.Meta("UninitializedStake", inst.AccountMetaSlice.Get(1)))
+						accountsBranch.Child(ag_format.Meta("              Vote", inst.AccountMetaSlice.Get(2)))
+						accountsBranch.Child(ag_format.Meta("       StakeConfig", inst.AccountMetaSlice.Get(3)))
+						accountsBranch.Child(ag_format.Meta("    StakeAuthority", inst.AccountMetaSlice.Get(4)))
+					})
+				})
+		})
+}
+
+func (inst Redelegate) MarshalWithEncoder(encoder *ag_binary.Encoder) error {
+	return nil
+}
+
+func (inst *Redelegate) UnmarshalWithDecoder(decoder *ag_binary.Decoder) error {
+	return nil
+}
+
+// NewRedelegateInstruction declares a new Redelegate instruction with the provided parameters and accounts.
+func NewRedelegateInstruction(
+	// Accounts:
+	stakeAccount ag_solanago.PublicKey,
+	uninitializedStakeAccount ag_solanago.PublicKey,
+	voteAccount ag_solanago.PublicKey,
+	stakeAuthorityAccount ag_solanago.PublicKey) *Redelegate {
+	return NewRedelegateInstructionBuilder().
+		SetStakeAccount(stakeAccount).
+		SetUninitializedStakeAccount(uninitializedStakeAccount).
+		SetVoteAccount(voteAccount).
+		SetStakeAuthorityAccount(stakeAuthorityAccount)
+}
@@ -0,0 +1,184 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stake
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	ag_binary "github.com/gagliardetto/binary"
+	ag_solanago "github.com/gagliardetto/solana-go"
+	ag_format "github.com/gagliardetto/solana-go/text/format"
+	ag_treeout "github.com/gagliardetto/treeout"
+)
+
+// Set stake lockup.
+//
+// This instruction behaves like SetLockup with the additional requirement
+// that the new lockup authority also be a signer.
+type SetLockupChecked struct {
+	// Lockup parameters to change
+	LockupCheckedArgs *LockupCheckedArgs
+
+	// [0] = [WRITE] StakeAccount
+	// ··········· Initialized stake account
+	//
+	// [1] = [SIGNER] AuthorityAccount
+	// ··········· Lockup authority or withdraw authority
+	//
+	// [2] = [SIGNER] NewLockupAuthorityAccount
+	// ··········· New lockup authority (optional)
+	ag_solanago.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+// NewSetLockupCheckedInstructionBuilder creates a new `SetLockupChecked` instruction builder.
+func NewSetLockupCheckedInstructionBuilder() *SetLockupChecked {
+	nd := &SetLockupChecked{
+		AccountMetaSlice: make(ag_solanago.AccountMetaSlice, 2),
+	}
+	return nd
+}
+
+// Lockup parameters to change
+func (inst *SetLockupChecked) SetLockupCheckedArgs(lockupCheckedArgs LockupCheckedArgs) *SetLockupChecked {
+	inst.LockupCheckedArgs = &lockupCheckedArgs
+	return inst
+}
+
+// Initialized stake account
+func (inst *SetLockupChecked) SetStakeAccount(stakeAccount ag_solanago.PublicKey) *SetLockupChecked {
+	inst.AccountMetaSlice[0] = ag_solanago.Meta(stakeAccount).WRITE()
+	return inst
+}
+
+func (inst *SetLockupChecked) GetStakeAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[0]
+}
+
+// Lockup authority or withdraw authority
+func (inst *SetLockupChecked) SetAuthorityAccount(authorityAccount ag_solanago.PublicKey) *SetLockupChecked {
+	inst.AccountMetaSlice[1] = ag_solanago.Meta(authorityAccount).SIGNER()
+	return inst
+}
+
+func (inst *SetLockupChecked) GetAuthorityAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[1]
+}
+
+// New lockup authority
+func (inst *SetLockupChecked) SetNewLockupAuthorityAccount(newLockupAuthorityAccount ag_solanago.PublicKey) *SetLockupChecked {
+	if len(inst.AccountMetaSlice) <= 2 {
+		inst.AccountMetaSlice = append(inst.AccountMetaSlice, nil)
+	}
+	inst.AccountMetaSlice[2] = ag_solanago.Meta(newLockupAuthorityAccount).SIGNER()
+	return inst
+}
+
+func (inst *SetLockupChecked) GetNewLockupAuthorityAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(2)
+}
+
+func (inst SetLockupChecked) Build() *Instruction {
+	return &Instruction{BaseVariant: ag_binary.BaseVariant{
+		Impl:   inst,
+		TypeID: ag_binary.TypeIDFromUint32(Instruction_SetLockupChecked, binary.LittleEndian),
+	}}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (inst SetLockupChecked) ValidateAndBuild() (*Instruction, error) {
+	if err := inst.Validate(); err != nil {
+		return nil, err
+	}
+	return inst.Build(), nil
+}
+
+func (inst *SetLockupChecked) Validate() error {
+	// Check whether all (required) parameters are set:
+	{
+		if inst.LockupCheckedArgs == nil {
+			return errors.New("LockupCheckedArgs parameter is not set")
+		}
+	}
+
+	// Check whether all (required) accounts are set:
+	for accIndex, acc := range inst.AccountMetaSlice[:2] {
+		if acc == nil {
+			return fmt.Errorf("ins.AccountMetaSlice[%v] is not set", accIndex)
+		}
+	}
+	return nil
+}
+
+func (inst *SetLockupChecked) EncodeToTree(parent ag_treeout.Branches) {
+	parent.Child(ag_format.Program(ProgramName, ProgramID)).
+		//
+		ParentFunc(func(programBranch ag_treeout.Branches) {
+			programBranch.Child(ag_format.Instruction("SetLockupChecked")).
+				//
+				ParentFunc(func(instructionBranch ag_treeout.Branches) {
+
+					// Parameters of the instruction:
+					instructionBranch.Child("Params").ParentFunc(func(paramsBranch ag_treeout.Branches) {
+						paramsBranch.Child(ag_format.Param("LockupCheckedArgs", *inst.LockupCheckedArgs))
+					})
+
+					// Accounts of the instruction:
+					instructionBranch.Child("Accounts").ParentFunc(func(accountsBranch ag_treeout.Branches) {
+						accountsBranch.Child(ag_format.Meta("             Stake", inst.AccountMetaSlice.Get(0)))
+						accountsBranch.Child(ag_format.Meta("         Authority", inst.AccountMetaSlice.Get(1)))
+						accountsBranch.Child(ag_format.Meta("NewLockupAuthority", inst.AccountMetaSlice.Get(2)))
+					})
+				})
+		})
+}
+
+func (inst SetLockupChecked) MarshalWithEncoder(encoder *ag_binary.Encoder) error {
+	// Serialize `LockupCheckedArgs` param:
+	{
+		err := encoder.Encode(*inst.LockupCheckedArgs)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (inst *SetLockupChecked) UnmarshalWithDecoder(decoder *ag_binary.Decoder) error {
+	// Deserialize `LockupCheckedArgs` param:
+	{
+		err := decoder.Decode(&inst.LockupCheckedArgs)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// NewSetLockupCheckedInstruction declares a new SetLockupChecked instruction with the provided parameters and accounts.
+func NewSetLockupCheckedInstruction(
+	// Parameters:
+	lockupCheckedArgs LockupCheckedArgs,
+	// Accounts:
+	stakeAccount ag_solanago.PublicKey,
+	authorityAccount ag_solanago.PublicKey) *SetLockupChecked {
+	return NewSetLockupCheckedInstructionBuilder().
+		SetLockupCheckedArgs(lockupCheckedArgs).
+		SetStakeAccount(stakeAccount).
+		SetAuthorityAccount(authorityAccount)
+}
@@ -0,0 +1,272 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stake
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+
+	ag_binary "github.com/gagliardetto/binary"
+	ag_solanago "github.com/gagliardetto/solana-go"
+)
+
+type StakeStateType uint32
+
+const (
+	StakeStateUninitialized StakeStateType = iota
+	StakeStateInitialized
+	StakeStateStake
+	StakeStateRewardsPool
+)
+
+func (t StakeStateType) String() string {
+	switch t {
+	case StakeStateUninitialized:
+		return "Uninitialized"
+	case StakeStateInitialized:
+		return "Initialized"
+	case StakeStateStake:
+		return "Stake"
+	case StakeStateRewardsPool:
+		return "RewardsPool"
+	default:
+		return fmt.Sprintf("Unknown(%d)", uint32(t))
+	}
+}
+
+// StakeFlags are additional flags of a delegated stake account.
+type StakeFlags uint8
+
+const (
+	// The stake must be fully activated before it can be deactivated
+	// (set by Redelegate).
+	StakeFlagMustFullyActivateBeforeDeactivationIsPermitted StakeFlags = 1 << 0
+)
+
+// StakeStateV2 is the state of an account owned by the stake program.
+type StakeStateV2 struct {
+	Type StakeStateType
+
+	// Set when Type is StakeStateInitialized or StakeStateStake.
+	Meta *Meta
+
+	// Set when Type is StakeStateStake.
+	Stake *Stake
+
+	// Set when Type is StakeStateStake.
+	StakeFlags StakeFlags
+}
+
+// Meta holds the rent reserve, authorities and lockup of a stake account.
+type Meta struct {
+	RentExemptReserve uint64
+	Authorized        Authorized
+	Lockup            Lockup
+}
+
+// Stake is the delegation of an active stake account.
+type Stake struct {
+	Delegation Delegation
+
+	// Credits observed is credits from vote account state when delegated or redeemed.
+	CreditsObserved uint64
+}
+
+// Delegation describes the vote account the stake is delegated to,
+// and the epochs in which the stake was (de)activated.
+type Delegation struct {
+	// To whom the stake is delegated.
+	VoterPubkey ag_solanago.PublicKey
+
+	// Activated stake amount, set at delegate() time.
+	Stake uint64
+
+	// Epoch at which this stake was activated, std::Epoch::MAX if is a bootstrap stake.
+	ActivationEpoch uint64
+
+	// Epoch the stake was deactivated, std::Epoch::MAX if not deactivated.
+	DeactivationEpoch uint64
+
+	// Deprecated: no longer used by the runtime.
+	WarmupCooldownRate float64
+}
+
+// IsBootstrap returns true if the stake was part of the genesis configuration.
+func (d Delegation) IsBootstrap() bool {
+	return d.ActivationEpoch == math.MaxUint64
+}
+
+// IsDeactivated returns true if the stake has been deactivated.
+func (d Delegation) IsDeactivated() bool {
+	return d.DeactivationEpoch != math.MaxUint64
+}
+
+// DecodeStakeStateV2 decodes the data of a stake account.
+func DecodeStakeStateV2(data []byte) (*StakeStateV2, error) {
+	state := new(StakeStateV2)
+	if err := state.UnmarshalWithDecoder(ag_binary.NewBinDecoder(data)); err != nil {
+		return nil, fmt.Errorf("unable to decode stake state: %w", err)
+	}
+	return state, nil
+}
+
+func (obj *StakeStateV2) UnmarshalWithDecoder(decoder *ag_binary.Decoder) (err error) {
+	typ, err := decoder.ReadUint32(binary.LittleEndian)
+	if err != nil {
+		return err
+	}
+	obj.Type = StakeStateType(typ)
+	obj.Meta = nil
+	obj.Stake = nil
+	obj.StakeFlags = 0
+	switch obj.Type {
+	case StakeStateUninitialized, StakeStateRewardsPool:
+		return nil
+	case StakeStateInitialized:
+		obj.Meta = new(Meta)
+		return obj.Meta.UnmarshalWithDecoder(decoder)
+	case StakeStateStake:
+		obj.Meta = new(Meta)
+		if err = obj.Meta.UnmarshalWithDecoder(decoder); err != nil {
+			return err
+		}
+		obj.Stake = new(Stake)
+		if err = obj.Stake.UnmarshalWithDecoder(decoder); err != nil {
+			return err
+		}
+		flags, err := decoder.ReadUint8()
+		if err != nil {
+			return err
+		}
+		obj.StakeFlags = StakeFlags(flags)
+		return nil
+	default:
+		return fmt.Errorf("invalid stake state type: %d", typ)
+	}
+}
+
+// MarshalWithEncoder encodes the state; the output is zero-padded
+// to StakeStateV2Size, which is the size of every stake account.
+func (obj StakeStateV2) MarshalWithEncoder(encoder *ag_binary.Encoder) (err error) {
+	start := encoder.Written()
+	if err = encoder.WriteUint32(uint32(obj.Type), binary.LittleEndian); err != nil {
+		return err
+	}
+	switch obj.Type {
+	case StakeStateUninitialized, StakeStateRewardsPool:
+	case StakeStateInitialized:
+		if obj.Meta == nil {
+			return fmt.Errorf("Meta is not set")
+		}
+		if err = obj.Meta.MarshalWithEncoder(encoder); err != nil {
+			return err
+		}
+	case StakeStateStake:
+		if obj.Meta == nil {
+			return fmt.Errorf("Meta is not set")
+		}
+		if obj.Stake == nil {
+			return fmt.Errorf("Stake is not set")
+		}
+		if err = obj.Meta.MarshalWithEncoder(encoder); err != nil {
+			return err
+		}
+		if err = obj.Stake.MarshalWithEncoder(encoder); err != nil {
+			return err
+		}
+		if err = encoder.WriteUint8(uint8(obj.StakeFlags)); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("invalid stake state type: %d", obj.Type)
+	}
+	if written := encoder.Written() - start; written < StakeStateV2Size {
+		return encoder.WriteBytes(make([]byte, StakeStateV2Size-written), false)
+	}
+	return nil
+}
+
+func (obj *Meta) UnmarshalWithDecoder(decoder *ag_binary.Decoder) (err error) {
+	if obj.RentExemptReserve, err = decoder.ReadUint64(binary.LittleEndian); err != nil {
+		return err
+	}
+	if err = decoder.Decode(&obj.Authorized); err != nil {
+		return err
+	}
+	return decoder.Decode(&obj.Lockup)
+}
+
+func (obj Meta) MarshalWithEncoder(encoder *ag_binary.Encoder) (err error) {
+	if err = encoder.WriteUint64(obj.RentExemptReserve, binary.LittleEndian); err != nil {
+		return err
+	}
+	if err = encoder.Encode(obj.Authorized); err != nil {
+		return err
+	}
+	return encoder.Encode(obj.Lockup)
+}
+
+func (obj *Stake) UnmarshalWithDecoder(decoder *ag_binary.Decoder) (err error) {
+	if err = obj.Delegation.UnmarshalWithDecoder(decoder); err != nil {
+		return err
+	}
+	obj.CreditsObserved, err = decoder.ReadUint64(binary.LittleEndian)
+	return err
+}
+
+func (obj Stake) MarshalWithEncoder(encoder *ag_binary.Encoder) (err error) {
+	if err = obj.Delegation.MarshalWithEncoder(encoder); err != nil {
+		return err
+	}
+	return encoder.WriteUint64(obj.CreditsObserved, binary.LittleEndian)
+}
+
+func (obj *Delegation) UnmarshalWithDecoder(decoder *ag_binary.Decoder) (err error) {
+	{
+		v, err := decoder.ReadNBytes(32)
+		if err != nil {
+			return err
+		}
+		obj.VoterPubkey = ag_solanago.PublicKeyFromBytes(v)
+	}
+	if obj.Stake, err = decoder.ReadUint64(binary.LittleEndian); err != nil {
+		return err
+	}
+	if obj.ActivationEpoch, err = decoder.ReadUint64(binary.LittleEndian); err != nil {
+		return err
+	}
+	if obj.DeactivationEpoch, err = decoder.ReadUint64(binary.LittleEndian); err != nil {
+		return err
+	}
+	obj.WarmupCooldownRate, err = decoder.ReadFloat64(binary.LittleEndian)
+	return err
+}
+
+func (obj Delegation) MarshalWithEncoder(encoder *ag_binary.Encoder) (err error) {
+	if err = encoder.WriteBytes(obj.VoterPubkey[:], false); err != nil {
+		return err
+	}
+	if err = encoder.WriteUint64(obj.Stake, binary.LittleEndian); err != nil {
+		return err
+	}
+	if err = encoder.WriteUint64(obj.ActivationEpoch, binary.LittleEndian); err != nil {
+		return err
+	}
+	if err = encoder.WriteUint64(obj.DeactivationEpoch, binary.LittleEndian); err != nil {
+		return err
+	}
+	return encoder.WriteFloat64(obj.WarmupCooldownRate, binary.LittleEndian)
+}
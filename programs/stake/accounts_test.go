@@ -0,0 +1,141 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stake
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"testing"
+
+	ag_binary "github.com/gagliardetto/binary"
+	ag_solanago "github.com/gagliardetto/solana-go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStakeStateV2(t *testing.T) {
+	state := StakeStateV2{
+		Type: StakeStateStake,
+		Meta: &Meta{
+			RentExemptReserve: 2282880,
+			Authorized: Authorized{
+				Staker:     ag_solanago.MustPublicKeyFromBase58("7ZhCLV46C9fdnyZBQWkNN8pLUHMYaYPEAWyVL2nmZMfk"),
+				Withdrawer: ag_solanago.MustPublicKeyFromBase58("9WzDXwBbmkg8ZTbNMqUxvQRAyrZzDsGYdLVL9zYtAWWM"),
+			},
+			Lockup: Lockup{
+				UnixTimestamp: 1700000000,
+				Epoch:         500,
+				Custodian:     ag_solanago.MustPublicKeyFromBase58("CEv7bAukcYMyZxF8jr1iZj9eh3PNYq4hPqTs6AS3tBDD"),
+			},
+		},
+		Stake: &Stake{
+			Delegation: Delegation{
+				VoterPubkey:        ag_solanago.MustPublicKeyFromBase58("GJpTBQtpTFAkeSnotG3AG4NxzT3TaZBN9KoDKr1wDfc6"),
+				Stake:              1_000_000_000,
+				ActivationEpoch:    420,
+				DeactivationEpoch:  math.MaxUint64,
+				WarmupCooldownRate: 0.25,
+			},
+			CreditsObserved: 123456,
+		},
+		StakeFlags: StakeFlagMustFullyActivateBeforeDeactivationIsPermitted,
+	}
+
+	buf := new(bytes.Buffer)
+	require.NoError(t, ag_binary.NewBinEncoder(buf).Encode(state))
+	data := buf.Bytes()
+	require.Len(t, data, StakeStateV2Size)
+
+	// Spot-check the bincode layout.
+	require.Equal(t, uint32(StakeStateStake), binary.LittleEndian.Uint32(data[0:4]))
+	require.Equal(t, uint64(2282880), binary.LittleEndian.Uint64(data[4:12]))
+	require.Equal(t, state.Meta.Authorized.Staker[:], data[12:44])
+	require.Equal(t, state.Stake.Delegation.VoterPubkey[:], data[124:156])
+	require.Equal(t, uint64(123456), binary.LittleEndian.Uint64(data[188:196]))
+	require.Equal(t, byte(1), data[196])
+
+	got, err := DecodeStakeStateV2(data)
+	require.NoError(t, err)
+	require.Equal(t, &state, got)
+	require.False(t, got.Stake.Delegation.IsDeactivated())
+	require.False(t, got.Stake.Delegation.IsBootstrap())
+}
+
+func TestStakeStateV2_Initialized(t *testing.T) {
+	state := StakeStateV2{
+		Type: StakeStateInitialized,
+		Meta: &Meta{RentExemptReserve: 42},
+	}
+	buf := new(bytes.Buffer)
+	require.NoError(t, ag_binary.NewBinEncoder(buf).Encode(state))
+	require.Len(t, buf.Bytes(), StakeStateV2Size)
+
+	got, err := DecodeStakeStateV2(buf.Bytes())
+	require.NoError(t, err)
+	require.Equal(t, &state, got)
+	require.Nil(t, got.Stake)
+
+	_, err = DecodeStakeStateV2([]byte{9, 0, 0, 0})
+	require.Error(t, err)
+}
+
+func TestLockup_IsInForce(t *testing.T) {
+	custodian := ag_solanago.NewWallet().PublicKey()
+	lockup := Lockup{UnixTimestamp: 100, Epoch: 10, Custodian: custodian}
+
+	require.True(t, lockup.IsInForce(50, 20, nil))
+	require.True(t, lockup.IsInForce(150, 5, nil))
+	require.False(t, lockup.IsInForce(150, 20, nil))
+	require.False(t, lockup.IsInForce(50, 5, &custodian))
+}
+
+func TestInstructionData(t *testing.T) {
+	stake := ag_solanago.NewWallet().PublicKey()
+	recipient := ag_solanago.NewWallet().PublicKey()
+	authority := ag_solanago.NewWallet().PublicKey()
+	custodian := ag_solanago.NewWallet().PublicKey()
+
+	inst, err := NewWithdrawInstruction(1000, stake, recipient, authority).
+		SetLockupAuthorityAccount(custodian).
+		ValidateAndBuild()
+	require.NoError(t, err)
+
+	data, err := inst.Data()
+	require.NoError(t, err)
+	require.Equal(t, []byte{4, 0, 0, 0, 0xe8, 0x03, 0, 0, 0, 0, 0, 0}, data)
+
+	accounts := inst.Accounts()
+	require.Len(t, accounts, 6)
+	require.Equal(t, ag_solanago.SysVarClockPubkey, accounts[2].PublicKey)
+	require.Equal(t, ag_solanago.SysVarStakeHistoryPubkey, accounts[3].PublicKey)
+	require.True(t, accounts[5].IsSigner)
+
+	decoded, err := DecodeInstruction(accounts, data)
+	require.NoError(t, err)
+	withdraw, ok := decoded.Impl.(*Withdraw)
+	require.True(t, ok)
+	require.Equal(t, uint64(1000), *withdraw.Lamports)
+	require.Equal(t, custodian, withdraw.GetLockupAuthorityAccount().PublicKey)
+
+	// The optional lockup authority is omitted by default.
+	inst = NewDeactivateInstruction(stake, authority).Build()
+	require.Len(t, inst.Accounts(), 3)
+
+	epoch := uint64(7)
+	args := LockupArgs{Epoch: &epoch}
+	data, err = NewSetLockupInstruction(args, stake, authority).Build().Data()
+	require.NoError(t, err)
+	require.Equal(t, []byte{6, 0, 0, 0, 0, 1, 7, 0, 0, 0, 0, 0, 0, 0, 0}, data)
+}
@@ -0,0 +1,34 @@
+package stake
+
+import (
+	"github.com/gagliardetto/solana-go"
+)
+
+var (
+	DecodeAuthorize                = decode[*Authorize]()
+	DecodeAuthorizeChecked         = decode[*AuthorizeChecked]()
+	DecodeAuthorizeCheckedWithSeed = decode[*AuthorizeCheckedWithSeed]()
+	DecodeAuthorizeWithSeed        = decode[*AuthorizeWithSeed]()
+	DecodeDeactivate               = decode[*Deactivate]()
+	DecodeDeactivateDelinquent     = decode[*DeactivateDelinquent]()
+	DecodeDelegateStake            = decode[*DelegateStake]()
+	DecodeGetMinimumDelegation     = decode[*GetMinimumDelegation]()
+	DecodeInitialize               = decode[*Initialize]()
+	DecodeInitializeChecked        = decode[*InitializeChecked]()
+	DecodeMerge                    = decode[*Merge]()
+	DecodeMoveLamports             = decode[*MoveLamports]()
+	DecodeMoveStake                = decode[*MoveStake]()
+	DecodeRedelegate               = decode[*Redelegate]()
+	DecodeSetLockup                = decode[*SetLockup]()
+	DecodeSetLockupChecked         = decode[*SetLockupChecked]()
+	DecodeSplit                    = decode[*Split]()
+	DecodeWithdraw                 = decode[*Withdraw]()
+)
+
+func decode[T any]() func(*solana.Message, int) (T, error) {
+	return solana.DecodeInstructionType[*Instruction, T](
+		ProgramID,
+		InstructionImplDef,
+		DecodeInstruction,
+	)
+}
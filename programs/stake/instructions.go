@@ -0,0 +1,245 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Create and manage accounts representing stake and rewards for delegations to validators.
+
+package stake
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+
+	ag_spew "github.com/davecgh/go-spew/spew"
+	ag_binary "github.com/gagliardetto/binary"
+	ag_solanago "github.com/gagliardetto/solana-go"
+	ag_text "github.com/gagliardetto/solana-go/text"
+	ag_treeout "github.com/gagliardetto/treeout"
+)
+
+var ProgramID ag_solanago.PublicKey = ag_solanago.StakeProgramID
+
+func SetProgramID(pubkey ag_solanago.PublicKey) {
+	ProgramID = pubkey
+	ag_solanago.RegisterInstructionDecoder(ProgramID, registryDecodeInstruction)
+}
+
+const ProgramName = "Stake"
+
+func init() {
+	ag_solanago.RegisterInstructionDecoder(ProgramID, registryDecodeInstruction)
+}
+
+const (
+	// Initialize a stake with lockup and authorization information
+	Instruction_Initialize uint32 = iota
+
+	// Authorize a key to manage stake or withdrawal
+	Instruction_Authorize
+
+	// Delegate a stake to a particular vote account
+	Instruction_DelegateStake
+
+	// Split u64 tokens and stake off a stake account into another stake account
+	Instruction_Split
+
+	// Withdraw unstaked lamports from the stake account
+	Instruction_Withdraw
+
+	// Deactivates the stake in the account
+	Instruction_Deactivate
+
+	// Set stake lockup
+	Instruction_SetLockup
+
+	// Merge two stake accounts
+	Instruction_Merge
+
+	// Authorize a key to manage stake or withdrawal with a derived key
+	Instruction_AuthorizeWithSeed
+
+	// Initialize a stake with authorization information, requiring the withdraw authority to sign
+	Instruction_InitializeChecked
+
+	// Authorize a key to manage stake or withdrawal, requiring the new authority to sign
+	Instruction_AuthorizeChecked
+
+	// Authorize a key to manage stake or withdrawal with a derived key, requiring the new authority to sign
+	Instruction_AuthorizeCheckedWithSeed
+
+	// Set stake lockup, requiring the new lockup authority to sign
+	Instruction_SetLockupChecked
+
+	// Get the minimum stake delegation, in lamports
+	Instruction_GetMinimumDelegation
+
+	// Deactivate stake delegated to a vote account that has been delinquent for at least
+	// `MINIMUM_DELINQUENT_EPOCHS_FOR_DEACTIVATION` epochs
+	Instruction_DeactivateDelinquent
+
+	// Redelegate activated stake to another vote account (deprecated, disabled on all clusters)
+	Instruction_Redelegate
+
+	// Move stake between accounts with the same authorities and lockups
+	Instruction_MoveStake
+
+	// Move unstaked lamports between accounts with the same authorities and lockups
+	Instruction_MoveLamports
+)
+
+// InstructionIDToName returns the name of the instruction given its ID.
+func InstructionIDToName(id uint32) string {
+	switch id {
+	case Instruction_Initialize:
+		return "Initialize"
+	case Instruction_Authorize:
+		return "Authorize"
+	case Instruction_DelegateStake:
+		return "DelegateStake"
+	case Instruction_Split:
+		return "Split"
+	case Instruction_Withdraw:
+		return "Withdraw"
+	case Instruction_Deactivate:
+		return "Deactivate"
+	case Instruction_SetLockup:
+		return "SetLockup"
+	case Instruction_Merge:
+		return "Merge"
+	case Instruction_AuthorizeWithSeed:
+		return "AuthorizeWithSeed"
+	case Instruction_InitializeChecked:
+		return "InitializeChecked"
+	case Instruction_AuthorizeChecked:
+		return "AuthorizeChecked"
+	case Instruction_AuthorizeCheckedWithSeed:
+		return "AuthorizeCheckedWithSeed"
+	case Instruction_SetLockupChecked:
+		return "SetLockupChecked"
+	case Instruction_GetMinimumDelegation:
+		return "GetMinimumDelegation"
+	case Instruction_DeactivateDelinquent:
+		return "DeactivateDelinquent"
+	case Instruction_Redelegate:
+		return "Redelegate"
+	case Instruction_MoveStake:
+		return "MoveStake"
+	case Instruction_MoveLamports:
+		return "MoveLamports"
+	default:
+		return ""
+	}
+}
+
+type Instruction struct {
+	ag_binary.BaseVariant
+}
+
+func (inst *Instruction) EncodeToTree(parent ag_treeout.Branches) {
+	if enToTree, ok := inst.Impl.(ag_text.EncodableToTree); ok {
+		enToTree.EncodeToTree(parent)
+	} else {
+		parent.Child(ag_spew.Sdump(inst))
+	}
+}
+
+var InstructionImplDef = ag_binary.NewVariantDefinition(
+	ag_binary.Uint32TypeIDEncoding,
+	[]ag_binary.VariantType{
+		{Name: "Initialize", Type: (*Initialize)(nil)},
+		{Name: "Authorize", Type: (*Authorize)(nil)},
+		{Name: "DelegateStake", Type: (*DelegateStake)(nil)},
+		{Name: "Split", Type: (*Split)(nil)},
+		{Name: "Withdraw", Type: (*Withdraw)(nil)},
+		{Name: "Deactivate", Type: (*Deactivate)(nil)},
+		{Name: "SetLockup", Type: (*SetLockup)(nil)},
+		{Name: "Merge", Type: (*Merge)(nil)},
+		{Name: "AuthorizeWithSeed", Type: (*AuthorizeWithSeed)(nil)},
+		{Name: "InitializeChecked", Type: (*InitializeChecked)(nil)},
+		{Name: "AuthorizeChecked", Type: (*AuthorizeChecked)(nil)},
+		{Name: "AuthorizeCheckedWithSeed", Type: (*AuthorizeCheckedWithSeed)(nil)},
+		{Name: "SetLockupChecked", Type: (*SetLockupChecked)(nil)},
+		{Name: "GetMinimumDelegation", Type: (*GetMinimumDelegation)(nil)},
+		{Name: "DeactivateDelinquent", Type: (*DeactivateDelinquent)(nil)},
+		{Name: "Redelegate", Type: (*Redelegate)(nil)},
+		{Name: "MoveStake", Type: (*MoveStake)(nil)},
+		{Name: "MoveLamports", Type: (*MoveLamports)(nil)},
+	},
+)
+
+func (inst *Instruction) ProgramID() ag_solanago.PublicKey {
+	return ProgramID
+}
+
+func (inst *Instruction) Accounts() (out []*ag_solanago.AccountMeta) {
+	return inst.Impl.(ag_solanago.AccountsGettable).GetAccounts()
+}
+
+func (inst *Instruction) Data() ([]byte, error) {
+	buf := new(bytes.Buffer)
+	if err := ag_binary.NewBinEncoder(buf).Encode(inst); err != nil {
+		return nil, fmt.Errorf("unable to encode instruction: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func (a *Instruction) AssertEquivalent(in ag_solanago.Instruction) error {
+	b, ok := in.(*Instruction)
+	if !ok {
+		return fmt.Errorf("expected %T, but got %T", a, in)
+	}
+	equiv, ok := a.BaseVariant.Impl.(ag_solanago.EquivalenceAssertable[interface{}])
+	if !ok {
+		return ag_solanago.CheckInstructionEquivalence(a, b)
+	}
+	return equiv.AssertEquivalent(b.BaseVariant.Impl)
+}
+
+func (inst *Instruction) TextEncode(encoder *ag_text.Encoder, option *ag_text.Option) error {
+	return encoder.Encode(inst.Impl, option)
+}
+
+func (inst *Instruction) UnmarshalWithDecoder(decoder *ag_binary.Decoder) error {
+	return inst.BaseVariant.UnmarshalBinaryVariant(decoder, InstructionImplDef)
+}
+
+func (inst Instruction) MarshalWithEncoder(encoder *ag_binary.Encoder) error {
+	err := encoder.WriteUint32(inst.TypeID.Uint32(), binary.LittleEndian)
+	if err != nil {
+		return fmt.Errorf("unable to write variant type: %w", err)
+	}
+	return encoder.Encode(inst.Impl)
+}
+
+func registryDecodeInstruction(accounts []*ag_solanago.AccountMeta, data []byte) (interface{}, error) {
+	inst, err := DecodeInstruction(accounts, data)
+	if err != nil {
+		return nil, err
+	}
+	return inst, nil
+}
+
+func DecodeInstruction(accounts []*ag_solanago.AccountMeta, data []byte) (*Instruction, error) {
+	inst := new(Instruction)
+	if err := ag_binary.NewBinDecoder(data).Decode(inst); err != nil {
+		return nil, fmt.Errorf("unable to decode instruction: %w", err)
+	}
+	if v, ok := inst.Impl.(ag_solanago.AccountsSettable); ok {
+		err := v.SetAccounts(accounts)
+		if err != nil {
+			return nil, fmt.Errorf("unable to set accounts for instruction: %w", err)
+		}
+	}
+	return inst, nil
+}
@@ -0,0 +1,197 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stake
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	ag_binary "github.com/gagliardetto/binary"
+	ag_solanago "github.com/gagliardetto/solana-go"
+)
+
+// The stake config account, used by DelegateStake (and Redelegate);
+// the program no longer reads it, but the account is still required.
+var StakeConfigPubkey = ag_solanago.MustPublicKeyFromBase58("StakeConfig11111111111111111111111111111111")
+
+// Size in bytes of a StakeStateV2 account.
+const StakeStateV2Size = 200
+
+// StakeAuthorize selects which authority of a stake account is being changed.
+type StakeAuthorize uint32
+
+const (
+	StakeAuthorizeStaker StakeAuthorize = iota
+	StakeAuthorizeWithdrawer
+)
+
+func (a StakeAuthorize) String() string {
+	switch a {
+	case StakeAuthorizeStaker:
+		return "Staker"
+	case StakeAuthorizeWithdrawer:
+		return "Withdrawer"
+	default:
+		return fmt.Sprintf("Unknown(%d)", uint32(a))
+	}
+}
+
+// Authorized holds the authorities of a stake account.
+type Authorized struct {
+	Staker     ag_solanago.PublicKey
+	Withdrawer ag_solanago.PublicKey
+}
+
+// Lockup prevents the withdrawal of lamports until the unix timestamp
+// or epoch are reached, unless the custodian signs the transaction.
+type Lockup struct {
+	// UnixTimestamp at which this stake will allow withdrawal, unless the
+	// transaction is signed by the custodian.
+	UnixTimestamp int64
+
+	// Epoch height at which this stake will allow withdrawal, unless the
+	// transaction is signed by the custodian.
+	Epoch uint64
+
+	// Custodian signature on a transaction exempts the operation from
+	// lockup constraints.
+	Custodian ag_solanago.PublicKey
+}
+
+// IsInForce returns true if the lockup is still active at the provided
+// unix timestamp and epoch, and the custodian is not among the signers.
+func (lockup Lockup) IsInForce(unixTimestamp int64, epoch uint64, custodian *ag_solanago.PublicKey) bool {
+	if custodian != nil && custodian.Equals(lockup.Custodian) {
+		return false
+	}
+	return lockup.UnixTimestamp > unixTimestamp || lockup.Epoch > epoch
+}
+
+// LockupArgs are the parameters of the SetLockup instruction;
+// only the set fields are changed.
+type LockupArgs struct {
+	UnixTimestamp *int64
+	Epoch         *uint64
+	Custodian     *ag_solanago.PublicKey
+}
+
+func (obj LockupArgs) MarshalWithEncoder(encoder *ag_binary.Encoder) (err error) {
+	if err = writeOptionInt64(encoder, obj.UnixTimestamp); err != nil {
+		return err
+	}
+	if err = writeOptionUint64(encoder, obj.Epoch); err != nil {
+		return err
+	}
+	return writeOptionPublicKey(encoder, obj.Custodian)
+}
+
+func (obj *LockupArgs) UnmarshalWithDecoder(decoder *ag_binary.Decoder) (err error) {
+	if obj.UnixTimestamp, err = readOptionInt64(decoder); err != nil {
+		return err
+	}
+	if obj.Epoch, err = readOptionUint64(decoder); err != nil {
+		return err
+	}
+	obj.Custodian, err = readOptionPublicKey(decoder)
+	return err
+}
+
+// LockupCheckedArgs are the parameters of the SetLockupChecked instruction;
+// the new custodian is passed as a (signing) account instead.
+type LockupCheckedArgs struct {
+	UnixTimestamp *int64
+	Epoch         *uint64
+}
+
+func (obj LockupCheckedArgs) MarshalWithEncoder(encoder *ag_binary.Encoder) (err error) {
+	if err = writeOptionInt64(encoder, obj.UnixTimestamp); err != nil {
+		return err
+	}
+	return writeOptionUint64(encoder, obj.Epoch)
+}
+
+func (obj *LockupCheckedArgs) UnmarshalWithDecoder(decoder *ag_binary.Decoder) (err error) {
+	if obj.UnixTimestamp, err = readOptionInt64(decoder); err != nil {
+		return err
+	}
+	obj.Epoch, err = readOptionUint64(decoder)
+	return err
+}
+
+func writeOptionInt64(encoder *ag_binary.Encoder, v *int64) error {
+	if v == nil {
+		return encoder.WriteOption(false)
+	}
+	if err := encoder.WriteOption(true); err != nil {
+		return err
+	}
+	return encoder.WriteInt64(*v, binary.LittleEndian)
+}
+
+func writeOptionUint64(encoder *ag_binary.Encoder, v *uint64) error {
+	if v == nil {
+		return encoder.WriteOption(false)
+	}
+	if err := encoder.WriteOption(true); err != nil {
+		return err
+	}
+	return encoder.WriteUint64(*v, binary.LittleEndian)
+}
+
+func writeOptionPublicKey(encoder *ag_binary.Encoder, v *ag_solanago.PublicKey) error {
+	if v == nil {
+		return encoder.WriteOption(false)
+	}
+	if err := encoder.WriteOption(true); err != nil {
+		return err
+	}
+	return encoder.WriteBytes(v[:], false)
+}
+
+func readOptionInt64(decoder *ag_binary.Decoder) (*int64, error) {
+	ok, err := decoder.ReadOption()
+	if err != nil || !ok {
+		return nil, err
+	}
+	v, err := decoder.ReadInt64(binary.LittleEndian)
+	if err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+func readOptionUint64(decoder *ag_binary.Decoder) (*uint64, error) {
+	ok, err := decoder.ReadOption()
+	if err != nil || !ok {
+		return nil, err
+	}
+	v, err := decoder.ReadUint64(binary.LittleEndian)
+	if err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+func readOptionPublicKey(decoder *ag_binary.Decoder) (*ag_solanago.PublicKey, error) {
+	ok, err := decoder.ReadOption()
+	if err != nil || !ok {
+		return nil, err
+	}
+	v, err := decoder.ReadNBytes(32)
+	if err != nil {
+		return nil, err
+	}
+	return ag_solanago.PublicKeyFromBytes(v).ToPointer(), nil
+}
@@ -16,11 +16,31 @@ package system
 
 import (
 	"encoding/binary"
+	"fmt"
 
 	bin "github.com/gagliardetto/binary"
 	"github.com/gagliardetto/solana-go"
 )
 
+// NonceAccountSize is the size of a nonce account's data.
+const NonceAccountSize = 80
+
+// Versions of the nonce account state.
+const (
+	// The nonce is a recent blockhash.
+	NonceVersionLegacy uint32 = iota
+
+	// The nonce is derived from a recent blockhash, so that it cannot
+	// collide with one.
+	NonceVersionCurrent
+)
+
+// States of the nonce account.
+const (
+	NonceStateUninitialized uint32 = iota
+	NonceStateInitialized
+)
+
 type NonceAccount struct {
 	Version          uint32
 	State            uint32
@@ -33,6 +53,26 @@ type FeeCalculator struct {
 	LamportsPerSignature uint64
 }
 
+// IsInitialized reports whether the nonce account holds a nonce.
+func (obj NonceAccount) IsInitialized() bool {
+	return obj.State == NonceStateInitialized
+}
+
+// IsLegacy reports whether the nonce account has the legacy version,
+// which UpgradeNonceAccount upgrades to the current one.
+func (obj NonceAccount) IsLegacy() bool {
+	return obj.Version == NonceVersionLegacy
+}
+
+// DecodeNonceAccount decodes the data of a nonce account.
+func DecodeNonceAccount(data []byte) (*NonceAccount, error) {
+	acc := new(NonceAccount)
+	if err := acc.UnmarshalWithDecoder(bin.NewBinDecoder(data)); err != nil {
+		return nil, fmt.Errorf("unable to decode nonce account: %w", err)
+	}
+	return acc, nil
+}
+
 func (obj NonceAccount) MarshalWithEncoder(encoder *bin.Encoder) (err error) {
 	err = encoder.WriteUint32(obj.Version, binary.LittleEndian)
 	if err != nil {
@@ -59,12 +99,26 @@ func (obj *NonceAccount) UnmarshalWithDecoder(decoder *bin.Decoder) (err error)
 		if err != nil {
 			return err
 		}
+		if obj.Version > NonceVersionCurrent {
+			return fmt.Errorf("unknown nonce version: %d", obj.Version)
+		}
 	}
 	{
 		obj.State, err = decoder.ReadUint32(binary.LittleEndian)
 		if err != nil {
 			return err
 		}
+		switch obj.State {
+		case NonceStateUninitialized:
+			// An uninitialized nonce has no data.
+			obj.AuthorizedPubkey = solana.PublicKey{}
+			obj.Nonce = solana.PublicKey{}
+			obj.FeeCalculator = FeeCalculator{}
+			return nil
+		case NonceStateInitialized:
+		default:
+			return fmt.Errorf("unknown nonce state: %d", obj.State)
+		}
 	}
 	{
 		buf, err := decoder.ReadNBytes(32)
@@ -42,3 +42,44 @@ func TestDecode(t *testing.T) {
 	assert.Equal(t, solana.MustPublicKeyFromBase58("8ksS6xXd7vzNrpZfBTf9gJ87Bma5AjnQ9baEcT7xH5QE"), acc.Nonce)
 	assert.Equal(t, uint64(5000), acc.FeeCalculator.LamportsPerSignature)
 }
+
+func TestDecodeNonceAccountVersions(t *testing.T) {
+	{
+		acc, err := DecodeNonceAccount(make([]byte, NonceAccountSize))
+		assert.NoError(t, err)
+		assert.True(t, acc.IsLegacy())
+		assert.False(t, acc.IsInitialized())
+	}
+	{
+		authority := solana.MustPublicKeyFromBase58("5omQJtDUHA3gMFdHEQg1zZSvcBUVzey5WaKWYRmqF1Vj")
+		nonce := solana.MustPublicKeyFromBase58("8ksS6xXd7vzNrpZfBTf9gJ87Bma5AjnQ9baEcT7xH5QE")
+		data, err := bin.MarshalBin(NonceAccount{
+			Version:          NonceVersionCurrent,
+			State:            NonceStateInitialized,
+			AuthorizedPubkey: authority,
+			Nonce:            nonce,
+			FeeCalculator:    FeeCalculator{LamportsPerSignature: 5000},
+		})
+		assert.NoError(t, err)
+		assert.Len(t, data, NonceAccountSize)
+
+		acc, err := DecodeNonceAccount(data)
+		assert.NoError(t, err)
+		assert.False(t, acc.IsLegacy())
+		assert.True(t, acc.IsInitialized())
+		assert.Equal(t, authority, acc.AuthorizedPubkey)
+		assert.Equal(t, nonce, acc.Nonce)
+	}
+	{
+		data := make([]byte, NonceAccountSize)
+		data[0] = 2
+		_, err := DecodeNonceAccount(data)
+		assert.Error(t, err)
+	}
+	{
+		data := make([]byte, NonceAccountSize)
+		data[4] = 2
+		_, err := DecodeNonceAccount(data)
+		assert.Error(t, err)
+	}
+}
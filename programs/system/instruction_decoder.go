@@ -16,6 +16,7 @@ var (
 	DecodeInitializeNonceAccount = decode[*InitializeNonceAccount]()
 	DecodeTransfer               = decode[*Transfer]()
 	DecodeTransferWithSeed       = decode[*TransferWithSeed]()
+	DecodeUpgradeNonceAccount    = decode[*UpgradeNonceAccount]()
 	DecodeWithdrawNonceAccount   = decode[*WithdrawNonceAccount]()
 )
 
@@ -78,6 +78,10 @@ const (
 
 	// Transfer lamports from a derived address
 	Instruction_TransferWithSeed
+
+	// One-time idempotent upgrade of legacy nonce versions in order to bump
+	// them out of chain blockhash domain
+	Instruction_UpgradeNonceAccount
 )
 
 // InstructionIDToName returns the name of the instruction given its ID.
@@ -107,6 +111,8 @@ func InstructionIDToName(id uint32) string {
 		return "AssignWithSeed"
 	case Instruction_TransferWithSeed:
 		return "TransferWithSeed"
+	case Instruction_UpgradeNonceAccount:
+		return "UpgradeNonceAccount"
 	default:
 		return ""
 	}
@@ -163,6 +169,9 @@ var InstructionImplDef = ag_binary.NewVariantDefinition(
 		{
 			"TransferWithSeed", (*TransferWithSeed)(nil),
 		},
+		{
+			"UpgradeNonceAccount", (*UpgradeNonceAccount)(nil),
+		},
 	},
 )
 
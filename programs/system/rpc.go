@@ -0,0 +1,95 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package system
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+)
+
+// FetchNonceAccount fetches and decodes the nonce account, checking that it
+// is owned by the system program and that it is initialized.
+func FetchNonceAccount(
+	ctx context.Context,
+	rpcCli *rpc.Client,
+	nonceAccount solana.PublicKey,
+	commitment rpc.CommitmentType,
+) (*NonceAccount, error) {
+	resp, err := rpcCli.GetAccountInfoWithOpts(
+		ctx,
+		nonceAccount,
+		&rpc.GetAccountInfoOpts{
+			Commitment: commitment,
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get nonce account %s: %w", nonceAccount, err)
+	}
+	if !resp.Value.Owner.Equals(ProgramID) {
+		return nil, fmt.Errorf("nonce account %s is owned by %s, not by the system program", nonceAccount, resp.Value.Owner)
+	}
+	data := resp.Value.Data.GetBinary()
+	if len(data) != NonceAccountSize {
+		return nil, fmt.Errorf("nonce account %s has %d bytes of data, expected %d", nonceAccount, len(data), NonceAccountSize)
+	}
+	acc, err := DecodeNonceAccount(data)
+	if err != nil {
+		return nil, err
+	}
+	if !acc.IsInitialized() {
+		return nil, fmt.Errorf("nonce account %s is not initialized", nonceAccount)
+	}
+	return acc, nil
+}
+
+// FetchNonce returns the current nonce value of the nonce account, checking
+// that the account is an initialized nonce account whose authority is
+// nonceAuthority.
+func FetchNonce(
+	ctx context.Context,
+	rpcCli *rpc.Client,
+	nonceAccount solana.PublicKey,
+	nonceAuthority solana.PublicKey,
+	commitment rpc.CommitmentType,
+) (solana.Hash, error) {
+	acc, err := FetchNonceAccount(ctx, rpcCli, nonceAccount, commitment)
+	if err != nil {
+		return solana.Hash{}, err
+	}
+	if !acc.AuthorizedPubkey.Equals(nonceAuthority) {
+		return solana.Hash{}, fmt.Errorf("nonce account %s has authority %s, not %s", nonceAccount, acc.AuthorizedPubkey, nonceAuthority)
+	}
+	return solana.Hash(acc.Nonce), nil
+}
+
+// FetchDurableNonce fetches the current nonce value of the nonce account and
+// returns the TransactionOption that makes a transaction use it; see
+// solana.TransactionDurableNonce.
+func FetchDurableNonce(
+	ctx context.Context,
+	rpcCli *rpc.Client,
+	nonceAccount solana.PublicKey,
+	nonceAuthority solana.PublicKey,
+	commitment rpc.CommitmentType,
+) (solana.TransactionOption, error) {
+	nonce, err := FetchNonce(ctx, rpcCli, nonceAccount, nonceAuthority, commitment)
+	if err != nil {
+		return nil, err
+	}
+	return solana.TransactionDurableNonce(nonceAccount, nonceAuthority, nonce), nil
+}
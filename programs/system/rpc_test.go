@@ -0,0 +1,79 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package system
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	bin "github.com/gagliardetto/binary"
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFetchNonce(t *testing.T) {
+	nonceAccount := solana.NewWallet().PublicKey()
+	authority := solana.NewWallet().PublicKey()
+	nonce := solana.NewWallet().PublicKey()
+
+	data, err := bin.MarshalBin(NonceAccount{
+		Version:          NonceVersionCurrent,
+		State:            NonceStateInitialized,
+		AuthorizedPubkey: authority,
+		Nonce:            nonce,
+	})
+	require.NoError(t, err)
+
+	var requestBody string
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		body, _ := io.ReadAll(req.Body)
+		requestBody = string(body)
+		fmt.Fprintf(rw,
+			`{"jsonrpc":"2.0","id":0,"result":{"context":{"slot":1},"value":{"lamports":1447680,"owner":%q,"data":[%q,"base64"],"executable":false,"rentEpoch":0}}}`,
+			ProgramID, base64.StdEncoding.EncodeToString(data),
+		)
+	}))
+	defer server.Close()
+	rpcCli := rpc.New(server.URL)
+
+	got, err := FetchNonce(context.Background(), rpcCli, nonceAccount, authority, rpc.CommitmentConfirmed)
+	require.NoError(t, err)
+	require.Equal(t, solana.Hash(nonce), got)
+	require.Contains(t, requestBody, `"commitment":"confirmed"`)
+
+	_, err = FetchNonce(context.Background(), rpcCli, nonceAccount, solana.NewWallet().PublicKey(), rpc.CommitmentFinalized)
+	require.Error(t, err)
+
+	opt, err := FetchDurableNonce(context.Background(), rpcCli, nonceAccount, authority, rpc.CommitmentConfirmed)
+	require.NoError(t, err)
+	tx, err := solana.NewTransaction(
+		[]solana.Instruction{NewTransferInstruction(1, authority, solana.NewWallet().PublicKey()).Build()},
+		solana.Hash{},
+		opt,
+	)
+	require.NoError(t, err)
+	require.Equal(t, solana.Hash(nonce), tx.Message.RecentBlockhash)
+
+	advance, err := DecodeAdvanceNonceAccount(&tx.Message, 0)
+	require.NoError(t, err)
+	require.Equal(t, nonceAccount, advance.GetNonceAccount().PublicKey)
+	require.Equal(t, authority, advance.GetNonceAuthorityAccount().PublicKey)
+}
@@ -0,0 +1,368 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tokenmetadata
+
+import (
+	"errors"
+	"fmt"
+
+	ag_binary "github.com/gagliardetto/binary"
+	ag_solanago "github.com/gagliardetto/solana-go"
+	ag_format "github.com/gagliardetto/solana-go/text/format"
+	ag_treeout "github.com/gagliardetto/treeout"
+)
+
+// Create the metadata and, for non-fungible assets, the master edition of a mint;
+// the mint is created if it doesn't exist.
+//
+// Optional accounts that are not set are replaced by the program ID.
+type Create struct {
+	// Metadata of the asset.
+	AssetData *AssetData
+
+	// Decimals of the mint, if it is created (optional).
+	Decimals *uint8
+
+	// Print supply of the master edition (optional).
+	PrintSupply *PrintSupply
+
+	// [0] = [WRITE] MetadataAccount
+	// ··········· Metadata account; seeds ['metadata', program id, mint]
+	//
+	// [1] = [WRITE] MasterEditionAccount
+	// ··········· Master edition account; seeds ['metadata', program id, mint, 'edition'] (optional)
+	//
+	// [2] = [WRITE, SIGNER?] MintAccount
+	// ··········· Mint of the asset; must sign if it is created
+	//
+	// [3] = [SIGNER] AuthorityAccount
+	// ··········· Mint authority
+	//
+	// [4] = [WRITE, SIGNER] PayerAccount
+	// ··········· Payer
+	//
+	// [5] = [SIGNER?] UpdateAuthorityAccount
+	// ··········· Update authority of the metadata
+	//
+	// [6] = [] SystemProgram
+	// ··········· System program
+	//
+	// [7] = [] SysVarInstructionsPubkey
+	// ··········· Instructions sysvar
+	//
+	// [8] = [] SplTokenProgram
+	// ··········· Token program
+	ag_solanago.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+// NewCreateInstructionBuilder creates a new `Create` instruction builder.
+func NewCreateInstructionBuilder() *Create {
+	nd := &Create{
+		AccountMetaSlice: make(ag_solanago.AccountMetaSlice, 9),
+	}
+	nd.AccountMetaSlice[1] = ag_solanago.Meta(ProgramID)
+	nd.AccountMetaSlice[6] = ag_solanago.Meta(ag_solanago.SystemProgramID)
+	nd.AccountMetaSlice[7] = ag_solanago.Meta(ag_solanago.SysVarInstructionsPubkey)
+	nd.AccountMetaSlice[8] = ag_solanago.Meta(ag_solanago.TokenProgramID)
+	return nd
+}
+
+// Metadata of the asset.
+func (inst *Create) SetAssetData(assetData AssetData) *Create {
+	inst.AssetData = &assetData
+	return inst
+}
+
+// Decimals of the mint, if it is created.
+func (inst *Create) SetDecimals(decimals uint8) *Create {
+	inst.Decimals = &decimals
+	return inst
+}
+
+// Print supply of the master edition.
+func (inst *Create) SetPrintSupply(printSupply PrintSupply) *Create {
+	inst.PrintSupply = &printSupply
+	return inst
+}
+
+// Metadata account; seeds ['metadata', program id, mint].
+func (inst *Create) SetMetadataAccount(metadataAccount ag_solanago.PublicKey) *Create {
+	inst.AccountMetaSlice[0] = ag_solanago.Meta(metadataAccount).WRITE()
+	return inst
+}
+
+func (inst *Create) GetMetadataAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[0]
+}
+
+// Master edition account; seeds ['metadata', program id, mint, 'edition'].
+func (inst *Create) SetMasterEditionAccount(masterEditionAccount ag_solanago.PublicKey) *Create {
+	inst.AccountMetaSlice[1] = ag_solanago.Meta(masterEditionAccount).WRITE()
+	return inst
+}
+
+func (inst *Create) GetMasterEditionAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[1]
+}
+
+// Mint of the asset; must sign if it is created.
+func (inst *Create) SetMintAccount(mintAccount ag_solanago.PublicKey, isSigner bool) *Create {
+	inst.AccountMetaSlice[2] = ag_solanago.Meta(mintAccount).WRITE()
+	inst.AccountMetaSlice[2].IsSigner = isSigner
+	return inst
+}
+
+func (inst *Create) GetMintAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[2]
+}
+
+// Mint authority.
+func (inst *Create) SetAuthorityAccount(authorityAccount ag_solanago.PublicKey) *Create {
+	inst.AccountMetaSlice[3] = ag_solanago.Meta(authorityAccount).SIGNER()
+	return inst
+}
+
+func (inst *Create) GetAuthorityAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[3]
+}
+
+// Payer.
+func (inst *Create) SetPayerAccount(payerAccount ag_solanago.PublicKey) *Create {
+	inst.AccountMetaSlice[4] = ag_solanago.Meta(payerAccount).WRITE().SIGNER()
+	return inst
+}
+
+func (inst *Create) GetPayerAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[4]
+}
+
+// Update authority of the metadata.
+func (inst *Create) SetUpdateAuthorityAccount(updateAuthorityAccount ag_solanago.PublicKey, isSigner bool) *Create {
+	inst.AccountMetaSlice[5] = ag_solanago.Meta(updateAuthorityAccount)
+	inst.AccountMetaSlice[5].IsSigner = isSigner
+	return inst
+}
+
+func (inst *Create) GetUpdateAuthorityAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[5]
+}
+
+// System program.
+func (inst *Create) SetSystemProgram(systemProgram ag_solanago.PublicKey) *Create {
+	inst.AccountMetaSlice[6] = ag_solanago.Meta(systemProgram)
+	return inst
+}
+
+func (inst *Create) GetSystemProgram() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[6]
+}
+
+// Instructions sysvar.
+func (inst *Create) SetSysVarInstructionsPubkey(sysVarInstructionsPubkey ag_solanago.PublicKey) *Create {
+	inst.AccountMetaSlice[7] = ag_solanago.Meta(sysVarInstructionsPubkey)
+	return inst
+}
+
+func (inst *Create) GetSysVarInstructionsPubkey() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[7]
+}
+
+// Token program.
+func (inst *Create) SetSplTokenProgram(splTokenProgram ag_solanago.PublicKey) *Create {
+	inst.AccountMetaSlice[8] = ag_solanago.Meta(splTokenProgram)
+	return inst
+}
+
+func (inst *Create) GetSplTokenProgram() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[8]
+}
+
+func (inst Create) Build() *Instruction {
+	return &Instruction{BaseVariant: ag_binary.BaseVariant{
+		Impl:   inst,
+		TypeID: ag_binary.TypeIDFromUint8(Instruction_Create),
+	}}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (inst Create) ValidateAndBuild() (*Instruction, error) {
+	if err := inst.Validate(); err != nil {
+		return nil, err
+	}
+	return inst.Build(), nil
+}
+
+func (inst *Create) Validate() error {
+	// Check whether all (required) parameters are set:
+	{
+		if inst.AssetData == nil {
+			return errors.New("AssetData parameter is not set")
+		}
+	}
+
+	// Check whether all (required) accounts are set:
+	for accIndex, acc := range inst.AccountMetaSlice {
+		if acc == nil {
+			return fmt.Errorf("ins.AccountMetaSlice[%v] is not set", accIndex)
+		}
+	}
+	return nil
+}
+
+func (inst *Create) EncodeToTree(parent ag_treeout.Branches) {
+	parent.Child(ag_format.Program(ProgramName, ProgramID)).
+		//
+		ParentFunc(func(programBranch ag_treeout.Branches) {
+			programBranch.Child(ag_format.Instruction("Create")).
+				//
+				ParentFunc(func(instructionBranch ag_treeout.Branches) {
+
+					// Parameters of the instruction:
+					instructionBranch.Child("Params").ParentFunc(func(paramsBranch ag_treeout.Branches) {
+						paramsBranch.Child(ag_format.Param("       AssetData", *inst.AssetData))
+						paramsBranch.Child(ag_format.Param("  Decimals (OPT)", inst.Decimals))
+						paramsBranch.Child(ag_format.Param("PrintSupply (OPT)", inst.PrintSupply))
+					})
+
+					// Accounts of the instruction:
+					instructionBranch.Child("Accounts").ParentFunc(func(accountsBranch ag_treeout.Branches) {
+						accountsBranch.Child(ag_format.Meta("          Metadata", inst.AccountMetaSlice.Get(0)))
+						accountsBranch.Child(ag_format.Meta("     MasterEdition", inst.AccountMetaSlice.Get(1)))
+						accountsBranch.Child(ag_format.Meta("              Mint", inst.AccountMetaSlice.Get(2)))
+						accountsBranch.Child(ag_format.Meta("         Authority", inst.AccountMetaSlice.Get(3)))
+						accountsBranch.Child(ag_format.Meta("             Payer", inst.AccountMetaSlice.Get(4)))
+						accountsBranch.Child(ag_format.Meta("   UpdateAuthority", inst.AccountMetaSlice.Get(5)))
+						accountsBranch.Child(ag_format.Meta("     SystemProgram", inst.AccountMetaSlice.Get(6)))
+						accountsBranch.Child(ag_format.Meta("SysVarInstructions", inst.AccountMetaSlice.Get(7)))
+						accountsBranch.Child(ag_format.Meta("   SplTokenProgram", inst.AccountMetaSlice.Get(8)))
+					})
+				})
+		})
+}
+
+func (obj Create) MarshalWithEncoder(encoder *ag_binary.Encoder) (err error) {
+	// Serialize the version of the arguments (V1):
+	err = encoder.WriteUint8(0)
+	if err != nil {
+		return err
+	}
+	// Serialize `AssetData` param:
+	err = (*obj.AssetData).MarshalWithEncoder(encoder)
+	if err != nil {
+		return err
+	}
+	// Serialize `Decimals` param (optional):
+	{
+		err = encoder.WriteOption(obj.Decimals != nil)
+		if err != nil {
+			return err
+		}
+		if obj.Decimals != nil {
+			err = encoder.WriteUint8(*obj.Decimals)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	// Serialize `PrintSupply` param (optional):
+	{
+		err = encoder.WriteOption(obj.PrintSupply != nil)
+		if err != nil {
+			return err
+		}
+		if obj.PrintSupply != nil {
+			err = (*obj.PrintSupply).MarshalWithEncoder(encoder)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+func (obj *Create) UnmarshalWithDecoder(decoder *ag_binary.Decoder) (err error) {
+	// Deserialize the version of the arguments:
+	{
+		version, err := decoder.ReadUint8()
+		if err != nil {
+			return err
+		}
+		if version != 0 {
+			return fmt.Errorf("unsupported Create arguments version: %d", version)
+		}
+	}
+	// Deserialize `AssetData`:
+	{
+		var v AssetData
+		err := v.UnmarshalWithDecoder(decoder)
+		if err != nil {
+			return err
+		}
+		obj.AssetData = &v
+	}
+	// Deserialize `Decimals` (optional):
+	{
+		ok, err := decoder.ReadOption()
+		if err != nil {
+			return err
+		}
+		if ok {
+			v, err := decoder.ReadUint8()
+			if err != nil {
+				return err
+			}
+			obj.Decimals = &v
+		}
+	}
+	// Deserialize `PrintSupply` (optional):
+	{
+		ok, err := decoder.ReadOption()
+		if err != nil {
+			return err
+		}
+		if ok {
+			var v PrintSupply
+			err := v.UnmarshalWithDecoder(decoder)
+			if err != nil {
+				return err
+			}
+			obj.PrintSupply = &v
+		}
+	}
+	return nil
+}
+
+// NewCreateInstruction declares a new Create instruction with the provided parameters and accounts.
+func NewCreateInstruction(
+	// Parameters:
+	assetData AssetData,
+	// Accounts:
+	metadataAccount ag_solanago.PublicKey,
+	mintAccount ag_solanago.PublicKey,
+	mintIsSigner bool,
+	authorityAccount ag_solanago.PublicKey,
+	payerAccount ag_solanago.PublicKey,
+	updateAuthorityAccount ag_solanago.PublicKey,
+	updateAuthorityIsSigner bool,
+) *Create {
+	return NewCreateInstructionBuilder().
+		SetAssetData(assetData).
+		SetMetadataAccount(metadataAccount).
+		SetMintAccount(mintAccount, mintIsSigner).
+		SetAuthorityAccount(authorityAccount).
+		SetPayerAccount(payerAccount).
+		SetUpdateAuthorityAccount(updateAuthorityAccount, updateAuthorityIsSigner)
+}
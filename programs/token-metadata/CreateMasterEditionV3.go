@@ -0,0 +1,275 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tokenmetadata
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	ag_binary "github.com/gagliardetto/binary"
+	ag_solanago "github.com/gagliardetto/solana-go"
+	ag_format "github.com/gagliardetto/solana-go/text/format"
+	ag_treeout "github.com/gagliardetto/treeout"
+)
+
+// Create the master edition of a non-fungible asset, taking over its mint and freeze authorities.
+type CreateMasterEditionV3 struct {
+	// Maximum number of prints; nil for unlimited prints (optional).
+	MaxSupply *uint64
+
+	// [0] = [WRITE] EditionAccount
+	// ··········· Master edition account; seeds ['metadata', program id, mint, 'edition']
+	//
+	// [1] = [WRITE] MintAccount
+	// ··········· Mint of the asset
+	//
+	// [2] = [SIGNER] UpdateAuthorityAccount
+	// ··········· Update authority of the metadata
+	//
+	// [3] = [SIGNER] MintAuthorityAccount
+	// ··········· Mint authority
+	//
+	// [4] = [WRITE, SIGNER] PayerAccount
+	// ··········· Payer of the master edition account
+	//
+	// [5] = [WRITE] MetadataAccount
+	// ··········· Metadata account
+	//
+	// [6] = [] TokenProgram
+	// ··········· Token program
+	//
+	// [7] = [] SystemProgram
+	// ··········· System program
+	//
+	// [8] = [] SysVarRentPubkey
+	// ··········· Rent sysvar
+	ag_solanago.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+// NewCreateMasterEditionV3InstructionBuilder creates a new `CreateMasterEditionV3` instruction builder.
+func NewCreateMasterEditionV3InstructionBuilder() *CreateMasterEditionV3 {
+	nd := &CreateMasterEditionV3{
+		AccountMetaSlice: make(ag_solanago.AccountMetaSlice, 9),
+	}
+	nd.AccountMetaSlice[6] = ag_solanago.Meta(ag_solanago.TokenProgramID)
+	nd.AccountMetaSlice[7] = ag_solanago.Meta(ag_solanago.SystemProgramID)
+	nd.AccountMetaSlice[8] = ag_solanago.Meta(ag_solanago.SysVarRentPubkey)
+	return nd
+}
+
+// Maximum number of prints; nil for unlimited prints.
+func (inst *CreateMasterEditionV3) SetMaxSupply(maxSupply uint64) *CreateMasterEditionV3 {
+	inst.MaxSupply = &maxSupply
+	return inst
+}
+
+// Master edition account; seeds ['metadata', program id, mint, 'edition'].
+func (inst *CreateMasterEditionV3) SetEditionAccount(editionAccount ag_solanago.PublicKey) *CreateMasterEditionV3 {
+	inst.AccountMetaSlice[0] = ag_solanago.Meta(editionAccount).WRITE()
+	return inst
+}
+
+func (inst *CreateMasterEditionV3) GetEditionAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[0]
+}
+
+// Mint of the asset.
+func (inst *CreateMasterEditionV3) SetMintAccount(mintAccount ag_solanago.PublicKey) *CreateMasterEditionV3 {
+	inst.AccountMetaSlice[1] = ag_solanago.Meta(mintAccount).WRITE()
+	return inst
+}
+
+func (inst *CreateMasterEditionV3) GetMintAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[1]
+}
+
+// Update authority of the metadata.
+func (inst *CreateMasterEditionV3) SetUpdateAuthorityAccount(updateAuthorityAccount ag_solanago.PublicKey) *CreateMasterEditionV3 {
+	inst.AccountMetaSlice[2] = ag_solanago.Meta(updateAuthorityAccount).SIGNER()
+	return inst
+}
+
+func (inst *CreateMasterEditionV3) GetUpdateAuthorityAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[2]
+}
+
+// Mint authority.
+func (inst *CreateMasterEditionV3) SetMintAuthorityAccount(mintAuthorityAccount ag_solanago.PublicKey) *CreateMasterEditionV3 {
+	inst.AccountMetaSlice[3] = ag_solanago.Meta(mintAuthorityAccount).SIGNER()
+	return inst
+}
+
+func (inst *CreateMasterEditionV3) GetMintAuthorityAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[3]
+}
+
+// Payer of the master edition account.
+func (inst *CreateMasterEditionV3) SetPayerAccount(payerAccount ag_solanago.PublicKey) *CreateMasterEditionV3 {
+	inst.AccountMetaSlice[4] = ag_solanago.Meta(payerAccount).WRITE().SIGNER()
+	return inst
+}
+
+func (inst *CreateMasterEditionV3) GetPayerAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[4]
+}
+
+// Metadata account.
+func (inst *CreateMasterEditionV3) SetMetadataAccount(metadataAccount ag_solanago.PublicKey) *CreateMasterEditionV3 {
+	inst.AccountMetaSlice[5] = ag_solanago.Meta(metadataAccount).WRITE()
+	return inst
+}
+
+func (inst *CreateMasterEditionV3) GetMetadataAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[5]
+}
+
+// Token program.
+func (inst *CreateMasterEditionV3) SetTokenProgram(tokenProgram ag_solanago.PublicKey) *CreateMasterEditionV3 {
+	inst.AccountMetaSlice[6] = ag_solanago.Meta(tokenProgram)
+	return inst
+}
+
+func (inst *CreateMasterEditionV3) GetTokenProgram() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[6]
+}
+
+// System program.
+func (inst *CreateMasterEditionV3) SetSystemProgram(systemProgram ag_solanago.PublicKey) *CreateMasterEditionV3 {
+	inst.AccountMetaSlice[7] = ag_solanago.Meta(systemProgram)
+	return inst
+}
+
+func (inst *CreateMasterEditionV3) GetSystemProgram() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[7]
+}
+
+// Rent sysvar.
+func (inst *CreateMasterEditionV3) SetSysVarRentPubkey(sysVarRentPubkey ag_solanago.PublicKey) *CreateMasterEditionV3 {
+	inst.AccountMetaSlice[8] = ag_solanago.Meta(sysVarRentPubkey)
+	return inst
+}
+
+func (inst *CreateMasterEditionV3) GetSysVarRentPubkey() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[8]
+}
+
+func (inst CreateMasterEditionV3) Build() *Instruction {
+	return &Instruction{BaseVariant: ag_binary.BaseVariant{
+		Impl:   inst,
+		TypeID: ag_binary.TypeIDFromUint8(Instruction_CreateMasterEditionV3),
+	}}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (inst CreateMasterEditionV3) ValidateAndBuild() (*Instruction, error) {
+	if err := inst.Validate(); err != nil {
+		return nil, err
+	}
+	return inst.Build(), nil
+}
+
+func (inst *CreateMasterEditionV3) Validate() error {
+	// Check whether all (required) accounts are set:
+	for accIndex, acc := range inst.AccountMetaSlice {
+		if acc == nil {
+			return fmt.Errorf("ins.AccountMetaSlice[%v] is not set", accIndex)
+		}
+	}
+	return nil
+}
+
+func (inst *CreateMasterEditionV3) EncodeToTree(parent ag_treeout.Branches) {
+	parent.Child(ag_format.Program(ProgramName, ProgramID)).
+		//
+		ParentFunc(func(programBranch ag_treeout.Branches) {
+			programBranch.Child(ag_format.Instruction("CreateMasterEditionV3")).
+				//
+				ParentFunc(func(instructionBranch ag_treeout.Branches) {
+
+					// Parameters of the instruction:
+					instructionBranch.Child("Params").ParentFunc(func(paramsBranch ag_treeout.Branches) {
+						paramsBranch.Child(ag_format.Param("MaxSupply (OPT)", inst.MaxSupply))
+					})
+
+					// Accounts of the instruction:
+					instructionBranch.Child("Accounts").ParentFunc(func(accountsBranch ag_treeout.Branches) {
+						accountsBranch.Child(ag_format.Meta("        Edition", inst.AccountMetaSlice.Get(0)))
+						accountsBranch.Child(ag_format.Meta("           Mint", inst.AccountMetaSlice.Get(1)))
+						accountsBranch.Child(ag_format.Meta("UpdateAuthority", inst.AccountMetaSlice.Get(2)))
+						accountsBranch.Child(ag_format.Meta("  MintAuthority", inst.AccountMetaSlice.Get(3)))
+						accountsBranch.Child(ag_format.Meta("          Payer", inst.AccountMetaSlice.Get(4)))
+						accountsBranch.Child(ag_format.Meta("       Metadata", inst.AccountMetaSlice.Get(5)))
+						accountsBranch.Child(ag_format.Meta("   TokenProgram", inst.AccountMetaSlice.Get(6)))
+						accountsBranch.Child(ag_format.Meta("  SystemProgram", inst.AccountMetaSlice.Get(7)))
+						accountsBranch.Child(ag_format.Meta("     SysVarRent", inst.AccountMetaSlice.Get(8)))
+					})
+				})
+		})
+}
+
+func (obj CreateMasterEditionV3) MarshalWithEncoder(encoder *ag_binary.Encoder) (err error) {
+	// Serialize `MaxSupply` param (optional):
+	{
+		err = encoder.WriteOption(obj.MaxSupply != nil)
+		if err != nil {
+			return err
+		}
+		if obj.MaxSupply != nil {
+			err = encoder.WriteUint64(*obj.MaxSupply, binary.LittleEndian)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+func (obj *CreateMasterEditionV3) UnmarshalWithDecoder(decoder *ag_binary.Decoder) (err error) {
+	// Deserialize `MaxSupply` (optional):
+	{
+		ok, err := decoder.ReadOption()
+		if err != nil {
+			return err
+		}
+		if ok {
+			v, err := decoder.ReadUint64(binary.LittleEndian)
+			if err != nil {
+				return err
+			}
+			obj.MaxSupply = &v
+		}
+	}
+	return nil
+}
+
+// NewCreateMasterEditionV3Instruction declares a new CreateMasterEditionV3 instruction with the provided parameters and accounts.
+func NewCreateMasterEditionV3Instruction(
+	// Accounts:
+	editionAccount ag_solanago.PublicKey,
+	mintAccount ag_solanago.PublicKey,
+	updateAuthorityAccount ag_solanago.PublicKey,
+	mintAuthorityAccount ag_solanago.PublicKey,
+	payerAccount ag_solanago.PublicKey,
+	metadataAccount ag_solanago.PublicKey,
+) *CreateMasterEditionV3 {
+	return NewCreateMasterEditionV3InstructionBuilder().
+		SetEditionAccount(editionAccount).
+		SetMintAccount(mintAccount).
+		SetUpdateAuthorityAccount(updateAuthorityAccount).
+		SetMintAuthorityAccount(mintAuthorityAccount).
+		SetPayerAccount(payerAccount).
+		SetMetadataAccount(metadataAccount)
+}
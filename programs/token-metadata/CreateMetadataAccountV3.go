@@ -0,0 +1,294 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tokenmetadata
+
+import (
+	"errors"
+	"fmt"
+
+	ag_binary "github.com/gagliardetto/binary"
+	ag_solanago "github.com/gagliardetto/solana-go"
+	ag_format "github.com/gagliardetto/solana-go/text/format"
+	ag_treeout "github.com/gagliardetto/treeout"
+)
+
+// Create the metadata account of a mint.
+type CreateMetadataAccountV3 struct {
+	// Metadata of the asset.
+	Data *DataV2
+
+	// Whether the metadata can be updated.
+	IsMutable *bool
+
+	// Details of the collection, if the asset is a collection parent (optional).
+	CollectionDetails *CollectionDetails
+
+	// [0] = [WRITE] MetadataAccount
+	// ··········· Metadata account; seeds ['metadata', program id, mint]
+	//
+	// [1] = [] MintAccount
+	// ··········· Mint of the asset
+	//
+	// [2] = [SIGNER] MintAuthorityAccount
+	// ··········· Mint authority
+	//
+	// [3] = [WRITE, SIGNER] PayerAccount
+	// ··········· Payer of the metadata account
+	//
+	// [4] = [SIGNER?] UpdateAuthorityAccount
+	// ··········· Update authority of the metadata
+	//
+	// [5] = [] SystemProgram
+	// ··········· System program
+	ag_solanago.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+// NewCreateMetadataAccountV3InstructionBuilder creates a new `CreateMetadataAccountV3` instruction builder.
+func NewCreateMetadataAccountV3InstructionBuilder() *CreateMetadataAccountV3 {
+	nd := &CreateMetadataAccountV3{
+		AccountMetaSlice: make(ag_solanago.AccountMetaSlice, 6),
+	}
+	nd.AccountMetaSlice[5] = ag_solanago.Meta(ag_solanago.SystemProgramID)
+	return nd
+}
+
+// Metadata of the asset.
+func (inst *CreateMetadataAccountV3) SetData(data DataV2) *CreateMetadataAccountV3 {
+	inst.Data = &data
+	return inst
+}
+
+// Whether the metadata can be updated.
+func (inst *CreateMetadataAccountV3) SetIsMutable(isMutable bool) *CreateMetadataAccountV3 {
+	inst.IsMutable = &isMutable
+	return inst
+}
+
+// Details of the collection, if the asset is a collection parent.
+func (inst *CreateMetadataAccountV3) SetCollectionDetails(collectionDetails CollectionDetails) *CreateMetadataAccountV3 {
+	inst.CollectionDetails = &collectionDetails
+	return inst
+}
+
+// Metadata account; seeds ['metadata', program id, mint].
+func (inst *CreateMetadataAccountV3) SetMetadataAccount(metadataAccount ag_solanago.PublicKey) *CreateMetadataAccountV3 {
+	inst.AccountMetaSlice[0] = ag_solanago.Meta(metadataAccount).WRITE()
+	return inst
+}
+
+func (inst *CreateMetadataAccountV3) GetMetadataAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[0]
+}
+
+// Mint of the asset.
+func (inst *CreateMetadataAccountV3) SetMintAccount(mintAccount ag_solanago.PublicKey) *CreateMetadataAccountV3 {
+	inst.AccountMetaSlice[1] = ag_solanago.Meta(mintAccount)
+	return inst
+}
+
+func (inst *CreateMetadataAccountV3) GetMintAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[1]
+}
+
+// Mint authority.
+func (inst *CreateMetadataAccountV3) SetMintAuthorityAccount(mintAuthorityAccount ag_solanago.PublicKey) *CreateMetadataAccountV3 {
+	inst.AccountMetaSlice[2] = ag_solanago.Meta(mintAuthorityAccount).SIGNER()
+	return inst
+}
+
+func (inst *CreateMetadataAccountV3) GetMintAuthorityAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[2]
+}
+
+// Payer of the metadata account.
+func (inst *CreateMetadataAccountV3) SetPayerAccount(payerAccount ag_solanago.PublicKey) *CreateMetadataAccountV3 {
+	inst.AccountMetaSlice[3] = ag_solanago.Meta(payerAccount).WRITE().SIGNER()
+	return inst
+}
+
+func (inst *CreateMetadataAccountV3) GetPayerAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[3]
+}
+
+// Update authority of the metadata.
+func (inst *CreateMetadataAccountV3) SetUpdateAuthorityAccount(updateAuthorityAccount ag_solanago.PublicKey, isSigner bool) *CreateMetadataAccountV3 {
+	inst.AccountMetaSlice[4] = ag_solanago.Meta(updateAuthorityAccount)
+	inst.AccountMetaSlice[4].IsSigner = isSigner
+	return inst
+}
+
+func (inst *CreateMetadataAccountV3) GetUpdateAuthorityAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[4]
+}
+
+// System program.
+func (inst *CreateMetadataAccountV3) SetSystemProgram(systemProgram ag_solanago.PublicKey) *CreateMetadataAccountV3 {
+	inst.AccountMetaSlice[5] = ag_solanago.Meta(systemProgram)
+	return inst
+}
+
+func (inst *CreateMetadataAccountV3) GetSystemProgram() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[5]
+}
+
+func (inst CreateMetadataAccountV3) Build() *Instruction {
+	return &Instruction{BaseVariant: ag_binary.BaseVariant{
+		Impl:   inst,
+		TypeID: ag_binary.TypeIDFromUint8(Instruction_CreateMetadataAccountV3),
+	}}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (inst CreateMetadataAccountV3) ValidateAndBuild() (*Instruction, error) {
+	if err := inst.Validate(); err != nil {
+		return nil, err
+	}
+	return inst.Build(), nil
+}
+
+func (inst *CreateMetadataAccountV3) Validate() error {
+	// Check whether all (required) parameters are set:
+	{
+		if inst.Data == nil {
+			return errors.New("Data parameter is not set")
+		}
+		if inst.IsMutable == nil {
+			return errors.New("IsMutable parameter is not set")
+		}
+	}
+
+	// Check whether all (required) accounts are set:
+	for accIndex, acc := range inst.AccountMetaSlice {
+		if acc == nil {
+			return fmt.Errorf("ins.AccountMetaSlice[%v] is not set", accIndex)
+		}
+	}
+	return nil
+}
+
+func (inst *CreateMetadataAccountV3) EncodeToTree(parent ag_treeout.Branches) {
+	parent.Child(ag_format.Program(ProgramName, ProgramID)).
+		//
+		ParentFunc(func(programBranch ag_treeout.Branches) {
+			programBranch.Child(ag_format.Instruction("CreateMetadataAccountV3")).
+				//
+				ParentFunc(func(instructionBranch ag_treeout.Branches) {
+
+					// Parameters of the instruction:
+					instructionBranch.Child("Params").ParentFunc(func(paramsBranch ag_treeout.Branches) {
+						paramsBranch.Child(ag_format.Param("                  Data", *inst.Data))
+						paramsBranch.Child(ag_format.Param("             IsMutable", *inst.IsMutable))
+						paramsBranch.Child(ag_format.Param("CollectionDetails (OPT)", inst.CollectionDetails))
+					})
+
+					// Accounts of the instruction:
+					instructionBranch.Child("Accounts").ParentFunc(func(accountsBranch ag_treeout.Branches) {
+						accountsBranch.Child(ag_format.Meta("       Metadata", inst.AccountMetaSlice.Get(0)))
+						accountsBranch.Child(ag_format.Meta("           Mint", inst.AccountMetaSlice.Get(1)))
+						accountsBranch.Child(ag_format.Meta("  MintAuthority", inst.AccountMetaSlice.Get(2)))
+						accountsBranch.Child(ag_format.Meta("          Payer", inst.AccountMetaSlice.Get(3)))
+						accountsBranch.Child(ag_format.Meta("UpdateAuthority", inst.AccountMetaSlice.Get(4)))
+						accountsBranch.Child(ag_format.Meta("  SystemProgram", inst.AccountMetaSlice.Get(5)))
+					})
+				})
+		})
+}
+
+func (obj CreateMetadataAccountV3) MarshalWithEncoder(encoder *ag_binary.Encoder) (err error) {
+	// Serialize `Data` param:
+	err = (*obj.Data).MarshalWithEncoder(encoder)
+	if err != nil {
+		return err
+	}
+	// Serialize `IsMutable` param:
+	err = encoder.WriteBool(*obj.IsMutable)
+	if err != nil {
+		return err
+	}
+	// Serialize `CollectionDetails` param (optional):
+	{
+		err = encoder.WriteOption(obj.CollectionDetails != nil)
+		if err != nil {
+			return err
+		}
+		if obj.CollectionDetails != nil {
+			err = (*obj.CollectionDetails).MarshalWithEncoder(encoder)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+func (obj *CreateMetadataAccountV3) UnmarshalWithDecoder(decoder *ag_binary.Decoder) (err error) {
+	// Deserialize `Data`:
+	{
+		var v DataV2
+		err := v.UnmarshalWithDecoder(decoder)
+		if err != nil {
+			return err
+		}
+		obj.Data = &v
+	}
+	// Deserialize `IsMutable`:
+	{
+		v, err := decoder.ReadBool()
+		if err != nil {
+			return err
+		}
+		obj.IsMutable = &v
+	}
+	// Deserialize `CollectionDetails` (optional):
+	{
+		ok, err := decoder.ReadOption()
+		if err != nil {
+			return err
+		}
+		if ok {
+			var v CollectionDetails
+			err := v.UnmarshalWithDecoder(decoder)
+			if err != nil {
+				return err
+			}
+			obj.CollectionDetails = &v
+		}
+	}
+	return nil
+}
+
+// NewCreateMetadataAccountV3Instruction declares a new CreateMetadataAccountV3 instruction with the provided parameters and accounts.
+func NewCreateMetadataAccountV3Instruction(
+	// Parameters:
+	data DataV2,
+	isMutable bool,
+	// Accounts:
+	metadataAccount ag_solanago.PublicKey,
+	mintAccount ag_solanago.PublicKey,
+	mintAuthorityAccount ag_solanago.PublicKey,
+	payerAccount ag_solanago.PublicKey,
+	updateAuthorityAccount ag_solanago.PublicKey,
+	updateAuthorityIsSigner bool,
+) *CreateMetadataAccountV3 {
+	return NewCreateMetadataAccountV3InstructionBuilder().
+		SetData(data).
+		SetIsMutable(isMutable).
+		SetMetadataAccount(metadataAccount).
+		SetMintAccount(mintAccount).
+		SetMintAuthorityAccount(mintAuthorityAccount).
+		SetPayerAccount(payerAccount).
+		SetUpdateAuthorityAccount(updateAuthorityAccount, updateAuthorityIsSigner)
+}
@@ -0,0 +1,393 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tokenmetadata
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	ag_binary "github.com/gagliardetto/binary"
+	ag_solanago "github.com/gagliardetto/solana-go"
+	ag_format "github.com/gagliardetto/solana-go/text/format"
+	ag_treeout "github.com/gagliardetto/treeout"
+)
+
+// Mint tokens of an asset, creating the token account if needed.
+//
+// Optional accounts that are not set are replaced by the program ID.
+type Mint struct {
+	// Amount of tokens to mint.
+	Amount *uint64
+
+	// [0] = [WRITE] TokenAccount
+	// ··········· Token account, or associated token account
+	//
+	// [1] = [] TokenOwnerAccount
+	// ··········· Owner of the token account (optional)
+	//
+	// [2] = [] MetadataAccount
+	// ··········· Metadata account
+	//
+	// [3] = [WRITE] MasterEditionAccount
+	// ··········· Master edition account (optional)
+	//
+	// [4] = [WRITE] TokenRecordAccount
+	// ··········· Token record account (optional)
+	//
+	// [5] = [WRITE] MintAccount
+	// ··········· Mint of the asset
+	//
+	// [6] = [SIGNER] AuthorityAccount
+	// ··········· Mint or update authority
+	//
+	// [7] = [] DelegateRecordAccount
+	// ··········· Metadata delegate record (optional)
+	//
+	// [8] = [WRITE, SIGNER] PayerAccount
+	// ··········· Payer
+	//
+	// [9] = [] SystemProgram
+	// ··········· System program
+	//
+	// [10] = [] SysVarInstructionsPubkey
+	// ··········· Instructions sysvar
+	//
+	// [11] = [] SplTokenProgram
+	// ··········· Token program
+	//
+	// [12] = [] SplAtaProgram
+	// ··········· Associated token account program
+	//
+	// [13] = [] AuthorizationRulesProgram
+	// ··········· Token authorization rules program (optional)
+	//
+	// [14] = [] AuthorizationRulesAccount
+	// ··········· Token authorization rules account (optional)
+	ag_solanago.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+// NewMintInstructionBuilder creates a new `Mint` instruction builder.
+func NewMintInstructionBuilder() *Mint {
+	nd := &Mint{
+		AccountMetaSlice: make(ag_solanago.AccountMetaSlice, 15),
+	}
+	nd.AccountMetaSlice[1] = ag_solanago.Meta(ProgramID)
+	nd.AccountMetaSlice[3] = ag_solanago.Meta(ProgramID)
+	nd.AccountMetaSlice[4] = ag_solanago.Meta(ProgramID)
+	nd.AccountMetaSlice[7] = ag_solanago.Meta(ProgramID)
+	nd.AccountMetaSlice[9] = ag_solanago.Meta(ag_solanago.SystemProgramID)
+	nd.AccountMetaSlice[10] = ag_solanago.Meta(ag_solanago.SysVarInstructionsPubkey)
+	nd.AccountMetaSlice[11] = ag_solanago.Meta(ag_solanago.TokenProgramID)
+	nd.AccountMetaSlice[12] = ag_solanago.Meta(ag_solanago.SPLAssociatedTokenAccountProgramID)
+	nd.AccountMetaSlice[13] = ag_solanago.Meta(ProgramID)
+	nd.AccountMetaSlice[14] = ag_solanago.Meta(ProgramID)
+	return nd
+}
+
+// Amount of tokens to mint.
+func (inst *Mint) SetAmount(amount uint64) *Mint {
+	inst.Amount = &amount
+	return inst
+}
+
+// Token account, or associated token account.
+func (inst *Mint) SetTokenAccount(tokenAccount ag_solanago.PublicKey) *Mint {
+	inst.AccountMetaSlice[0] = ag_solanago.Meta(tokenAccount).WRITE()
+	return inst
+}
+
+func (inst *Mint) GetTokenAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[0]
+}
+
+// Owner of the token account.
+func (inst *Mint) SetTokenOwnerAccount(tokenOwnerAccount ag_solanago.PublicKey) *Mint {
+	inst.AccountMetaSlice[1] = ag_solanago.Meta(tokenOwnerAccount)
+	return inst
+}
+
+func (inst *Mint) GetTokenOwnerAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[1]
+}
+
+// Metadata account.
+func (inst *Mint) SetMetadataAccount(metadataAccount ag_solanago.PublicKey) *Mint {
+	inst.AccountMetaSlice[2] = ag_solanago.Meta(metadataAccount)
+	return inst
+}
+
+func (inst *Mint) GetMetadataAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[2]
+}
+
+// Master edition account.
+func (inst *Mint) SetMasterEditionAccount(masterEditionAccount ag_solanago.PublicKey) *Mint {
+	inst.AccountMetaSlice[3] = ag_solanago.Meta(masterEditionAccount).WRITE()
+	return inst
+}
+
+func (inst *Mint) GetMasterEditionAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[3]
+}
+
+// Token record account.
+func (inst *Mint) SetTokenRecordAccount(tokenRecordAccount ag_solanago.PublicKey) *Mint {
+	inst.AccountMetaSlice[4] = ag_solanago.Meta(tokenRecordAccount).WRITE()
+	return inst
+}
+
+func (inst *Mint) GetTokenRecordAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[4]
+}
+
+// Mint of the asset.
+func (inst *Mint) SetMintAccount(mintAccount ag_solanago.PublicKey) *Mint {
+	inst.AccountMetaSlice[5] = ag_solanago.Meta(mintAccount).WRITE()
+	return inst
+}
+
+func (inst *Mint) GetMintAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[5]
+}
+
+// Mint or update authority.
+func (inst *Mint) SetAuthorityAccount(authorityAccount ag_solanago.PublicKey) *Mint {
+	inst.AccountMetaSlice[6] = ag_solanago.Meta(authorityAccount).SIGNER()
+	return inst
+}
+
+func (inst *Mint) GetAuthorityAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[6]
+}
+
+// Metadata delegate record.
+func (inst *Mint) SetDelegateRecordAccount(delegateRecordAccount ag_solanago.PublicKey) *Mint {
+	inst.AccountMetaSlice[7] = ag_solanago.Meta(delegateRecordAccount)
+	return inst
+}
+
+func (inst *Mint) GetDelegateRecordAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[7]
+}
+
+// Payer.
+func (inst *Mint) SetPayerAccount(payerAccount ag_solanago.PublicKey) *Mint {
+	inst.AccountMetaSlice[8] = ag_solanago.Meta(payerAccount).WRITE().SIGNER()
+	return inst
+}
+
+func (inst *Mint) GetPayerAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[8]
+}
+
+// System program.
+func (inst *Mint) SetSystemProgram(systemProgram ag_solanago.PublicKey) *Mint {
+	inst.AccountMetaSlice[9] = ag_solanago.Meta(systemProgram)
+	return inst
+}
+
+func (inst *Mint) GetSystemProgram() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[9]
+}
+
+// Instructions sysvar.
+func (inst *Mint) SetSysVarInstructionsPubkey(sysVarInstructionsPubkey ag_solanago.PublicKey) *Mint {
+	inst.AccountMetaSlice[10] = ag_solanago.Meta(sysVarInstructionsPubkey)
+	return inst
+}
+
+func (inst *Mint) GetSysVarInstructionsPubkey() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[10]
+}
+
+// Token program.
+func (inst *Mint) SetSplTokenProgram(splTokenProgram ag_solanago.PublicKey) *Mint {
+	inst.AccountMetaSlice[11] = ag_solanago.Meta(splTokenProgram)
+	return inst
+}
+
+func (inst *Mint) GetSplTokenProgram() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[11]
+}
+
+// Associated token account program.
+func (inst *Mint) SetSplAtaProgram(splAtaProgram ag_solanago.PublicKey) *Mint {
+	inst.AccountMetaSlice[12] = ag_solanago.Meta(splAtaProgram)
+	return inst
+}
+
+func (inst *Mint) GetSplAtaProgram() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[12]
+}
+
+// Token authorization rules program.
+func (inst *Mint) SetAuthorizationRulesProgram(authorizationRulesProgram ag_solanago.PublicKey) *Mint {
+	inst.AccountMetaSlice[13] = ag_solanago.Meta(authorizationRulesProgram)
+	return inst
+}
+
+func (inst *Mint) GetAuthorizationRulesProgram() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[13]
+}
+
+// Token authorization rules account.
+func (inst *Mint) SetAuthorizationRulesAccount(authorizationRulesAccount ag_solanago.PublicKey) *Mint {
+	inst.AccountMetaSlice[14] = ag_solanago.Meta(authorizationRulesAccount)
+	return inst
+}
+
+func (inst *Mint) GetAuthorizationRulesAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[14]
+}
+
+func (inst Mint) Build() *Instruction {
+	return &Instruction{BaseVariant: ag_binary.BaseVariant{
+		Impl:   inst,
+		TypeID: ag_binary.TypeIDFromUint8(Instruction_Mint),
+	}}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (inst Mint) ValidateAndBuild() (*Instruction, error) {
+	if err := inst.Validate(); err != nil {
+		return nil, err
+	}
+	return inst.Build(), nil
+}
+
+func (inst *Mint) Validate() error {
+	// Check whether all (required) parameters are set:
+	{
+		if inst.Amount == nil {
+			return errors.New("Amount parameter is not set")
+		}
+	}
+
+	// Check whether all (required) accounts are set:
+	for accIndex, acc := range inst.AccountMetaSlice {
+		if acc == nil {
+			return fmt.Errorf("ins.AccountMetaSlice[%v] is not set", accIndex)
+		}
+	}
+	return nil
+}
+
+func (inst *Mint) EncodeToTree(parent ag_treeout.Branches) {
+	parent.Child(ag_format.Program(ProgramName, ProgramID)).
+		//
+		ParentFunc(func(programBranch ag_treeout.Branches) {
+			programBranch.Child(ag_format.Instruction("Mint")).
+				//
+				ParentFunc(func(instructionBranch ag_treeout.Branches) {
+
+					// Parameters of the instruction:
+					instructionBranch.Child("Params").ParentFunc(func(paramsBranch ag_treeout.Branches) {
+						paramsBranch.Child(ag_format.Param("Amount", *inst.Amount))
+					})
+
+					// Accounts of the instruction:
+					instructionBranch.Child("Accounts").ParentFunc(func(accountsBranch ag_treeout.Branches) {
+						accountsBranch.Child(ag_format.Meta("                    Token", inst.AccountMetaSlice.Get(0)))
+						accountsBranch.Child(ag_format.Meta("               TokenOwner", inst.AccountMetaSlice.Get(1)))
+						accountsBranch.Child(ag_format.Meta("                 Metadata", inst.AccountMetaSlice.Get(2)))
+						accountsBranch.Child(ag_format.Meta("            MasterEdition", inst.AccountMetaSlice.Get(3)))
+						accountsBranch.Child(ag_format.Meta("              TokenRecord", inst.AccountMetaSlice.Get(4)))
+						accountsBranch.Child(ag_format.Meta("                     Mint", inst.AccountMetaSlice.Get(5)))
+						accountsBranch.Child(ag_format.Meta("                Authority", inst.AccountMetaSlice.Get(6)))
+						accountsBranch.Child(ag_format.Meta("           DelegateRecord", inst.AccountMetaSlice.Get(7)))
+						accountsBranch.Child(ag_format.Meta("                    Payer", inst.AccountMetaSlice.Get(8)))
+						accountsBranch.Child(ag_format.Meta("            SystemProgram", inst.AccountMetaSlice.Get(9)))
+						accountsBranch.Child(ag_format.Meta("       SysVarInstructions", inst.AccountMetaSlice.Get(10)))
+						accountsBranch.Child(ag_format.Meta("          SplTokenProgram", inst.AccountMetaSlice.Get(11)))
+						accountsBranch.Child(ag_format.Meta("            SplAtaProgram", inst.AccountMetaSlice.Get(12)))
+						accountsBranch.Child(ag_format.Meta("AuthorizationRulesProgram", inst.AccountMetaSlice.Get(13)))
+						accountsBranch.Child(ag_format.Meta("       AuthorizationRules", inst.AccountMetaSlice.Get(14)))
+					})
+				})
+		})
+}
+
+func (obj Mint) MarshalWithEncoder(encoder *ag_binary.Encoder) (err error) {
+	// Serialize the version of the arguments (V1):
+	err = encoder.WriteUint8(0)
+	if err != nil {
+		return err
+	}
+	// Serialize `Amount` param:
+	err = encoder.WriteUint64(*obj.Amount, binary.LittleEndian)
+	if err != nil {
+		return err
+	}
+	// Serialize `AuthorizationData` param (unsupported, always None):
+	err = encoder.WriteOption(false)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+func (obj *Mint) UnmarshalWithDecoder(decoder *ag_binary.Decoder) (err error) {
+	// Deserialize the version of the arguments:
+	{
+		version, err := decoder.ReadUint8()
+		if err != nil {
+			return err
+		}
+		if version != 0 {
+			return fmt.Errorf("unsupported Mint arguments version: %d", version)
+		}
+	}
+	// Deserialize `Amount`:
+	{
+		v, err := decoder.ReadUint64(binary.LittleEndian)
+		if err != nil {
+			return err
+		}
+		obj.Amount = &v
+	}
+	// Deserialize `AuthorizationData` (unsupported):
+	{
+		ok, err := decoder.ReadOption()
+		if err != nil {
+			return err
+		}
+		if ok {
+			return errors.New("authorization data is not supported")
+		}
+	}
+	return nil
+}
+
+// NewMintInstruction declares a new Mint instruction with the provided parameters and accounts.
+func NewMintInstruction(
+	// Parameters:
+	amount uint64,
+	// Accounts:
+	tokenAccount ag_solanago.PublicKey,
+	metadataAccount ag_solanago.PublicKey,
+	mintAccount ag_solanago.PublicKey,
+	authorityAccount ag_solanago.PublicKey,
+	payerAccount ag_solanago.PublicKey,
+) *Mint {
+	return NewMintInstructionBuilder().
+		SetAmount(amount).
+		SetTokenAccount(tokenAccount).
+		SetMetadataAccount(metadataAccount).
+		SetMintAccount(mintAccount).
+		SetAuthorityAccount(authorityAccount).
+		SetPayerAccount(payerAccount)
+}
@@ -0,0 +1,426 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tokenmetadata
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	ag_binary "github.com/gagliardetto/binary"
+	ag_solanago "github.com/gagliardetto/solana-go"
+	ag_format "github.com/gagliardetto/solana-go/text/format"
+	ag_treeout "github.com/gagliardetto/treeout"
+)
+
+// Transfer tokens of an asset, creating the destination token account if needed.
+//
+// Optional accounts that are not set are replaced by the program ID.
+type Transfer struct {
+	// Amount of tokens to transfer.
+	Amount *uint64
+
+	// [0] = [WRITE] TokenAccount
+	// ··········· Token account
+	//
+	// [1] = [] TokenOwnerAccount
+	// ··········· Owner of the token account
+	//
+	// [2] = [WRITE] DestinationAccount
+	// ··········· Destination token account
+	//
+	// [3] = [] DestinationOwnerAccount
+	// ··········· Owner of the destination token account
+	//
+	// [4] = [] MintAccount
+	// ··········· Mint of the asset
+	//
+	// [5] = [WRITE] MetadataAccount
+	// ··········· Metadata account
+	//
+	// [6] = [] EditionAccount
+	// ··········· Edition account of the asset (optional)
+	//
+	// [7] = [WRITE] OwnerTokenRecordAccount
+	// ··········· Token record account of the owner (optional)
+	//
+	// [8] = [WRITE] DestinationTokenRecordAccount
+	// ··········· Token record account of the destination (optional)
+	//
+	// [9] = [SIGNER] AuthorityAccount
+	// ··········· Transfer authority; token owner or delegate
+	//
+	// [10] = [WRITE, SIGNER] PayerAccount
+	// ··········· Payer
+	//
+	// [11] = [] SystemProgram
+	// ··········· System program
+	//
+	// [12] = [] SysVarInstructionsPubkey
+	// ··········· Instructions sysvar
+	//
+	// [13] = [] SplTokenProgram
+	// ··········· Token program
+	//
+	// [14] = [] SplAtaProgram
+	// ··········· Associated token account program
+	//
+	// [15] = [] AuthorizationRulesProgram
+	// ··········· Token authorization rules program (optional)
+	//
+	// [16] = [] AuthorizationRulesAccount
+	// ··········· Token authorization rules account (optional)
+	ag_solanago.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+// NewTransferInstructionBuilder creates a new `Transfer` instruction builder.
+func NewTransferInstructionBuilder() *Transfer {
+	nd := &Transfer{
+		AccountMetaSlice: make(ag_solanago.AccountMetaSlice, 17),
+	}
+	nd.AccountMetaSlice[6] = ag_solanago.Meta(ProgramID)
+	nd.AccountMetaSlice[7] = ag_solanago.Meta(ProgramID)
+	nd.AccountMetaSlice[8] = ag_solanago.Meta(ProgramID)
+	nd.AccountMetaSlice[11] = ag_solanago.Meta(ag_solanago.SystemProgramID)
+	nd.AccountMetaSlice[12] = ag_solanago.Meta(ag_solanago.SysVarInstructionsPubkey)
+	nd.AccountMetaSlice[13] = ag_solanago.Meta(ag_solanago.TokenProgramID)
+	nd.AccountMetaSlice[14] = ag_solanago.Meta(ag_solanago.SPLAssociatedTokenAccountProgramID)
+	nd.AccountMetaSlice[15] = ag_solanago.Meta(ProgramID)
+	nd.AccountMetaSlice[16] = ag_solanago.Meta(ProgramID)
+	return nd
+}
+
+// Amount of tokens to transfer.
+func (inst *Transfer) SetAmount(amount uint64) *Transfer {
+	inst.Amount = &amount
+	return inst
+}
+
+// Token account.
+func (inst *Transfer) SetTokenAccount(tokenAccount ag_solanago.PublicKey) *Transfer {
+	inst.AccountMetaSlice[0] = ag_solanago.Meta(tokenAccount).WRITE()
+	return inst
+}
+
+func (inst *Transfer) GetTokenAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[0]
+}
+
+// Owner of the token account.
+func (inst *Transfer) SetTokenOwnerAccount(tokenOwnerAccount ag_solanago.PublicKey) *Transfer {
+	inst.AccountMetaSlice[1] = ag_solanago.Meta(tokenOwnerAccount)
+	return inst
+}
+
+func (inst *Transfer) GetTokenOwnerAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[1]
+}
+
+// Destination token account.
+func (inst *Transfer) SetDestinationAccount(destinationAccount ag_solanago.PublicKey) *Transfer {
+	inst.AccountMetaSlice[2] = ag_solanago.Meta(destinationAccount).WRITE()
+	return inst
+}
+
+func (inst *Transfer) GetDestinationAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[2]
+}
+
+// Owner of the destination token account.
+func (inst *Transfer) SetDestinationOwnerAccount(destinationOwnerAccount ag_solanago.PublicKey) *Transfer {
+	inst.AccountMetaSlice[3] = ag_solanago.Meta(destinationOwnerAccount)
+	return inst
+}
+
+func (inst *Transfer) GetDestinationOwnerAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[3]
+}
+
+// Mint of the asset.
+func (inst *Transfer) SetMintAccount(mintAccount ag_solanago.PublicKey) *Transfer {
+	inst.AccountMetaSlice[4] = ag_solanago.Meta(mintAccount)
+	return inst
+}
+
+func (inst *Transfer) GetMintAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[4]
+}
+
+// Metadata account.
+func (inst *Transfer) SetMetadataAccount(metadataAccount ag_solanago.PublicKey) *Transfer {
+	inst.AccountMetaSlice[5] = ag_solanago.Meta(metadataAccount).WRITE()
+	return inst
+}
+
+func (inst *Transfer) GetMetadataAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[5]
+}
+
+// Edition account of the asset.
+func (inst *Transfer) SetEditionAccount(editionAccount ag_solanago.PublicKey) *Transfer {
+	inst.AccountMetaSlice[6] = ag_solanago.Meta(editionAccount)
+	return inst
+}
+
+func (inst *Transfer) GetEditionAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[6]
+}
+
+// Token record account of the owner.
+func (inst *Transfer) SetOwnerTokenRecordAccount(ownerTokenRecordAccount ag_solanago.PublicKey) *Transfer {
+	inst.AccountMetaSlice[7] = ag_solanago.Meta(ownerTokenRecordAccount).WRITE()
+	return inst
+}
+
+func (inst *Transfer) GetOwnerTokenRecordAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[7]
+}
+
+// Token record account of the destination.
+func (inst *Transfer) SetDestinationTokenRecordAccount(destinationTokenRecordAccount ag_solanago.PublicKey) *Transfer {
+	inst.AccountMetaSlice[8] = ag_solanago.Meta(destinationTokenRecordAccount).WRITE()
+	return inst
+}
+
+func (inst *Transfer) GetDestinationTokenRecordAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[8]
+}
+
+// Transfer authority; token owner or delegate.
+func (inst *Transfer) SetAuthorityAccount(authorityAccount ag_solanago.PublicKey) *Transfer {
+	inst.AccountMetaSlice[9] = ag_solanago.Meta(authorityAccount).SIGNER()
+	return inst
+}
+
+func (inst *Transfer) GetAuthorityAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[9]
+}
+
+// Payer.
+func (inst *Transfer) SetPayerAccount(payerAccount ag_solanago.PublicKey) *Transfer {
+	inst.AccountMetaSlice[10] = ag_solanago.Meta(payerAccount).WRITE().SIGNER()
+	return inst
+}
+
+func (inst *Transfer) GetPayerAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[10]
+}
+
+// System program.
+func (inst *Transfer) SetSystemProgram(systemProgram ag_solanago.PublicKey) *Transfer {
+	inst.AccountMetaSlice[11] = ag_solanago.Meta(systemProgram)
+	return inst
+}
+
+func (inst *Transfer) GetSystemProgram() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[11]
+}
+
+// Instructions sysvar.
+func (inst *Transfer) SetSysVarInstructionsPubkey(sysVarInstructionsPubkey ag_solanago.PublicKey) *Transfer {
+	inst.AccountMetaSlice[12] = ag_solanago.Meta(sysVarInstructionsPubkey)
+	return inst
+}
+
+func (inst *Transfer) GetSysVarInstructionsPubkey() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[12]
+}
+
+// Token program.
+func (inst *Transfer) SetSplTokenProgram(splTokenProgram ag_solanago.PublicKey) *Transfer {
+	inst.AccountMetaSlice[13] = ag_solanago.Meta(splTokenProgram)
+	return inst
+}
+
+func (inst *Transfer) GetSplTokenProgram() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[13]
+}
+
+// Associated token account program.
+func (inst *Transfer) SetSplAtaProgram(splAtaProgram ag_solanago.PublicKey) *Transfer {
+	inst.AccountMetaSlice[14] = ag_solanago.Meta(splAtaProgram)
+	return inst
+}
+
+func (inst *Transfer) GetSplAtaProgram() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[14]
+}
+
+// Token authorization rules program.
+func (inst *Transfer) SetAuthorizationRulesProgram(authorizationRulesProgram ag_solanago.PublicKey) *Transfer {
+	inst.AccountMetaSlice[15] = ag_solanago.Meta(authorizationRulesProgram)
+	return inst
+}
+
+func (inst *Transfer) GetAuthorizationRulesProgram() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[15]
+}
+
+// Token authorization rules account.
+func (inst *Transfer) SetAuthorizationRulesAccount(authorizationRulesAccount ag_solanago.PublicKey) *Transfer {
+	inst.AccountMetaSlice[16] = ag_solanago.Meta(authorizationRulesAccount)
+	return inst
+}
+
+func (inst *Transfer) GetAuthorizationRulesAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[16]
+}
+
+func (inst Transfer) Build() *Instruction {
+	return &Instruction{BaseVariant: ag_binary.BaseVariant{
+		Impl:   inst,
+		TypeID: ag_binary.TypeIDFromUint8(Instruction_Transfer),
+	}}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (inst Transfer) ValidateAndBuild() (*Instruction, error) {
+	if err := inst.Validate(); err != nil {
+		return nil, err
+	}
+	return inst.Build(), nil
+}
+
+func (inst *Transfer) Validate() error {
+	// Check whether all (required) parameters are set:
+	{
+		if inst.Amount == nil {
+			return errors.New("Amount parameter is not set")
+		}
+	}
+
+	// Check whether all (required) accounts are set:
+	for accIndex, acc := range inst.AccountMetaSlice {
+		if acc == nil {
+			return fmt.Errorf("ins.AccountMetaSlice[%v] is not set", accIndex)
+		}
+	}
+	return nil
+}
+
+func (inst *Transfer) EncodeToTree(parent ag_treeout.Branches) {
+	parent.Child(ag_format.Program(ProgramName, ProgramID)).
+		//
+		ParentFunc(func(programBranch ag_treeout.Branches) {
+			programBranch.Child(ag_format.Instruction("Transfer")).
+				//
+				ParentFunc(func(instructionBranch ag_treeout.Branches) {
+
+					// Parameters of the instruction:
+					instructionBranch.Child("Params").ParentFunc(func(paramsBranch ag_treeout.Branches) {
+						paramsBranch.Child(ag_format.Param("Amount", *inst.Amount))
+					})
+
+					// Accounts of the instruction:
+					instructionBranch.Child("Accounts").ParentFunc(func(accountsBranch ag_treeout.Branches) {
+						accountsBranch.Child(ag_format.Meta("                    Token", inst.AccountMetaSlice.Get(0)))
+						accountsBranch.Child(ag_format.Meta("               TokenOwner", inst.AccountMetaSlice.Get(1)))
+						accountsBranch.Child(ag_format.Meta("              Destination", inst.AccountMetaSlice.Get(2)))
+						accountsBranch.Child(ag_format.Meta("         DestinationOwner", inst.AccountMetaSlice.Get(3)))
+						accountsBranch.Child(ag_format.Meta("                     Mint", inst.AccountMetaSlice.Get(4)))
+						accountsBranch.Child(ag_format.Meta("                 Metadata", inst.AccountMetaSlice.Get(5)))
+						accountsBranch.Child(ag_format.Meta("                  Edition", inst.AccountMetaSlice.Get(6)))
+						accountsBranch.Child(ag_format.Meta("         OwnerTokenRecord", inst.AccountMetaSlice.Get(7)))
+						accountsBranch.Child(ag_format.Meta("   DestinationTokenRecord", inst.AccountMetaSlice.Get(8)))
+						accountsBranch.Child(ag_format.Meta("                Authority", inst.AccountMetaSlice.Get(9)))
+						accountsBranch.Child(ag_format.Meta("                    Payer", inst.AccountMetaSlice.Get(10)))
+						accountsBranch.Child(ag_format.Meta("            SystemProgram", inst.AccountMetaSlice.Get(11)))
+						accountsBranch.Child(ag_format.Meta("       SysVarInstructions", inst.AccountMetaSlice.Get(12)))
+						accountsBranch.Child(ag_format.Meta("          SplTokenProgram", inst.AccountMetaSlice.Get(13)))
+						accountsBranch.Child(ag_format.Meta("            SplAtaProgram", inst.AccountMetaSlice.Get(14)))
+						accountsBranch.Child(ag_format.Meta("AuthorizationRulesProgram", inst.AccountMetaSlice.Get(15)))
+						accountsBranch.Child(ag_format.Meta("       AuthorizationRules", inst.AccountMetaSlice.Get(16)))
+					})
+				})
+		})
+}
+
+func (obj Transfer) MarshalWithEncoder(encoder *ag_binary.Encoder) (err error) {
+	// Serialize the version of the arguments (V1):
+	err = encoder.WriteUint8(0)
+	if err != nil {
+		return err
+	}
+	// Serialize `Amount` param:
+	err = encoder.WriteUint64(*obj.Amount, binary.LittleEndian)
+	if err != nil {
+		return err
+	}
+	// Serialize `AuthorizationData` param (unsupported, always None):
+	err = encoder.WriteOption(false)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+func (obj *Transfer) UnmarshalWithDecoder(decoder *ag_binary.Decoder) (err error) {
+	// Deserialize the version of the arguments:
+	{
+		version, err := decoder.ReadUint8()
+		if err != nil {
+			return err
+		}
+		if version != 0 {
+			return fmt.Errorf("unsupported Transfer arguments version: %d", version)
+		}
+	}
+	// Deserialize `Amount`:
+	{
+		v, err := decoder.ReadUint64(binary.LittleEndian)
+		if err != nil {
+			return err
+		}
+		obj.Amount = &v
+	}
+	// Deserialize `AuthorizationData` (unsupported):
+	{
+		ok, err := decoder.ReadOption()
+		if err != nil {
+			return err
+		}
+		if ok {
+			return errors.New("authorization data is not supported")
+		}
+	}
+	return nil
+}
+
+// NewTransferInstruction declares a new Transfer instruction with the provided parameters and accounts.
+func NewTransferInstruction(
+	// Parameters:
+	amount uint64,
+	// Accounts:
+	tokenAccount ag_solanago.PublicKey,
+	tokenOwnerAccount ag_solanago.PublicKey,
+	destinationAccount ag_solanago.PublicKey,
+	destinationOwnerAccount ag_solanago.PublicKey,
+	mintAccount ag_solanago.PublicKey,
+	metadataAccount ag_solanago.PublicKey,
+	authorityAccount ag_solanago.PublicKey,
+	payerAccount ag_solanago.PublicKey,
+) *Transfer {
+	return NewTransferInstructionBuilder().
+		SetAmount(amount).
+		SetTokenAccount(tokenAccount).
+		SetTokenOwnerAccount(tokenOwnerAccount).
+		SetDestinationAccount(destinationAccount).
+		SetDestinationOwnerAccount(destinationOwnerAccount).
+		SetMintAccount(mintAccount).
+		SetMetadataAccount(metadataAccount).
+		SetAuthorityAccount(authorityAccount).
+		SetPayerAccount(payerAccount)
+}
@@ -0,0 +1,277 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tokenmetadata
+
+import (
+	"fmt"
+
+	ag_binary "github.com/gagliardetto/binary"
+	ag_solanago "github.com/gagliardetto/solana-go"
+	ag_format "github.com/gagliardetto/solana-go/text/format"
+	ag_treeout "github.com/gagliardetto/treeout"
+)
+
+// Update the metadata of an asset.
+type UpdateMetadataAccountV2 struct {
+	// New metadata of the asset (optional).
+	Data *DataV2
+
+	// New update authority (optional).
+	NewUpdateAuthority *ag_solanago.PublicKey
+
+	// Whether the primary sale happened; can only be set to true (optional).
+	PrimarySaleHappened *bool
+
+	// Whether the metadata can be updated; can only be set to false (optional).
+	IsMutable *bool
+
+	// [0] = [WRITE] MetadataAccount
+	// ··········· Metadata account
+	//
+	// [1] = [SIGNER] UpdateAuthorityAccount
+	// ··········· Update authority of the metadata
+	ag_solanago.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+// NewUpdateMetadataAccountV2InstructionBuilder creates a new `UpdateMetadataAccountV2` instruction builder.
+func NewUpdateMetadataAccountV2InstructionBuilder() *UpdateMetadataAccountV2 {
+	nd := &UpdateMetadataAccountV2{
+		AccountMetaSlice: make(ag_solanago.AccountMetaSlice, 2),
+	}
+	return nd
+}
+
+// New metadata of the asset.
+func (inst *UpdateMetadataAccountV2) SetData(data DataV2) *UpdateMetadataAccountV2 {
+	inst.Data = &data
+	return inst
+}
+
+// New update authority.
+func (inst *UpdateMetadataAccountV2) SetNewUpdateAuthority(newUpdateAuthority ag_solanago.PublicKey) *UpdateMetadataAccountV2 {
+	inst.NewUpdateAuthority = &newUpdateAuthority
+	return inst
+}
+
+// Whether the primary sale happened; can only be set to true.
+func (inst *UpdateMetadataAccountV2) SetPrimarySaleHappened(primarySaleHappened bool) *UpdateMetadataAccountV2 {
+	inst.PrimarySaleHappened = &primarySaleHappened
+	return inst
+}
+
+// Whether the metadata can be updated; can only be set to false.
+func (inst *UpdateMetadataAccountV2) SetIsMutable(isMutable bool) *UpdateMetadataAccountV2 {
+	inst.IsMutable = &isMutable
+	return inst
+}
+
+// Metadata account.
+func (inst *UpdateMetadataAccountV2) SetMetadataAccount(metadataAccount ag_solanago.PublicKey) *UpdateMetadataAccountV2 {
+	inst.AccountMetaSlice[0] = ag_solanago.Meta(metadataAccount).WRITE()
+	return inst
+}
+
+func (inst *UpdateMetadataAccountV2) GetMetadataAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[0]
+}
+
+// Update authority of the metadata.
+func (inst *UpdateMetadataAccountV2) SetUpdateAuthorityAccount(updateAuthorityAccount ag_solanago.PublicKey) *UpdateMetadataAccountV2 {
+	inst.AccountMetaSlice[1] = ag_solanago.Meta(updateAuthorityAccount).SIGNER()
+	return inst
+}
+
+func (inst *UpdateMetadataAccountV2) GetUpdateAuthorityAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[1]
+}
+
+func (inst UpdateMetadataAccountV2) Build() *Instruction {
+	return &Instruction{BaseVariant: ag_binary.BaseVariant{
+		Impl:   inst,
+		TypeID: ag_binary.TypeIDFromUint8(Instruction_UpdateMetadataAccountV2),
+	}}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (inst UpdateMetadataAccountV2) ValidateAndBuild() (*Instruction, error) {
+	if err := inst.Validate(); err != nil {
+		return nil, err
+	}
+	return inst.Build(), nil
+}
+
+func (inst *UpdateMetadataAccountV2) Validate() error {
+	// Check whether all (required) accounts are set:
+	for accIndex, acc := range inst.AccountMetaSlice {
+		if acc == nil {
+			return fmt.Errorf("ins.AccountMetaSlice[%v] is not set", accIndex)
+		}
+	}
+	return nil
+}
+
+func (inst *UpdateMetadataAccountV2) EncodeToTree(parent ag_treeout.Branches) {
+	parent.Child(ag_format.Program(ProgramName, ProgramID)).
+		//
+		ParentFunc(func(programBranch ag_treeout.Branches) {
+			programBranch.Child(ag_format.Instruction("UpdateMetadataAccountV2")).
+				//
+				ParentFunc(func(instructionBranch ag_treeout.Branches) {
+
+					// Parameters of the instruction:
+					instructionBranch.Child("Params").ParentFunc(func(paramsBranch ag_treeout.Branches) {
+						paramsBranch.Child(ag_format.Param("              Data (OPT)", inst.Data))
+						paramsBranch.Child(ag_format.Param("NewUpdateAuthority (OPT)", inst.NewUpdateAuthority))
+						paramsBranch.Child(ag_format.Param("PrimarySaleHappened (OPT)", inst.PrimarySaleHappened))
+						paramsBranch.Child(ag_format.Param("         IsMutable (OPT)", inst.IsMutable))
+					})
+
+					// Accounts of the instruction:
+					instructionBranch.Child("Accounts").ParentFunc(func(accountsBranch ag_treeout.Branches) {
+						accountsBranch.Child(ag_format.Meta("       Metadata", inst.AccountMetaSlice.Get(0)))
+						accountsBranch.Child(ag_format.Meta("UpdateAuthority", inst.AccountMetaSlice.Get(1)))
+					})
+				})
+		})
+}
+
+func (obj UpdateMetadataAccountV2) MarshalWithEncoder(encoder *ag_binary.Encoder) (err error) {
+	// Serialize `Data` param (optional):
+	{
+		err = encoder.WriteOption(obj.Data != nil)
+		if err != nil {
+			return err
+		}
+		if obj.Data != nil {
+			err = (*obj.Data).MarshalWithEncoder(encoder)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	// Serialize `NewUpdateAuthority` param (optional):
+	{
+		err = encoder.WriteOption(obj.NewUpdateAuthority != nil)
+		if err != nil {
+			return err
+		}
+		if obj.NewUpdateAuthority != nil {
+			err = encoder.WriteBytes((*obj.NewUpdateAuthority)[:], false)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	// Serialize `PrimarySaleHappened` param (optional):
+	{
+		err = encoder.WriteOption(obj.PrimarySaleHappened != nil)
+		if err != nil {
+			return err
+		}
+		if obj.PrimarySaleHappened != nil {
+			err = encoder.WriteBool(*obj.PrimarySaleHappened)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	// Serialize `IsMutable` param (optional):
+	{
+		err = encoder.WriteOption(obj.IsMutable != nil)
+		if err != nil {
+			return err
+		}
+		if obj.IsMutable != nil {
+			err = encoder.WriteBool(*obj.IsMutable)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+func (obj *UpdateMetadataAccountV2) UnmarshalWithDecoder(decoder *ag_binary.Decoder) (err error) {
+	// Deserialize `Data` (optional):
+	{
+		ok, err := decoder.ReadOption()
+		if err != nil {
+			return err
+		}
+		if ok {
+			var v DataV2
+			err := v.UnmarshalWithDecoder(decoder)
+			if err != nil {
+				return err
+			}
+			obj.Data = &v
+		}
+	}
+	// Deserialize `NewUpdateAuthority` (optional):
+	{
+		ok, err := decoder.ReadOption()
+		if err != nil {
+			return err
+		}
+		if ok {
+			v, err := readPublicKey(decoder)
+			if err != nil {
+				return err
+			}
+			obj.NewUpdateAuthority = &v
+		}
+	}
+	// Deserialize `PrimarySaleHappened` (optional):
+	{
+		ok, err := decoder.ReadOption()
+		if err != nil {
+			return err
+		}
+		if ok {
+			v, err := decoder.ReadBool()
+			if err != nil {
+				return err
+			}
+			obj.PrimarySaleHappened = &v
+		}
+	}
+	// Deserialize `IsMutable` (optional):
+	{
+		ok, err := decoder.ReadOption()
+		if err != nil {
+			return err
+		}
+		if ok {
+			v, err := decoder.ReadBool()
+			if err != nil {
+				return err
+			}
+			obj.IsMutable = &v
+		}
+	}
+	return nil
+}
+
+// NewUpdateMetadataAccountV2Instruction declares a new UpdateMetadataAccountV2 instruction with the provided parameters and accounts.
+func NewUpdateMetadataAccountV2Instruction(
+	// Accounts:
+	metadataAccount ag_solanago.PublicKey,
+	updateAuthorityAccount ag_solanago.PublicKey,
+) *UpdateMetadataAccountV2 {
+	return NewUpdateMetadataAccountV2InstructionBuilder().
+		SetMetadataAccount(metadataAccount).
+		SetUpdateAuthorityAccount(updateAuthorityAccount)
+}
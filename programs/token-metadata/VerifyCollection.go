@@ -0,0 +1,208 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tokenmetadata
+
+import (
+	"fmt"
+
+	ag_binary "github.com/gagliardetto/binary"
+	ag_solanago "github.com/gagliardetto/solana-go"
+	ag_format "github.com/gagliardetto/solana-go/text/format"
+	ag_treeout "github.com/gagliardetto/treeout"
+)
+
+// Verify that an asset belongs to a collection.
+type VerifyCollection struct {
+	// [0] = [WRITE] MetadataAccount
+	// ··········· Metadata account of the asset
+	//
+	// [1] = [WRITE, SIGNER] CollectionAuthorityAccount
+	// ··········· Update authority of the collection, or a delegated collection authority
+	//
+	// [2] = [WRITE, SIGNER] PayerAccount
+	// ··········· Payer
+	//
+	// [3] = [] CollectionMintAccount
+	// ··········· Mint of the collection
+	//
+	// [4] = [] CollectionAccount
+	// ··········· Metadata account of the collection
+	//
+	// [5] = [] CollectionMasterEditionAccount
+	// ··········· Master edition account of the collection
+	//
+	// [6] = [] CollectionAuthorityRecordAccount
+	// ··········· Collection authority record, if the authority is delegated (optional)
+	ag_solanago.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+// NewVerifyCollectionInstructionBuilder creates a new `VerifyCollection` instruction builder.
+func NewVerifyCollectionInstructionBuilder() *VerifyCollection {
+	nd := &VerifyCollection{
+		AccountMetaSlice: make(ag_solanago.AccountMetaSlice, 6),
+	}
+	return nd
+}
+
+// Metadata account of the asset.
+func (inst *VerifyCollection) SetMetadataAccount(metadataAccount ag_solanago.PublicKey) *VerifyCollection {
+	inst.AccountMetaSlice[0] = ag_solanago.Meta(metadataAccount).WRITE()
+	return inst
+}
+
+func (inst *VerifyCollection) GetMetadataAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[0]
+}
+
+// Update authority of the collection, or a delegated collection authority.
+func (inst *VerifyCollection) SetCollectionAuthorityAccount(collectionAuthorityAccount ag_solanago.PublicKey) *VerifyCollection {
+	inst.AccountMetaSlice[1] = ag_solanago.Meta(collectionAuthorityAccount).WRITE().SIGNER()
+	return inst
+}
+
+func (inst *VerifyCollection) GetCollectionAuthorityAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[1]
+}
+
+// Payer.
+func (inst *VerifyCollection) SetPayerAccount(payerAccount ag_solanago.PublicKey) *VerifyCollection {
+	inst.AccountMetaSlice[2] = ag_solanago.Meta(payerAccount).WRITE().SIGNER()
+	return inst
+}
+
+func (inst *VerifyCollection) GetPayerAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[2]
+}
+
+// Mint of the collection.
+func (inst *VerifyCollection) SetCollectionMintAccount(collectionMintAccount ag_solanago.PublicKey) *VerifyCollection {
+	inst.AccountMetaSlice[3] = ag_solanago.Meta(collectionMintAccount)
+	return inst
+}
+
+func (inst *VerifyCollection) GetCollectionMintAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[3]
+}
+
+// Metadata account of the collection.
+func (inst *VerifyCollection) SetCollectionAccount(collectionAccount ag_solanago.PublicKey) *VerifyCollection {
+	inst.AccountMetaSlice[4] = ag_solanago.Meta(collectionAccount)
+	return inst
+}
+
+func (inst *VerifyCollection) GetCollectionAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[4]
+}
+
+// Master edition account of the collection.
+func (inst *VerifyCollection) SetCollectionMasterEditionAccount(collectionMasterEditionAccount ag_solanago.PublicKey) *VerifyCollection {
+	inst.AccountMetaSlice[5] = ag_solanago.Meta(collectionMasterEditionAccount)
+	return inst
+}
+
+func (inst *VerifyCollection) GetCollectionMasterEditionAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[5]
+}
+
+// Collection authority record, if the authority is delegated.
+func (inst *VerifyCollection) SetCollectionAuthorityRecordAccount(collectionAuthorityRecordAccount ag_solanago.PublicKey) *VerifyCollection {
+	if len(inst.AccountMetaSlice) <= 6 {
+		inst.AccountMetaSlice = append(inst.AccountMetaSlice, nil)
+	}
+	inst.AccountMetaSlice[6] = ag_solanago.Meta(collectionAuthorityRecordAccount)
+	return inst
+}
+
+func (inst *VerifyCollection) GetCollectionAuthorityRecordAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice.Get(6)
+}
+
+func (inst VerifyCollection) Build() *Instruction {
+	return &Instruction{BaseVariant: ag_binary.BaseVariant{
+		Impl:   inst,
+		TypeID: ag_binary.TypeIDFromUint8(Instruction_VerifyCollection),
+	}}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (inst VerifyCollection) ValidateAndBuild() (*Instruction, error) {
+	if err := inst.Validate(); err != nil {
+		return nil, err
+	}
+	return inst.Build(), nil
+}
+
+func (inst *VerifyCollection) Validate() error {
+	// Check whether all (required) accounts are set:
+	for accIndex, acc := range inst.AccountMetaSlice[:6] {
+		if acc == nil {
+			return fmt.Errorf("ins.AccountMetaSlice[%v] is not set", accIndex)
+		}
+	}
+	return nil
+}
+
+func (inst *VerifyCollection) EncodeToTree(parent ag_treeout.Branches) {
+	parent.Child(ag_format.Program(ProgramName, ProgramID)).
+		//
+		ParentFunc(func(programBranch ag_treeout.Branches) {
+			programBranch.Child(ag_format.Instruction("VerifyCollection")).
+				//
+				ParentFunc(func(instructionBranch ag_treeout.Branches) {
+
+					// Parameters of the instruction:
+					instructionBranch.Child("Params").ParentFunc(func(paramsBranch ag_treeout.Branches) {})
+
+					// Accounts of the instruction:
+					instructionBranch.Child("Accounts").ParentFunc(func(accountsBranch ag_treeout.Branches) {
+						accountsBranch.Child(ag_format.Meta("                 Metadata", inst.AccountMetaSlice.Get(0)))
+						accountsBranch.Child(ag_format.Meta("      CollectionAuthority", inst.AccountMetaSlice.Get(1)))
+						accountsBranch.Child(ag_format.Meta("                    Payer", inst.AccountMetaSlice.Get(2)))
+						accountsBranch.Child(ag_format.Meta("           CollectionMint", inst.AccountMetaSlice.Get(3)))
+						accountsBranch.Child(ag_format.Meta("               Collection", inst.AccountMetaSlice.Get(4)))
+						accountsBranch.Child(ag_format.Meta("  CollectionMasterEdition", inst.AccountMetaSlice.Get(5)))
+						accountsBranch.Child(ag_format.Meta("CollectionAuthorityRecord", inst.AccountMetaSlice.Get(6)))
+					})
+				})
+		})
+}
+
+func (obj VerifyCollection) MarshalWithEncoder(encoder *ag_binary.Encoder) (err error) {
+	return nil
+}
+func (obj *VerifyCollection) UnmarshalWithDecoder(decoder *ag_binary.Decoder) (err error) {
+	return nil
+}
+
+// NewVerifyCollectionInstruction declares a new VerifyCollection instruction with the provided parameters and accounts.
+func NewVerifyCollectionInstruction(
+	// Accounts:
+	metadataAccount ag_solanago.PublicKey,
+	collectionAuthorityAccount ag_solanago.PublicKey,
+	payerAccount ag_solanago.PublicKey,
+	collectionMintAccount ag_solanago.PublicKey,
+	collectionAccount ag_solanago.PublicKey,
+	collectionMasterEditionAccount ag_solanago.PublicKey,
+) *VerifyCollection {
+	return NewVerifyCollectionInstructionBuilder().
+		SetMetadataAccount(metadataAccount).
+		SetCollectionAuthorityAccount(collectionAuthorityAccount).
+		SetPayerAccount(payerAccount).
+		SetCollectionMintAccount(collectionMintAccount).
+		SetCollectionAccount(collectionAccount).
+		SetCollectionMasterEditionAccount(collectionMasterEditionAccount)
+}
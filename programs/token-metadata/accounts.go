@@ -0,0 +1,359 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tokenmetadata
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+
+	ag_binary "github.com/gagliardetto/binary"
+	ag_solanago "github.com/gagliardetto/solana-go"
+)
+
+const (
+	// Maximum size of a metadata account.
+	MaxMetadataLen = 679
+
+	// Size of a master edition account.
+	MaxMasterEditionLen = 282
+
+	// Size of an edition account.
+	MaxEditionLen = 241
+)
+
+// FindMetadataAddress returns the address of the metadata account of the mint.
+func FindMetadataAddress(mint ag_solanago.PublicKey) (ag_solanago.PublicKey, uint8, error) {
+	return ag_solanago.FindProgramAddress(
+		[][]byte{
+			[]byte("metadata"),
+			ProgramID[:],
+			mint[:],
+		},
+		ProgramID,
+	)
+}
+
+// FindMasterEditionAddress returns the address of the master edition,
+// or edition, account of the mint.
+func FindMasterEditionAddress(mint ag_solanago.PublicKey) (ag_solanago.PublicKey, uint8, error) {
+	return ag_solanago.FindProgramAddress(
+		[][]byte{
+			[]byte("metadata"),
+			ProgramID[:],
+			mint[:],
+			[]byte("edition"),
+		},
+		ProgramID,
+	)
+}
+
+// FindTokenRecordAddress returns the address of the token record account
+// of a token account of a programmable non-fungible mint.
+func FindTokenRecordAddress(mint ag_solanago.PublicKey, token ag_solanago.PublicKey) (ag_solanago.PublicKey, uint8, error) {
+	return ag_solanago.FindProgramAddress(
+		[][]byte{
+			[]byte("metadata"),
+			ProgramID[:],
+			mint[:],
+			[]byte("token_record"),
+			token[:],
+		},
+		ProgramID,
+	)
+}
+
+// FindCollectionAuthorityRecordAddress returns the address of the record
+// delegating the collection authority of the mint to the provided authority.
+func FindCollectionAuthorityRecordAddress(mint ag_solanago.PublicKey, authority ag_solanago.PublicKey) (ag_solanago.PublicKey, uint8, error) {
+	return ag_solanago.FindProgramAddress(
+		[][]byte{
+			[]byte("metadata"),
+			ProgramID[:],
+			mint[:],
+			[]byte("collection_authority"),
+			authority[:],
+		},
+		ProgramID,
+	)
+}
+
+// Metadata is the content of a metadata account.
+type Metadata struct {
+	Key                 Key
+	UpdateAuthority     ag_solanago.PublicKey
+	Mint                ag_solanago.PublicKey
+	Data                Data
+	PrimarySaleHappened bool
+	IsMutable           bool
+
+	// The fields below were added by later versions of the program,
+	// and are nil when missing from older accounts.
+
+	// Bump seed of the edition account, used to verify it.
+	EditionNonce       *uint8
+	TokenStandard      *TokenStandard
+	Collection         *Collection
+	Uses               *Uses
+	CollectionDetails  *CollectionDetails
+	ProgrammableConfig *ProgrammableConfig
+}
+
+// DecodeMetadata decodes the data of a metadata account.
+func DecodeMetadata(data []byte) (*Metadata, error) {
+	metadata := new(Metadata)
+	if err := metadata.UnmarshalWithDecoder(ag_binary.NewBorshDecoder(data)); err != nil {
+		return nil, fmt.Errorf("unable to decode metadata: %w", err)
+	}
+	return metadata, nil
+}
+
+func (obj *Metadata) UnmarshalWithDecoder(decoder *ag_binary.Decoder) (err error) {
+	key, err := decoder.ReadUint8()
+	if err != nil {
+		return err
+	}
+	obj.Key = Key(key)
+	if obj.Key != KeyMetadataV1 {
+		return fmt.Errorf("invalid metadata key: %d", key)
+	}
+	if obj.UpdateAuthority, err = readPublicKey(decoder); err != nil {
+		return err
+	}
+	if obj.Mint, err = readPublicKey(decoder); err != nil {
+		return err
+	}
+	if err = obj.Data.UnmarshalWithDecoder(decoder); err != nil {
+		return err
+	}
+	if obj.PrimarySaleHappened, err = decoder.ReadBool(); err != nil {
+		return err
+	}
+	if obj.IsMutable, err = decoder.ReadBool(); err != nil {
+		return err
+	}
+
+	// Like the program, be lenient with the optional fields:
+	// a field that can't be decoded is left unset.
+	if decoder.Remaining() == 0 {
+		return nil
+	}
+	if obj.EditionNonce, err = readOptionalUint8(decoder); err != nil {
+		obj.EditionNonce = nil
+		return nil
+	}
+	{
+		// The token standard, collection and uses were added together.
+		tokenStandard, errStandard := readOptionalUint8(decoder)
+		collection, errCollection := readOptional[Collection](decoder)
+		uses, errUses := readOptional[Uses](decoder)
+		if errStandard != nil || errCollection != nil || errUses != nil {
+			return nil
+		}
+		if tokenStandard != nil {
+			v := TokenStandard(*tokenStandard)
+			obj.TokenStandard = &v
+		}
+		obj.Collection = collection
+		obj.Uses = uses
+	}
+	if obj.CollectionDetails, err = readOptional[CollectionDetails](decoder); err != nil {
+		obj.CollectionDetails = nil
+		return nil
+	}
+	if obj.ProgrammableConfig, err = readOptional[ProgrammableConfig](decoder); err != nil {
+		obj.ProgrammableConfig = nil
+	}
+	return nil
+}
+
+func (obj Metadata) MarshalWithEncoder(encoder *ag_binary.Encoder) (err error) {
+	if err = encoder.WriteUint8(uint8(obj.Key)); err != nil {
+		return err
+	}
+	if err = encoder.WriteBytes(obj.UpdateAuthority[:], false); err != nil {
+		return err
+	}
+	if err = encoder.WriteBytes(obj.Mint[:], false); err != nil {
+		return err
+	}
+	if err = obj.Data.MarshalWithEncoder(encoder); err != nil {
+		return err
+	}
+	if err = encoder.WriteBool(obj.PrimarySaleHappened); err != nil {
+		return err
+	}
+	if err = encoder.WriteBool(obj.IsMutable); err != nil {
+		return err
+	}
+	if err = writeOptionalUint8(encoder, obj.EditionNonce); err != nil {
+		return err
+	}
+	var tokenStandard *uint8
+	if obj.TokenStandard != nil {
+		v := uint8(*obj.TokenStandard)
+		tokenStandard = &v
+	}
+	if err = writeOptionalUint8(encoder, tokenStandard); err != nil {
+		return err
+	}
+	if err = writeOptional(encoder, obj.Collection); err != nil {
+		return err
+	}
+	if err = writeOptional(encoder, obj.Uses); err != nil {
+		return err
+	}
+	if err = writeOptional(encoder, obj.CollectionDetails); err != nil {
+		return err
+	}
+	return writeOptional(encoder, obj.ProgrammableConfig)
+}
+
+// MarshalBinary returns the data of the metadata account,
+// zero padded to MaxMetadataLen.
+func (obj Metadata) MarshalBinary() ([]byte, error) {
+	buf := new(bytes.Buffer)
+	if err := obj.MarshalWithEncoder(ag_binary.NewBorshEncoder(buf)); err != nil {
+		return nil, err
+	}
+	if buf.Len() < MaxMetadataLen {
+		buf.Write(make([]byte, MaxMetadataLen-buf.Len()))
+	}
+	return buf.Bytes(), nil
+}
+
+// MasterEdition is the content of a master edition account.
+type MasterEdition struct {
+	Key Key
+	// Number of prints.
+	Supply uint64
+	// Maximum number of prints; nil if unlimited.
+	MaxSupply *uint64
+}
+
+// DecodeMasterEdition decodes the data of a master edition account.
+func DecodeMasterEdition(data []byte) (*MasterEdition, error) {
+	edition := new(MasterEdition)
+	if err := edition.UnmarshalWithDecoder(ag_binary.NewBorshDecoder(data)); err != nil {
+		return nil, fmt.Errorf("unable to decode master edition: %w", err)
+	}
+	return edition, nil
+}
+
+func (obj *MasterEdition) UnmarshalWithDecoder(decoder *ag_binary.Decoder) (err error) {
+	key, err := decoder.ReadUint8()
+	if err != nil {
+		return err
+	}
+	obj.Key = Key(key)
+	if obj.Key != KeyMasterEditionV2 && obj.Key != KeyMasterEditionV1 {
+		return fmt.Errorf("invalid master edition key: %d", key)
+	}
+	if obj.Supply, err = decoder.ReadUint64(binary.LittleEndian); err != nil {
+		return err
+	}
+	ok, err := decoder.ReadOption()
+	if err != nil || !ok {
+		return err
+	}
+	maxSupply, err := decoder.ReadUint64(binary.LittleEndian)
+	if err != nil {
+		return err
+	}
+	obj.MaxSupply = &maxSupply
+	return nil
+}
+
+func (obj MasterEdition) MarshalWithEncoder(encoder *ag_binary.Encoder) (err error) {
+	if err = encoder.WriteUint8(uint8(obj.Key)); err != nil {
+		return err
+	}
+	if err = encoder.WriteUint64(obj.Supply, binary.LittleEndian); err != nil {
+		return err
+	}
+	if err = encoder.WriteOption(obj.MaxSupply != nil); err != nil {
+		return err
+	}
+	if obj.MaxSupply == nil {
+		return nil
+	}
+	return encoder.WriteUint64(*obj.MaxSupply, binary.LittleEndian)
+}
+
+// Edition is the content of the edition account of a print.
+type Edition struct {
+	Key Key
+	// Master edition account the print was made from.
+	Parent ag_solanago.PublicKey
+	// Number of the print.
+	Edition uint64
+}
+
+// DecodeEdition decodes the data of an edition account.
+func DecodeEdition(data []byte) (*Edition, error) {
+	edition := new(Edition)
+	if err := edition.UnmarshalWithDecoder(ag_binary.NewBorshDecoder(data)); err != nil {
+		return nil, fmt.Errorf("unable to decode edition: %w", err)
+	}
+	return edition, nil
+}
+
+func (obj *Edition) UnmarshalWithDecoder(decoder *ag_binary.Decoder) (err error) {
+	key, err := decoder.ReadUint8()
+	if err != nil {
+		return err
+	}
+	obj.Key = Key(key)
+	if obj.Key != KeyEditionV1 {
+		return fmt.Errorf("invalid edition key: %d", key)
+	}
+	if obj.Parent, err = readPublicKey(decoder); err != nil {
+		return err
+	}
+	obj.Edition, err = decoder.ReadUint64(binary.LittleEndian)
+	return err
+}
+
+func (obj Edition) MarshalWithEncoder(encoder *ag_binary.Encoder) (err error) {
+	if err = encoder.WriteUint8(uint8(obj.Key)); err != nil {
+		return err
+	}
+	if err = encoder.WriteBytes(obj.Parent[:], false); err != nil {
+		return err
+	}
+	return encoder.WriteUint64(obj.Edition, binary.LittleEndian)
+}
+
+func writeOptionalUint8(encoder *ag_binary.Encoder, value *uint8) error {
+	if err := encoder.WriteOption(value != nil); err != nil {
+		return err
+	}
+	if value == nil {
+		return nil
+	}
+	return encoder.WriteUint8(*value)
+}
+
+func readOptionalUint8(decoder *ag_binary.Decoder) (*uint8, error) {
+	ok, err := decoder.ReadOption()
+	if err != nil || !ok {
+		return nil, err
+	}
+	v, err := decoder.ReadUint8()
+	if err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
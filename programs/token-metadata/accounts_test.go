@@ -0,0 +1,138 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tokenmetadata
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	ag_binary "github.com/gagliardetto/binary"
+	ag_solanago "github.com/gagliardetto/solana-go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetadata(t *testing.T) {
+	updateAuthority := ag_solanago.NewWallet().PublicKey()
+	mint := ag_solanago.NewWallet().PublicKey()
+	collection := ag_solanago.NewWallet().PublicKey()
+	ruleSet := ag_solanago.NewWallet().PublicKey()
+	nonce := uint8(254)
+	standard := TokenStandardProgrammableNonFungible
+
+	metadata := Metadata{
+		Key:             KeyMetadataV1,
+		UpdateAuthority: updateAuthority,
+		Mint:            mint,
+		Data: Data{
+			Name:                 "Asset #1",
+			Symbol:               "AST",
+			URI:                  "https://example.com/1.json",
+			SellerFeeBasisPoints: 500,
+			Creators: []Creator{
+				{Address: updateAuthority, Verified: true, Share: 100},
+			},
+		},
+		IsMutable:          true,
+		EditionNonce:       &nonce,
+		TokenStandard:      &standard,
+		Collection:         &Collection{Verified: true, Key: collection},
+		CollectionDetails:  &CollectionDetails{Kind: CollectionDetailsV1, Size: 10},
+		ProgrammableConfig: &ProgrammableConfig{RuleSet: &ruleSet},
+	}
+	data, err := metadata.MarshalBinary()
+	require.NoError(t, err)
+	require.Len(t, data, MaxMetadataLen)
+	require.Equal(t, byte(KeyMetadataV1), data[0])
+	require.Equal(t, updateAuthority[:], data[1:33])
+	require.Equal(t, mint[:], data[33:65])
+	require.Equal(t, uint32(len("Asset #1")), binary.LittleEndian.Uint32(data[65:69]))
+	require.Equal(t, "Asset #1", string(data[69:77]))
+
+	got, err := DecodeMetadata(data)
+	require.NoError(t, err)
+	require.Equal(t, &metadata, got)
+	require.Equal(t, "ProgrammableNonFungible", got.TokenStandard.String())
+
+	// Accounts of older program versions end after IsMutable,
+	// and pad the strings with NULs.
+	legacy := Metadata{
+		Key:             KeyMetadataV1,
+		UpdateAuthority: updateAuthority,
+		Mint:            mint,
+		Data: Data{
+			Name:   "Legacy\x00\x00\x00\x00",
+			Symbol: "LGC\x00\x00",
+			URI:    "https://example.com\x00\x00\x00",
+		},
+	}
+	buf := new(bytes.Buffer)
+	enc := ag_binary.NewBorshEncoder(buf)
+	require.NoError(t, enc.WriteUint8(uint8(KeyMetadataV1)))
+	require.NoError(t, enc.WriteBytes(updateAuthority[:], false))
+	require.NoError(t, enc.WriteBytes(mint[:], false))
+	require.NoError(t, legacy.Data.MarshalWithEncoder(enc))
+	require.NoError(t, enc.WriteBool(false))
+	require.NoError(t, enc.WriteBool(false))
+	got, err = DecodeMetadata(buf.Bytes())
+	require.NoError(t, err)
+	require.Equal(t, &legacy, got)
+	require.Equal(t, "Legacy", got.Data.Trimmed().Name)
+	require.Equal(t, "LGC", got.Data.Trimmed().Symbol)
+	require.Equal(t, "https://example.com", got.Data.Trimmed().URI)
+
+	_, err = DecodeMetadata(data[:40])
+	require.Error(t, err)
+	_, err = DecodeMasterEdition(data)
+	require.Error(t, err)
+}
+
+func TestEditions(t *testing.T) {
+	maxSupply := uint64(100)
+	masterEdition := MasterEdition{Key: KeyMasterEditionV2, Supply: 3, MaxSupply: &maxSupply}
+	buf := new(bytes.Buffer)
+	require.NoError(t, ag_binary.NewBorshEncoder(buf).Encode(masterEdition))
+	require.Equal(t, []byte{6, 3, 0, 0, 0, 0, 0, 0, 0, 1, 100, 0, 0, 0, 0, 0, 0, 0}, buf.Bytes())
+
+	data := make([]byte, MaxMasterEditionLen)
+	copy(data, buf.Bytes())
+	got, err := DecodeMasterEdition(data)
+	require.NoError(t, err)
+	require.Equal(t, &masterEdition, got)
+
+	parent := ag_solanago.NewWallet().PublicKey()
+	edition := Edition{Key: KeyEditionV1, Parent: parent, Edition: 7}
+	buf.Reset()
+	require.NoError(t, ag_binary.NewBorshEncoder(buf).Encode(edition))
+	data = make([]byte, MaxEditionLen)
+	copy(data, buf.Bytes())
+	gotEdition, err := DecodeEdition(data)
+	require.NoError(t, err)
+	require.Equal(t, &edition, gotEdition)
+}
+
+func TestFindAddresses(t *testing.T) {
+	mint := ag_solanago.NewWallet().PublicKey()
+
+	metadata, _, err := FindMetadataAddress(mint)
+	require.NoError(t, err)
+	expected, _, err := ag_solanago.FindTokenMetadataAddress(mint)
+	require.NoError(t, err)
+	require.Equal(t, expected, metadata)
+
+	edition, _, err := FindMasterEditionAddress(mint)
+	require.NoError(t, err)
+	require.NotEqual(t, metadata, edition)
+}
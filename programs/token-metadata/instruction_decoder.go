@@ -0,0 +1,23 @@
+package tokenmetadata
+
+import (
+	"github.com/gagliardetto/solana-go"
+)
+
+var (
+	DecodeUpdateMetadataAccountV2 = decode[*UpdateMetadataAccountV2]()
+	DecodeCreateMasterEditionV3   = decode[*CreateMasterEditionV3]()
+	DecodeVerifyCollection        = decode[*VerifyCollection]()
+	DecodeCreateMetadataAccountV3 = decode[*CreateMetadataAccountV3]()
+	DecodeCreate                  = decode[*Create]()
+	DecodeMint                    = decode[*Mint]()
+	DecodeTransfer                = decode[*Transfer]()
+)
+
+func decode[T any]() func(*solana.Message, int) (T, error) {
+	return solana.DecodeInstructionType[*Instruction, T](
+		ProgramID,
+		InstructionImplDef,
+		DecodeInstruction,
+	)
+}
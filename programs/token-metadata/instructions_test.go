@@ -0,0 +1,173 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tokenmetadata
+
+import (
+	"encoding/binary"
+	"strings"
+	"testing"
+
+	ag_solanago "github.com/gagliardetto/solana-go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateMetadataAccountV3(t *testing.T) {
+	mint := ag_solanago.NewWallet().PublicKey()
+	authority := ag_solanago.NewWallet().PublicKey()
+	metadata, _, err := FindMetadataAddress(mint)
+	require.NoError(t, err)
+
+	inst, err := NewCreateMetadataAccountV3Instruction(
+		DataV2{Name: "Token", Symbol: "TKN", URI: "https://example.com/token.json"},
+		true,
+		metadata,
+		mint,
+		authority,
+		authority,
+		authority,
+		true,
+	).ValidateAndBuild()
+	require.NoError(t, err)
+
+	data, err := inst.Data()
+	require.NoError(t, err)
+	expected := []byte{Instruction_CreateMetadataAccountV3}
+	for _, s := range []string{"Token", "TKN", "https://example.com/token.json"} {
+		expected = binary.LittleEndian.AppendUint32(expected, uint32(len(s)))
+		expected = append(expected, s...)
+	}
+	expected = append(expected,
+		0, 0, // seller fee basis points
+		0, // creators
+		0, // collection
+		0, // uses
+		1, // is mutable
+		0, // collection details
+	)
+	require.Equal(t, expected, data)
+
+	accounts := inst.Accounts()
+	require.Len(t, accounts, 6)
+	require.True(t, accounts[4].IsSigner)
+	require.Equal(t, ag_solanago.SystemProgramID, accounts[5].PublicKey)
+
+	decoded, err := DecodeInstruction(accounts, data)
+	require.NoError(t, err)
+	create := decoded.Impl.(*CreateMetadataAccountV3)
+	require.Equal(t, "TKN", create.Data.Symbol)
+	require.Equal(t, mint, create.GetMintAccount().PublicKey)
+}
+
+func TestTransfer(t *testing.T) {
+	mint := ag_solanago.NewWallet().PublicKey()
+	owner := ag_solanago.NewWallet().PublicKey()
+	destination := ag_solanago.NewWallet().PublicKey()
+
+	transfer := NewTransferInstruction(
+		1,
+		ag_solanago.NewWallet().PublicKey(),
+		owner,
+		ag_solanago.NewWallet().PublicKey(),
+		destination,
+		mint,
+		ag_solanago.NewWallet().PublicKey(),
+		owner,
+		owner,
+	)
+	inst, err := transfer.ValidateAndBuild()
+	require.NoError(t, err)
+
+	data, err := inst.Data()
+	require.NoError(t, err)
+	require.Equal(t, []byte{Instruction_Transfer, 0, 1, 0, 0, 0, 0, 0, 0, 0, 0}, data)
+
+	// Unset optional accounts are replaced by the program ID.
+	accounts := inst.Accounts()
+	require.Len(t, accounts, 17)
+	require.Equal(t, ProgramID, accounts[6].PublicKey)
+	require.False(t, accounts[6].IsWritable)
+	require.Equal(t, ProgramID, accounts[16].PublicKey)
+
+	_, err = DecodeInstruction(accounts, []byte{Instruction_Transfer, 0, 1, 0, 0, 0, 0, 0, 0, 0, 1})
+	require.Error(t, err)
+	_, err = DecodeInstruction(accounts, []byte{Instruction_Transfer, 1, 1, 0, 0, 0, 0, 0, 0, 0, 0})
+	require.Error(t, err)
+
+	// Unsupported instructions fail to decode.
+	_, err = DecodeInstruction(nil, []byte{Instruction_Burn, 0})
+	require.Error(t, err)
+
+	tx, err := ag_solanago.NewTransaction(
+		[]ag_solanago.Instruction{inst},
+		ag_solanago.Hash{},
+		ag_solanago.TransactionPayer(owner),
+	)
+	require.NoError(t, err)
+	out := tx.String()
+	require.True(t, strings.Contains(out, "TokenMetadata"), out)
+	require.True(t, strings.Contains(out, "Transfer"), out)
+
+	got, err := DecodeTransfer(&tx.Message, 0)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), *got.Amount)
+}
+
+func TestCreate(t *testing.T) {
+	mint := ag_solanago.NewWallet().PublicKey()
+	authority := ag_solanago.NewWallet().PublicKey()
+	metadata, _, err := FindMetadataAddress(mint)
+	require.NoError(t, err)
+	masterEdition, _, err := FindMasterEditionAddress(mint)
+	require.NoError(t, err)
+
+	inst, err := NewCreateInstruction(
+		AssetData{
+			Name:          "NFT",
+			URI:           "https://example.com/nft.json",
+			IsMutable:     true,
+			TokenStandard: TokenStandardNonFungible,
+		},
+		metadata,
+		mint,
+		true,
+		authority,
+		authority,
+		authority,
+		false,
+	).
+		SetMasterEditionAccount(masterEdition).
+		SetDecimals(0).
+		SetPrintSupply(PrintSupply{Kind: PrintSupplyZero}).
+		ValidateAndBuild()
+	require.NoError(t, err)
+
+	accounts := inst.Accounts()
+	require.Equal(t, masterEdition, accounts[1].PublicKey)
+	require.True(t, accounts[1].IsWritable)
+	require.True(t, accounts[2].IsSigner)
+	require.False(t, accounts[5].IsSigner)
+
+	data, err := inst.Data()
+	require.NoError(t, err)
+	require.Equal(t, []byte{Instruction_Create, 0}, data[:2])
+	// decimals, print supply
+	require.Equal(t, []byte{1, 0, 1, byte(PrintSupplyZero)}, data[len(data)-4:])
+
+	decoded, err := DecodeInstruction(accounts, data)
+	require.NoError(t, err)
+	create := decoded.Impl.(*Create)
+	require.Equal(t, "NFT", create.AssetData.Name)
+	require.Equal(t, PrintSupplyZero, create.PrintSupply.Kind)
+}
@@ -0,0 +1,606 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tokenmetadata
+
+import (
+	"encoding/binary"
+	"fmt"
+	"strings"
+
+	ag_binary "github.com/gagliardetto/binary"
+	ag_solanago "github.com/gagliardetto/solana-go"
+)
+
+// Key identifies the type of an account owned by the token metadata program.
+type Key ag_binary.BorshEnum
+
+const (
+	KeyUninitialized Key = iota
+	KeyEditionV1
+	KeyMasterEditionV1
+	KeyReservationListV1
+	KeyMetadataV1
+	KeyReservationListV2
+	KeyMasterEditionV2
+	KeyEditionMarker
+	KeyUseAuthorityRecord
+	KeyCollectionAuthorityRecord
+	KeyTokenOwnedEscrow
+	KeyTokenRecord
+	KeyMetadataDelegate
+	KeyEditionMarkerV2
+	KeyHolderDelegate
+)
+
+// TokenStandard is the kind of asset a mint represents.
+type TokenStandard ag_binary.BorshEnum
+
+const (
+	// A non-fungible token with a master edition.
+	TokenStandardNonFungible TokenStandard = iota
+	// A token with metadata that can also have attributes, sometimes called semi-fungible.
+	TokenStandardFungibleAsset
+	// A token with simple metadata.
+	TokenStandardFungible
+	// A non-fungible token with an edition.
+	TokenStandardNonFungibleEdition
+	// A non-fungible token with programmable configuration.
+	TokenStandardProgrammableNonFungible
+	// An edition of a programmable non-fungible token.
+	TokenStandardProgrammableNonFungibleEdition
+)
+
+func (value TokenStandard) String() string {
+	switch value {
+	case TokenStandardNonFungible:
+		return "NonFungible"
+	case TokenStandardFungibleAsset:
+		return "FungibleAsset"
+	case TokenStandardFungible:
+		return "Fungible"
+	case TokenStandardNonFungibleEdition:
+		return "NonFungibleEdition"
+	case TokenStandardProgrammableNonFungible:
+		return "ProgrammableNonFungible"
+	case TokenStandardProgrammableNonFungibleEdition:
+		return "ProgrammableNonFungibleEdition"
+	default:
+		return fmt.Sprintf("Unknown(%d)", uint8(value))
+	}
+}
+
+// UseMethod is how the uses of an asset are consumed.
+type UseMethod ag_binary.BorshEnum
+
+const (
+	UseMethodBurn UseMethod = iota
+	UseMethodMultiple
+	UseMethodSingle
+)
+
+// Creator of an asset, entitled to a share of the royalties.
+type Creator struct {
+	Address  ag_solanago.PublicKey
+	Verified bool
+	// Share of the royalties, in percent.
+	Share uint8
+}
+
+func (obj Creator) MarshalWithEncoder(encoder *ag_binary.Encoder) (err error) {
+	if err = encoder.WriteBytes(obj.Address[:], false); err != nil {
+		return err
+	}
+	if err = encoder.WriteBool(obj.Verified); err != nil {
+		return err
+	}
+	return encoder.WriteUint8(obj.Share)
+}
+
+func (obj *Creator) UnmarshalWithDecoder(decoder *ag_binary.Decoder) (err error) {
+	if obj.Address, err = readPublicKey(decoder); err != nil {
+		return err
+	}
+	if obj.Verified, err = decoder.ReadBool(); err != nil {
+		return err
+	}
+	obj.Share, err = decoder.ReadUint8()
+	return err
+}
+
+// Collection an asset belongs to.
+type Collection struct {
+	Verified bool
+	// Mint of the collection parent.
+	Key ag_solanago.PublicKey
+}
+
+func (obj Collection) MarshalWithEncoder(encoder *ag_binary.Encoder) (err error) {
+	if err = encoder.WriteBool(obj.Verified); err != nil {
+		return err
+	}
+	return encoder.WriteBytes(obj.Key[:], false)
+}
+
+func (obj *Collection) UnmarshalWithDecoder(decoder *ag_binary.Decoder) (err error) {
+	if obj.Verified, err = decoder.ReadBool(); err != nil {
+		return err
+	}
+	obj.Key, err = readPublicKey(decoder)
+	return err
+}
+
+// Uses of an asset.
+type Uses struct {
+	UseMethod UseMethod
+	Remaining uint64
+	Total     uint64
+}
+
+func (obj Uses) MarshalWithEncoder(encoder *ag_binary.Encoder) (err error) {
+	if err = encoder.WriteUint8(uint8(obj.UseMethod)); err != nil {
+		return err
+	}
+	if err = encoder.WriteUint64(obj.Remaining, binary.LittleEndian); err != nil {
+		return err
+	}
+	return encoder.WriteUint64(obj.Total, binary.LittleEndian)
+}
+
+func (obj *Uses) UnmarshalWithDecoder(decoder *ag_binary.Decoder) (err error) {
+	method, err := decoder.ReadUint8()
+	if err != nil {
+		return err
+	}
+	obj.UseMethod = UseMethod(method)
+	if obj.Remaining, err = decoder.ReadUint64(binary.LittleEndian); err != nil {
+		return err
+	}
+	obj.Total, err = decoder.ReadUint64(binary.LittleEndian)
+	return err
+}
+
+// Data is the metadata of an asset, as stored in the metadata account.
+type Data struct {
+	Name   string
+	Symbol string
+	URI    string
+	// Royalties, in basis points.
+	SellerFeeBasisPoints uint16
+	// Creators of the asset; nil if none.
+	Creators []Creator
+}
+
+// Trimmed returns a copy of the data without the NUL padding of the
+// name, symbol and URI of accounts created by older program versions.
+func (obj Data) Trimmed() Data {
+	obj.Name = strings.TrimRight(obj.Name, "\x00")
+	obj.Symbol = strings.TrimRight(obj.Symbol, "\x00")
+	obj.URI = strings.TrimRight(obj.URI, "\x00")
+	return obj
+}
+
+func (obj Data) MarshalWithEncoder(encoder *ag_binary.Encoder) (err error) {
+	if err = writeString(encoder, obj.Name); err != nil {
+		return err
+	}
+	if err = writeString(encoder, obj.Symbol); err != nil {
+		return err
+	}
+	if err = writeString(encoder, obj.URI); err != nil {
+		return err
+	}
+	if err = encoder.WriteUint16(obj.SellerFeeBasisPoints, binary.LittleEndian); err != nil {
+		return err
+	}
+	return writeCreators(encoder, obj.Creators)
+}
+
+func (obj *Data) UnmarshalWithDecoder(decoder *ag_binary.Decoder) (err error) {
+	if obj.Name, err = readString(decoder); err != nil {
+		return err
+	}
+	if obj.Symbol, err = readString(decoder); err != nil {
+		return err
+	}
+	if obj.URI, err = readString(decoder); err != nil {
+		return err
+	}
+	if obj.SellerFeeBasisPoints, err = decoder.ReadUint16(binary.LittleEndian); err != nil {
+		return err
+	}
+	obj.Creators, err = readCreators(decoder)
+	return err
+}
+
+// DataV2 is the metadata of an asset, as provided when creating or updating it.
+type DataV2 struct {
+	Name   string
+	Symbol string
+	URI    string
+	// Royalties, in basis points.
+	SellerFeeBasisPoints uint16
+	// Creators of the asset; nil if none.
+	Creators   []Creator
+	Collection *Collection
+	Uses       *Uses
+}
+
+func (obj DataV2) MarshalWithEncoder(encoder *ag_binary.Encoder) (err error) {
+	err = Data{
+		Name:                 obj.Name,
+		Symbol:               obj.Symbol,
+		URI:                  obj.URI,
+		SellerFeeBasisPoints: obj.SellerFeeBasisPoints,
+		Creators:             obj.Creators,
+	}.MarshalWithEncoder(encoder)
+	if err != nil {
+		return err
+	}
+	if err = writeOptional(encoder, obj.Collection); err != nil {
+		return err
+	}
+	return writeOptional(encoder, obj.Uses)
+}
+
+func (obj *DataV2) UnmarshalWithDecoder(decoder *ag_binary.Decoder) (err error) {
+	var data Data
+	if err = data.UnmarshalWithDecoder(decoder); err != nil {
+		return err
+	}
+	obj.Name = data.Name
+	obj.Symbol = data.Symbol
+	obj.URI = data.URI
+	obj.SellerFeeBasisPoints = data.SellerFeeBasisPoints
+	obj.Creators = data.Creators
+	if obj.Collection, err = readOptional[Collection](decoder); err != nil {
+		return err
+	}
+	obj.Uses, err = readOptional[Uses](decoder)
+	return err
+}
+
+// CollectionDetailsKind is the variant of the details of a collection.
+type CollectionDetailsKind ag_binary.BorshEnum
+
+const (
+	// The collection tracks its size.
+	CollectionDetailsV1 CollectionDetailsKind = iota
+	// The collection size is tracked off-chain.
+	CollectionDetailsV2
+)
+
+// CollectionDetails marks a collection parent.
+type CollectionDetails struct {
+	Kind CollectionDetailsKind
+	// Number of verified items of a V1 collection.
+	Size uint64
+	// Padding of a V2 collection.
+	Padding [8]byte
+}
+
+func (obj CollectionDetails) MarshalWithEncoder(encoder *ag_binary.Encoder) (err error) {
+	if err = encoder.WriteUint8(uint8(obj.Kind)); err != nil {
+		return err
+	}
+	switch obj.Kind {
+	case CollectionDetailsV1:
+		return encoder.WriteUint64(obj.Size, binary.LittleEndian)
+	case CollectionDetailsV2:
+		return encoder.WriteBytes(obj.Padding[:], false)
+	default:
+		return fmt.Errorf("invalid collection details kind: %d", obj.Kind)
+	}
+}
+
+func (obj *CollectionDetails) UnmarshalWithDecoder(decoder *ag_binary.Decoder) (err error) {
+	kind, err := decoder.ReadUint8()
+	if err != nil {
+		return err
+	}
+	obj.Kind = CollectionDetailsKind(kind)
+	switch obj.Kind {
+	case CollectionDetailsV1:
+		obj.Size, err = decoder.ReadUint64(binary.LittleEndian)
+		return err
+	case CollectionDetailsV2:
+		padding, err := decoder.ReadNBytes(8)
+		if err != nil {
+			return err
+		}
+		copy(obj.Padding[:], padding)
+		return nil
+	default:
+		return fmt.Errorf("invalid collection details kind: %d", kind)
+	}
+}
+
+// ProgrammableConfig is the configuration of a programmable non-fungible token.
+type ProgrammableConfig struct {
+	// Authorization rule set applied to the operations on the token; nil if none.
+	RuleSet *ag_solanago.PublicKey
+}
+
+func (obj ProgrammableConfig) MarshalWithEncoder(encoder *ag_binary.Encoder) (err error) {
+	// ProgrammableConfig::V1
+	if err = encoder.WriteUint8(0); err != nil {
+		return err
+	}
+	return writeOptionalPublicKey(encoder, obj.RuleSet)
+}
+
+func (obj *ProgrammableConfig) UnmarshalWithDecoder(decoder *ag_binary.Decoder) (err error) {
+	version, err := decoder.ReadUint8()
+	if err != nil {
+		return err
+	}
+	if version != 0 {
+		return fmt.Errorf("invalid programmable config version: %d", version)
+	}
+	obj.RuleSet, err = readOptionalPublicKey(decoder)
+	return err
+}
+
+// PrintSupplyKind is the kind of limit on the number of prints of a master edition.
+type PrintSupplyKind ag_binary.BorshEnum
+
+const (
+	// No prints can be made.
+	PrintSupplyZero PrintSupplyKind = iota
+	// Up to Limit prints can be made.
+	PrintSupplyLimited
+	// Any number of prints can be made.
+	PrintSupplyUnlimited
+)
+
+// PrintSupply limits the number of prints of a master edition.
+type PrintSupply struct {
+	Kind PrintSupplyKind
+	// Maximum number of prints of a Limited supply.
+	Limit uint64
+}
+
+func (obj PrintSupply) MarshalWithEncoder(encoder *ag_binary.Encoder) (err error) {
+	if err = encoder.WriteUint8(uint8(obj.Kind)); err != nil {
+		return err
+	}
+	switch obj.Kind {
+	case PrintSupplyZero, PrintSupplyUnlimited:
+		return nil
+	case PrintSupplyLimited:
+		return encoder.WriteUint64(obj.Limit, binary.LittleEndian)
+	default:
+		return fmt.Errorf("invalid print supply kind: %d", obj.Kind)
+	}
+}
+
+func (obj *PrintSupply) UnmarshalWithDecoder(decoder *ag_binary.Decoder) (err error) {
+	kind, err := decoder.ReadUint8()
+	if err != nil {
+		return err
+	}
+	obj.Kind = PrintSupplyKind(kind)
+	switch obj.Kind {
+	case PrintSupplyZero, PrintSupplyUnlimited:
+		return nil
+	case PrintSupplyLimited:
+		obj.Limit, err = decoder.ReadUint64(binary.LittleEndian)
+		return err
+	default:
+		return fmt.Errorf("invalid print supply kind: %d", kind)
+	}
+}
+
+// AssetData is the metadata of an asset created with the Create instruction.
+type AssetData struct {
+	Name   string
+	Symbol string
+	URI    string
+	// Royalties, in basis points.
+	SellerFeeBasisPoints uint16
+	// Creators of the asset; nil if none.
+	Creators            []Creator
+	PrimarySaleHappened bool
+	IsMutable           bool
+	TokenStandard       TokenStandard
+	Collection          *Collection
+	Uses                *Uses
+	CollectionDetails   *CollectionDetails
+	// Authorization rule set of a programmable non-fungible token; nil if none.
+	RuleSet *ag_solanago.PublicKey
+}
+
+func (obj AssetData) MarshalWithEncoder(encoder *ag_binary.Encoder) (err error) {
+	err = Data{
+		Name:                 obj.Name,
+		Symbol:               obj.Symbol,
+		URI:                  obj.URI,
+		SellerFeeBasisPoints: obj.SellerFeeBasisPoints,
+		Creators:             obj.Creators,
+	}.MarshalWithEncoder(encoder)
+	if err != nil {
+		return err
+	}
+	if err = encoder.WriteBool(obj.PrimarySaleHappened); err != nil {
+		return err
+	}
+	if err = encoder.WriteBool(obj.IsMutable); err != nil {
+		return err
+	}
+	if err = encoder.WriteUint8(uint8(obj.TokenStandard)); err != nil {
+		return err
+	}
+	if err = writeOptional(encoder, obj.Collection); err != nil {
+		return err
+	}
+	if err = writeOptional(encoder, obj.Uses); err != nil {
+		return err
+	}
+	if err = writeOptional(encoder, obj.CollectionDetails); err != nil {
+		return err
+	}
+	return writeOptionalPublicKey(encoder, obj.RuleSet)
+}
+
+func (obj *AssetData) UnmarshalWithDecoder(decoder *ag_binary.Decoder) (err error) {
+	var data Data
+	if err = data.UnmarshalWithDecoder(decoder); err != nil {
+		return err
+	}
+	obj.Name = data.Name
+	obj.Symbol = data.Symbol
+	obj.URI = data.URI
+	obj.SellerFeeBasisPoints = data.SellerFeeBasisPoints
+	obj.Creators = data.Creators
+	if obj.PrimarySaleHappened, err = decoder.ReadBool(); err != nil {
+		return err
+	}
+	if obj.IsMutable, err = decoder.ReadBool(); err != nil {
+		return err
+	}
+	standard, err := decoder.ReadUint8()
+	if err != nil {
+		return err
+	}
+	obj.TokenStandard = TokenStandard(standard)
+	if obj.Collection, err = readOptional[Collection](decoder); err != nil {
+		return err
+	}
+	if obj.Uses, err = readOptional[Uses](decoder); err != nil {
+		return err
+	}
+	if obj.CollectionDetails, err = readOptional[CollectionDetails](decoder); err != nil {
+		return err
+	}
+	obj.RuleSet, err = readOptionalPublicKey(decoder)
+	return err
+}
+
+// Borsh strings and vectors are prefixed by their u32 length.
+
+func writeString(encoder *ag_binary.Encoder, s string) error {
+	if err := encoder.WriteUint32(uint32(len(s)), binary.LittleEndian); err != nil {
+		return err
+	}
+	return encoder.WriteBytes([]byte(s), false)
+}
+
+func readString(decoder *ag_binary.Decoder) (string, error) {
+	length, err := decoder.ReadUint32(binary.LittleEndian)
+	if err != nil {
+		return "", err
+	}
+	if int(length) > decoder.Remaining() {
+		return "", fmt.Errorf("invalid string length: %d", length)
+	}
+	data, err := decoder.ReadNBytes(int(length))
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+func writeCreators(encoder *ag_binary.Encoder, creators []Creator) error {
+	if err := encoder.WriteOption(creators != nil); err != nil {
+		return err
+	}
+	if creators == nil {
+		return nil
+	}
+	if err := encoder.WriteUint32(uint32(len(creators)), binary.LittleEndian); err != nil {
+		return err
+	}
+	for _, creator := range creators {
+		if err := creator.MarshalWithEncoder(encoder); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func readCreators(decoder *ag_binary.Decoder) ([]Creator, error) {
+	ok, err := decoder.ReadOption()
+	if err != nil || !ok {
+		return nil, err
+	}
+	count, err := decoder.ReadUint32(binary.LittleEndian)
+	if err != nil {
+		return nil, err
+	}
+	if int(count)*34 > decoder.Remaining() {
+		return nil, fmt.Errorf("invalid creators count: %d", count)
+	}
+	creators := make([]Creator, count)
+	for i := range creators {
+		if err := creators[i].UnmarshalWithDecoder(decoder); err != nil {
+			return nil, err
+		}
+	}
+	return creators, nil
+}
+
+func writeOptional[T ag_binary.BinaryMarshaler](encoder *ag_binary.Encoder, value *T) error {
+	if err := encoder.WriteOption(value != nil); err != nil {
+		return err
+	}
+	if value == nil {
+		return nil
+	}
+	return (*value).MarshalWithEncoder(encoder)
+}
+
+func readOptional[T any, PT interface {
+	*T
+	ag_binary.BinaryUnmarshaler
+}](decoder *ag_binary.Decoder) (*T, error) {
+	ok, err := decoder.ReadOption()
+	if err != nil || !ok {
+		return nil, err
+	}
+	value := PT(new(T))
+	if err := value.UnmarshalWithDecoder(decoder); err != nil {
+		return nil, err
+	}
+	return (*T)(value), nil
+}
+
+func writeOptionalPublicKey(encoder *ag_binary.Encoder, key *ag_solanago.PublicKey) error {
+	if err := encoder.WriteOption(key != nil); err != nil {
+		return err
+	}
+	if key == nil {
+		return nil
+	}
+	return encoder.WriteBytes(key[:], false)
+}
+
+func readOptionalPublicKey(decoder *ag_binary.Decoder) (*ag_solanago.PublicKey, error) {
+	ok, err := decoder.ReadOption()
+	if err != nil || !ok {
+		return nil, err
+	}
+	key, err := readPublicKey(decoder)
+	if err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+func readPublicKey(decoder *ag_binary.Decoder) (ag_solanago.PublicKey, error) {
+	v, err := decoder.ReadNBytes(32)
+	if err != nil {
+		return ag_solanago.PublicKey{}, err
+	}
+	return ag_solanago.PublicKeyFromBytes(v), nil
+}
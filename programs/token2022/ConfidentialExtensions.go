@@ -0,0 +1,117 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package token2022
+
+import (
+	"fmt"
+
+	ag_binary "github.com/gagliardetto/binary"
+	ag_solanago "github.com/gagliardetto/solana-go"
+	ag_format "github.com/gagliardetto/solana-go/text/format"
+	ag_treeout "github.com/gagliardetto/treeout"
+)
+
+// The confidential extensions carry zero-knowledge proof data; their
+// instructions are kept undecoded so that transactions using them can
+// still be parsed and re-encoded.
+
+// ConfidentialTransferExtension is an instruction of the confidential
+// transfer extension.
+type ConfidentialTransferExtension struct {
+	// The sub-instruction ID followed by its data.
+	Data []byte
+
+	ag_solanago.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+func (obj ConfidentialTransferExtension) MarshalWithEncoder(encoder *ag_binary.Encoder) error {
+	return encoder.WriteBytes(obj.Data, false)
+}
+
+func (obj *ConfidentialTransferExtension) UnmarshalWithDecoder(decoder *ag_binary.Decoder) (err error) {
+	obj.Data, err = decoder.ReadNBytes(decoder.Remaining())
+	return err
+}
+
+func (inst *ConfidentialTransferExtension) EncodeToTree(parent ag_treeout.Branches) {
+	encodeRawInstructionToTree(parent, "ConfidentialTransferExtension", inst.Data, inst.AccountMetaSlice)
+}
+
+// ConfidentialTransferFeeExtension is an instruction of the confidential
+// transfer fee extension.
+type ConfidentialTransferFeeExtension struct {
+	// The sub-instruction ID followed by its data.
+	Data []byte
+
+	ag_solanago.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+func (obj ConfidentialTransferFeeExtension) MarshalWithEncoder(encoder *ag_binary.Encoder) error {
+	return encoder.WriteBytes(obj.Data, false)
+}
+
+func (obj *ConfidentialTransferFeeExtension) UnmarshalWithDecoder(decoder *ag_binary.Decoder) (err error) {
+	obj.Data, err = decoder.ReadNBytes(decoder.Remaining())
+	return err
+}
+
+func (inst *ConfidentialTransferFeeExtension) EncodeToTree(parent ag_treeout.Branches) {
+	encodeRawInstructionToTree(parent, "ConfidentialTransferFeeExtension", inst.Data, inst.AccountMetaSlice)
+}
+
+// ConfidentialMintBurnExtension is an instruction of the confidential
+// mint-burn extension.
+type ConfidentialMintBurnExtension struct {
+	// The sub-instruction ID followed by its data.
+	Data []byte
+
+	ag_solanago.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+func (obj ConfidentialMintBurnExtension) MarshalWithEncoder(encoder *ag_binary.Encoder) error {
+	return encoder.WriteBytes(obj.Data, false)
+}
+
+func (obj *ConfidentialMintBurnExtension) UnmarshalWithDecoder(decoder *ag_binary.Decoder) (err error) {
+	obj.Data, err = decoder.ReadNBytes(decoder.Remaining())
+	return err
+}
+
+func (inst *ConfidentialMintBurnExtension) EncodeToTree(parent ag_treeout.Branches) {
+	encodeRawInstructionToTree(parent, "ConfidentialMintBurnExtension", inst.Data, inst.AccountMetaSlice)
+}
+
+func encodeRawInstructionToTree(parent ag_treeout.Branches, name string, data []byte, accounts ag_solanago.AccountMetaSlice) {
+	parent.Child(ag_format.Program(ProgramName, ProgramID)).
+		//
+		ParentFunc(func(programBranch ag_treeout.Branches) {
+			programBranch.Child(ag_format.Instruction(name)).
+				//
+				ParentFunc(func(instructionBranch ag_treeout.Branches) {
+
+					// Parameters of the instruction:
+					instructionBranch.Child("Params").ParentFunc(func(paramsBranch ag_treeout.Branches) {
+						paramsBranch.Child(ag_format.Param("Data", data))
+					})
+
+					// Accounts of the instruction:
+					instructionBranch.Child("Accounts").ParentFunc(func(accountsBranch ag_treeout.Branches) {
+						for i, v := range accounts {
+							accountsBranch.Child(ag_format.Meta(fmt.Sprintf("[%v]", i), v))
+						}
+					})
+				})
+		})
+}
@@ -0,0 +1,63 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package token2022
+
+import (
+	ag_binary "github.com/gagliardetto/binary"
+)
+
+// Instructions of the CPI guard extension, encoded after
+// `Instruction_CpiGuardExtension`.
+const (
+	// Lock certain token operations from taking place within CPI for this account.
+	CpiGuard_Enable uint8 = iota
+
+	// Allow all token operations to happen via CPI as normal.
+	CpiGuard_Disable
+)
+
+// CpiGuardInstructionIDToName returns the name of the CPI guard
+// extension instruction given its ID.
+func CpiGuardInstructionIDToName(id uint8) string {
+	switch id {
+	case CpiGuard_Enable:
+		return "EnableCpiGuard"
+	case CpiGuard_Disable:
+		return "DisableCpiGuard"
+	default:
+		return ""
+	}
+}
+
+var CpiGuardInstructionImplDef = ag_binary.NewVariantDefinition(
+	ag_binary.Uint8TypeIDEncoding,
+	[]ag_binary.VariantType{
+		{
+			"EnableCpiGuard", (*EnableCpiGuard)(nil),
+		},
+		{
+			"DisableCpiGuard", (*DisableCpiGuard)(nil),
+		},
+	},
+)
+
+// CpiGuardExtension wraps the instructions of the CPI guard extension.
+type CpiGuardExtension struct {
+	extensionInstruction
+}
+
+func (inst *CpiGuardExtension) UnmarshalWithDecoder(decoder *ag_binary.Decoder) error {
+	return inst.BaseVariant.UnmarshalBinaryVariant(decoder, CpiGuardInstructionImplDef)
+}
@@ -0,0 +1,162 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package token2022
+
+import (
+	"errors"
+
+	ag_binary "github.com/gagliardetto/binary"
+	ag_solanago "github.com/gagliardetto/solana-go"
+	ag_format "github.com/gagliardetto/solana-go/text/format"
+	ag_treeout "github.com/gagliardetto/treeout"
+)
+
+// Creates the native mint.
+//
+// This instruction only needs to be invoked once after deployment and is
+// permissionless. Wrapped SOL (`native_mint::id()`) will not be available
+// until this instruction is successfully executed.
+type CreateNativeMint struct {
+	// [0] = [WRITE, SIGNER] payer
+	// ··········· Funding account (must be a system account).
+	//
+	// [1] = [WRITE] nativeMint
+	// ··········· The native mint address.
+	//
+	// [2] = [] $(SystemProgramID)
+	// ··········· System program for mint account funding.
+	ag_solanago.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+// NewCreateNativeMintInstructionBuilder creates a new `CreateNativeMint` instruction builder.
+func NewCreateNativeMintInstructionBuilder() *CreateNativeMint {
+	nd := &CreateNativeMint{
+		AccountMetaSlice: make(ag_solanago.AccountMetaSlice, 3),
+	}
+	nd.AccountMetaSlice[2] = ag_solanago.Meta(ag_solanago.SystemProgramID)
+	return nd
+}
+
+// SetPayerAccount sets the "payer" account.
+// Funding account (must be a system account).
+func (inst *CreateNativeMint) SetPayerAccount(payer ag_solanago.PublicKey) *CreateNativeMint {
+	inst.AccountMetaSlice[0] = ag_solanago.Meta(payer).WRITE().SIGNER()
+	return inst
+}
+
+// GetPayerAccount gets the "payer" account.
+// Funding account (must be a system account).
+func (inst *CreateNativeMint) GetPayerAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[0]
+}
+
+// SetNativeMintAccount sets the "nativeMint" account.
+// The native mint address.
+func (inst *CreateNativeMint) SetNativeMintAccount(nativeMint ag_solanago.PublicKey) *CreateNativeMint {
+	inst.AccountMetaSlice[1] = ag_solanago.Meta(nativeMint).WRITE()
+	return inst
+}
+
+// GetNativeMintAccount gets the "nativeMint" account.
+// The native mint address.
+func (inst *CreateNativeMint) GetNativeMintAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[1]
+}
+
+// SetSystemProgramAccount sets the "systemProgram" account.
+// System program for mint account funding.
+func (inst *CreateNativeMint) SetSystemProgramAccount(systemProgram ag_solanago.PublicKey) *CreateNativeMint {
+	inst.AccountMetaSlice[2] = ag_solanago.Meta(systemProgram)
+	return inst
+}
+
+// GetSystemProgramAccount gets the "systemProgram" account.
+// System program for mint account funding.
+func (inst *CreateNativeMint) GetSystemProgramAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[2]
+}
+
+func (inst CreateNativeMint) Build() *Instruction {
+	return &Instruction{BaseVariant: ag_binary.BaseVariant{
+		Impl:   inst,
+		TypeID: ag_binary.TypeIDFromUint8(Instruction_CreateNativeMint),
+	}}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (inst CreateNativeMint) ValidateAndBuild() (*Instruction, error) {
+	if err := inst.Validate(); err != nil {
+		return nil, err
+	}
+	return inst.Build(), nil
+}
+
+func (inst *CreateNativeMint) Validate() error {
+	// Check whether all (required) accounts are set:
+	{
+		if inst.AccountMetaSlice[0] == nil {
+			return errors.New("accounts.Payer is not set")
+		}
+		if inst.AccountMetaSlice[1] == nil {
+			return errors.New("accounts.NativeMint is not set")
+		}
+		if inst.AccountMetaSlice[2] == nil {
+			return errors.New("accounts.SystemProgram is not set")
+		}
+	}
+	return nil
+}
+
+func (inst *CreateNativeMint) EncodeToTree(parent ag_treeout.Branches) {
+	parent.Child(ag_format.Program(ProgramName, ProgramID)).
+		//
+		ParentFunc(func(programBranch ag_treeout.Branches) {
+			programBranch.Child(ag_format.Instruction("CreateNativeMint")).
+				//
+				ParentFunc(func(instructionBranch ag_treeout.Branches) {
+
+					// Parameters of the instruction:
+					instructionBranch.Child("Params").ParentFunc(func(paramsBranch ag_treeout.Branches) {})
+
+					// Accounts of the instruction:
+					instructionBranch.Child("Accounts").ParentFunc(func(accountsBranch ag_treeout.Branches) {
+						accountsBranch.Child(ag_format.Meta("        payer", inst.AccountMetaSlice[0]))
+						accountsBranch.Child(ag_format.Meta("   nativeMint", inst.AccountMetaSlice[1]))
+						accountsBranch.Child(ag_format.Meta("systemProgram", inst.AccountMetaSlice[2]))
+					})
+				})
+		})
+}
+
+func (obj CreateNativeMint) MarshalWithEncoder(encoder *ag_binary.Encoder) (err error) {
+	return nil
+}
+func (obj *CreateNativeMint) UnmarshalWithDecoder(decoder *ag_binary.Decoder) (err error) {
+	return nil
+}
+
+// NewCreateNativeMintInstruction declares a new CreateNativeMint instruction with the provided parameters and accounts.
+func NewCreateNativeMintInstruction(
+	// Accounts:
+	payer ag_solanago.PublicKey,
+	nativeMint ag_solanago.PublicKey,
+	systemProgram ag_solanago.PublicKey) *CreateNativeMint {
+	return NewCreateNativeMintInstructionBuilder().
+		SetPayerAccount(payer).
+		SetNativeMintAccount(nativeMint).
+		SetSystemProgramAccount(systemProgram)
+}
@@ -0,0 +1,63 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package token2022
+
+import (
+	ag_binary "github.com/gagliardetto/binary"
+)
+
+// Instructions of the default account state extension, encoded after
+// `Instruction_DefaultAccountStateExtension`.
+const (
+	// Initialize a new mint with the default state for new Accounts.
+	DefaultAccountState_Initialize uint8 = iota
+
+	// Update the default state for new Accounts.
+	DefaultAccountState_Update
+)
+
+// DefaultAccountStateInstructionIDToName returns the name of the default account state
+// extension instruction given its ID.
+func DefaultAccountStateInstructionIDToName(id uint8) string {
+	switch id {
+	case DefaultAccountState_Initialize:
+		return "InitializeDefaultAccountState"
+	case DefaultAccountState_Update:
+		return "UpdateDefaultAccountState"
+	default:
+		return ""
+	}
+}
+
+var DefaultAccountStateInstructionImplDef = ag_binary.NewVariantDefinition(
+	ag_binary.Uint8TypeIDEncoding,
+	[]ag_binary.VariantType{
+		{
+			"InitializeDefaultAccountState", (*InitializeDefaultAccountState)(nil),
+		},
+		{
+			"UpdateDefaultAccountState", (*UpdateDefaultAccountState)(nil),
+		},
+	},
+)
+
+// DefaultAccountStateExtension wraps the instructions of the default account state extension.
+type DefaultAccountStateExtension struct {
+	extensionInstruction
+}
+
+func (inst *DefaultAccountStateExtension) UnmarshalWithDecoder(decoder *ag_binary.Decoder) error {
+	return inst.BaseVariant.UnmarshalBinaryVariant(decoder, DefaultAccountStateInstructionImplDef)
+}
@@ -0,0 +1,177 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package token2022
+
+import (
+	"errors"
+	"fmt"
+
+	ag_binary "github.com/gagliardetto/binary"
+	ag_solanago "github.com/gagliardetto/solana-go"
+	ag_format "github.com/gagliardetto/solana-go/text/format"
+	ag_treeout "github.com/gagliardetto/treeout"
+)
+
+// Allow all token operations to happen via CPI as normal.
+type DisableCpiGuard struct {
+	// [0] = [WRITE] account
+	// ··········· The account to update.
+	//
+	// [1] = [SIGNER] owner
+	// ··········· The account's owner or its multisignature account.
+	//
+	// [2...] = [SIGNER] signers
+	// ··········· M signer accounts.
+	Accounts ag_solanago.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+	Signers  ag_solanago.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+func (obj *DisableCpiGuard) SetAccounts(accounts []*ag_solanago.AccountMeta) error {
+	obj.Accounts, obj.Signers = ag_solanago.AccountMetaSlice(accounts).SplitFrom(2)
+	return nil
+}
+
+func (slice DisableCpiGuard) GetAccounts() (accounts []*ag_solanago.AccountMeta) {
+	accounts = append(accounts, slice.Accounts...)
+	accounts = append(accounts, slice.Signers...)
+	return
+}
+
+// NewDisableCpiGuardInstructionBuilder creates a new `DisableCpiGuard` instruction builder.
+func NewDisableCpiGuardInstructionBuilder() *DisableCpiGuard {
+	nd := &DisableCpiGuard{
+		Accounts: make(ag_solanago.AccountMetaSlice, 2),
+		Signers:  make(ag_solanago.AccountMetaSlice, 0),
+	}
+	return nd
+}
+
+// SetAccount sets the "account" account.
+// The account to update.
+func (inst *DisableCpiGuard) SetAccount(account ag_solanago.PublicKey) *DisableCpiGuard {
+	inst.Accounts[0] = ag_solanago.Meta(account).WRITE()
+	return inst
+}
+
+// GetAccount gets the "account" account.
+// The account to update.
+func (inst *DisableCpiGuard) GetAccount() *ag_solanago.AccountMeta {
+	return inst.Accounts[0]
+}
+
+// SetOwnerAccount sets the "owner" account.
+// The account's owner or its multisignature account.
+func (inst *DisableCpiGuard) SetOwnerAccount(owner ag_solanago.PublicKey, multisigSigners ...ag_solanago.PublicKey) *DisableCpiGuard {
+	inst.Accounts[1] = ag_solanago.Meta(owner)
+	if len(multisigSigners) == 0 {
+		inst.Accounts[1].SIGNER()
+	}
+	for _, signer := range multisigSigners {
+		inst.Signers = append(inst.Signers, ag_solanago.Meta(signer).SIGNER())
+	}
+	return inst
+}
+
+// GetOwnerAccount gets the "owner" account.
+// The account's owner or its multisignature account.
+func (inst *DisableCpiGuard) GetOwnerAccount() *ag_solanago.AccountMeta {
+	return inst.Accounts[1]
+}
+
+func (inst DisableCpiGuard) Build() *Instruction {
+	return &Instruction{BaseVariant: ag_binary.BaseVariant{
+		Impl: &CpiGuardExtension{extensionInstruction{ag_binary.BaseVariant{
+			Impl:   inst,
+			TypeID: ag_binary.TypeIDFromUint8(CpiGuard_Disable),
+		}}},
+		TypeID: ag_binary.TypeIDFromUint8(Instruction_CpiGuardExtension),
+	}}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (inst DisableCpiGuard) ValidateAndBuild() (*Instruction, error) {
+	if err := inst.Validate(); err != nil {
+		return nil, err
+	}
+	return inst.Build(), nil
+}
+
+func (inst *DisableCpiGuard) Validate() error {
+	// Check whether all (required) accounts are set:
+	{
+		if inst.Accounts[0] == nil {
+			return errors.New("accounts.Account is not set")
+		}
+		if inst.Accounts[1] == nil {
+			return errors.New("accounts.Owner is not set")
+		}
+		if !inst.Accounts[1].IsSigner && len(inst.Signers) == 0 {
+			return fmt.Errorf("accounts.Signers is not set")
+		}
+		if len(inst.Signers) > MAX_SIGNERS {
+			return fmt.Errorf("too many signers; got %v, but max is 11", len(inst.Signers))
+		}
+	}
+	return nil
+}
+
+func (inst *DisableCpiGuard) EncodeToTree(parent ag_treeout.Branches) {
+	parent.Child(ag_format.Program(ProgramName, ProgramID)).
+		//
+		ParentFunc(func(programBranch ag_treeout.Branches) {
+			programBranch.Child(ag_format.Instruction("DisableCpiGuard")).
+				//
+				ParentFunc(func(instructionBranch ag_treeout.Branches) {
+
+					// Parameters of the instruction:
+					instructionBranch.Child("Params").ParentFunc(func(paramsBranch ag_treeout.Branches) {})
+
+					// Accounts of the instruction:
+					instructionBranch.Child("Accounts").ParentFunc(func(accountsBranch ag_treeout.Branches) {
+						accountsBranch.Child(ag_format.Meta("account", inst.Accounts[0]))
+						accountsBranch.Child(ag_format.Meta("  owner", inst.Accounts[1]))
+
+						signersBranch := accountsBranch.Child(fmt.Sprintf("signers[len=%v]", len(inst.Signers)))
+						for i, v := range inst.Signers {
+							if len(inst.Signers) > 9 && i < 10 {
+								signersBranch.Child(ag_format.Meta(fmt.Sprintf(" [%v]", i), v))
+							} else {
+								signersBranch.Child(ag_format.Meta(fmt.Sprintf("[%v]", i), v))
+							}
+						}
+					})
+				})
+		})
+}
+
+func (obj DisableCpiGuard) MarshalWithEncoder(encoder *ag_binary.Encoder) (err error) {
+	return nil
+}
+func (obj *DisableCpiGuard) UnmarshalWithDecoder(decoder *ag_binary.Decoder) (err error) {
+	return nil
+}
+
+// NewDisableCpiGuardInstruction declares a new DisableCpiGuard instruction with the provided parameters and accounts.
+func NewDisableCpiGuardInstruction(
+	// Accounts:
+	account ag_solanago.PublicKey,
+	owner ag_solanago.PublicKey,
+	multisigSigners []ag_solanago.PublicKey) *DisableCpiGuard {
+	return NewDisableCpiGuardInstructionBuilder().
+		SetAccount(account).
+		SetOwnerAccount(owner, multisigSigners...)
+}
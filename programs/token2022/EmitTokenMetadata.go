@@ -0,0 +1,207 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package token2022
+
+import (
+	"errors"
+
+	ag_binary "github.com/gagliardetto/binary"
+	ag_solanago "github.com/gagliardetto/solana-go"
+	ag_format "github.com/gagliardetto/solana-go/text/format"
+	ag_treeout "github.com/gagliardetto/treeout"
+)
+
+// Emits the token-metadata as return data.
+//
+// The format of the data emitted follows exactly the `TokenMetadata`
+// struct, but it's possible that the account data is stored in another
+// format by the program.
+type EmitTokenMetadata struct {
+	// Start of range of data to emit.
+	Start *uint64 `bin:"optional"`
+
+	// End of range of data to emit.
+	End *uint64 `bin:"optional"`
+
+	// [0] = [] metadata
+	// ··········· The metadata account.
+	ag_solanago.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+// NewEmitTokenMetadataInstructionBuilder creates a new `EmitTokenMetadata` instruction builder.
+func NewEmitTokenMetadataInstructionBuilder() *EmitTokenMetadata {
+	nd := &EmitTokenMetadata{
+		AccountMetaSlice: make(ag_solanago.AccountMetaSlice, 1),
+	}
+	return nd
+}
+
+// SetStart sets the "start" parameter.
+// Start of range of data to emit.
+func (inst *EmitTokenMetadata) SetStart(start uint64) *EmitTokenMetadata {
+	inst.Start = &start
+	return inst
+}
+
+// SetEnd sets the "end" parameter.
+// End of range of data to emit.
+func (inst *EmitTokenMetadata) SetEnd(end uint64) *EmitTokenMetadata {
+	inst.End = &end
+	return inst
+}
+
+// SetMetadataAccount sets the "metadata" account.
+// The metadata account.
+func (inst *EmitTokenMetadata) SetMetadataAccount(metadata ag_solanago.PublicKey) *EmitTokenMetadata {
+	inst.AccountMetaSlice[0] = ag_solanago.Meta(metadata)
+	return inst
+}
+
+// GetMetadataAccount gets the "metadata" account.
+// The metadata account.
+func (inst *EmitTokenMetadata) GetMetadataAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[0]
+}
+
+func (inst EmitTokenMetadata) Build() *Instruction {
+	return &Instruction{BaseVariant: ag_binary.BaseVariant{
+		Impl:   inst,
+		TypeID: TokenMetadata_Emit,
+	}}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (inst EmitTokenMetadata) ValidateAndBuild() (*Instruction, error) {
+	if err := inst.Validate(); err != nil {
+		return nil, err
+	}
+	return inst.Build(), nil
+}
+
+func (inst *EmitTokenMetadata) Validate() error {
+	// Check whether all (required) accounts are set:
+	{
+		if inst.AccountMetaSlice[0] == nil {
+			return errors.New("accounts.Metadata is not set")
+		}
+	}
+	return nil
+}
+
+func (inst *EmitTokenMetadata) EncodeToTree(parent ag_treeout.Branches) {
+	parent.Child(ag_format.Program(ProgramName, ProgramID)).
+		//
+		ParentFunc(func(programBranch ag_treeout.Branches) {
+			programBranch.Child(ag_format.Instruction("EmitTokenMetadata")).
+				//
+				ParentFunc(func(instructionBranch ag_treeout.Branches) {
+
+					// Parameters of the instruction:
+					instructionBranch.Child("Params").ParentFunc(func(paramsBranch ag_treeout.Branches) {
+						paramsBranch.Child(ag_format.Param("Start (OPT)", inst.Start))
+						paramsBranch.Child(ag_format.Param("  End (OPT)", inst.End))
+					})
+
+					// Accounts of the instruction:
+					instructionBranch.Child("Accounts").ParentFunc(func(accountsBranch ag_treeout.Branches) {
+						accountsBranch.Child(ag_format.Meta("metadata", inst.AccountMetaSlice[0]))
+					})
+				})
+		})
+}
+
+func (obj EmitTokenMetadata) MarshalWithEncoder(encoder *ag_binary.Encoder) (err error) {
+	// Serialize `Start` param (optional):
+	{
+		if obj.Start == nil {
+			err = encoder.WriteBool(false)
+			if err != nil {
+				return err
+			}
+		} else {
+			err = encoder.WriteBool(true)
+			if err != nil {
+				return err
+			}
+			err = encoder.Encode(obj.Start)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	// Serialize `End` param (optional):
+	{
+		if obj.End == nil {
+			err = encoder.WriteBool(false)
+			if err != nil {
+				return err
+			}
+		} else {
+			err = encoder.WriteBool(true)
+			if err != nil {
+				return err
+			}
+			err = encoder.Encode(obj.End)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+func (obj *EmitTokenMetadata) UnmarshalWithDecoder(decoder *ag_binary.Decoder) (err error) {
+	// Deserialize `Start` (optional):
+	{
+		ok, err := decoder.ReadBool()
+		if err != nil {
+			return err
+		}
+		if ok {
+			err = decoder.Decode(&obj.Start)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	// Deserialize `End` (optional):
+	{
+		ok, err := decoder.ReadBool()
+		if err != nil {
+			return err
+		}
+		if ok {
+			err = decoder.Decode(&obj.End)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// NewEmitTokenMetadataInstruction declares a new EmitTokenMetadata instruction with the provided parameters and accounts.
+func NewEmitTokenMetadataInstruction(
+	// Parameters:
+	start uint64,
+	end uint64,
+	// Accounts:
+	metadata ag_solanago.PublicKey) *EmitTokenMetadata {
+	return NewEmitTokenMetadataInstructionBuilder().
+		SetStart(start).
+		SetEnd(end).
+		SetMetadataAccount(metadata)
+}
@@ -0,0 +1,145 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package token2022
+
+import (
+	"errors"
+
+	ag_binary "github.com/gagliardetto/binary"
+	ag_solanago "github.com/gagliardetto/solana-go"
+	ag_format "github.com/gagliardetto/solana-go/text/format"
+	ag_treeout "github.com/gagliardetto/treeout"
+)
+
+// Gets the required size of an account for the given mint as a little-endian
+// `u64`, via the program return data.
+type GetAccountDataSize struct {
+	// The account extensions to include on top of the mint's required ones.
+	ExtensionTypes []ExtensionType
+
+	// [0] = [] mint
+	// ··········· The mint to calculate for.
+	ag_solanago.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+// NewGetAccountDataSizeInstructionBuilder creates a new `GetAccountDataSize` instruction builder.
+func NewGetAccountDataSizeInstructionBuilder() *GetAccountDataSize {
+	nd := &GetAccountDataSize{
+		AccountMetaSlice: make(ag_solanago.AccountMetaSlice, 1),
+	}
+	return nd
+}
+
+// SetExtensionTypes sets the "extension_types" parameter.
+// The account extensions to include on top of the mint's required ones.
+func (inst *GetAccountDataSize) SetExtensionTypes(extension_types ...ExtensionType) *GetAccountDataSize {
+	inst.ExtensionTypes = extension_types
+	return inst
+}
+
+// SetMintAccount sets the "mint" account.
+// The mint to calculate for.
+func (inst *GetAccountDataSize) SetMintAccount(mint ag_solanago.PublicKey) *GetAccountDataSize {
+	inst.AccountMetaSlice[0] = ag_solanago.Meta(mint)
+	return inst
+}
+
+// GetMintAccount gets the "mint" account.
+// The mint to calculate for.
+func (inst *GetAccountDataSize) GetMintAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[0]
+}
+
+func (inst GetAccountDataSize) Build() *Instruction {
+	return &Instruction{BaseVariant: ag_binary.BaseVariant{
+		Impl:   inst,
+		TypeID: ag_binary.TypeIDFromUint8(Instruction_GetAccountDataSize),
+	}}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (inst GetAccountDataSize) ValidateAndBuild() (*Instruction, error) {
+	if err := inst.Validate(); err != nil {
+		return nil, err
+	}
+	return inst.Build(), nil
+}
+
+func (inst *GetAccountDataSize) Validate() error {
+	// Check whether all (required) accounts are set:
+	{
+		if inst.AccountMetaSlice[0] == nil {
+			return errors.New("accounts.Mint is not set")
+		}
+	}
+	return nil
+}
+
+func (inst *GetAccountDataSize) EncodeToTree(parent ag_treeout.Branches) {
+	parent.Child(ag_format.Program(ProgramName, ProgramID)).
+		//
+		ParentFunc(func(programBranch ag_treeout.Branches) {
+			programBranch.Child(ag_format.Instruction("GetAccountDataSize")).
+				//
+				ParentFunc(func(instructionBranch ag_treeout.Branches) {
+
+					// Parameters of the instruction:
+					instructionBranch.Child("Params").ParentFunc(func(paramsBranch ag_treeout.Branches) {
+						paramsBranch.Child(ag_format.Param("ExtensionTypes", inst.ExtensionTypes))
+					})
+
+					// Accounts of the instruction:
+					instructionBranch.Child("Accounts").ParentFunc(func(accountsBranch ag_treeout.Branches) {
+						accountsBranch.Child(ag_format.Meta("mint", inst.AccountMetaSlice[0]))
+					})
+				})
+		})
+}
+
+func (obj GetAccountDataSize) MarshalWithEncoder(encoder *ag_binary.Encoder) (err error) {
+	// Serialize `ExtensionTypes` param:
+	for _, extensionType := range obj.ExtensionTypes {
+		err = encoder.WriteUint16(uint16(extensionType), ag_binary.LE)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+func (obj *GetAccountDataSize) UnmarshalWithDecoder(decoder *ag_binary.Decoder) (err error) {
+	// Deserialize `ExtensionTypes`:
+	obj.ExtensionTypes = make([]ExtensionType, 0, decoder.Remaining()/2)
+	for decoder.Remaining() >= 2 {
+		v, err := decoder.ReadUint16(ag_binary.LE)
+		if err != nil {
+			return err
+		}
+		obj.ExtensionTypes = append(obj.ExtensionTypes, ExtensionType(v))
+	}
+	return nil
+}
+
+// NewGetAccountDataSizeInstruction declares a new GetAccountDataSize instruction with the provided parameters and accounts.
+func NewGetAccountDataSizeInstruction(
+	// Parameters:
+	extension_types []ExtensionType,
+	// Accounts:
+	mint ag_solanago.PublicKey) *GetAccountDataSize {
+	return NewGetAccountDataSizeInstructionBuilder().
+		SetExtensionTypes(extension_types...).
+		SetMintAccount(mint)
+}
@@ -0,0 +1,63 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package token2022
+
+import (
+	ag_binary "github.com/gagliardetto/binary"
+)
+
+// Instructions of the group member pointer extension, encoded after
+// `Instruction_GroupMemberPointerExtension`.
+const (
+	// Initialize a new mint with a group member pointer.
+	GroupMemberPointer_Initialize uint8 = iota
+
+	// Update the group member address.
+	GroupMemberPointer_Update
+)
+
+// GroupMemberPointerInstructionIDToName returns the name of the group member pointer
+// extension instruction given its ID.
+func GroupMemberPointerInstructionIDToName(id uint8) string {
+	switch id {
+	case GroupMemberPointer_Initialize:
+		return "InitializeGroupMemberPointer"
+	case GroupMemberPointer_Update:
+		return "UpdateGroupMemberPointer"
+	default:
+		return ""
+	}
+}
+
+var GroupMemberPointerInstructionImplDef = ag_binary.NewVariantDefinition(
+	ag_binary.Uint8TypeIDEncoding,
+	[]ag_binary.VariantType{
+		{
+			"InitializeGroupMemberPointer", (*InitializeGroupMemberPointer)(nil),
+		},
+		{
+			"UpdateGroupMemberPointer", (*UpdateGroupMemberPointer)(nil),
+		},
+	},
+)
+
+// GroupMemberPointerExtension wraps the instructions of the group member pointer extension.
+type GroupMemberPointerExtension struct {
+	extensionInstruction
+}
+
+func (inst *GroupMemberPointerExtension) UnmarshalWithDecoder(decoder *ag_binary.Decoder) error {
+	return inst.BaseVariant.UnmarshalBinaryVariant(decoder, GroupMemberPointerInstructionImplDef)
+}
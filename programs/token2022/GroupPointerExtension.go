@@ -0,0 +1,63 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package token2022
+
+import (
+	ag_binary "github.com/gagliardetto/binary"
+)
+
+// Instructions of the group pointer extension, encoded after
+// `Instruction_GroupPointerExtension`.
+const (
+	// Initialize a new mint with a group pointer.
+	GroupPointer_Initialize uint8 = iota
+
+	// Update the group address.
+	GroupPointer_Update
+)
+
+// GroupPointerInstructionIDToName returns the name of the group pointer
+// extension instruction given its ID.
+func GroupPointerInstructionIDToName(id uint8) string {
+	switch id {
+	case GroupPointer_Initialize:
+		return "InitializeGroupPointer"
+	case GroupPointer_Update:
+		return "UpdateGroupPointer"
+	default:
+		return ""
+	}
+}
+
+var GroupPointerInstructionImplDef = ag_binary.NewVariantDefinition(
+	ag_binary.Uint8TypeIDEncoding,
+	[]ag_binary.VariantType{
+		{
+			"InitializeGroupPointer", (*InitializeGroupPointer)(nil),
+		},
+		{
+			"UpdateGroupPointer", (*UpdateGroupPointer)(nil),
+		},
+	},
+)
+
+// GroupPointerExtension wraps the instructions of the group pointer extension.
+type GroupPointerExtension struct {
+	extensionInstruction
+}
+
+func (inst *GroupPointerExtension) UnmarshalWithDecoder(decoder *ag_binary.Decoder) error {
+	return inst.BaseVariant.UnmarshalBinaryVariant(decoder, GroupPointerInstructionImplDef)
+}
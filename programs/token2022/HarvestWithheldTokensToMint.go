@@ -0,0 +1,147 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package token2022
+
+import (
+	"errors"
+	"fmt"
+
+	ag_binary "github.com/gagliardetto/binary"
+	ag_solanago "github.com/gagliardetto/solana-go"
+	ag_format "github.com/gagliardetto/solana-go/text/format"
+	ag_treeout "github.com/gagliardetto/treeout"
+)
+
+// Permissionless instruction to transfer all withheld tokens to the mint.
+//
+// Succeeds for frozen accounts.
+//
+// Accounts provided should include the `TransferFeeAmount` extension. If
+// not, the account is skipped.
+type HarvestWithheldTokensToMint struct {
+	// [0] = [WRITE] mint
+	// ··········· The mint.
+	//
+	// [1...] = [WRITE] sources
+	// ··········· The source accounts to harvest from.
+	ag_solanago.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+// NewHarvestWithheldTokensToMintInstructionBuilder creates a new `HarvestWithheldTokensToMint` instruction builder.
+func NewHarvestWithheldTokensToMintInstructionBuilder() *HarvestWithheldTokensToMint {
+	nd := &HarvestWithheldTokensToMint{
+		AccountMetaSlice: make(ag_solanago.AccountMetaSlice, 1),
+	}
+	return nd
+}
+
+// SetMintAccount sets the "mint" account.
+// The mint.
+func (inst *HarvestWithheldTokensToMint) SetMintAccount(mint ag_solanago.PublicKey) *HarvestWithheldTokensToMint {
+	inst.AccountMetaSlice[0] = ag_solanago.Meta(mint).WRITE()
+	return inst
+}
+
+// GetMintAccount gets the "mint" account.
+// The mint.
+func (inst *HarvestWithheldTokensToMint) GetMintAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[0]
+}
+
+// AddSourceAccounts appends the "sources" accounts.
+// The source accounts to harvest from.
+func (inst *HarvestWithheldTokensToMint) AddSourceAccounts(sources ...ag_solanago.PublicKey) *HarvestWithheldTokensToMint {
+	for _, source := range sources {
+		inst.AccountMetaSlice = append(inst.AccountMetaSlice, ag_solanago.Meta(source).WRITE())
+	}
+	return inst
+}
+
+// GetSourceAccounts gets the "sources" accounts.
+// The source accounts to harvest from.
+func (inst *HarvestWithheldTokensToMint) GetSourceAccounts() ag_solanago.AccountMetaSlice {
+	return inst.AccountMetaSlice[1:]
+}
+
+func (inst HarvestWithheldTokensToMint) Build() *Instruction {
+	return &Instruction{BaseVariant: ag_binary.BaseVariant{
+		Impl: &TransferFeeExtension{extensionInstruction{ag_binary.BaseVariant{
+			Impl:   inst,
+			TypeID: ag_binary.TypeIDFromUint8(TransferFee_HarvestWithheldTokensToMint),
+		}}},
+		TypeID: ag_binary.TypeIDFromUint8(Instruction_TransferFeeExtension),
+	}}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (inst HarvestWithheldTokensToMint) ValidateAndBuild() (*Instruction, error) {
+	if err := inst.Validate(); err != nil {
+		return nil, err
+	}
+	return inst.Build(), nil
+}
+
+func (inst *HarvestWithheldTokensToMint) Validate() error {
+	// Check whether all (required) accounts are set:
+	{
+		if inst.AccountMetaSlice[0] == nil {
+			return errors.New("accounts.Mint is not set")
+		}
+	}
+	return nil
+}
+
+func (inst *HarvestWithheldTokensToMint) EncodeToTree(parent ag_treeout.Branches) {
+	parent.Child(ag_format.Program(ProgramName, ProgramID)).
+		//
+		ParentFunc(func(programBranch ag_treeout.Branches) {
+			programBranch.Child(ag_format.Instruction("HarvestWithheldTokensToMint")).
+				//
+				ParentFunc(func(instructionBranch ag_treeout.Branches) {
+
+					// Parameters of the instruction:
+					instructionBranch.Child("Params").ParentFunc(func(paramsBranch ag_treeout.Branches) {})
+
+					// Accounts of the instruction:
+					instructionBranch.Child("Accounts").ParentFunc(func(accountsBranch ag_treeout.Branches) {
+						accountsBranch.Child(ag_format.Meta("mint", inst.AccountMetaSlice[0]))
+
+						sourcesBranch := accountsBranch.Child(fmt.Sprintf("sources[len=%v]", len(inst.AccountMetaSlice)-1))
+						for i, v := range inst.AccountMetaSlice[1:] {
+							sourcesBranch.Child(ag_format.Meta(fmt.Sprintf("[%v]", i), v))
+						}
+					})
+				})
+		})
+}
+
+func (obj HarvestWithheldTokensToMint) MarshalWithEncoder(encoder *ag_binary.Encoder) (err error) {
+	return nil
+}
+func (obj *HarvestWithheldTokensToMint) UnmarshalWithDecoder(decoder *ag_binary.Decoder) (err error) {
+	return nil
+}
+
+// NewHarvestWithheldTokensToMintInstruction declares a new HarvestWithheldTokensToMint instruction with the provided parameters and accounts.
+func NewHarvestWithheldTokensToMintInstruction(
+	// Accounts:
+	mint ag_solanago.PublicKey,
+	sources []ag_solanago.PublicKey) *HarvestWithheldTokensToMint {
+	return NewHarvestWithheldTokensToMintInstructionBuilder().
+		SetMintAccount(mint).
+		AddSourceAccounts(sources...)
+}
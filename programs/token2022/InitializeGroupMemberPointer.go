@@ -0,0 +1,167 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package token2022
+
+import (
+	"errors"
+
+	ag_binary "github.com/gagliardetto/binary"
+	ag_solanago "github.com/gagliardetto/solana-go"
+	ag_format "github.com/gagliardetto/solana-go/text/format"
+	ag_treeout "github.com/gagliardetto/treeout"
+)
+
+// Initialize a new mint with a group member pointer.
+//
+// Fails if the mint has already been initialized, so must be called before
+// `InitializeMint`.
+type InitializeGroupMemberPointer struct {
+	// The public key for the account that can update the address.
+	Authority *ag_solanago.PublicKey
+
+	// The account address that holds the group member configurations.
+	MemberAddress *ag_solanago.PublicKey
+
+	// [0] = [WRITE] mint
+	// ··········· The mint to initialize.
+	ag_solanago.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+// NewInitializeGroupMemberPointerInstructionBuilder creates a new `InitializeGroupMemberPointer` instruction builder.
+func NewInitializeGroupMemberPointerInstructionBuilder() *InitializeGroupMemberPointer {
+	nd := &InitializeGroupMemberPointer{
+		AccountMetaSlice: make(ag_solanago.AccountMetaSlice, 1),
+	}
+	return nd
+}
+
+// SetAuthority sets the "authority" parameter.
+// The public key for the account that can update the address.
+func (inst *InitializeGroupMemberPointer) SetAuthority(authority ag_solanago.PublicKey) *InitializeGroupMemberPointer {
+	inst.Authority = &authority
+	return inst
+}
+
+// SetMemberAddress sets the "member_address" parameter.
+// The account address that holds the group member configurations.
+func (inst *InitializeGroupMemberPointer) SetMemberAddress(member_address ag_solanago.PublicKey) *InitializeGroupMemberPointer {
+	inst.MemberAddress = &member_address
+	return inst
+}
+
+// SetMintAccount sets the "mint" account.
+// The mint to initialize.
+func (inst *InitializeGroupMemberPointer) SetMintAccount(mint ag_solanago.PublicKey) *InitializeGroupMemberPointer {
+	inst.AccountMetaSlice[0] = ag_solanago.Meta(mint).WRITE()
+	return inst
+}
+
+// GetMintAccount gets the "mint" account.
+// The mint to initialize.
+func (inst *InitializeGroupMemberPointer) GetMintAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[0]
+}
+
+func (inst InitializeGroupMemberPointer) Build() *Instruction {
+	return &Instruction{BaseVariant: ag_binary.BaseVariant{
+		Impl: &GroupMemberPointerExtension{extensionInstruction{ag_binary.BaseVariant{
+			Impl:   inst,
+			TypeID: ag_binary.TypeIDFromUint8(GroupMemberPointer_Initialize),
+		}}},
+		TypeID: ag_binary.TypeIDFromUint8(Instruction_GroupMemberPointerExtension),
+	}}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (inst InitializeGroupMemberPointer) ValidateAndBuild() (*Instruction, error) {
+	if err := inst.Validate(); err != nil {
+		return nil, err
+	}
+	return inst.Build(), nil
+}
+
+func (inst *InitializeGroupMemberPointer) Validate() error {
+	// Check whether all (required) accounts are set:
+	{
+		if inst.AccountMetaSlice[0] == nil {
+			return errors.New("accounts.Mint is not set")
+		}
+	}
+	return nil
+}
+
+func (inst *InitializeGroupMemberPointer) EncodeToTree(parent ag_treeout.Branches) {
+	parent.Child(ag_format.Program(ProgramName, ProgramID)).
+		//
+		ParentFunc(func(programBranch ag_treeout.Branches) {
+			programBranch.Child(ag_format.Instruction("InitializeGroupMemberPointer")).
+				//
+				ParentFunc(func(instructionBranch ag_treeout.Branches) {
+
+					// Parameters of the instruction:
+					instructionBranch.Child("Params").ParentFunc(func(paramsBranch ag_treeout.Branches) {
+						paramsBranch.Child(ag_format.Param("    Authority (OPT)", inst.Authority))
+						paramsBranch.Child(ag_format.Param("MemberAddress (OPT)", inst.MemberAddress))
+					})
+
+					// Accounts of the instruction:
+					instructionBranch.Child("Accounts").ParentFunc(func(accountsBranch ag_treeout.Branches) {
+						accountsBranch.Child(ag_format.Meta("mint", inst.AccountMetaSlice[0]))
+					})
+				})
+		})
+}
+
+func (obj InitializeGroupMemberPointer) MarshalWithEncoder(encoder *ag_binary.Encoder) (err error) {
+	// Serialize `Authority` param (optional, zeroed when unset):
+	err = writeOptionalNonZeroPubkey(encoder, obj.Authority)
+	if err != nil {
+		return err
+	}
+	// Serialize `MemberAddress` param (optional, zeroed when unset):
+	err = writeOptionalNonZeroPubkey(encoder, obj.MemberAddress)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+func (obj *InitializeGroupMemberPointer) UnmarshalWithDecoder(decoder *ag_binary.Decoder) (err error) {
+	// Deserialize `Authority` (optional, zeroed when unset):
+	obj.Authority, err = readOptionalNonZeroPubkey(decoder)
+	if err != nil {
+		return err
+	}
+	// Deserialize `MemberAddress` (optional, zeroed when unset):
+	obj.MemberAddress, err = readOptionalNonZeroPubkey(decoder)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// NewInitializeGroupMemberPointerInstruction declares a new InitializeGroupMemberPointer instruction with the provided parameters and accounts.
+func NewInitializeGroupMemberPointerInstruction(
+	// Parameters:
+	authority ag_solanago.PublicKey,
+	member_address ag_solanago.PublicKey,
+	// Accounts:
+	mint ag_solanago.PublicKey) *InitializeGroupMemberPointer {
+	return NewInitializeGroupMemberPointerInstructionBuilder().
+		SetAuthority(authority).
+		SetMemberAddress(member_address).
+		SetMintAccount(mint)
+}
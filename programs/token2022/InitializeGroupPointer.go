@@ -0,0 +1,167 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package token2022
+
+import (
+	"errors"
+
+	ag_binary "github.com/gagliardetto/binary"
+	ag_solanago "github.com/gagliardetto/solana-go"
+	ag_format "github.com/gagliardetto/solana-go/text/format"
+	ag_treeout "github.com/gagliardetto/treeout"
+)
+
+// Initialize a new mint with a group pointer.
+//
+// Fails if the mint has already been initialized, so must be called before
+// `InitializeMint`.
+type InitializeGroupPointer struct {
+	// The public key for the account that can update the address.
+	Authority *ag_solanago.PublicKey
+
+	// The account address that holds the group configurations.
+	GroupAddress *ag_solanago.PublicKey
+
+	// [0] = [WRITE] mint
+	// ··········· The mint to initialize.
+	ag_solanago.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+// NewInitializeGroupPointerInstructionBuilder creates a new `InitializeGroupPointer` instruction builder.
+func NewInitializeGroupPointerInstructionBuilder() *InitializeGroupPointer {
+	nd := &InitializeGroupPointer{
+		AccountMetaSlice: make(ag_solanago.AccountMetaSlice, 1),
+	}
+	return nd
+}
+
+// SetAuthority sets the "authority" parameter.
+// The public key for the account that can update the address.
+func (inst *InitializeGroupPointer) SetAuthority(authority ag_solanago.PublicKey) *InitializeGroupPointer {
+	inst.Authority = &authority
+	return inst
+}
+
+// SetGroupAddress sets the "group_address" parameter.
+// The account address that holds the group configurations.
+func (inst *InitializeGroupPointer) SetGroupAddress(group_address ag_solanago.PublicKey) *InitializeGroupPointer {
+	inst.GroupAddress = &group_address
+	return inst
+}
+
+// SetMintAccount sets the "mint" account.
+// The mint to initialize.
+func (inst *InitializeGroupPointer) SetMintAccount(mint ag_solanago.PublicKey) *InitializeGroupPointer {
+	inst.AccountMetaSlice[0] = ag_solanago.Meta(mint).WRITE()
+	return inst
+}
+
+// GetMintAccount gets the "mint" account.
+// The mint to initialize.
+func (inst *InitializeGroupPointer) GetMintAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[0]
+}
+
+func (inst InitializeGroupPointer) Build() *Instruction {
+	return &Instruction{BaseVariant: ag_binary.BaseVariant{
+		Impl: &GroupPointerExtension{extensionInstruction{ag_binary.BaseVariant{
+			Impl:   inst,
+			TypeID: ag_binary.TypeIDFromUint8(GroupPointer_Initialize),
+		}}},
+		TypeID: ag_binary.TypeIDFromUint8(Instruction_GroupPointerExtension),
+	}}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (inst InitializeGroupPointer) ValidateAndBuild() (*Instruction, error) {
+	if err := inst.Validate(); err != nil {
+		return nil, err
+	}
+	return inst.Build(), nil
+}
+
+func (inst *InitializeGroupPointer) Validate() error {
+	// Check whether all (required) accounts are set:
+	{
+		if inst.AccountMetaSlice[0] == nil {
+			return errors.New("accounts.Mint is not set")
+		}
+	}
+	return nil
+}
+
+func (inst *InitializeGroupPointer) EncodeToTree(parent ag_treeout.Branches) {
+	parent.Child(ag_format.Program(ProgramName, ProgramID)).
+		//
+		ParentFunc(func(programBranch ag_treeout.Branches) {
+			programBranch.Child(ag_format.Instruction("InitializeGroupPointer")).
+				//
+				ParentFunc(func(instructionBranch ag_treeout.Branches) {
+
+					// Parameters of the instruction:
+					instructionBranch.Child("Params").ParentFunc(func(paramsBranch ag_treeout.Branches) {
+						paramsBranch.Child(ag_format.Param("   Authority (OPT)", inst.Authority))
+						paramsBranch.Child(ag_format.Param("GroupAddress (OPT)", inst.GroupAddress))
+					})
+
+					// Accounts of the instruction:
+					instructionBranch.Child("Accounts").ParentFunc(func(accountsBranch ag_treeout.Branches) {
+						accountsBranch.Child(ag_format.Meta("mint", inst.AccountMetaSlice[0]))
+					})
+				})
+		})
+}
+
+func (obj InitializeGroupPointer) MarshalWithEncoder(encoder *ag_binary.Encoder) (err error) {
+	// Serialize `Authority` param (optional, zeroed when unset):
+	err = writeOptionalNonZeroPubkey(encoder, obj.Authority)
+	if err != nil {
+		return err
+	}
+	// Serialize `GroupAddress` param (optional, zeroed when unset):
+	err = writeOptionalNonZeroPubkey(encoder, obj.GroupAddress)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+func (obj *InitializeGroupPointer) UnmarshalWithDecoder(decoder *ag_binary.Decoder) (err error) {
+	// Deserialize `Authority` (optional, zeroed when unset):
+	obj.Authority, err = readOptionalNonZeroPubkey(decoder)
+	if err != nil {
+		return err
+	}
+	// Deserialize `GroupAddress` (optional, zeroed when unset):
+	obj.GroupAddress, err = readOptionalNonZeroPubkey(decoder)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// NewInitializeGroupPointerInstruction declares a new InitializeGroupPointer instruction with the provided parameters and accounts.
+func NewInitializeGroupPointerInstruction(
+	// Parameters:
+	authority ag_solanago.PublicKey,
+	group_address ag_solanago.PublicKey,
+	// Accounts:
+	mint ag_solanago.PublicKey) *InitializeGroupPointer {
+	return NewInitializeGroupPointerInstructionBuilder().
+		SetAuthority(authority).
+		SetGroupAddress(group_address).
+		SetMintAccount(mint)
+}
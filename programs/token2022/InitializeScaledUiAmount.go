@@ -0,0 +1,174 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package token2022
+
+import (
+	"errors"
+
+	ag_binary "github.com/gagliardetto/binary"
+	ag_solanago "github.com/gagliardetto/solana-go"
+	ag_format "github.com/gagliardetto/solana-go/text/format"
+	ag_treeout "github.com/gagliardetto/treeout"
+)
+
+// Initialize a new mint with scaled UI amounts.
+//
+// Fails if the mint has already been initialized, so must be called before
+// `InitializeMint`.
+type InitializeScaledUiAmount struct {
+	// The authority that can update the multiplier.
+	Authority *ag_solanago.PublicKey
+
+	// The initial multiplier.
+	Multiplier *float64
+
+	// [0] = [WRITE] mint
+	// ··········· The mint to initialize.
+	ag_solanago.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+// NewInitializeScaledUiAmountInstructionBuilder creates a new `InitializeScaledUiAmount` instruction builder.
+func NewInitializeScaledUiAmountInstructionBuilder() *InitializeScaledUiAmount {
+	nd := &InitializeScaledUiAmount{
+		AccountMetaSlice: make(ag_solanago.AccountMetaSlice, 1),
+	}
+	return nd
+}
+
+// SetAuthority sets the "authority" parameter.
+// The authority that can update the multiplier.
+func (inst *InitializeScaledUiAmount) SetAuthority(authority ag_solanago.PublicKey) *InitializeScaledUiAmount {
+	inst.Authority = &authority
+	return inst
+}
+
+// SetMultiplier sets the "multiplier" parameter.
+// The initial multiplier.
+func (inst *InitializeScaledUiAmount) SetMultiplier(multiplier float64) *InitializeScaledUiAmount {
+	inst.Multiplier = &multiplier
+	return inst
+}
+
+// SetMintAccount sets the "mint" account.
+// The mint to initialize.
+func (inst *InitializeScaledUiAmount) SetMintAccount(mint ag_solanago.PublicKey) *InitializeScaledUiAmount {
+	inst.AccountMetaSlice[0] = ag_solanago.Meta(mint).WRITE()
+	return inst
+}
+
+// GetMintAccount gets the "mint" account.
+// The mint to initialize.
+func (inst *InitializeScaledUiAmount) GetMintAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[0]
+}
+
+func (inst InitializeScaledUiAmount) Build() *Instruction {
+	return &Instruction{BaseVariant: ag_binary.BaseVariant{
+		Impl: &ScaledUiAmountExtension{extensionInstruction{ag_binary.BaseVariant{
+			Impl:   inst,
+			TypeID: ag_binary.TypeIDFromUint8(ScaledUiAmount_Initialize),
+		}}},
+		TypeID: ag_binary.TypeIDFromUint8(Instruction_ScaledUiAmountExtension),
+	}}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (inst InitializeScaledUiAmount) ValidateAndBuild() (*Instruction, error) {
+	if err := inst.Validate(); err != nil {
+		return nil, err
+	}
+	return inst.Build(), nil
+}
+
+func (inst *InitializeScaledUiAmount) Validate() error {
+	// Check whether all (required) parameters are set:
+	{
+		if inst.Multiplier == nil {
+			return errors.New("Multiplier parameter is not set")
+		}
+	}
+
+	// Check whether all (required) accounts are set:
+	{
+		if inst.AccountMetaSlice[0] == nil {
+			return errors.New("accounts.Mint is not set")
+		}
+	}
+	return nil
+}
+
+func (inst *InitializeScaledUiAmount) EncodeToTree(parent ag_treeout.Branches) {
+	parent.Child(ag_format.Program(ProgramName, ProgramID)).
+		//
+		ParentFunc(func(programBranch ag_treeout.Branches) {
+			programBranch.Child(ag_format.Instruction("InitializeScaledUiAmount")).
+				//
+				ParentFunc(func(instructionBranch ag_treeout.Branches) {
+
+					// Parameters of the instruction:
+					instructionBranch.Child("Params").ParentFunc(func(paramsBranch ag_treeout.Branches) {
+						paramsBranch.Child(ag_format.Param("Authority (OPT)", inst.Authority))
+						paramsBranch.Child(ag_format.Param("     Multiplier", *inst.Multiplier))
+					})
+
+					// Accounts of the instruction:
+					instructionBranch.Child("Accounts").ParentFunc(func(accountsBranch ag_treeout.Branches) {
+						accountsBranch.Child(ag_format.Meta("mint", inst.AccountMetaSlice[0]))
+					})
+				})
+		})
+}
+
+func (obj InitializeScaledUiAmount) MarshalWithEncoder(encoder *ag_binary.Encoder) (err error) {
+	// Serialize `Authority` param (optional, zeroed when unset):
+	err = writeOptionalNonZeroPubkey(encoder, obj.Authority)
+	if err != nil {
+		return err
+	}
+	// Serialize `Multiplier` param:
+	err = encoder.Encode(obj.Multiplier)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+func (obj *InitializeScaledUiAmount) UnmarshalWithDecoder(decoder *ag_binary.Decoder) (err error) {
+	// Deserialize `Authority` (optional, zeroed when unset):
+	obj.Authority, err = readOptionalNonZeroPubkey(decoder)
+	if err != nil {
+		return err
+	}
+	// Deserialize `Multiplier`:
+	err = decoder.Decode(&obj.Multiplier)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// NewInitializeScaledUiAmountInstruction declares a new InitializeScaledUiAmount instruction with the provided parameters and accounts.
+func NewInitializeScaledUiAmountInstruction(
+	// Parameters:
+	authority ag_solanago.PublicKey,
+	multiplier float64,
+	// Accounts:
+	mint ag_solanago.PublicKey) *InitializeScaledUiAmount {
+	return NewInitializeScaledUiAmountInstructionBuilder().
+		SetAuthority(authority).
+		SetMultiplier(multiplier).
+		SetMintAccount(mint)
+}
@@ -0,0 +1,214 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package token2022
+
+import (
+	"errors"
+
+	ag_binary "github.com/gagliardetto/binary"
+	ag_solanago "github.com/gagliardetto/solana-go"
+	ag_format "github.com/gagliardetto/solana-go/text/format"
+	ag_treeout "github.com/gagliardetto/treeout"
+)
+
+// Initialize a new `Group`.
+//
+// Assumes one has already initialized a mint for the group.
+type InitializeTokenGroup struct {
+	// Update authority for the group.
+	UpdateAuthority *ag_solanago.PublicKey
+
+	// The maximum number of group members.
+	MaxSize *uint64
+
+	// [0] = [WRITE] group
+	// ··········· The group account.
+	//
+	// [1] = [] mint
+	// ··········· The mint.
+	//
+	// [2] = [SIGNER] mintAuthority
+	// ··········· The mint authority.
+	ag_solanago.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+// NewInitializeTokenGroupInstructionBuilder creates a new `InitializeTokenGroup` instruction builder.
+func NewInitializeTokenGroupInstructionBuilder() *InitializeTokenGroup {
+	nd := &InitializeTokenGroup{
+		AccountMetaSlice: make(ag_solanago.AccountMetaSlice, 3),
+	}
+	return nd
+}
+
+// SetUpdateAuthority sets the "update_authority" parameter.
+// Update authority for the group.
+func (inst *InitializeTokenGroup) SetUpdateAuthority(update_authority ag_solanago.PublicKey) *InitializeTokenGroup {
+	inst.UpdateAuthority = &update_authority
+	return inst
+}
+
+// SetMaxSize sets the "max_size" parameter.
+// The maximum number of group members.
+func (inst *InitializeTokenGroup) SetMaxSize(max_size uint64) *InitializeTokenGroup {
+	inst.MaxSize = &max_size
+	return inst
+}
+
+// SetGroupAccount sets the "group" account.
+// The group account.
+func (inst *InitializeTokenGroup) SetGroupAccount(group ag_solanago.PublicKey) *InitializeTokenGroup {
+	inst.AccountMetaSlice[0] = ag_solanago.Meta(group).WRITE()
+	return inst
+}
+
+// GetGroupAccount gets the "group" account.
+// The group account.
+func (inst *InitializeTokenGroup) GetGroupAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[0]
+}
+
+// SetMintAccount sets the "mint" account.
+// The mint.
+func (inst *InitializeTokenGroup) SetMintAccount(mint ag_solanago.PublicKey) *InitializeTokenGroup {
+	inst.AccountMetaSlice[1] = ag_solanago.Meta(mint)
+	return inst
+}
+
+// GetMintAccount gets the "mint" account.
+// The mint.
+func (inst *InitializeTokenGroup) GetMintAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[1]
+}
+
+// SetMintAuthorityAccount sets the "mintAuthority" account.
+// The mint authority.
+func (inst *InitializeTokenGroup) SetMintAuthorityAccount(mintAuthority ag_solanago.PublicKey) *InitializeTokenGroup {
+	inst.AccountMetaSlice[2] = ag_solanago.Meta(mintAuthority).SIGNER()
+	return inst
+}
+
+// GetMintAuthorityAccount gets the "mintAuthority" account.
+// The mint authority.
+func (inst *InitializeTokenGroup) GetMintAuthorityAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[2]
+}
+
+func (inst InitializeTokenGroup) Build() *Instruction {
+	return &Instruction{BaseVariant: ag_binary.BaseVariant{
+		Impl:   inst,
+		TypeID: TokenGroup_InitializeGroup,
+	}}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (inst InitializeTokenGroup) ValidateAndBuild() (*Instruction, error) {
+	if err := inst.Validate(); err != nil {
+		return nil, err
+	}
+	return inst.Build(), nil
+}
+
+func (inst *InitializeTokenGroup) Validate() error {
+	// Check whether all (required) parameters are set:
+	{
+		if inst.MaxSize == nil {
+			return errors.New("MaxSize parameter is not set")
+		}
+	}
+
+	// Check whether all (required) accounts are set:
+	{
+		if inst.AccountMetaSlice[0] == nil {
+			return errors.New("accounts.Group is not set")
+		}
+		if inst.AccountMetaSlice[1] == nil {
+			return errors.New("accounts.Mint is not set")
+		}
+		if inst.AccountMetaSlice[2] == nil {
+			return errors.New("accounts.MintAuthority is not set")
+		}
+	}
+	return nil
+}
+
+func (inst *InitializeTokenGroup) EncodeToTree(parent ag_treeout.Branches) {
+	parent.Child(ag_format.Program(ProgramName, ProgramID)).
+		//
+		ParentFunc(func(programBranch ag_treeout.Branches) {
+			programBranch.Child(ag_format.Instruction("InitializeTokenGroup")).
+				//
+				ParentFunc(func(instructionBranch ag_treeout.Branches) {
+
+					// Parameters of the instruction:
+					instructionBranch.Child("Params").ParentFunc(func(paramsBranch ag_treeout.Branches) {
+						paramsBranch.Child(ag_format.Param("UpdateAuthority (OPT)", inst.UpdateAuthority))
+						paramsBranch.Child(ag_format.Param("              MaxSize", *inst.MaxSize))
+					})
+
+					// Accounts of the instruction:
+					instructionBranch.Child("Accounts").ParentFunc(func(accountsBranch ag_treeout.Branches) {
+						accountsBranch.Child(ag_format.Meta("        group", inst.AccountMetaSlice[0]))
+						accountsBranch.Child(ag_format.Meta("         mint", inst.AccountMetaSlice[1]))
+						accountsBranch.Child(ag_format.Meta("mintAuthority", inst.AccountMetaSlice[2]))
+					})
+				})
+		})
+}
+
+func (obj InitializeTokenGroup) MarshalWithEncoder(encoder *ag_binary.Encoder) (err error) {
+	// Serialize `UpdateAuthority` param (optional, zeroed when unset):
+	err = writeOptionalNonZeroPubkey(encoder, obj.UpdateAuthority)
+	if err != nil {
+		return err
+	}
+	// Serialize `MaxSize` param:
+	err = encoder.Encode(obj.MaxSize)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+func (obj *InitializeTokenGroup) UnmarshalWithDecoder(decoder *ag_binary.Decoder) (err error) {
+	// Deserialize `UpdateAuthority` (optional, zeroed when unset):
+	obj.UpdateAuthority, err = readOptionalNonZeroPubkey(decoder)
+	if err != nil {
+		return err
+	}
+	// Deserialize `MaxSize`:
+	err = decoder.Decode(&obj.MaxSize)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// NewInitializeTokenGroupInstruction declares a new InitializeTokenGroup instruction with the provided parameters and accounts.
+func NewInitializeTokenGroupInstruction(
+	// Parameters:
+	update_authority ag_solanago.PublicKey,
+	max_size uint64,
+	// Accounts:
+	group ag_solanago.PublicKey,
+	mint ag_solanago.PublicKey,
+	mintAuthority ag_solanago.PublicKey) *InitializeTokenGroup {
+	return NewInitializeTokenGroupInstructionBuilder().
+		SetUpdateAuthority(update_authority).
+		SetMaxSize(max_size).
+		SetGroupAccount(group).
+		SetMintAccount(mint).
+		SetMintAuthorityAccount(mintAuthority)
+}
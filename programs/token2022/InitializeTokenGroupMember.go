@@ -0,0 +1,204 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package token2022
+
+import (
+	"errors"
+
+	ag_binary "github.com/gagliardetto/binary"
+	ag_solanago "github.com/gagliardetto/solana-go"
+	ag_format "github.com/gagliardetto/solana-go/text/format"
+	ag_treeout "github.com/gagliardetto/treeout"
+)
+
+// Initialize a new `Member` of a `Group`.
+//
+// Assumes the `Group` has already been initialized, as well as the mint
+// for the member.
+type InitializeTokenGroupMember struct {
+	// [0] = [WRITE] member
+	// ··········· The member account.
+	//
+	// [1] = [] memberMint
+	// ··········· The member mint.
+	//
+	// [2] = [SIGNER] memberMintAuthority
+	// ··········· The member mint authority.
+	//
+	// [3] = [WRITE] group
+	// ··········· The group account.
+	//
+	// [4] = [SIGNER] groupUpdateAuthority
+	// ··········· The group update authority.
+	ag_solanago.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+// NewInitializeTokenGroupMemberInstructionBuilder creates a new `InitializeTokenGroupMember` instruction builder.
+func NewInitializeTokenGroupMemberInstructionBuilder() *InitializeTokenGroupMember {
+	nd := &InitializeTokenGroupMember{
+		AccountMetaSlice: make(ag_solanago.AccountMetaSlice, 5),
+	}
+	return nd
+}
+
+// SetMemberAccount sets the "member" account.
+// The member account.
+func (inst *InitializeTokenGroupMember) SetMemberAccount(member ag_solanago.PublicKey) *InitializeTokenGroupMember {
+	inst.AccountMetaSlice[0] = ag_solanago.Meta(member).WRITE()
+	return inst
+}
+
+// GetMemberAccount gets the "member" account.
+// The member account.
+func (inst *InitializeTokenGroupMember) GetMemberAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[0]
+}
+
+// SetMemberMintAccount sets the "memberMint" account.
+// The member mint.
+func (inst *InitializeTokenGroupMember) SetMemberMintAccount(memberMint ag_solanago.PublicKey) *InitializeTokenGroupMember {
+	inst.AccountMetaSlice[1] = ag_solanago.Meta(memberMint)
+	return inst
+}
+
+// GetMemberMintAccount gets the "memberMint" account.
+// The member mint.
+func (inst *InitializeTokenGroupMember) GetMemberMintAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[1]
+}
+
+// SetMemberMintAuthorityAccount sets the "memberMintAuthority" account.
+// The member mint authority.
+func (inst *InitializeTokenGroupMember) SetMemberMintAuthorityAccount(memberMintAuthority ag_solanago.PublicKey) *InitializeTokenGroupMember {
+	inst.AccountMetaSlice[2] = ag_solanago.Meta(memberMintAuthority).SIGNER()
+	return inst
+}
+
+// GetMemberMintAuthorityAccount gets the "memberMintAuthority" account.
+// The member mint authority.
+func (inst *InitializeTokenGroupMember) GetMemberMintAuthorityAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[2]
+}
+
+// SetGroupAccount sets the "group" account.
+// The group account.
+func (inst *InitializeTokenGroupMember) SetGroupAccount(group ag_solanago.PublicKey) *InitializeTokenGroupMember {
+	inst.AccountMetaSlice[3] = ag_solanago.Meta(group).WRITE()
+	return inst
+}
+
+// GetGroupAccount gets the "group" account.
+// The group account.
+func (inst *InitializeTokenGroupMember) GetGroupAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[3]
+}
+
+// SetGroupUpdateAuthorityAccount sets the "groupUpdateAuthority" account.
+// The group update authority.
+func (inst *InitializeTokenGroupMember) SetGroupUpdateAuthorityAccount(groupUpdateAuthority ag_solanago.PublicKey) *InitializeTokenGroupMember {
+	inst.AccountMetaSlice[4] = ag_solanago.Meta(groupUpdateAuthority).SIGNER()
+	return inst
+}
+
+// GetGroupUpdateAuthorityAccount gets the "groupUpdateAuthority" account.
+// The group update authority.
+func (inst *InitializeTokenGroupMember) GetGroupUpdateAuthorityAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[4]
+}
+
+func (inst InitializeTokenGroupMember) Build() *Instruction {
+	return &Instruction{BaseVariant: ag_binary.BaseVariant{
+		Impl:   inst,
+		TypeID: TokenGroup_InitializeMember,
+	}}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (inst InitializeTokenGroupMember) ValidateAndBuild() (*Instruction, error) {
+	if err := inst.Validate(); err != nil {
+		return nil, err
+	}
+	return inst.Build(), nil
+}
+
+func (inst *InitializeTokenGroupMember) Validate() error {
+	// Check whether all (required) accounts are set:
+	{
+		if inst.AccountMetaSlice[0] == nil {
+			return errors.New("accounts.Member is not set")
+		}
+		if inst.AccountMetaSlice[1] == nil {
+			return errors.New("accounts.MemberMint is not set")
+		}
+		if inst.AccountMetaSlice[2] == nil {
+			return errors.New("accounts.MemberMintAuthority is not set")
+		}
+		if inst.AccountMetaSlice[3] == nil {
+			return errors.New("accounts.Group is not set")
+		}
+		if inst.AccountMetaSlice[4] == nil {
+			return errors.New("accounts.GroupUpdateAuthority is not set")
+		}
+	}
+	return nil
+}
+
+func (inst *InitializeTokenGroupMember) EncodeToTree(parent ag_treeout.Branches) {
+	parent.Child(ag_format.Program(ProgramName, ProgramID)).
+		//
+		ParentFunc(func(programBranch ag_treeout.Branches) {
+			programBranch.Child(ag_format.Instruction("InitializeTokenGroupMember")).
+				//
+				ParentFunc(func(instructionBranch ag_treeout.Branches) {
+
+					// Parameters of the instruction:
+					instructionBranch.Child("Params").ParentFunc(func(paramsBranch ag_treeout.Branches) {})
+
+					// Accounts of the instruction:
+					instructionBranch.Child("Accounts").ParentFunc(func(accountsBranch ag_treeout.Branches) {
+						accountsBranch.Child(ag_format.Meta("              member", inst.AccountMetaSlice[0]))
+						accountsBranch.Child(ag_format.Meta("          memberMint", inst.AccountMetaSlice[1]))
+						accountsBranch.Child(ag_format.Meta(" memberMintAuthority", inst.AccountMetaSlice[2]))
+						accountsBranch.Child(ag_format.Meta("               group", inst.AccountMetaSlice[3]))
+						accountsBranch.Child(ag_format.Meta("groupUpdateAuthority", inst.AccountMetaSlice[4]))
+					})
+				})
+		})
+}
+
+func (obj InitializeTokenGroupMember) MarshalWithEncoder(encoder *ag_binary.Encoder) (err error) {
+	return nil
+}
+func (obj *InitializeTokenGroupMember) UnmarshalWithDecoder(decoder *ag_binary.Decoder) (err error) {
+	return nil
+}
+
+// NewInitializeTokenGroupMemberInstruction declares a new InitializeTokenGroupMember instruction with the provided parameters and accounts.
+func NewInitializeTokenGroupMemberInstruction(
+	// Accounts:
+	member ag_solanago.PublicKey,
+	memberMint ag_solanago.PublicKey,
+	memberMintAuthority ag_solanago.PublicKey,
+	group ag_solanago.PublicKey,
+	groupUpdateAuthority ag_solanago.PublicKey) *InitializeTokenGroupMember {
+	return NewInitializeTokenGroupMemberInstructionBuilder().
+		SetMemberAccount(member).
+		SetMemberMintAccount(memberMint).
+		SetMemberMintAuthorityAccount(memberMintAuthority).
+		SetGroupAccount(group).
+		SetGroupUpdateAuthorityAccount(groupUpdateAuthority)
+}
@@ -0,0 +1,276 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package token2022
+
+import (
+	"errors"
+
+	ag_binary "github.com/gagliardetto/binary"
+	ag_solanago "github.com/gagliardetto/solana-go"
+	ag_format "github.com/gagliardetto/solana-go/text/format"
+	ag_treeout "github.com/gagliardetto/treeout"
+)
+
+// Initializes a TLV entry with the basic token-metadata fields.
+//
+// Assumes that the provided mint is an SPL token mint, that the metadata
+// account is allocated and assigned to the program, and that the metadata
+// account has enough lamports to cover the rent-exempt reserve.
+type InitializeTokenMetadata struct {
+	// Longer name of the token.
+	Name *string
+
+	// Shortened symbol of the token.
+	Symbol *string
+
+	// URI pointing to more metadata (image, video, etc.).
+	URI *string
+
+	// [0] = [WRITE] metadata
+	// ··········· The metadata account.
+	//
+	// [1] = [] updateAuthority
+	// ··········· The update authority.
+	//
+	// [2] = [] mint
+	// ··········· The mint.
+	//
+	// [3] = [SIGNER] mintAuthority
+	// ··········· The mint authority.
+	ag_solanago.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+// NewInitializeTokenMetadataInstructionBuilder creates a new `InitializeTokenMetadata` instruction builder.
+func NewInitializeTokenMetadataInstructionBuilder() *InitializeTokenMetadata {
+	nd := &InitializeTokenMetadata{
+		AccountMetaSlice: make(ag_solanago.AccountMetaSlice, 4),
+	}
+	return nd
+}
+
+// SetName sets the "name" parameter.
+// Longer name of the token.
+func (inst *InitializeTokenMetadata) SetName(name string) *InitializeTokenMetadata {
+	inst.Name = &name
+	return inst
+}
+
+// SetSymbol sets the "symbol" parameter.
+// Shortened symbol of the token.
+func (inst *InitializeTokenMetadata) SetSymbol(symbol string) *InitializeTokenMetadata {
+	inst.Symbol = &symbol
+	return inst
+}
+
+// SetURI sets the "u_r_i" parameter.
+// URI pointing to more metadata (image, video, etc.).
+func (inst *InitializeTokenMetadata) SetURI(u_r_i string) *InitializeTokenMetadata {
+	inst.URI = &u_r_i
+	return inst
+}
+
+// SetMetadataAccount sets the "metadata" account.
+// The metadata account.
+func (inst *InitializeTokenMetadata) SetMetadataAccount(metadata ag_solanago.PublicKey) *InitializeTokenMetadata {
+	inst.AccountMetaSlice[0] = ag_solanago.Meta(metadata).WRITE()
+	return inst
+}
+
+// GetMetadataAccount gets the "metadata" account.
+// The metadata account.
+func (inst *InitializeTokenMetadata) GetMetadataAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[0]
+}
+
+// SetUpdateAuthorityAccount sets the "updateAuthority" account.
+// The update authority.
+func (inst *InitializeTokenMetadata) SetUpdateAuthorityAccount(updateAuthority ag_solanago.PublicKey) *InitializeTokenMetadata {
+	inst.AccountMetaSlice[1] = ag_solanago.Meta(updateAuthority)
+	return inst
+}
+
+// GetUpdateAuthorityAccount gets the "updateAuthority" account.
+// The update authority.
+func (inst *InitializeTokenMetadata) GetUpdateAuthorityAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[1]
+}
+
+// SetMintAccount sets the "mint" account.
+// The mint.
+func (inst *InitializeTokenMetadata) SetMintAccount(mint ag_solanago.PublicKey) *InitializeTokenMetadata {
+	inst.AccountMetaSlice[2] = ag_solanago.Meta(mint)
+	return inst
+}
+
+// GetMintAccount gets the "mint" account.
+// The mint.
+func (inst *InitializeTokenMetadata) GetMintAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[2]
+}
+
+// SetMintAuthorityAccount sets the "mintAuthority" account.
+// The mint authority.
+func (inst *InitializeTokenMetadata) SetMintAuthorityAccount(mintAuthority ag_solanago.PublicKey) *InitializeTokenMetadata {
+	inst.AccountMetaSlice[3] = ag_solanago.Meta(mintAuthority).SIGNER()
+	return inst
+}
+
+// GetMintAuthorityAccount gets the "mintAuthority" account.
+// The mint authority.
+func (inst *InitializeTokenMetadata) GetMintAuthorityAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[3]
+}
+
+func (inst InitializeTokenMetadata) Build() *Instruction {
+	return &Instruction{BaseVariant: ag_binary.BaseVariant{
+		Impl:   inst,
+		TypeID: TokenMetadata_Initialize,
+	}}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (inst InitializeTokenMetadata) ValidateAndBuild() (*Instruction, error) {
+	if err := inst.Validate(); err != nil {
+		return nil, err
+	}
+	return inst.Build(), nil
+}
+
+func (inst *InitializeTokenMetadata) Validate() error {
+	// Check whether all (required) parameters are set:
+	{
+		if inst.Name == nil {
+			return errors.New("Name parameter is not set")
+		}
+		if inst.Symbol == nil {
+			return errors.New("Symbol parameter is not set")
+		}
+		if inst.URI == nil {
+			return errors.New("URI parameter is not set")
+		}
+	}
+
+	// Check whether all (required) accounts are set:
+	{
+		if inst.AccountMetaSlice[0] == nil {
+			return errors.New("accounts.Metadata is not set")
+		}
+		if inst.AccountMetaSlice[1] == nil {
+			return errors.New("accounts.UpdateAuthority is not set")
+		}
+		if inst.AccountMetaSlice[2] == nil {
+			return errors.New("accounts.Mint is not set")
+		}
+		if inst.AccountMetaSlice[3] == nil {
+			return errors.New("accounts.MintAuthority is not set")
+		}
+	}
+	return nil
+}
+
+func (inst *InitializeTokenMetadata) EncodeToTree(parent ag_treeout.Branches) {
+	parent.Child(ag_format.Program(ProgramName, ProgramID)).
+		//
+		ParentFunc(func(programBranch ag_treeout.Branches) {
+			programBranch.Child(ag_format.Instruction("InitializeTokenMetadata")).
+				//
+				ParentFunc(func(instructionBranch ag_treeout.Branches) {
+
+					// Parameters of the instruction:
+					instructionBranch.Child("Params").ParentFunc(func(paramsBranch ag_treeout.Branches) {
+						paramsBranch.Child(ag_format.Param("  Name", *inst.Name))
+						paramsBranch.Child(ag_format.Param("Symbol", *inst.Symbol))
+						paramsBranch.Child(ag_format.Param("   URI", *inst.URI))
+					})
+
+					// Accounts of the instruction:
+					instructionBranch.Child("Accounts").ParentFunc(func(accountsBranch ag_treeout.Branches) {
+						accountsBranch.Child(ag_format.Meta("       metadata", inst.AccountMetaSlice[0]))
+						accountsBranch.Child(ag_format.Meta("updateAuthority", inst.AccountMetaSlice[1]))
+						accountsBranch.Child(ag_format.Meta("           mint", inst.AccountMetaSlice[2]))
+						accountsBranch.Child(ag_format.Meta("  mintAuthority", inst.AccountMetaSlice[3]))
+					})
+				})
+		})
+}
+
+func (obj InitializeTokenMetadata) MarshalWithEncoder(encoder *ag_binary.Encoder) (err error) {
+	// Serialize `Name` param:
+	err = writeBorshString(encoder, *obj.Name)
+	if err != nil {
+		return err
+	}
+	// Serialize `Symbol` param:
+	err = writeBorshString(encoder, *obj.Symbol)
+	if err != nil {
+		return err
+	}
+	// Serialize `URI` param:
+	err = writeBorshString(encoder, *obj.URI)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+func (obj *InitializeTokenMetadata) UnmarshalWithDecoder(decoder *ag_binary.Decoder) (err error) {
+	// Deserialize `Name`:
+	{
+		v, err := readBorshString(decoder)
+		if err != nil {
+			return err
+		}
+		obj.Name = &v
+	}
+	// Deserialize `Symbol`:
+	{
+		v, err := readBorshString(decoder)
+		if err != nil {
+			return err
+		}
+		obj.Symbol = &v
+	}
+	// Deserialize `URI`:
+	{
+		v, err := readBorshString(decoder)
+		if err != nil {
+			return err
+		}
+		obj.URI = &v
+	}
+	return nil
+}
+
+// NewInitializeTokenMetadataInstruction declares a new InitializeTokenMetadata instruction with the provided parameters and accounts.
+func NewInitializeTokenMetadataInstruction(
+	// Parameters:
+	name string,
+	symbol string,
+	u_r_i string,
+	// Accounts:
+	metadata ag_solanago.PublicKey,
+	updateAuthority ag_solanago.PublicKey,
+	mint ag_solanago.PublicKey,
+	mintAuthority ag_solanago.PublicKey) *InitializeTokenMetadata {
+	return NewInitializeTokenMetadataInstructionBuilder().
+		SetName(name).
+		SetSymbol(symbol).
+		SetURI(u_r_i).
+		SetMetadataAccount(metadata).
+		SetUpdateAuthorityAccount(updateAuthority).
+		SetMintAccount(mint).
+		SetMintAuthorityAccount(mintAuthority)
+}
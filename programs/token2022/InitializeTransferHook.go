@@ -0,0 +1,167 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package token2022
+
+import (
+	"errors"
+
+	ag_binary "github.com/gagliardetto/binary"
+	ag_solanago "github.com/gagliardetto/solana-go"
+	ag_format "github.com/gagliardetto/solana-go/text/format"
+	ag_treeout "github.com/gagliardetto/treeout"
+)
+
+// Initialize a new mint with a transfer hook program.
+//
+// Fails if the mint has already been initialized, so must be called before
+// `InitializeMint`.
+type InitializeTransferHook struct {
+	// The public key for the account that can update the program id.
+	Authority *ag_solanago.PublicKey
+
+	// The program id that performs logic during transfers.
+	HookProgramID *ag_solanago.PublicKey
+
+	// [0] = [WRITE] mint
+	// ··········· The mint to initialize.
+	ag_solanago.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+// NewInitializeTransferHookInstructionBuilder creates a new `InitializeTransferHook` instruction builder.
+func NewInitializeTransferHookInstructionBuilder() *InitializeTransferHook {
+	nd := &InitializeTransferHook{
+		AccountMetaSlice: make(ag_solanago.AccountMetaSlice, 1),
+	}
+	return nd
+}
+
+// SetAuthority sets the "authority" parameter.
+// The public key for the account that can update the program id.
+func (inst *InitializeTransferHook) SetAuthority(authority ag_solanago.PublicKey) *InitializeTransferHook {
+	inst.Authority = &authority
+	return inst
+}
+
+// SetHookProgramID sets the "hook_program_i_d" parameter.
+// The program id that performs logic during transfers.
+func (inst *InitializeTransferHook) SetHookProgramID(hook_program_i_d ag_solanago.PublicKey) *InitializeTransferHook {
+	inst.HookProgramID = &hook_program_i_d
+	return inst
+}
+
+// SetMintAccount sets the "mint" account.
+// The mint to initialize.
+func (inst *InitializeTransferHook) SetMintAccount(mint ag_solanago.PublicKey) *InitializeTransferHook {
+	inst.AccountMetaSlice[0] = ag_solanago.Meta(mint).WRITE()
+	return inst
+}
+
+// GetMintAccount gets the "mint" account.
+// The mint to initialize.
+func (inst *InitializeTransferHook) GetMintAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[0]
+}
+
+func (inst InitializeTransferHook) Build() *Instruction {
+	return &Instruction{BaseVariant: ag_binary.BaseVariant{
+		Impl: &TransferHookExtension{extensionInstruction{ag_binary.BaseVariant{
+			Impl:   inst,
+			TypeID: ag_binary.TypeIDFromUint8(TransferHook_Initialize),
+		}}},
+		TypeID: ag_binary.TypeIDFromUint8(Instruction_TransferHookExtension),
+	}}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (inst InitializeTransferHook) ValidateAndBuild() (*Instruction, error) {
+	if err := inst.Validate(); err != nil {
+		return nil, err
+	}
+	return inst.Build(), nil
+}
+
+func (inst *InitializeTransferHook) Validate() error {
+	// Check whether all (required) accounts are set:
+	{
+		if inst.AccountMetaSlice[0] == nil {
+			return errors.New("accounts.Mint is not set")
+		}
+	}
+	return nil
+}
+
+func (inst *InitializeTransferHook) EncodeToTree(parent ag_treeout.Branches) {
+	parent.Child(ag_format.Program(ProgramName, ProgramID)).
+		//
+		ParentFunc(func(programBranch ag_treeout.Branches) {
+			programBranch.Child(ag_format.Instruction("InitializeTransferHook")).
+				//
+				ParentFunc(func(instructionBranch ag_treeout.Branches) {
+
+					// Parameters of the instruction:
+					instructionBranch.Child("Params").ParentFunc(func(paramsBranch ag_treeout.Branches) {
+						paramsBranch.Child(ag_format.Param("    Authority (OPT)", inst.Authority))
+						paramsBranch.Child(ag_format.Param("HookProgramID (OPT)", inst.HookProgramID))
+					})
+
+					// Accounts of the instruction:
+					instructionBranch.Child("Accounts").ParentFunc(func(accountsBranch ag_treeout.Branches) {
+						accountsBranch.Child(ag_format.Meta("mint", inst.AccountMetaSlice[0]))
+					})
+				})
+		})
+}
+
+func (obj InitializeTransferHook) MarshalWithEncoder(encoder *ag_binary.Encoder) (err error) {
+	// Serialize `Authority` param (optional, zeroed when unset):
+	err = writeOptionalNonZeroPubkey(encoder, obj.Authority)
+	if err != nil {
+		return err
+	}
+	// Serialize `HookProgramID` param (optional, zeroed when unset):
+	err = writeOptionalNonZeroPubkey(encoder, obj.HookProgramID)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+func (obj *InitializeTransferHook) UnmarshalWithDecoder(decoder *ag_binary.Decoder) (err error) {
+	// Deserialize `Authority` (optional, zeroed when unset):
+	obj.Authority, err = readOptionalNonZeroPubkey(decoder)
+	if err != nil {
+		return err
+	}
+	// Deserialize `HookProgramID` (optional, zeroed when unset):
+	obj.HookProgramID, err = readOptionalNonZeroPubkey(decoder)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// NewInitializeTransferHookInstruction declares a new InitializeTransferHook instruction with the provided parameters and accounts.
+func NewInitializeTransferHookInstruction(
+	// Parameters:
+	authority ag_solanago.PublicKey,
+	hook_program_i_d ag_solanago.PublicKey,
+	// Accounts:
+	mint ag_solanago.PublicKey) *InitializeTransferHook {
+	return NewInitializeTransferHookInstructionBuilder().
+		SetAuthority(authority).
+		SetHookProgramID(hook_program_i_d).
+		SetMintAccount(mint)
+}
@@ -0,0 +1,63 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package token2022
+
+import (
+	ag_binary "github.com/gagliardetto/binary"
+)
+
+// Instructions of the interest-bearing mint extension, encoded after
+// `Instruction_InterestBearingMintExtension`.
+const (
+	// Initialize a new mint with interest accrual.
+	InterestBearingMint_Initialize uint8 = iota
+
+	// Update the interest rate.
+	InterestBearingMint_UpdateRate
+)
+
+// InterestBearingMintInstructionIDToName returns the name of the interest-bearing mint
+// extension instruction given its ID.
+func InterestBearingMintInstructionIDToName(id uint8) string {
+	switch id {
+	case InterestBearingMint_Initialize:
+		return "InitializeInterestBearingMint"
+	case InterestBearingMint_UpdateRate:
+		return "UpdateInterestBearingMintRate"
+	default:
+		return ""
+	}
+}
+
+var InterestBearingMintInstructionImplDef = ag_binary.NewVariantDefinition(
+	ag_binary.Uint8TypeIDEncoding,
+	[]ag_binary.VariantType{
+		{
+			"InitializeInterestBearingMint", (*InitializeInterestBearingMint)(nil),
+		},
+		{
+			"UpdateInterestBearingMintRate", (*UpdateInterestBearingMintRate)(nil),
+		},
+	},
+)
+
+// InterestBearingMintExtension wraps the instructions of the interest-bearing mint extension.
+type InterestBearingMintExtension struct {
+	extensionInstruction
+}
+
+func (inst *InterestBearingMintExtension) UnmarshalWithDecoder(decoder *ag_binary.Decoder) error {
+	return inst.BaseVariant.UnmarshalBinaryVariant(decoder, InterestBearingMintInstructionImplDef)
+}
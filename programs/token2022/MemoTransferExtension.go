@@ -0,0 +1,63 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package token2022
+
+import (
+	ag_binary "github.com/gagliardetto/binary"
+)
+
+// Instructions of the memo transfer extension, encoded after
+// `Instruction_MemoTransferExtension`.
+const (
+	// Require memos for transfers into this account.
+	MemoTransfer_Enable uint8 = iota
+
+	// Stop requiring memos for transfers into this account.
+	MemoTransfer_Disable
+)
+
+// MemoTransferInstructionIDToName returns the name of the memo transfer
+// extension instruction given its ID.
+func MemoTransferInstructionIDToName(id uint8) string {
+	switch id {
+	case MemoTransfer_Enable:
+		return "EnableRequiredMemoTransfers"
+	case MemoTransfer_Disable:
+		return "DisableRequiredMemoTransfers"
+	default:
+		return ""
+	}
+}
+
+var MemoTransferInstructionImplDef = ag_binary.NewVariantDefinition(
+	ag_binary.Uint8TypeIDEncoding,
+	[]ag_binary.VariantType{
+		{
+			"EnableRequiredMemoTransfers", (*EnableRequiredMemoTransfers)(nil),
+		},
+		{
+			"DisableRequiredMemoTransfers", (*DisableRequiredMemoTransfers)(nil),
+		},
+	},
+)
+
+// MemoTransferExtension wraps the instructions of the memo transfer extension.
+type MemoTransferExtension struct {
+	extensionInstruction
+}
+
+func (inst *MemoTransferExtension) UnmarshalWithDecoder(decoder *ag_binary.Decoder) error {
+	return inst.BaseVariant.UnmarshalBinaryVariant(decoder, MemoTransferInstructionImplDef)
+}
@@ -0,0 +1,63 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package token2022
+
+import (
+	ag_binary "github.com/gagliardetto/binary"
+)
+
+// Instructions of the metadata pointer extension, encoded after
+// `Instruction_MetadataPointerExtension`.
+const (
+	// Initialize a new mint with a metadata pointer.
+	MetadataPointer_Initialize uint8 = iota
+
+	// Update the metadata address.
+	MetadataPointer_Update
+)
+
+// MetadataPointerInstructionIDToName returns the name of the metadata pointer
+// extension instruction given its ID.
+func MetadataPointerInstructionIDToName(id uint8) string {
+	switch id {
+	case MetadataPointer_Initialize:
+		return "InitializeMetadataPointer"
+	case MetadataPointer_Update:
+		return "UpdateMetadataPointer"
+	default:
+		return ""
+	}
+}
+
+var MetadataPointerInstructionImplDef = ag_binary.NewVariantDefinition(
+	ag_binary.Uint8TypeIDEncoding,
+	[]ag_binary.VariantType{
+		{
+			"InitializeMetadataPointer", (*InitializeMetadataPointer)(nil),
+		},
+		{
+			"UpdateMetadataPointer", (*UpdateMetadataPointer)(nil),
+		},
+	},
+)
+
+// MetadataPointerExtension wraps the instructions of the metadata pointer extension.
+type MetadataPointerExtension struct {
+	extensionInstruction
+}
+
+func (inst *MetadataPointerExtension) UnmarshalWithDecoder(decoder *ag_binary.Decoder) error {
+	return inst.BaseVariant.UnmarshalBinaryVariant(decoder, MetadataPointerInstructionImplDef)
+}
@@ -0,0 +1,71 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package token2022
+
+import (
+	ag_binary "github.com/gagliardetto/binary"
+)
+
+// Instructions of the pausable extension, encoded after
+// `Instruction_PausableExtension`.
+const (
+	// Initialize the pausable extension for the given mint account.
+	Pausable_Initialize uint8 = iota
+
+	// Pause minting, burning, and transferring for the mint.
+	Pausable_Pause
+
+	// Resume minting, burning, and transferring for the mint.
+	Pausable_Resume
+)
+
+// PausableInstructionIDToName returns the name of the pausable
+// extension instruction given its ID.
+func PausableInstructionIDToName(id uint8) string {
+	switch id {
+	case Pausable_Initialize:
+		return "InitializePausable"
+	case Pausable_Pause:
+		return "Pause"
+	case Pausable_Resume:
+		return "Resume"
+	default:
+		return ""
+	}
+}
+
+var PausableInstructionImplDef = ag_binary.NewVariantDefinition(
+	ag_binary.Uint8TypeIDEncoding,
+	[]ag_binary.VariantType{
+		{
+			"InitializePausable", (*InitializePausable)(nil),
+		},
+		{
+			"Pause", (*Pause)(nil),
+		},
+		{
+			"Resume", (*Resume)(nil),
+		},
+	},
+)
+
+// PausableExtension wraps the instructions of the pausable extension.
+type PausableExtension struct {
+	extensionInstruction
+}
+
+func (inst *PausableExtension) UnmarshalWithDecoder(decoder *ag_binary.Decoder) error {
+	return inst.BaseVariant.UnmarshalBinaryVariant(decoder, PausableInstructionImplDef)
+}
@@ -0,0 +1,258 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package token2022
+
+import (
+	"errors"
+	"fmt"
+
+	ag_binary "github.com/gagliardetto/binary"
+	ag_solanago "github.com/gagliardetto/solana-go"
+	ag_format "github.com/gagliardetto/solana-go/text/format"
+	ag_treeout "github.com/gagliardetto/treeout"
+)
+
+// Check to see if a token account is large enough for a list of
+// ExtensionTypes, and if not, use reallocation to increase the data size.
+type Reallocate struct {
+	// New extension types to include in the reallocated account.
+	ExtensionTypes []ExtensionType
+
+	// [0] = [WRITE] account
+	// ··········· The account to reallocate.
+	//
+	// [1] = [WRITE, SIGNER] payer
+	// ··········· The payer account to fund reallocation.
+	//
+	// [2] = [] $(SystemProgramID)
+	// ··········· System program for reallocation funding.
+	//
+	// [3] = [SIGNER] owner
+	// ··········· The account's owner or its multisignature account.
+	//
+	// [4...] = [SIGNER] signers
+	// ··········· M signer accounts.
+	Accounts ag_solanago.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+	Signers  ag_solanago.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+func (obj *Reallocate) SetAccounts(accounts []*ag_solanago.AccountMeta) error {
+	obj.Accounts, obj.Signers = ag_solanago.AccountMetaSlice(accounts).SplitFrom(4)
+	return nil
+}
+
+func (slice Reallocate) GetAccounts() (accounts []*ag_solanago.AccountMeta) {
+	accounts = append(accounts, slice.Accounts...)
+	accounts = append(accounts, slice.Signers...)
+	return
+}
+
+// NewReallocateInstructionBuilder creates a new `Reallocate` instruction builder.
+func NewReallocateInstructionBuilder() *Reallocate {
+	nd := &Reallocate{
+		Accounts: make(ag_solanago.AccountMetaSlice, 4),
+		Signers:  make(ag_solanago.AccountMetaSlice, 0),
+	}
+	nd.Accounts[2] = ag_solanago.Meta(ag_solanago.SystemProgramID)
+	return nd
+}
+
+// SetExtensionTypes sets the "extension_types" parameter.
+// New extension types to include in the reallocated account.
+func (inst *Reallocate) SetExtensionTypes(extension_types ...ExtensionType) *Reallocate {
+	inst.ExtensionTypes = extension_types
+	return inst
+}
+
+// SetAccount sets the "account" account.
+// The account to reallocate.
+func (inst *Reallocate) SetAccount(account ag_solanago.PublicKey) *Reallocate {
+	inst.Accounts[0] = ag_solanago.Meta(account).WRITE()
+	return inst
+}
+
+// GetAccount gets the "account" account.
+// The account to reallocate.
+func (inst *Reallocate) GetAccount() *ag_solanago.AccountMeta {
+	return inst.Accounts[0]
+}
+
+// SetPayerAccount sets the "payer" account.
+// The payer account to fund reallocation.
+func (inst *Reallocate) SetPayerAccount(payer ag_solanago.PublicKey) *Reallocate {
+	inst.Accounts[1] = ag_solanago.Meta(payer).WRITE().SIGNER()
+	return inst
+}
+
+// GetPayerAccount gets the "payer" account.
+// The payer account to fund reallocation.
+func (inst *Reallocate) GetPayerAccount() *ag_solanago.AccountMeta {
+	return inst.Accounts[1]
+}
+
+// SetSystemProgramAccount sets the "systemProgram" account.
+// System program for reallocation funding.
+func (inst *Reallocate) SetSystemProgramAccount(systemProgram ag_solanago.PublicKey) *Reallocate {
+	inst.Accounts[2] = ag_solanago.Meta(systemProgram)
+	return inst
+}
+
+// GetSystemProgramAccount gets the "systemProgram" account.
+// System program for reallocation funding.
+func (inst *Reallocate) GetSystemProgramAccount() *ag_solanago.AccountMeta {
+	return inst.Accounts[2]
+}
+
+// SetOwnerAccount sets the "owner" account.
+// The account's owner or its multisignature account.
+func (inst *Reallocate) SetOwnerAccount(owner ag_solanago.PublicKey, multisigSigners ...ag_solanago.PublicKey) *Reallocate {
+	inst.Accounts[3] = ag_solanago.Meta(owner)
+	if len(multisigSigners) == 0 {
+		inst.Accounts[3].SIGNER()
+	}
+	for _, signer := range multisigSigners {
+		inst.Signers = append(inst.Signers, ag_solanago.Meta(signer).SIGNER())
+	}
+	return inst
+}
+
+// GetOwnerAccount gets the "owner" account.
+// The account's owner or its multisignature account.
+func (inst *Reallocate) GetOwnerAccount() *ag_solanago.AccountMeta {
+	return inst.Accounts[3]
+}
+
+func (inst Reallocate) Build() *Instruction {
+	return &Instruction{BaseVariant: ag_binary.BaseVariant{
+		Impl:   inst,
+		TypeID: ag_binary.TypeIDFromUint8(Instruction_Reallocate),
+	}}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (inst Reallocate) ValidateAndBuild() (*Instruction, error) {
+	if err := inst.Validate(); err != nil {
+		return nil, err
+	}
+	return inst.Build(), nil
+}
+
+func (inst *Reallocate) Validate() error {
+	// Check whether all (required) parameters are set:
+	{
+		if len(inst.ExtensionTypes) == 0 {
+			return errors.New("ExtensionTypes parameter is not set")
+		}
+	}
+
+	// Check whether all (required) accounts are set:
+	{
+		if inst.Accounts[0] == nil {
+			return errors.New("accounts.Account is not set")
+		}
+		if inst.Accounts[1] == nil {
+			return errors.New("accounts.Payer is not set")
+		}
+		if inst.Accounts[2] == nil {
+			return errors.New("accounts.SystemProgram is not set")
+		}
+		if inst.Accounts[3] == nil {
+			return errors.New("accounts.Owner is not set")
+		}
+		if !inst.Accounts[3].IsSigner && len(inst.Signers) == 0 {
+			return fmt.Errorf("accounts.Signers is not set")
+		}
+		if len(inst.Signers) > MAX_SIGNERS {
+			return fmt.Errorf("too many signers; got %v, but max is 11", len(inst.Signers))
+		}
+	}
+	return nil
+}
+
+func (inst *Reallocate) EncodeToTree(parent ag_treeout.Branches) {
+	parent.Child(ag_format.Program(ProgramName, ProgramID)).
+		//
+		ParentFunc(func(programBranch ag_treeout.Branches) {
+			programBranch.Child(ag_format.Instruction("Reallocate")).
+				//
+				ParentFunc(func(instructionBranch ag_treeout.Branches) {
+
+					// Parameters of the instruction:
+					instructionBranch.Child("Params").ParentFunc(func(paramsBranch ag_treeout.Branches) {
+						paramsBranch.Child(ag_format.Param("ExtensionTypes", inst.ExtensionTypes))
+					})
+
+					// Accounts of the instruction:
+					instructionBranch.Child("Accounts").ParentFunc(func(accountsBranch ag_treeout.Branches) {
+						accountsBranch.Child(ag_format.Meta("      account", inst.Accounts[0]))
+						accountsBranch.Child(ag_format.Meta("        payer", inst.Accounts[1]))
+						accountsBranch.Child(ag_format.Meta("systemProgram", inst.Accounts[2]))
+						accountsBranch.Child(ag_format.Meta("        owner", inst.Accounts[3]))
+
+						signersBranch := accountsBranch.Child(fmt.Sprintf("signers[len=%v]", len(inst.Signers)))
+						for i, v := range inst.Signers {
+							if len(inst.Signers) > 9 && i < 10 {
+								signersBranch.Child(ag_format.Meta(fmt.Sprintf(" [%v]", i), v))
+							} else {
+								signersBranch.Child(ag_format.Meta(fmt.Sprintf("[%v]", i), v))
+							}
+						}
+					})
+				})
+		})
+}
+
+func (obj Reallocate) MarshalWithEncoder(encoder *ag_binary.Encoder) (err error) {
+	// Serialize `ExtensionTypes` param:
+	for _, extensionType := range obj.ExtensionTypes {
+		err = encoder.WriteUint16(uint16(extensionType), ag_binary.LE)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+func (obj *Reallocate) UnmarshalWithDecoder(decoder *ag_binary.Decoder) (err error) {
+	// Deserialize `ExtensionTypes`:
+	obj.ExtensionTypes = make([]ExtensionType, 0, decoder.Remaining()/2)
+	for decoder.Remaining() >= 2 {
+		v, err := decoder.ReadUint16(ag_binary.LE)
+		if err != nil {
+			return err
+		}
+		obj.ExtensionTypes = append(obj.ExtensionTypes, ExtensionType(v))
+	}
+	return nil
+}
+
+// NewReallocateInstruction declares a new Reallocate instruction with the provided parameters and accounts.
+func NewReallocateInstruction(
+	// Parameters:
+	extension_types []ExtensionType,
+	// Accounts:
+	account ag_solanago.PublicKey,
+	payer ag_solanago.PublicKey,
+	systemProgram ag_solanago.PublicKey,
+	owner ag_solanago.PublicKey,
+	multisigSigners []ag_solanago.PublicKey) *Reallocate {
+	return NewReallocateInstructionBuilder().
+		SetExtensionTypes(extension_types...).
+		SetAccount(account).
+		SetPayerAccount(payer).
+		SetSystemProgramAccount(systemProgram).
+		SetOwnerAccount(owner, multisigSigners...)
+}
@@ -0,0 +1,201 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package token2022
+
+import (
+	"errors"
+
+	ag_binary "github.com/gagliardetto/binary"
+	ag_solanago "github.com/gagliardetto/solana-go"
+	ag_format "github.com/gagliardetto/solana-go/text/format"
+	ag_treeout "github.com/gagliardetto/treeout"
+)
+
+// Removes a key-value pair in a token-metadata account.
+//
+// This only applies to additional fields, and not the base name / symbol /
+// URI fields.
+type RemoveTokenMetadataKey struct {
+	// If the idempotent flag is set to true, then the instruction will not
+	// error if the key does not exist.
+	Idempotent *bool
+
+	// Key to remove in the additional metadata portion.
+	Key *string
+
+	// [0] = [WRITE] metadata
+	// ··········· The metadata account.
+	//
+	// [1] = [SIGNER] updateAuthority
+	// ··········· The update authority.
+	ag_solanago.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+// NewRemoveTokenMetadataKeyInstructionBuilder creates a new `RemoveTokenMetadataKey` instruction builder.
+func NewRemoveTokenMetadataKeyInstructionBuilder() *RemoveTokenMetadataKey {
+	nd := &RemoveTokenMetadataKey{
+		AccountMetaSlice: make(ag_solanago.AccountMetaSlice, 2),
+	}
+	return nd
+}
+
+// SetIdempotent sets the "idempotent" parameter.
+// If the idempotent flag is set to true, then the instruction will not
+// error if the key does not exist.
+func (inst *RemoveTokenMetadataKey) SetIdempotent(idempotent bool) *RemoveTokenMetadataKey {
+	inst.Idempotent = &idempotent
+	return inst
+}
+
+// SetKey sets the "key" parameter.
+// Key to remove in the additional metadata portion.
+func (inst *RemoveTokenMetadataKey) SetKey(key string) *RemoveTokenMetadataKey {
+	inst.Key = &key
+	return inst
+}
+
+// SetMetadataAccount sets the "metadata" account.
+// The metadata account.
+func (inst *RemoveTokenMetadataKey) SetMetadataAccount(metadata ag_solanago.PublicKey) *RemoveTokenMetadataKey {
+	inst.AccountMetaSlice[0] = ag_solanago.Meta(metadata).WRITE()
+	return inst
+}
+
+// GetMetadataAccount gets the "metadata" account.
+// The metadata account.
+func (inst *RemoveTokenMetadataKey) GetMetadataAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[0]
+}
+
+// SetUpdateAuthorityAccount sets the "updateAuthority" account.
+// The update authority.
+func (inst *RemoveTokenMetadataKey) SetUpdateAuthorityAccount(updateAuthority ag_solanago.PublicKey) *RemoveTokenMetadataKey {
+	inst.AccountMetaSlice[1] = ag_solanago.Meta(updateAuthority).SIGNER()
+	return inst
+}
+
+// GetUpdateAuthorityAccount gets the "updateAuthority" account.
+// The update authority.
+func (inst *RemoveTokenMetadataKey) GetUpdateAuthorityAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[1]
+}
+
+func (inst RemoveTokenMetadataKey) Build() *Instruction {
+	return &Instruction{BaseVariant: ag_binary.BaseVariant{
+		Impl:   inst,
+		TypeID: TokenMetadata_RemoveKey,
+	}}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (inst RemoveTokenMetadataKey) ValidateAndBuild() (*Instruction, error) {
+	if err := inst.Validate(); err != nil {
+		return nil, err
+	}
+	return inst.Build(), nil
+}
+
+func (inst *RemoveTokenMetadataKey) Validate() error {
+	// Check whether all (required) parameters are set:
+	{
+		if inst.Idempotent == nil {
+			return errors.New("Idempotent parameter is not set")
+		}
+		if inst.Key == nil {
+			return errors.New("Key parameter is not set")
+		}
+	}
+
+	// Check whether all (required) accounts are set:
+	{
+		if inst.AccountMetaSlice[0] == nil {
+			return errors.New("accounts.Metadata is not set")
+		}
+		if inst.AccountMetaSlice[1] == nil {
+			return errors.New("accounts.UpdateAuthority is not set")
+		}
+	}
+	return nil
+}
+
+func (inst *RemoveTokenMetadataKey) EncodeToTree(parent ag_treeout.Branches) {
+	parent.Child(ag_format.Program(ProgramName, ProgramID)).
+		//
+		ParentFunc(func(programBranch ag_treeout.Branches) {
+			programBranch.Child(ag_format.Instruction("RemoveTokenMetadataKey")).
+				//
+				ParentFunc(func(instructionBranch ag_treeout.Branches) {
+
+					// Parameters of the instruction:
+					instructionBranch.Child("Params").ParentFunc(func(paramsBranch ag_treeout.Branches) {
+						paramsBranch.Child(ag_format.Param("Idempotent", *inst.Idempotent))
+						paramsBranch.Child(ag_format.Param("       Key", *inst.Key))
+					})
+
+					// Accounts of the instruction:
+					instructionBranch.Child("Accounts").ParentFunc(func(accountsBranch ag_treeout.Branches) {
+						accountsBranch.Child(ag_format.Meta("       metadata", inst.AccountMetaSlice[0]))
+						accountsBranch.Child(ag_format.Meta("updateAuthority", inst.AccountMetaSlice[1]))
+					})
+				})
+		})
+}
+
+func (obj RemoveTokenMetadataKey) MarshalWithEncoder(encoder *ag_binary.Encoder) (err error) {
+	// Serialize `Idempotent` param:
+	err = encoder.Encode(obj.Idempotent)
+	if err != nil {
+		return err
+	}
+	// Serialize `Key` param:
+	err = writeBorshString(encoder, *obj.Key)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+func (obj *RemoveTokenMetadataKey) UnmarshalWithDecoder(decoder *ag_binary.Decoder) (err error) {
+	// Deserialize `Idempotent`:
+	err = decoder.Decode(&obj.Idempotent)
+	if err != nil {
+		return err
+	}
+	// Deserialize `Key`:
+	{
+		v, err := readBorshString(decoder)
+		if err != nil {
+			return err
+		}
+		obj.Key = &v
+	}
+	return nil
+}
+
+// NewRemoveTokenMetadataKeyInstruction declares a new RemoveTokenMetadataKey instruction with the provided parameters and accounts.
+func NewRemoveTokenMetadataKeyInstruction(
+	// Parameters:
+	idempotent bool,
+	key string,
+	// Accounts:
+	metadata ag_solanago.PublicKey,
+	updateAuthority ag_solanago.PublicKey) *RemoveTokenMetadataKey {
+	return NewRemoveTokenMetadataKeyInstructionBuilder().
+		SetIdempotent(idempotent).
+		SetKey(key).
+		SetMetadataAccount(metadata).
+		SetUpdateAuthorityAccount(updateAuthority)
+}
@@ -0,0 +1,63 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package token2022
+
+import (
+	ag_binary "github.com/gagliardetto/binary"
+)
+
+// Instructions of the scaled UI amount extension, encoded after
+// `Instruction_ScaledUiAmountExtension`.
+const (
+	// Initialize a new mint with scaled UI amounts.
+	ScaledUiAmount_Initialize uint8 = iota
+
+	// Update the multiplier.
+	ScaledUiAmount_UpdateMultiplier
+)
+
+// ScaledUiAmountInstructionIDToName returns the name of the scaled UI amount
+// extension instruction given its ID.
+func ScaledUiAmountInstructionIDToName(id uint8) string {
+	switch id {
+	case ScaledUiAmount_Initialize:
+		return "InitializeScaledUiAmount"
+	case ScaledUiAmount_UpdateMultiplier:
+		return "UpdateScaledUiAmountMultiplier"
+	default:
+		return ""
+	}
+}
+
+var ScaledUiAmountInstructionImplDef = ag_binary.NewVariantDefinition(
+	ag_binary.Uint8TypeIDEncoding,
+	[]ag_binary.VariantType{
+		{
+			"InitializeScaledUiAmount", (*InitializeScaledUiAmount)(nil),
+		},
+		{
+			"UpdateScaledUiAmountMultiplier", (*UpdateScaledUiAmountMultiplier)(nil),
+		},
+	},
+)
+
+// ScaledUiAmountExtension wraps the instructions of the scaled UI amount extension.
+type ScaledUiAmountExtension struct {
+	extensionInstruction
+}
+
+func (inst *ScaledUiAmountExtension) UnmarshalWithDecoder(decoder *ag_binary.Decoder) error {
+	return inst.BaseVariant.UnmarshalBinaryVariant(decoder, ScaledUiAmountInstructionImplDef)
+}
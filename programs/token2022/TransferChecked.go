@@ -53,8 +53,13 @@ type TransferChecked struct {
 	//
 	// [4...] = [SIGNER] signers
 	// ··········· M signer accounts.
-	Accounts ag_solanago.AccountMetaSlice `bin:"-" borsh_skip:"true"`
-	Signers  ag_solanago.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+	//
+	// [4+M...] = [] extra accounts
+	// ··········· Accounts required by the mint's transfer hook program
+	// ··········· (see NewTransferCheckedInstructionWithExtraMetas).
+	Accounts      ag_solanago.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+	Signers       ag_solanago.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+	ExtraAccounts ag_solanago.AccountMetaSlice `bin:"-" borsh_skip:"true"`
 }
 
 func (obj *TransferChecked) SetAccounts(accounts []*ag_solanago.AccountMeta) error {
@@ -65,6 +70,7 @@ func (obj *TransferChecked) SetAccounts(accounts []*ag_solanago.AccountMeta) err
 func (slice TransferChecked) GetAccounts() (accounts []*ag_solanago.AccountMeta) {
 	accounts = append(accounts, slice.Accounts...)
 	accounts = append(accounts, slice.Signers...)
+	accounts = append(accounts, slice.ExtraAccounts...)
 	return
 }
 
@@ -149,6 +155,13 @@ func (inst *TransferChecked) GetOwnerAccount() *ag_solanago.AccountMeta {
 	return inst.Accounts[3]
 }
 
+// AddExtraAccounts appends accounts after the signers, e.g. the
+// accounts required by the mint's transfer hook program.
+func (inst *TransferChecked) AddExtraAccounts(accounts ...*ag_solanago.AccountMeta) *TransferChecked {
+	inst.ExtraAccounts = append(inst.ExtraAccounts, accounts...)
+	return inst
+}
+
 func (inst TransferChecked) Build() *Instruction {
 	return &Instruction{BaseVariant: ag_binary.BaseVariant{
 		Impl:   inst,
@@ -230,6 +243,13 @@ func (inst *TransferChecked) EncodeToTree(parent ag_treeout.Branches) {
 								signersBranch.Child(ag_format.Meta(fmt.Sprintf("[%v]", i), v))
 							}
 						}
+
+						if len(inst.ExtraAccounts) > 0 {
+							extraBranch := accountsBranch.Child(fmt.Sprintf("extra[len=%v]", len(inst.ExtraAccounts)))
+							for i, v := range inst.ExtraAccounts {
+								extraBranch.Child(ag_format.Meta(fmt.Sprintf("[%v]", i), v))
+							}
+						}
 					})
 				})
 		})
@@ -279,6 +299,9 @@ func (a *TransferChecked) AssertEquivalent(in interface{}) error {
 	if err := a.Signers.AssertEquivalent(b.Signers); err != nil {
 		return fmt.Errorf("(%T) signers: %w", a, err)
 	}
+	if err := a.ExtraAccounts.AssertEquivalent(b.ExtraAccounts); err != nil {
+		return fmt.Errorf("(%T) extra accounts: %w", a, err)
+	}
 	return nil
 }
 
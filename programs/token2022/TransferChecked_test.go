@@ -32,6 +32,7 @@ func TestEncodeDecode_TransferChecked(t *testing.T) {
 				fu.Fuzz(params)
 				params.Accounts = nil
 				params.Signers = nil
+				params.ExtraAccounts = nil
 				buf := new(bytes.Buffer)
 				err := encodeT(*params, buf)
 				ag_require.NoError(t, err)
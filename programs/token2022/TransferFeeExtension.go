@@ -0,0 +1,95 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package token2022
+
+import (
+	ag_binary "github.com/gagliardetto/binary"
+)
+
+// Instructions of the transfer fee extension, encoded after
+// `Instruction_TransferFeeExtension`.
+const (
+	// Initialize the transfer fee on a new mint.
+	TransferFee_InitializeTransferFeeConfig uint8 = iota
+
+	// Transfer, providing expected mint information and fees.
+	TransferFee_TransferCheckedWithFee
+
+	// Transfer all withheld tokens in the mint to an account.
+	TransferFee_WithdrawWithheldTokensFromMint
+
+	// Transfer all withheld tokens to an account.
+	TransferFee_WithdrawWithheldTokensFromAccounts
+
+	// Permissionless instruction to transfer all withheld tokens to the mint.
+	TransferFee_HarvestWithheldTokensToMint
+
+	// Set transfer fee.
+	TransferFee_SetTransferFee
+)
+
+// TransferFeeInstructionIDToName returns the name of the transfer fee
+// extension instruction given its ID.
+func TransferFeeInstructionIDToName(id uint8) string {
+	switch id {
+	case TransferFee_InitializeTransferFeeConfig:
+		return "InitializeTransferFeeConfig"
+	case TransferFee_TransferCheckedWithFee:
+		return "TransferCheckedWithFee"
+	case TransferFee_WithdrawWithheldTokensFromMint:
+		return "WithdrawWithheldTokensFromMint"
+	case TransferFee_WithdrawWithheldTokensFromAccounts:
+		return "WithdrawWithheldTokensFromAccounts"
+	case TransferFee_HarvestWithheldTokensToMint:
+		return "HarvestWithheldTokensToMint"
+	case TransferFee_SetTransferFee:
+		return "SetTransferFee"
+	default:
+		return ""
+	}
+}
+
+var TransferFeeInstructionImplDef = ag_binary.NewVariantDefinition(
+	ag_binary.Uint8TypeIDEncoding,
+	[]ag_binary.VariantType{
+		{
+			"InitializeTransferFeeConfig", (*InitializeTransferFeeConfig)(nil),
+		},
+		{
+			"TransferCheckedWithFee", (*TransferCheckedWithFee)(nil),
+		},
+		{
+			"WithdrawWithheldTokensFromMint", (*WithdrawWithheldTokensFromMint)(nil),
+		},
+		{
+			"WithdrawWithheldTokensFromAccounts", (*WithdrawWithheldTokensFromAccounts)(nil),
+		},
+		{
+			"HarvestWithheldTokensToMint", (*HarvestWithheldTokensToMint)(nil),
+		},
+		{
+			"SetTransferFee", (*SetTransferFee)(nil),
+		},
+	},
+)
+
+// TransferFeeExtension wraps the instructions of the transfer fee extension.
+type TransferFeeExtension struct {
+	extensionInstruction
+}
+
+func (inst *TransferFeeExtension) UnmarshalWithDecoder(decoder *ag_binary.Decoder) error {
+	return inst.BaseVariant.UnmarshalBinaryVariant(decoder, TransferFeeInstructionImplDef)
+}
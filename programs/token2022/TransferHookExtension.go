@@ -0,0 +1,63 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package token2022
+
+import (
+	ag_binary "github.com/gagliardetto/binary"
+)
+
+// Instructions of the transfer hook extension, encoded after
+// `Instruction_TransferHookExtension`.
+const (
+	// Initialize a new mint with a transfer hook program.
+	TransferHook_Initialize uint8 = iota
+
+	// Update the transfer hook program id.
+	TransferHook_Update
+)
+
+// TransferHookInstructionIDToName returns the name of the transfer hook
+// extension instruction given its ID.
+func TransferHookInstructionIDToName(id uint8) string {
+	switch id {
+	case TransferHook_Initialize:
+		return "InitializeTransferHook"
+	case TransferHook_Update:
+		return "UpdateTransferHook"
+	default:
+		return ""
+	}
+}
+
+var TransferHookInstructionImplDef = ag_binary.NewVariantDefinition(
+	ag_binary.Uint8TypeIDEncoding,
+	[]ag_binary.VariantType{
+		{
+			"InitializeTransferHook", (*InitializeTransferHook)(nil),
+		},
+		{
+			"UpdateTransferHook", (*UpdateTransferHook)(nil),
+		},
+	},
+)
+
+// TransferHookExtension wraps the instructions of the transfer hook extension.
+type TransferHookExtension struct {
+	extensionInstruction
+}
+
+func (inst *TransferHookExtension) UnmarshalWithDecoder(decoder *ag_binary.Decoder) error {
+	return inst.BaseVariant.UnmarshalBinaryVariant(decoder, TransferHookInstructionImplDef)
+}
@@ -0,0 +1,150 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package token2022
+
+import (
+	"errors"
+
+	ag_binary "github.com/gagliardetto/binary"
+	ag_solanago "github.com/gagliardetto/solana-go"
+	ag_format "github.com/gagliardetto/solana-go/text/format"
+	ag_treeout "github.com/gagliardetto/treeout"
+)
+
+// Convert a UiAmount of tokens to a little-endian `u64` raw Amount, using
+// the given mint, returned via the program return data.
+type UiAmountToAmount struct {
+	// The ui_amount of tokens to convert.
+	UiAmount *string
+
+	// [0] = [] mint
+	// ··········· The mint to calculate for.
+	ag_solanago.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+// NewUiAmountToAmountInstructionBuilder creates a new `UiAmountToAmount` instruction builder.
+func NewUiAmountToAmountInstructionBuilder() *UiAmountToAmount {
+	nd := &UiAmountToAmount{
+		AccountMetaSlice: make(ag_solanago.AccountMetaSlice, 1),
+	}
+	return nd
+}
+
+// SetUiAmount sets the "ui_amount" parameter.
+// The ui_amount of tokens to convert.
+func (inst *UiAmountToAmount) SetUiAmount(ui_amount string) *UiAmountToAmount {
+	inst.UiAmount = &ui_amount
+	return inst
+}
+
+// SetMintAccount sets the "mint" account.
+// The mint to calculate for.
+func (inst *UiAmountToAmount) SetMintAccount(mint ag_solanago.PublicKey) *UiAmountToAmount {
+	inst.AccountMetaSlice[0] = ag_solanago.Meta(mint)
+	return inst
+}
+
+// GetMintAccount gets the "mint" account.
+// The mint to calculate for.
+func (inst *UiAmountToAmount) GetMintAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[0]
+}
+
+func (inst UiAmountToAmount) Build() *Instruction {
+	return &Instruction{BaseVariant: ag_binary.BaseVariant{
+		Impl:   inst,
+		TypeID: ag_binary.TypeIDFromUint8(Instruction_UiAmountToAmount),
+	}}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (inst UiAmountToAmount) ValidateAndBuild() (*Instruction, error) {
+	if err := inst.Validate(); err != nil {
+		return nil, err
+	}
+	return inst.Build(), nil
+}
+
+func (inst *UiAmountToAmount) Validate() error {
+	// Check whether all (required) parameters are set:
+	{
+		if inst.UiAmount == nil {
+			return errors.New("UiAmount parameter is not set")
+		}
+	}
+
+	// Check whether all (required) accounts are set:
+	{
+		if inst.AccountMetaSlice[0] == nil {
+			return errors.New("accounts.Mint is not set")
+		}
+	}
+	return nil
+}
+
+func (inst *UiAmountToAmount) EncodeToTree(parent ag_treeout.Branches) {
+	parent.Child(ag_format.Program(ProgramName, ProgramID)).
+		//
+		ParentFunc(func(programBranch ag_treeout.Branches) {
+			programBranch.Child(ag_format.Instruction("UiAmountToAmount")).
+				//
+				ParentFunc(func(instructionBranch ag_treeout.Branches) {
+
+					// Parameters of the instruction:
+					instructionBranch.Child("Params").ParentFunc(func(paramsBranch ag_treeout.Branches) {
+						paramsBranch.Child(ag_format.Param("UiAmount", *inst.UiAmount))
+					})
+
+					// Accounts of the instruction:
+					instructionBranch.Child("Accounts").ParentFunc(func(accountsBranch ag_treeout.Branches) {
+						accountsBranch.Child(ag_format.Meta("mint", inst.AccountMetaSlice[0]))
+					})
+				})
+		})
+}
+
+func (obj UiAmountToAmount) MarshalWithEncoder(encoder *ag_binary.Encoder) (err error) {
+	// Serialize `UiAmount` param:
+	err = encoder.WriteBytes([]byte(*obj.UiAmount), false)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+func (obj *UiAmountToAmount) UnmarshalWithDecoder(decoder *ag_binary.Decoder) (err error) {
+	// Deserialize `UiAmount`:
+	{
+		v, err := decoder.ReadNBytes(decoder.Remaining())
+		if err != nil {
+			return err
+		}
+		s := string(v)
+		obj.UiAmount = &s
+	}
+	return nil
+}
+
+// NewUiAmountToAmountInstruction declares a new UiAmountToAmount instruction with the provided parameters and accounts.
+func NewUiAmountToAmountInstruction(
+	// Parameters:
+	ui_amount string,
+	// Accounts:
+	mint ag_solanago.PublicKey) *UiAmountToAmount {
+	return NewUiAmountToAmountInstructionBuilder().
+		SetUiAmount(ui_amount).
+		SetMintAccount(mint)
+}
@@ -0,0 +1,210 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package token2022
+
+import (
+	"errors"
+	"fmt"
+
+	ag_binary "github.com/gagliardetto/binary"
+	ag_solanago "github.com/gagliardetto/solana-go"
+	ag_format "github.com/gagliardetto/solana-go/text/format"
+	ag_treeout "github.com/gagliardetto/treeout"
+)
+
+// Update the default state for new Accounts. Only supported for mints that
+// include the `DefaultAccountState` extension.
+type UpdateDefaultAccountState struct {
+	// The state every new account of the mint is initialized in.
+	State *AccountState
+
+	// [0] = [WRITE] mint
+	// ··········· The mint.
+	//
+	// [1] = [SIGNER] freezeAuthority
+	// ··········· The mint freeze authority or its multisignature account.
+	//
+	// [2...] = [SIGNER] signers
+	// ··········· M signer accounts.
+	Accounts ag_solanago.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+	Signers  ag_solanago.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+func (obj *UpdateDefaultAccountState) SetAccounts(accounts []*ag_solanago.AccountMeta) error {
+	obj.Accounts, obj.Signers = ag_solanago.AccountMetaSlice(accounts).SplitFrom(2)
+	return nil
+}
+
+func (slice UpdateDefaultAccountState) GetAccounts() (accounts []*ag_solanago.AccountMeta) {
+	accounts = append(accounts, slice.Accounts...)
+	accounts = append(accounts, slice.Signers...)
+	return
+}
+
+// NewUpdateDefaultAccountStateInstructionBuilder creates a new `UpdateDefaultAccountState` instruction builder.
+func NewUpdateDefaultAccountStateInstructionBuilder() *UpdateDefaultAccountState {
+	nd := &UpdateDefaultAccountState{
+		Accounts: make(ag_solanago.AccountMetaSlice, 2),
+		Signers:  make(ag_solanago.AccountMetaSlice, 0),
+	}
+	return nd
+}
+
+// SetState sets the "state" parameter.
+// The state every new account of the mint is initialized in.
+func (inst *UpdateDefaultAccountState) SetState(state AccountState) *UpdateDefaultAccountState {
+	inst.State = &state
+	return inst
+}
+
+// SetMintAccount sets the "mint" account.
+// The mint.
+func (inst *UpdateDefaultAccountState) SetMintAccount(mint ag_solanago.PublicKey) *UpdateDefaultAccountState {
+	inst.Accounts[0] = ag_solanago.Meta(mint).WRITE()
+	return inst
+}
+
+// GetMintAccount gets the "mint" account.
+// The mint.
+func (inst *UpdateDefaultAccountState) GetMintAccount() *ag_solanago.AccountMeta {
+	return inst.Accounts[0]
+}
+
+// SetFreezeAuthorityAccount sets the "freezeAuthority" account.
+// The mint freeze authority or its multisignature account.
+func (inst *UpdateDefaultAccountState) SetFreezeAuthorityAccount(freezeAuthority ag_solanago.PublicKey, multisigSigners ...ag_solanago.PublicKey) *UpdateDefaultAccountState {
+	inst.Accounts[1] = ag_solanago.Meta(freezeAuthority)
+	if len(multisigSigners) == 0 {
+		inst.Accounts[1].SIGNER()
+	}
+	for _, signer := range multisigSigners {
+		inst.Signers = append(inst.Signers, ag_solanago.Meta(signer).SIGNER())
+	}
+	return inst
+}
+
+// GetFreezeAuthorityAccount gets the "freezeAuthority" account.
+// The mint freeze authority or its multisignature account.
+func (inst *UpdateDefaultAccountState) GetFreezeAuthorityAccount() *ag_solanago.AccountMeta {
+	return inst.Accounts[1]
+}
+
+func (inst UpdateDefaultAccountState) Build() *Instruction {
+	return &Instruction{BaseVariant: ag_binary.BaseVariant{
+		Impl: &DefaultAccountStateExtension{extensionInstruction{ag_binary.BaseVariant{
+			Impl:   inst,
+			TypeID: ag_binary.TypeIDFromUint8(DefaultAccountState_Update),
+		}}},
+		TypeID: ag_binary.TypeIDFromUint8(Instruction_DefaultAccountStateExtension),
+	}}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (inst UpdateDefaultAccountState) ValidateAndBuild() (*Instruction, error) {
+	if err := inst.Validate(); err != nil {
+		return nil, err
+	}
+	return inst.Build(), nil
+}
+
+func (inst *UpdateDefaultAccountState) Validate() error {
+	// Check whether all (required) parameters are set:
+	{
+		if inst.State == nil {
+			return errors.New("State parameter is not set")
+		}
+	}
+
+	// Check whether all (required) accounts are set:
+	{
+		if inst.Accounts[0] == nil {
+			return errors.New("accounts.Mint is not set")
+		}
+		if inst.Accounts[1] == nil {
+			return errors.New("accounts.FreezeAuthority is not set")
+		}
+		if !inst.Accounts[1].IsSigner && len(inst.Signers) == 0 {
+			return fmt.Errorf("accounts.Signers is not set")
+		}
+		if len(inst.Signers) > MAX_SIGNERS {
+			return fmt.Errorf("too many signers; got %v, but max is 11", len(inst.Signers))
+		}
+	}
+	return nil
+}
+
+func (inst *UpdateDefaultAccountState) EncodeToTree(parent ag_treeout.Branches) {
+	parent.Child(ag_format.Program(ProgramName, ProgramID)).
+		//
+		ParentFunc(func(programBranch ag_treeout.Branches) {
+			programBranch.Child(ag_format.Instruction("UpdateDefaultAccountState")).
+				//
+				ParentFunc(func(instructionBranch ag_treeout.Branches) {
+
+					// Parameters of the instruction:
+					instructionBranch.Child("Params").ParentFunc(func(paramsBranch ag_treeout.Branches) {
+						paramsBranch.Child(ag_format.Param("State", *inst.State))
+					})
+
+					// Accounts of the instruction:
+					instructionBranch.Child("Accounts").ParentFunc(func(accountsBranch ag_treeout.Branches) {
+						accountsBranch.Child(ag_format.Meta("           mint", inst.Accounts[0]))
+						accountsBranch.Child(ag_format.Meta("freezeAuthority", inst.Accounts[1]))
+
+						signersBranch := accountsBranch.Child(fmt.Sprintf("signers[len=%v]", len(inst.Signers)))
+						for i, v := range inst.Signers {
+							if len(inst.Signers) > 9 && i < 10 {
+								signersBranch.Child(ag_format.Meta(fmt.Sprintf(" [%v]", i), v))
+							} else {
+								signersBranch.Child(ag_format.Meta(fmt.Sprintf("[%v]", i), v))
+							}
+						}
+					})
+				})
+		})
+}
+
+func (obj UpdateDefaultAccountState) MarshalWithEncoder(encoder *ag_binary.Encoder) (err error) {
+	// Serialize `State` param:
+	err = encoder.Encode(obj.State)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+func (obj *UpdateDefaultAccountState) UnmarshalWithDecoder(decoder *ag_binary.Decoder) (err error) {
+	// Deserialize `State`:
+	err = decoder.Decode(&obj.State)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// NewUpdateDefaultAccountStateInstruction declares a new UpdateDefaultAccountState instruction with the provided parameters and accounts.
+func NewUpdateDefaultAccountStateInstruction(
+	// Parameters:
+	state AccountState,
+	// Accounts:
+	mint ag_solanago.PublicKey,
+	freezeAuthority ag_solanago.PublicKey,
+	multisigSigners []ag_solanago.PublicKey) *UpdateDefaultAccountState {
+	return NewUpdateDefaultAccountStateInstructionBuilder().
+		SetState(state).
+		SetMintAccount(mint).
+		SetFreezeAuthorityAccount(freezeAuthority, multisigSigners...)
+}
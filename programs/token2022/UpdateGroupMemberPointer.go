@@ -0,0 +1,203 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package token2022
+
+import (
+	"errors"
+	"fmt"
+
+	ag_binary "github.com/gagliardetto/binary"
+	ag_solanago "github.com/gagliardetto/solana-go"
+	ag_format "github.com/gagliardetto/solana-go/text/format"
+	ag_treeout "github.com/gagliardetto/treeout"
+)
+
+// Update the group member address. Only supported for mints that include the
+// `GroupMemberPointer` extension.
+type UpdateGroupMemberPointer struct {
+	// The new account address that holds the group member configurations.
+	MemberAddress *ag_solanago.PublicKey
+
+	// [0] = [WRITE] mint
+	// ··········· The mint.
+	//
+	// [1] = [SIGNER] authority
+	// ··········· The group member pointer authority or its multisignature account.
+	//
+	// [2...] = [SIGNER] signers
+	// ··········· M signer accounts.
+	Accounts ag_solanago.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+	Signers  ag_solanago.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+func (obj *UpdateGroupMemberPointer) SetAccounts(accounts []*ag_solanago.AccountMeta) error {
+	obj.Accounts, obj.Signers = ag_solanago.AccountMetaSlice(accounts).SplitFrom(2)
+	return nil
+}
+
+func (slice UpdateGroupMemberPointer) GetAccounts() (accounts []*ag_solanago.AccountMeta) {
+	accounts = append(accounts, slice.Accounts...)
+	accounts = append(accounts, slice.Signers...)
+	return
+}
+
+// NewUpdateGroupMemberPointerInstructionBuilder creates a new `UpdateGroupMemberPointer` instruction builder.
+func NewUpdateGroupMemberPointerInstructionBuilder() *UpdateGroupMemberPointer {
+	nd := &UpdateGroupMemberPointer{
+		Accounts: make(ag_solanago.AccountMetaSlice, 2),
+		Signers:  make(ag_solanago.AccountMetaSlice, 0),
+	}
+	return nd
+}
+
+// SetMemberAddress sets the "member_address" parameter.
+// The new account address that holds the group member configurations.
+func (inst *UpdateGroupMemberPointer) SetMemberAddress(member_address ag_solanago.PublicKey) *UpdateGroupMemberPointer {
+	inst.MemberAddress = &member_address
+	return inst
+}
+
+// SetMintAccount sets the "mint" account.
+// The mint.
+func (inst *UpdateGroupMemberPointer) SetMintAccount(mint ag_solanago.PublicKey) *UpdateGroupMemberPointer {
+	inst.Accounts[0] = ag_solanago.Meta(mint).WRITE()
+	return inst
+}
+
+// GetMintAccount gets the "mint" account.
+// The mint.
+func (inst *UpdateGroupMemberPointer) GetMintAccount() *ag_solanago.AccountMeta {
+	return inst.Accounts[0]
+}
+
+// SetAuthorityAccount sets the "authority" account.
+// The group member pointer authority or its multisignature account.
+func (inst *UpdateGroupMemberPointer) SetAuthorityAccount(authority ag_solanago.PublicKey, multisigSigners ...ag_solanago.PublicKey) *UpdateGroupMemberPointer {
+	inst.Accounts[1] = ag_solanago.Meta(authority)
+	if len(multisigSigners) == 0 {
+		inst.Accounts[1].SIGNER()
+	}
+	for _, signer := range multisigSigners {
+		inst.Signers = append(inst.Signers, ag_solanago.Meta(signer).SIGNER())
+	}
+	return inst
+}
+
+// GetAuthorityAccount gets the "authority" account.
+// The group member pointer authority or its multisignature account.
+func (inst *UpdateGroupMemberPointer) GetAuthorityAccount() *ag_solanago.AccountMeta {
+	return inst.Accounts[1]
+}
+
+func (inst UpdateGroupMemberPointer) Build() *Instruction {
+	return &Instruction{BaseVariant: ag_binary.BaseVariant{
+		Impl: &GroupMemberPointerExtension{extensionInstruction{ag_binary.BaseVariant{
+			Impl:   inst,
+			TypeID: ag_binary.TypeIDFromUint8(GroupMemberPointer_Update),
+		}}},
+		TypeID: ag_binary.TypeIDFromUint8(Instruction_GroupMemberPointerExtension),
+	}}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (inst UpdateGroupMemberPointer) ValidateAndBuild() (*Instruction, error) {
+	if err := inst.Validate(); err != nil {
+		return nil, err
+	}
+	return inst.Build(), nil
+}
+
+func (inst *UpdateGroupMemberPointer) Validate() error {
+	// Check whether all (required) accounts are set:
+	{
+		if inst.Accounts[0] == nil {
+			return errors.New("accounts.Mint is not set")
+		}
+		if inst.Accounts[1] == nil {
+			return errors.New("accounts.Authority is not set")
+		}
+		if !inst.Accounts[1].IsSigner && len(inst.Signers) == 0 {
+			return fmt.Errorf("accounts.Signers is not set")
+		}
+		if len(inst.Signers) > MAX_SIGNERS {
+			return fmt.Errorf("too many signers; got %v, but max is 11", len(inst.Signers))
+		}
+	}
+	return nil
+}
+
+func (inst *UpdateGroupMemberPointer) EncodeToTree(parent ag_treeout.Branches) {
+	parent.Child(ag_format.Program(ProgramName, ProgramID)).
+		//
+		ParentFunc(func(programBranch ag_treeout.Branches) {
+			programBranch.Child(ag_format.Instruction("UpdateGroupMemberPointer")).
+				//
+				ParentFunc(func(instructionBranch ag_treeout.Branches) {
+
+					// Parameters of the instruction:
+					instructionBranch.Child("Params").ParentFunc(func(paramsBranch ag_treeout.Branches) {
+						paramsBranch.Child(ag_format.Param("MemberAddress (OPT)", inst.MemberAddress))
+					})
+
+					// Accounts of the instruction:
+					instructionBranch.Child("Accounts").ParentFunc(func(accountsBranch ag_treeout.Branches) {
+						accountsBranch.Child(ag_format.Meta("     mint", inst.Accounts[0]))
+						accountsBranch.Child(ag_format.Meta("authority", inst.Accounts[1]))
+
+						signersBranch := accountsBranch.Child(fmt.Sprintf("signers[len=%v]", len(inst.Signers)))
+						for i, v := range inst.Signers {
+							if len(inst.Signers) > 9 && i < 10 {
+								signersBranch.Child(ag_format.Meta(fmt.Sprintf(" [%v]", i), v))
+							} else {
+								signersBranch.Child(ag_format.Meta(fmt.Sprintf("[%v]", i), v))
+							}
+						}
+					})
+				})
+		})
+}
+
+func (obj UpdateGroupMemberPointer) MarshalWithEncoder(encoder *ag_binary.Encoder) (err error) {
+	// Serialize `MemberAddress` param (optional, zeroed when unset):
+	err = writeOptionalNonZeroPubkey(encoder, obj.MemberAddress)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+func (obj *UpdateGroupMemberPointer) UnmarshalWithDecoder(decoder *ag_binary.Decoder) (err error) {
+	// Deserialize `MemberAddress` (optional, zeroed when unset):
+	obj.MemberAddress, err = readOptionalNonZeroPubkey(decoder)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// NewUpdateGroupMemberPointerInstruction declares a new UpdateGroupMemberPointer instruction with the provided parameters and accounts.
+func NewUpdateGroupMemberPointerInstruction(
+	// Parameters:
+	member_address ag_solanago.PublicKey,
+	// Accounts:
+	mint ag_solanago.PublicKey,
+	authority ag_solanago.PublicKey,
+	multisigSigners []ag_solanago.PublicKey) *UpdateGroupMemberPointer {
+	return NewUpdateGroupMemberPointerInstructionBuilder().
+		SetMemberAddress(member_address).
+		SetMintAccount(mint).
+		SetAuthorityAccount(authority, multisigSigners...)
+}
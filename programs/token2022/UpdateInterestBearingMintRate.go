@@ -0,0 +1,210 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package token2022
+
+import (
+	"errors"
+	"fmt"
+
+	ag_binary "github.com/gagliardetto/binary"
+	ag_solanago "github.com/gagliardetto/solana-go"
+	ag_format "github.com/gagliardetto/solana-go/text/format"
+	ag_treeout "github.com/gagliardetto/treeout"
+)
+
+// Update the interest rate. Only supported for mints that include the
+// `InterestBearingConfig` extension.
+type UpdateInterestBearingMintRate struct {
+	// The new interest rate, in basis points.
+	Rate *int16
+
+	// [0] = [WRITE] mint
+	// ··········· The mint.
+	//
+	// [1] = [SIGNER] rateAuthority
+	// ··········· The mint rate authority or its multisignature account.
+	//
+	// [2...] = [SIGNER] signers
+	// ··········· M signer accounts.
+	Accounts ag_solanago.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+	Signers  ag_solanago.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+func (obj *UpdateInterestBearingMintRate) SetAccounts(accounts []*ag_solanago.AccountMeta) error {
+	obj.Accounts, obj.Signers = ag_solanago.AccountMetaSlice(accounts).SplitFrom(2)
+	return nil
+}
+
+func (slice UpdateInterestBearingMintRate) GetAccounts() (accounts []*ag_solanago.AccountMeta) {
+	accounts = append(accounts, slice.Accounts...)
+	accounts = append(accounts, slice.Signers...)
+	return
+}
+
+// NewUpdateInterestBearingMintRateInstructionBuilder creates a new `UpdateInterestBearingMintRate` instruction builder.
+func NewUpdateInterestBearingMintRateInstructionBuilder() *UpdateInterestBearingMintRate {
+	nd := &UpdateInterestBearingMintRate{
+		Accounts: make(ag_solanago.AccountMetaSlice, 2),
+		Signers:  make(ag_solanago.AccountMetaSlice, 0),
+	}
+	return nd
+}
+
+// SetRate sets the "rate" parameter.
+// The new interest rate, in basis points.
+func (inst *UpdateInterestBearingMintRate) SetRate(rate int16) *UpdateInterestBearingMintRate {
+	inst.Rate = &rate
+	return inst
+}
+
+// SetMintAccount sets the "mint" account.
+// The mint.
+func (inst *UpdateInterestBearingMintRate) SetMintAccount(mint ag_solanago.PublicKey) *UpdateInterestBearingMintRate {
+	inst.Accounts[0] = ag_solanago.Meta(mint).WRITE()
+	return inst
+}
+
+// GetMintAccount gets the "mint" account.
+// The mint.
+func (inst *UpdateInterestBearingMintRate) GetMintAccount() *ag_solanago.AccountMeta {
+	return inst.Accounts[0]
+}
+
+// SetRateAuthorityAccount sets the "rateAuthority" account.
+// The mint rate authority or its multisignature account.
+func (inst *UpdateInterestBearingMintRate) SetRateAuthorityAccount(rateAuthority ag_solanago.PublicKey, multisigSigners ...ag_solanago.PublicKey) *UpdateInterestBearingMintRate {
+	inst.Accounts[1] = ag_solanago.Meta(rateAuthority)
+	if len(multisigSigners) == 0 {
+		inst.Accounts[1].SIGNER()
+	}
+	for _, signer := range multisigSigners {
+		inst.Signers = append(inst.Signers, ag_solanago.Meta(signer).SIGNER())
+	}
+	return inst
+}
+
+// GetRateAuthorityAccount gets the "rateAuthority" account.
+// The mint rate authority or its multisignature account.
+func (inst *UpdateInterestBearingMintRate) GetRateAuthorityAccount() *ag_solanago.AccountMeta {
+	return inst.Accounts[1]
+}
+
+func (inst UpdateInterestBearingMintRate) Build() *Instruction {
+	return &Instruction{BaseVariant: ag_binary.BaseVariant{
+		Impl: &InterestBearingMintExtension{extensionInstruction{ag_binary.BaseVariant{
+			Impl:   inst,
+			TypeID: ag_binary.TypeIDFromUint8(InterestBearingMint_UpdateRate),
+		}}},
+		TypeID: ag_binary.TypeIDFromUint8(Instruction_InterestBearingMintExtension),
+	}}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (inst UpdateInterestBearingMintRate) ValidateAndBuild() (*Instruction, error) {
+	if err := inst.Validate(); err != nil {
+		return nil, err
+	}
+	return inst.Build(), nil
+}
+
+func (inst *UpdateInterestBearingMintRate) Validate() error {
+	// Check whether all (required) parameters are set:
+	{
+		if inst.Rate == nil {
+			return errors.New("Rate parameter is not set")
+		}
+	}
+
+	// Check whether all (required) accounts are set:
+	{
+		if inst.Accounts[0] == nil {
+			return errors.New("accounts.Mint is not set")
+		}
+		if inst.Accounts[1] == nil {
+			return errors.New("accounts.RateAuthority is not set")
+		}
+		if !inst.Accounts[1].IsSigner && len(inst.Signers) == 0 {
+			return fmt.Errorf("accounts.Signers is not set")
+		}
+		if len(inst.Signers) > MAX_SIGNERS {
+			return fmt.Errorf("too many signers; got %v, but max is 11", len(inst.Signers))
+		}
+	}
+	return nil
+}
+
+func (inst *UpdateInterestBearingMintRate) EncodeToTree(parent ag_treeout.Branches) {
+	parent.Child(ag_format.Program(ProgramName, ProgramID)).
+		//
+		ParentFunc(func(programBranch ag_treeout.Branches) {
+			programBranch.Child(ag_format.Instruction("UpdateInterestBearingMintRate")).
+				//
+				ParentFunc(func(instructionBranch ag_treeout.Branches) {
+
+					// Parameters of the instruction:
+					instructionBranch.Child("Params").ParentFunc(func(paramsBranch ag_treeout.Branches) {
+						paramsBranch.Child(ag_format.Param("Rate", *inst.Rate))
+					})
+
+					// Accounts of the instruction:
+					instructionBranch.Child("Accounts").ParentFunc(func(accountsBranch ag_treeout.Branches) {
+						accountsBranch.Child(ag_format.Meta("         mint", inst.Accounts[0]))
+						accountsBranch.Child(ag_format.Meta("rateAuthority", inst.Accounts[1]))
+
+						signersBranch := accountsBranch.Child(fmt.Sprintf("signers[len=%v]", len(inst.Signers)))
+						for i, v := range inst.Signers {
+							if len(inst.Signers) > 9 && i < 10 {
+								signersBranch.Child(ag_format.Meta(fmt.Sprintf(" [%v]", i), v))
+							} else {
+								signersBranch.Child(ag_format.Meta(fmt.Sprintf("[%v]", i), v))
+							}
+						}
+					})
+				})
+		})
+}
+
+func (obj UpdateInterestBearingMintRate) MarshalWithEncoder(encoder *ag_binary.Encoder) (err error) {
+	// Serialize `Rate` param:
+	err = encoder.Encode(obj.Rate)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+func (obj *UpdateInterestBearingMintRate) UnmarshalWithDecoder(decoder *ag_binary.Decoder) (err error) {
+	// Deserialize `Rate`:
+	err = decoder.Decode(&obj.Rate)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// NewUpdateInterestBearingMintRateInstruction declares a new UpdateInterestBearingMintRate instruction with the provided parameters and accounts.
+func NewUpdateInterestBearingMintRateInstruction(
+	// Parameters:
+	rate int16,
+	// Accounts:
+	mint ag_solanago.PublicKey,
+	rateAuthority ag_solanago.PublicKey,
+	multisigSigners []ag_solanago.PublicKey) *UpdateInterestBearingMintRate {
+	return NewUpdateInterestBearingMintRateInstructionBuilder().
+		SetRate(rate).
+		SetMintAccount(mint).
+		SetRateAuthorityAccount(rateAuthority, multisigSigners...)
+}
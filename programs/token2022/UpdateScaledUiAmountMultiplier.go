@@ -0,0 +1,236 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package token2022
+
+import (
+	"errors"
+	"fmt"
+
+	ag_binary "github.com/gagliardetto/binary"
+	ag_solanago "github.com/gagliardetto/solana-go"
+	ag_format "github.com/gagliardetto/solana-go/text/format"
+	ag_treeout "github.com/gagliardetto/treeout"
+)
+
+// Update the multiplier. Only supported for mints that include the
+// `ScaledUiAmountConfig` extension.
+type UpdateScaledUiAmountMultiplier struct {
+	// The new multiplier.
+	Multiplier *float64
+
+	// Timestamp at which the new multiplier will take effect.
+	EffectiveTimestamp *int64
+
+	// [0] = [WRITE] mint
+	// ··········· The mint.
+	//
+	// [1] = [SIGNER] authority
+	// ··········· The multiplier authority or its multisignature account.
+	//
+	// [2...] = [SIGNER] signers
+	// ··········· M signer accounts.
+	Accounts ag_solanago.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+	Signers  ag_solanago.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+func (obj *UpdateScaledUiAmountMultiplier) SetAccounts(accounts []*ag_solanago.AccountMeta) error {
+	obj.Accounts, obj.Signers = ag_solanago.AccountMetaSlice(accounts).SplitFrom(2)
+	return nil
+}
+
+func (slice UpdateScaledUiAmountMultiplier) GetAccounts() (accounts []*ag_solanago.AccountMeta) {
+	accounts = append(accounts, slice.Accounts...)
+	accounts = append(accounts, slice.Signers...)
+	return
+}
+
+// NewUpdateScaledUiAmountMultiplierInstructionBuilder creates a new `UpdateScaledUiAmountMultiplier` instruction builder.
+func NewUpdateScaledUiAmountMultiplierInstructionBuilder() *UpdateScaledUiAmountMultiplier {
+	nd := &UpdateScaledUiAmountMultiplier{
+		Accounts: make(ag_solanago.AccountMetaSlice, 2),
+		Signers:  make(ag_solanago.AccountMetaSlice, 0),
+	}
+	return nd
+}
+
+// SetMultiplier sets the "multiplier" parameter.
+// The new multiplier.
+func (inst *UpdateScaledUiAmountMultiplier) SetMultiplier(multiplier float64) *UpdateScaledUiAmountMultiplier {
+	inst.Multiplier = &multiplier
+	return inst
+}
+
+// SetEffectiveTimestamp sets the "effective_timestamp" parameter.
+// Timestamp at which the new multiplier will take effect.
+func (inst *UpdateScaledUiAmountMultiplier) SetEffectiveTimestamp(effective_timestamp int64) *UpdateScaledUiAmountMultiplier {
+	inst.EffectiveTimestamp = &effective_timestamp
+	return inst
+}
+
+// SetMintAccount sets the "mint" account.
+// The mint.
+func (inst *UpdateScaledUiAmountMultiplier) SetMintAccount(mint ag_solanago.PublicKey) *UpdateScaledUiAmountMultiplier {
+	inst.Accounts[0] = ag_solanago.Meta(mint).WRITE()
+	return inst
+}
+
+// GetMintAccount gets the "mint" account.
+// The mint.
+func (inst *UpdateScaledUiAmountMultiplier) GetMintAccount() *ag_solanago.AccountMeta {
+	return inst.Accounts[0]
+}
+
+// SetAuthorityAccount sets the "authority" account.
+// The multiplier authority or its multisignature account.
+func (inst *UpdateScaledUiAmountMultiplier) SetAuthorityAccount(authority ag_solanago.PublicKey, multisigSigners ...ag_solanago.PublicKey) *UpdateScaledUiAmountMultiplier {
+	inst.Accounts[1] = ag_solanago.Meta(authority)
+	if len(multisigSigners) == 0 {
+		inst.Accounts[1].SIGNER()
+	}
+	for _, signer := range multisigSigners {
+		inst.Signers = append(inst.Signers, ag_solanago.Meta(signer).SIGNER())
+	}
+	return inst
+}
+
+// GetAuthorityAccount gets the "authority" account.
+// The multiplier authority or its multisignature account.
+func (inst *UpdateScaledUiAmountMultiplier) GetAuthorityAccount() *ag_solanago.AccountMeta {
+	return inst.Accounts[1]
+}
+
+func (inst UpdateScaledUiAmountMultiplier) Build() *Instruction {
+	return &Instruction{BaseVariant: ag_binary.BaseVariant{
+		Impl: &ScaledUiAmountExtension{extensionInstruction{ag_binary.BaseVariant{
+			Impl:   inst,
+			TypeID: ag_binary.TypeIDFromUint8(ScaledUiAmount_UpdateMultiplier),
+		}}},
+		TypeID: ag_binary.TypeIDFromUint8(Instruction_ScaledUiAmountExtension),
+	}}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (inst UpdateScaledUiAmountMultiplier) ValidateAndBuild() (*Instruction, error) {
+	if err := inst.Validate(); err != nil {
+		return nil, err
+	}
+	return inst.Build(), nil
+}
+
+func (inst *UpdateScaledUiAmountMultiplier) Validate() error {
+	// Check whether all (required) parameters are set:
+	{
+		if inst.Multiplier == nil {
+			return errors.New("Multiplier parameter is not set")
+		}
+		if inst.EffectiveTimestamp == nil {
+			return errors.New("EffectiveTimestamp parameter is not set")
+		}
+	}
+
+	// Check whether all (required) accounts are set:
+	{
+		if inst.Accounts[0] == nil {
+			return errors.New("accounts.Mint is not set")
+		}
+		if inst.Accounts[1] == nil {
+			return errors.New("accounts.Authority is not set")
+		}
+		if !inst.Accounts[1].IsSigner && len(inst.Signers) == 0 {
+			return fmt.Errorf("accounts.Signers is not set")
+		}
+		if len(inst.Signers) > MAX_SIGNERS {
+			return fmt.Errorf("too many signers; got %v, but max is 11", len(inst.Signers))
+		}
+	}
+	return nil
+}
+
+func (inst *UpdateScaledUiAmountMultiplier) EncodeToTree(parent ag_treeout.Branches) {
+	parent.Child(ag_format.Program(ProgramName, ProgramID)).
+		//
+		ParentFunc(func(programBranch ag_treeout.Branches) {
+			programBranch.Child(ag_format.Instruction("UpdateScaledUiAmountMultiplier")).
+				//
+				ParentFunc(func(instructionBranch ag_treeout.Branches) {
+
+					// Parameters of the instruction:
+					instructionBranch.Child("Params").ParentFunc(func(paramsBranch ag_treeout.Branches) {
+						paramsBranch.Child(ag_format.Param("        Multiplier", *inst.Multiplier))
+						paramsBranch.Child(ag_format.Param("EffectiveTimestamp", *inst.EffectiveTimestamp))
+					})
+
+					// Accounts of the instruction:
+					instructionBranch.Child("Accounts").ParentFunc(func(accountsBranch ag_treeout.Branches) {
+						accountsBranch.Child(ag_format.Meta("     mint", inst.Accounts[0]))
+						accountsBranch.Child(ag_format.Meta("authority", inst.Accounts[1]))
+
+						signersBranch := accountsBranch.Child(fmt.Sprintf("signers[len=%v]", len(inst.Signers)))
+						for i, v := range inst.Signers {
+							if len(inst.Signers) > 9 && i < 10 {
+								signersBranch.Child(ag_format.Meta(fmt.Sprintf(" [%v]", i), v))
+							} else {
+								signersBranch.Child(ag_format.Meta(fmt.Sprintf("[%v]", i), v))
+							}
+						}
+					})
+				})
+		})
+}
+
+func (obj UpdateScaledUiAmountMultiplier) MarshalWithEncoder(encoder *ag_binary.Encoder) (err error) {
+	// Serialize `Multiplier` param:
+	err = encoder.Encode(obj.Multiplier)
+	if err != nil {
+		return err
+	}
+	// Serialize `EffectiveTimestamp` param:
+	err = encoder.Encode(obj.EffectiveTimestamp)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+func (obj *UpdateScaledUiAmountMultiplier) UnmarshalWithDecoder(decoder *ag_binary.Decoder) (err error) {
+	// Deserialize `Multiplier`:
+	err = decoder.Decode(&obj.Multiplier)
+	if err != nil {
+		return err
+	}
+	// Deserialize `EffectiveTimestamp`:
+	err = decoder.Decode(&obj.EffectiveTimestamp)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// NewUpdateScaledUiAmountMultiplierInstruction declares a new UpdateScaledUiAmountMultiplier instruction with the provided parameters and accounts.
+func NewUpdateScaledUiAmountMultiplierInstruction(
+	// Parameters:
+	multiplier float64,
+	effective_timestamp int64,
+	// Accounts:
+	mint ag_solanago.PublicKey,
+	authority ag_solanago.PublicKey,
+	multisigSigners []ag_solanago.PublicKey) *UpdateScaledUiAmountMultiplier {
+	return NewUpdateScaledUiAmountMultiplierInstructionBuilder().
+		SetMultiplier(multiplier).
+		SetEffectiveTimestamp(effective_timestamp).
+		SetMintAccount(mint).
+		SetAuthorityAccount(authority, multisigSigners...)
+}
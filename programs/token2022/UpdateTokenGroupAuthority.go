@@ -0,0 +1,160 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package token2022
+
+import (
+	"errors"
+
+	ag_binary "github.com/gagliardetto/binary"
+	ag_solanago "github.com/gagliardetto/solana-go"
+	ag_format "github.com/gagliardetto/solana-go/text/format"
+	ag_treeout "github.com/gagliardetto/treeout"
+)
+
+// Update the authority of a `Group`.
+type UpdateTokenGroupAuthority struct {
+	// New authority for the group, or unset if it should be immutable.
+	NewAuthority *ag_solanago.PublicKey
+
+	// [0] = [WRITE] group
+	// ··········· The group account.
+	//
+	// [1] = [SIGNER] updateAuthority
+	// ··········· The current update authority.
+	ag_solanago.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+// NewUpdateTokenGroupAuthorityInstructionBuilder creates a new `UpdateTokenGroupAuthority` instruction builder.
+func NewUpdateTokenGroupAuthorityInstructionBuilder() *UpdateTokenGroupAuthority {
+	nd := &UpdateTokenGroupAuthority{
+		AccountMetaSlice: make(ag_solanago.AccountMetaSlice, 2),
+	}
+	return nd
+}
+
+// SetNewAuthority sets the "new_authority" parameter.
+// New authority for the group, or unset if it should be immutable.
+func (inst *UpdateTokenGroupAuthority) SetNewAuthority(new_authority ag_solanago.PublicKey) *UpdateTokenGroupAuthority {
+	inst.NewAuthority = &new_authority
+	return inst
+}
+
+// SetGroupAccount sets the "group" account.
+// The group account.
+func (inst *UpdateTokenGroupAuthority) SetGroupAccount(group ag_solanago.PublicKey) *UpdateTokenGroupAuthority {
+	inst.AccountMetaSlice[0] = ag_solanago.Meta(group).WRITE()
+	return inst
+}
+
+// GetGroupAccount gets the "group" account.
+// The group account.
+func (inst *UpdateTokenGroupAuthority) GetGroupAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[0]
+}
+
+// SetUpdateAuthorityAccount sets the "updateAuthority" account.
+// The current update authority.
+func (inst *UpdateTokenGroupAuthority) SetUpdateAuthorityAccount(updateAuthority ag_solanago.PublicKey) *UpdateTokenGroupAuthority {
+	inst.AccountMetaSlice[1] = ag_solanago.Meta(updateAuthority).SIGNER()
+	return inst
+}
+
+// GetUpdateAuthorityAccount gets the "updateAuthority" account.
+// The current update authority.
+func (inst *UpdateTokenGroupAuthority) GetUpdateAuthorityAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[1]
+}
+
+func (inst UpdateTokenGroupAuthority) Build() *Instruction {
+	return &Instruction{BaseVariant: ag_binary.BaseVariant{
+		Impl:   inst,
+		TypeID: TokenGroup_UpdateGroupAuthority,
+	}}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (inst UpdateTokenGroupAuthority) ValidateAndBuild() (*Instruction, error) {
+	if err := inst.Validate(); err != nil {
+		return nil, err
+	}
+	return inst.Build(), nil
+}
+
+func (inst *UpdateTokenGroupAuthority) Validate() error {
+	// Check whether all (required) accounts are set:
+	{
+		if inst.AccountMetaSlice[0] == nil {
+			return errors.New("accounts.Group is not set")
+		}
+		if inst.AccountMetaSlice[1] == nil {
+			return errors.New("accounts.UpdateAuthority is not set")
+		}
+	}
+	return nil
+}
+
+func (inst *UpdateTokenGroupAuthority) EncodeToTree(parent ag_treeout.Branches) {
+	parent.Child(ag_format.Program(ProgramName, ProgramID)).
+		//
+		ParentFunc(func(programBranch ag_treeout.Branches) {
+			programBranch.Child(ag_format.Instruction("UpdateTokenGroupAuthority")).
+				//
+				ParentFunc(func(instructionBranch ag_treeout.Branches) {
+
+					// Parameters of the instruction:
+					instructionBranch.Child("Params").ParentFunc(func(paramsBranch ag_treeout.Branches) {
+						paramsBranch.Child(ag_format.Param("NewAuthority (OPT)", inst.NewAuthority))
+					})
+
+					// Accounts of the instruction:
+					instructionBranch.Child("Accounts").ParentFunc(func(accountsBranch ag_treeout.Branches) {
+						accountsBranch.Child(ag_format.Meta("          group", inst.AccountMetaSlice[0]))
+						accountsBranch.Child(ag_format.Meta("updateAuthority", inst.AccountMetaSlice[1]))
+					})
+				})
+		})
+}
+
+func (obj UpdateTokenGroupAuthority) MarshalWithEncoder(encoder *ag_binary.Encoder) (err error) {
+	// Serialize `NewAuthority` param (optional, zeroed when unset):
+	err = writeOptionalNonZeroPubkey(encoder, obj.NewAuthority)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+func (obj *UpdateTokenGroupAuthority) UnmarshalWithDecoder(decoder *ag_binary.Decoder) (err error) {
+	// Deserialize `NewAuthority` (optional, zeroed when unset):
+	obj.NewAuthority, err = readOptionalNonZeroPubkey(decoder)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// NewUpdateTokenGroupAuthorityInstruction declares a new UpdateTokenGroupAuthority instruction with the provided parameters and accounts.
+func NewUpdateTokenGroupAuthorityInstruction(
+	// Parameters:
+	new_authority ag_solanago.PublicKey,
+	// Accounts:
+	group ag_solanago.PublicKey,
+	updateAuthority ag_solanago.PublicKey) *UpdateTokenGroupAuthority {
+	return NewUpdateTokenGroupAuthorityInstructionBuilder().
+		SetNewAuthority(new_authority).
+		SetGroupAccount(group).
+		SetUpdateAuthorityAccount(updateAuthority)
+}
@@ -0,0 +1,167 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package token2022
+
+import (
+	"errors"
+
+	ag_binary "github.com/gagliardetto/binary"
+	ag_solanago "github.com/gagliardetto/solana-go"
+	ag_format "github.com/gagliardetto/solana-go/text/format"
+	ag_treeout "github.com/gagliardetto/treeout"
+)
+
+// Update the max size of a `Group`.
+type UpdateTokenGroupMaxSize struct {
+	// New max size for the group.
+	MaxSize *uint64
+
+	// [0] = [WRITE] group
+	// ··········· The group account.
+	//
+	// [1] = [SIGNER] updateAuthority
+	// ··········· The update authority.
+	ag_solanago.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+// NewUpdateTokenGroupMaxSizeInstructionBuilder creates a new `UpdateTokenGroupMaxSize` instruction builder.
+func NewUpdateTokenGroupMaxSizeInstructionBuilder() *UpdateTokenGroupMaxSize {
+	nd := &UpdateTokenGroupMaxSize{
+		AccountMetaSlice: make(ag_solanago.AccountMetaSlice, 2),
+	}
+	return nd
+}
+
+// SetMaxSize sets the "max_size" parameter.
+// New max size for the group.
+func (inst *UpdateTokenGroupMaxSize) SetMaxSize(max_size uint64) *UpdateTokenGroupMaxSize {
+	inst.MaxSize = &max_size
+	return inst
+}
+
+// SetGroupAccount sets the "group" account.
+// The group account.
+func (inst *UpdateTokenGroupMaxSize) SetGroupAccount(group ag_solanago.PublicKey) *UpdateTokenGroupMaxSize {
+	inst.AccountMetaSlice[0] = ag_solanago.Meta(group).WRITE()
+	return inst
+}
+
+// GetGroupAccount gets the "group" account.
+// The group account.
+func (inst *UpdateTokenGroupMaxSize) GetGroupAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[0]
+}
+
+// SetUpdateAuthorityAccount sets the "updateAuthority" account.
+// The update authority.
+func (inst *UpdateTokenGroupMaxSize) SetUpdateAuthorityAccount(updateAuthority ag_solanago.PublicKey) *UpdateTokenGroupMaxSize {
+	inst.AccountMetaSlice[1] = ag_solanago.Meta(updateAuthority).SIGNER()
+	return inst
+}
+
+// GetUpdateAuthorityAccount gets the "updateAuthority" account.
+// The update authority.
+func (inst *UpdateTokenGroupMaxSize) GetUpdateAuthorityAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[1]
+}
+
+func (inst UpdateTokenGroupMaxSize) Build() *Instruction {
+	return &Instruction{BaseVariant: ag_binary.BaseVariant{
+		Impl:   inst,
+		TypeID: TokenGroup_UpdateGroupMaxSize,
+	}}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (inst UpdateTokenGroupMaxSize) ValidateAndBuild() (*Instruction, error) {
+	if err := inst.Validate(); err != nil {
+		return nil, err
+	}
+	return inst.Build(), nil
+}
+
+func (inst *UpdateTokenGroupMaxSize) Validate() error {
+	// Check whether all (required) parameters are set:
+	{
+		if inst.MaxSize == nil {
+			return errors.New("MaxSize parameter is not set")
+		}
+	}
+
+	// Check whether all (required) accounts are set:
+	{
+		if inst.AccountMetaSlice[0] == nil {
+			return errors.New("accounts.Group is not set")
+		}
+		if inst.AccountMetaSlice[1] == nil {
+			return errors.New("accounts.UpdateAuthority is not set")
+		}
+	}
+	return nil
+}
+
+func (inst *UpdateTokenGroupMaxSize) EncodeToTree(parent ag_treeout.Branches) {
+	parent.Child(ag_format.Program(ProgramName, ProgramID)).
+		//
+		ParentFunc(func(programBranch ag_treeout.Branches) {
+			programBranch.Child(ag_format.Instruction("UpdateTokenGroupMaxSize")).
+				//
+				ParentFunc(func(instructionBranch ag_treeout.Branches) {
+
+					// Parameters of the instruction:
+					instructionBranch.Child("Params").ParentFunc(func(paramsBranch ag_treeout.Branches) {
+						paramsBranch.Child(ag_format.Param("MaxSize", *inst.MaxSize))
+					})
+
+					// Accounts of the instruction:
+					instructionBranch.Child("Accounts").ParentFunc(func(accountsBranch ag_treeout.Branches) {
+						accountsBranch.Child(ag_format.Meta("          group", inst.AccountMetaSlice[0]))
+						accountsBranch.Child(ag_format.Meta("updateAuthority", inst.AccountMetaSlice[1]))
+					})
+				})
+		})
+}
+
+func (obj UpdateTokenGroupMaxSize) MarshalWithEncoder(encoder *ag_binary.Encoder) (err error) {
+	// Serialize `MaxSize` param:
+	err = encoder.Encode(obj.MaxSize)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+func (obj *UpdateTokenGroupMaxSize) UnmarshalWithDecoder(decoder *ag_binary.Decoder) (err error) {
+	// Deserialize `MaxSize`:
+	err = decoder.Decode(&obj.MaxSize)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// NewUpdateTokenGroupMaxSizeInstruction declares a new UpdateTokenGroupMaxSize instruction with the provided parameters and accounts.
+func NewUpdateTokenGroupMaxSizeInstruction(
+	// Parameters:
+	max_size uint64,
+	// Accounts:
+	group ag_solanago.PublicKey,
+	updateAuthority ag_solanago.PublicKey) *UpdateTokenGroupMaxSize {
+	return NewUpdateTokenGroupMaxSizeInstructionBuilder().
+		SetMaxSize(max_size).
+		SetGroupAccount(group).
+		SetUpdateAuthorityAccount(updateAuthority)
+}
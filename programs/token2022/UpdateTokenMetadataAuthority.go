@@ -0,0 +1,160 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package token2022
+
+import (
+	"errors"
+
+	ag_binary "github.com/gagliardetto/binary"
+	ag_solanago "github.com/gagliardetto/solana-go"
+	ag_format "github.com/gagliardetto/solana-go/text/format"
+	ag_treeout "github.com/gagliardetto/treeout"
+)
+
+// Updates the token-metadata authority.
+type UpdateTokenMetadataAuthority struct {
+	// New authority for the token metadata, or unset if it should be immutable.
+	NewAuthority *ag_solanago.PublicKey
+
+	// [0] = [WRITE] metadata
+	// ··········· The metadata account.
+	//
+	// [1] = [SIGNER] updateAuthority
+	// ··········· The current update authority.
+	ag_solanago.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+// NewUpdateTokenMetadataAuthorityInstructionBuilder creates a new `UpdateTokenMetadataAuthority` instruction builder.
+func NewUpdateTokenMetadataAuthorityInstructionBuilder() *UpdateTokenMetadataAuthority {
+	nd := &UpdateTokenMetadataAuthority{
+		AccountMetaSlice: make(ag_solanago.AccountMetaSlice, 2),
+	}
+	return nd
+}
+
+// SetNewAuthority sets the "new_authority" parameter.
+// New authority for the token metadata, or unset if it should be immutable.
+func (inst *UpdateTokenMetadataAuthority) SetNewAuthority(new_authority ag_solanago.PublicKey) *UpdateTokenMetadataAuthority {
+	inst.NewAuthority = &new_authority
+	return inst
+}
+
+// SetMetadataAccount sets the "metadata" account.
+// The metadata account.
+func (inst *UpdateTokenMetadataAuthority) SetMetadataAccount(metadata ag_solanago.PublicKey) *UpdateTokenMetadataAuthority {
+	inst.AccountMetaSlice[0] = ag_solanago.Meta(metadata).WRITE()
+	return inst
+}
+
+// GetMetadataAccount gets the "metadata" account.
+// The metadata account.
+func (inst *UpdateTokenMetadataAuthority) GetMetadataAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[0]
+}
+
+// SetUpdateAuthorityAccount sets the "updateAuthority" account.
+// The current update authority.
+func (inst *UpdateTokenMetadataAuthority) SetUpdateAuthorityAccount(updateAuthority ag_solanago.PublicKey) *UpdateTokenMetadataAuthority {
+	inst.AccountMetaSlice[1] = ag_solanago.Meta(updateAuthority).SIGNER()
+	return inst
+}
+
+// GetUpdateAuthorityAccount gets the "updateAuthority" account.
+// The current update authority.
+func (inst *UpdateTokenMetadataAuthority) GetUpdateAuthorityAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[1]
+}
+
+func (inst UpdateTokenMetadataAuthority) Build() *Instruction {
+	return &Instruction{BaseVariant: ag_binary.BaseVariant{
+		Impl:   inst,
+		TypeID: TokenMetadata_UpdateAuthority,
+	}}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (inst UpdateTokenMetadataAuthority) ValidateAndBuild() (*Instruction, error) {
+	if err := inst.Validate(); err != nil {
+		return nil, err
+	}
+	return inst.Build(), nil
+}
+
+func (inst *UpdateTokenMetadataAuthority) Validate() error {
+	// Check whether all (required) accounts are set:
+	{
+		if inst.AccountMetaSlice[0] == nil {
+			return errors.New("accounts.Metadata is not set")
+		}
+		if inst.AccountMetaSlice[1] == nil {
+			return errors.New("accounts.UpdateAuthority is not set")
+		}
+	}
+	return nil
+}
+
+func (inst *UpdateTokenMetadataAuthority) EncodeToTree(parent ag_treeout.Branches) {
+	parent.Child(ag_format.Program(ProgramName, ProgramID)).
+		//
+		ParentFunc(func(programBranch ag_treeout.Branches) {
+			programBranch.Child(ag_format.Instruction("UpdateTokenMetadataAuthority")).
+				//
+				ParentFunc(func(instructionBranch ag_treeout.Branches) {
+
+					// Parameters of the instruction:
+					instructionBranch.Child("Params").ParentFunc(func(paramsBranch ag_treeout.Branches) {
+						paramsBranch.Child(ag_format.Param("NewAuthority (OPT)", inst.NewAuthority))
+					})
+
+					// Accounts of the instruction:
+					instructionBranch.Child("Accounts").ParentFunc(func(accountsBranch ag_treeout.Branches) {
+						accountsBranch.Child(ag_format.Meta("       metadata", inst.AccountMetaSlice[0]))
+						accountsBranch.Child(ag_format.Meta("updateAuthority", inst.AccountMetaSlice[1]))
+					})
+				})
+		})
+}
+
+func (obj UpdateTokenMetadataAuthority) MarshalWithEncoder(encoder *ag_binary.Encoder) (err error) {
+	// Serialize `NewAuthority` param (optional, zeroed when unset):
+	err = writeOptionalNonZeroPubkey(encoder, obj.NewAuthority)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+func (obj *UpdateTokenMetadataAuthority) UnmarshalWithDecoder(decoder *ag_binary.Decoder) (err error) {
+	// Deserialize `NewAuthority` (optional, zeroed when unset):
+	obj.NewAuthority, err = readOptionalNonZeroPubkey(decoder)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// NewUpdateTokenMetadataAuthorityInstruction declares a new UpdateTokenMetadataAuthority instruction with the provided parameters and accounts.
+func NewUpdateTokenMetadataAuthorityInstruction(
+	// Parameters:
+	new_authority ag_solanago.PublicKey,
+	// Accounts:
+	metadata ag_solanago.PublicKey,
+	updateAuthority ag_solanago.PublicKey) *UpdateTokenMetadataAuthority {
+	return NewUpdateTokenMetadataAuthorityInstructionBuilder().
+		SetNewAuthority(new_authority).
+		SetMetadataAccount(metadata).
+		SetUpdateAuthorityAccount(updateAuthority)
+}
@@ -0,0 +1,199 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package token2022
+
+import (
+	"errors"
+
+	ag_binary "github.com/gagliardetto/binary"
+	ag_solanago "github.com/gagliardetto/solana-go"
+	ag_format "github.com/gagliardetto/solana-go/text/format"
+	ag_treeout "github.com/gagliardetto/treeout"
+)
+
+// Updates a field in a token-metadata account.
+//
+// The field can be one of the required fields (name, symbol, URI), or a
+// totally new field denoted by a "key" string.
+type UpdateTokenMetadataField struct {
+	// Field to update in the metadata.
+	Field *TokenMetadataField
+
+	// Value to write for the field.
+	Value *string
+
+	// [0] = [WRITE] metadata
+	// ··········· The metadata account.
+	//
+	// [1] = [SIGNER] updateAuthority
+	// ··········· The update authority.
+	ag_solanago.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+// NewUpdateTokenMetadataFieldInstructionBuilder creates a new `UpdateTokenMetadataField` instruction builder.
+func NewUpdateTokenMetadataFieldInstructionBuilder() *UpdateTokenMetadataField {
+	nd := &UpdateTokenMetadataField{
+		AccountMetaSlice: make(ag_solanago.AccountMetaSlice, 2),
+	}
+	return nd
+}
+
+// SetField sets the "field" parameter.
+// Field to update in the metadata.
+func (inst *UpdateTokenMetadataField) SetField(field TokenMetadataField) *UpdateTokenMetadataField {
+	inst.Field = &field
+	return inst
+}
+
+// SetValue sets the "value" parameter.
+// Value to write for the field.
+func (inst *UpdateTokenMetadataField) SetValue(value string) *UpdateTokenMetadataField {
+	inst.Value = &value
+	return inst
+}
+
+// SetMetadataAccount sets the "metadata" account.
+// The metadata account.
+func (inst *UpdateTokenMetadataField) SetMetadataAccount(metadata ag_solanago.PublicKey) *UpdateTokenMetadataField {
+	inst.AccountMetaSlice[0] = ag_solanago.Meta(metadata).WRITE()
+	return inst
+}
+
+// GetMetadataAccount gets the "metadata" account.
+// The metadata account.
+func (inst *UpdateTokenMetadataField) GetMetadataAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[0]
+}
+
+// SetUpdateAuthorityAccount sets the "updateAuthority" account.
+// The update authority.
+func (inst *UpdateTokenMetadataField) SetUpdateAuthorityAccount(updateAuthority ag_solanago.PublicKey) *UpdateTokenMetadataField {
+	inst.AccountMetaSlice[1] = ag_solanago.Meta(updateAuthority).SIGNER()
+	return inst
+}
+
+// GetUpdateAuthorityAccount gets the "updateAuthority" account.
+// The update authority.
+func (inst *UpdateTokenMetadataField) GetUpdateAuthorityAccount() *ag_solanago.AccountMeta {
+	return inst.AccountMetaSlice[1]
+}
+
+func (inst UpdateTokenMetadataField) Build() *Instruction {
+	return &Instruction{BaseVariant: ag_binary.BaseVariant{
+		Impl:   inst,
+		TypeID: TokenMetadata_UpdateField,
+	}}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (inst UpdateTokenMetadataField) ValidateAndBuild() (*Instruction, error) {
+	if err := inst.Validate(); err != nil {
+		return nil, err
+	}
+	return inst.Build(), nil
+}
+
+func (inst *UpdateTokenMetadataField) Validate() error {
+	// Check whether all (required) parameters are set:
+	{
+		if inst.Field == nil {
+			return errors.New("Field parameter is not set")
+		}
+		if inst.Value == nil {
+			return errors.New("Value parameter is not set")
+		}
+	}
+
+	// Check whether all (required) accounts are set:
+	{
+		if inst.AccountMetaSlice[0] == nil {
+			return errors.New("accounts.Metadata is not set")
+		}
+		if inst.AccountMetaSlice[1] == nil {
+			return errors.New("accounts.UpdateAuthority is not set")
+		}
+	}
+	return nil
+}
+
+func (inst *UpdateTokenMetadataField) EncodeToTree(parent ag_treeout.Branches) {
+	parent.Child(ag_format.Program(ProgramName, ProgramID)).
+		//
+		ParentFunc(func(programBranch ag_treeout.Branches) {
+			programBranch.Child(ag_format.Instruction("UpdateTokenMetadataField")).
+				//
+				ParentFunc(func(instructionBranch ag_treeout.Branches) {
+
+					// Parameters of the instruction:
+					instructionBranch.Child("Params").ParentFunc(func(paramsBranch ag_treeout.Branches) {
+						paramsBranch.Child(ag_format.Param("Field", *inst.Field))
+						paramsBranch.Child(ag_format.Param("Value", *inst.Value))
+					})
+
+					// Accounts of the instruction:
+					instructionBranch.Child("Accounts").ParentFunc(func(accountsBranch ag_treeout.Branches) {
+						accountsBranch.Child(ag_format.Meta("       metadata", inst.AccountMetaSlice[0]))
+						accountsBranch.Child(ag_format.Meta("updateAuthority", inst.AccountMetaSlice[1]))
+					})
+				})
+		})
+}
+
+func (obj UpdateTokenMetadataField) MarshalWithEncoder(encoder *ag_binary.Encoder) (err error) {
+	// Serialize `Field` param:
+	err = encoder.Encode(obj.Field)
+	if err != nil {
+		return err
+	}
+	// Serialize `Value` param:
+	err = writeBorshString(encoder, *obj.Value)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+func (obj *UpdateTokenMetadataField) UnmarshalWithDecoder(decoder *ag_binary.Decoder) (err error) {
+	// Deserialize `Field`:
+	err = decoder.Decode(&obj.Field)
+	if err != nil {
+		return err
+	}
+	// Deserialize `Value`:
+	{
+		v, err := readBorshString(decoder)
+		if err != nil {
+			return err
+		}
+		obj.Value = &v
+	}
+	return nil
+}
+
+// NewUpdateTokenMetadataFieldInstruction declares a new UpdateTokenMetadataField instruction with the provided parameters and accounts.
+func NewUpdateTokenMetadataFieldInstruction(
+	// Parameters:
+	field TokenMetadataField,
+	value string,
+	// Accounts:
+	metadata ag_solanago.PublicKey,
+	updateAuthority ag_solanago.PublicKey) *UpdateTokenMetadataField {
+	return NewUpdateTokenMetadataFieldInstructionBuilder().
+		SetField(field).
+		SetValue(value).
+		SetMetadataAccount(metadata).
+		SetUpdateAuthorityAccount(updateAuthority)
+}
@@ -38,6 +38,14 @@ type Mint struct {
 
 	// Optional authority to freeze token accounts.
 	FreezeAuthority *solana.PublicKey `bin:"optional"`
+
+	// Extensions of the mint, in the order they appear in the account data.
+	Extensions Extensions
+}
+
+// GetExtension returns the extension of the provided type, or nil if the mint doesn't have it.
+func (mint *Mint) GetExtension(typ ExtensionType) Extension {
+	return mint.Extensions.Get(typ)
 }
 
 func (mint *Mint) UnmarshalWithDecoder(dec *bin.Decoder) (err error) {
@@ -100,6 +108,16 @@ func (mint *Mint) UnmarshalWithDecoder(dec *bin.Decoder) (err error) {
 			}
 		}
 	}
+	if dec.Remaining() > ACCOUNT_SIZE-MINT_SIZE {
+		// Mints with extensions are padded to the size of an account,
+		// followed by the account type and the extensions.
+		if err = dec.Discard(ACCOUNT_SIZE - MINT_SIZE); err != nil {
+			return err
+		}
+		if mint.Extensions, err = decodeExtensions(dec, AccountTypeMint); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -160,6 +178,16 @@ func (mint Mint) MarshalWithEncoder(encoder *bin.Encoder) (err error) {
 			}
 		}
 	}
+	if len(mint.Extensions) > 0 {
+		err = encoder.WriteBytes(make([]byte, ACCOUNT_SIZE-MINT_SIZE), false)
+		if err != nil {
+			return err
+		}
+		err = encodeExtensions(encoder, AccountTypeMint, mint.Extensions)
+		if err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -190,6 +218,14 @@ type Account struct {
 
 	// Optional authority to close the account.
 	CloseAuthority *solana.PublicKey `bin:"optional"`
+
+	// Extensions of the account, in the order they appear in the account data.
+	Extensions Extensions
+}
+
+// GetExtension returns the extension of the provided type, or nil if the account doesn't have it.
+func (mint *Account) GetExtension(typ ExtensionType) Extension {
+	return mint.Extensions.Get(typ)
 }
 
 func (mint *Account) UnmarshalWithDecoder(dec *bin.Decoder) (err error) {
@@ -285,6 +321,11 @@ func (mint *Account) UnmarshalWithDecoder(dec *bin.Decoder) (err error) {
 			}
 		}
 	}
+	if dec.Remaining() > 0 {
+		if mint.Extensions, err = decodeExtensions(dec, AccountTypeAccount); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -382,6 +423,12 @@ func (mint Account) MarshalWithEncoder(encoder *bin.Encoder) (err error) {
 			}
 		}
 	}
+	if len(mint.Extensions) > 0 {
+		err = encodeExtensions(encoder, AccountTypeAccount, mint.Extensions)
+		if err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -0,0 +1,244 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package token2022
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInitializeTransferFeeConfig(t *testing.T) {
+	mint := solana.NewWallet().PublicKey()
+	configAuthority := solana.NewWallet().PublicKey()
+
+	inst, err := NewInitializeTransferFeeConfigInstructionBuilder().
+		SetTransferFeeConfigAuthority(configAuthority).
+		SetTransferFeeBasisPoints(50).
+		SetMaximumFee(5_000).
+		SetMintAccount(mint).
+		ValidateAndBuild()
+	require.NoError(t, err)
+
+	data, err := inst.Data()
+	require.NoError(t, err)
+	expected := []byte{Instruction_TransferFeeExtension, TransferFee_InitializeTransferFeeConfig, 1}
+	expected = append(expected, configAuthority[:]...)
+	expected = append(expected, 0)
+	expected = binary.LittleEndian.AppendUint16(expected, 50)
+	expected = binary.LittleEndian.AppendUint64(expected, 5_000)
+	require.Equal(t, expected, data)
+
+	decoded, err := DecodeInstruction(inst.Accounts(), data)
+	require.NoError(t, err)
+	family, ok := decoded.Impl.(*TransferFeeExtension)
+	require.True(t, ok)
+	got, ok := family.Impl.(*InitializeTransferFeeConfig)
+	require.True(t, ok)
+	require.Equal(t, configAuthority, *got.TransferFeeConfigAuthority)
+	require.Nil(t, got.WithdrawWithheldAuthority)
+	require.Equal(t, uint16(50), *got.TransferFeeBasisPoints)
+	require.Equal(t, uint64(5_000), *got.MaximumFee)
+	require.Equal(t, mint, got.GetMintAccount().PublicKey)
+}
+
+func TestTransferCheckedWithFee_Multisig(t *testing.T) {
+	source := solana.NewWallet().PublicKey()
+	mint := solana.NewWallet().PublicKey()
+	destination := solana.NewWallet().PublicKey()
+	multisig := solana.NewWallet().PublicKey()
+	signers := []solana.PublicKey{
+		solana.NewWallet().PublicKey(),
+		solana.NewWallet().PublicKey(),
+	}
+
+	inst, err := NewTransferCheckedWithFeeInstruction(1_000, 6, 5, source, mint, destination, multisig, signers).ValidateAndBuild()
+	require.NoError(t, err)
+
+	accounts := inst.Accounts()
+	require.Len(t, accounts, 6)
+	require.False(t, accounts[3].IsSigner)
+	require.True(t, accounts[4].IsSigner)
+
+	data, err := inst.Data()
+	require.NoError(t, err)
+	require.Equal(t, []byte{Instruction_TransferFeeExtension, TransferFee_TransferCheckedWithFee}, data[:2])
+	require.Len(t, data, 2+8+1+8)
+
+	decoded, err := DecodeInstruction(accounts, data)
+	require.NoError(t, err)
+	got := decoded.Impl.(*TransferFeeExtension).Impl.(*TransferCheckedWithFee)
+	require.Equal(t, uint64(1_000), *got.Amount)
+	require.Equal(t, uint8(6), *got.Decimals)
+	require.Equal(t, uint64(5), *got.Fee)
+	require.Equal(t, multisig, got.GetOwnerAccount().PublicKey)
+	require.Len(t, got.Signers, 2)
+}
+
+func TestWithdrawWithheldTokensFromAccounts(t *testing.T) {
+	mint := solana.NewWallet().PublicKey()
+	destination := solana.NewWallet().PublicKey()
+	multisig := solana.NewWallet().PublicKey()
+	signer := solana.NewWallet().PublicKey()
+	sources := []solana.PublicKey{
+		solana.NewWallet().PublicKey(),
+		solana.NewWallet().PublicKey(),
+		solana.NewWallet().PublicKey(),
+	}
+
+	inst, err := NewWithdrawWithheldTokensFromAccountsInstruction(mint, destination, multisig, []solana.PublicKey{signer}, sources).ValidateAndBuild()
+	require.NoError(t, err)
+
+	data, err := inst.Data()
+	require.NoError(t, err)
+	require.Equal(t, []byte{Instruction_TransferFeeExtension, TransferFee_WithdrawWithheldTokensFromAccounts, 3}, data)
+
+	accounts := inst.Accounts()
+	require.Len(t, accounts, 3+1+3)
+
+	decoded, err := DecodeInstruction(accounts, data)
+	require.NoError(t, err)
+	got := decoded.Impl.(*TransferFeeExtension).Impl.(*WithdrawWithheldTokensFromAccounts)
+	require.Len(t, got.Signers, 1)
+	require.Equal(t, signer, got.Signers[0].PublicKey)
+	require.Len(t, got.Sources, 3)
+	for i, source := range sources {
+		require.Equal(t, source, got.Sources[i].PublicKey)
+		require.True(t, got.Sources[i].IsWritable)
+	}
+
+	_, err = DecodeInstruction(accounts[:4], data)
+	require.Error(t, err)
+}
+
+func TestHarvestWithheldTokensToMint(t *testing.T) {
+	mint := solana.NewWallet().PublicKey()
+	sources := []solana.PublicKey{
+		solana.NewWallet().PublicKey(),
+		solana.NewWallet().PublicKey(),
+	}
+
+	inst, err := NewHarvestWithheldTokensToMintInstruction(mint, sources).ValidateAndBuild()
+	require.NoError(t, err)
+
+	data, err := inst.Data()
+	require.NoError(t, err)
+	require.Equal(t, []byte{Instruction_TransferFeeExtension, TransferFee_HarvestWithheldTokensToMint}, data)
+
+	decoded, err := DecodeInstruction(inst.Accounts(), data)
+	require.NoError(t, err)
+	got := decoded.Impl.(*TransferFeeExtension).Impl.(*HarvestWithheldTokensToMint)
+	require.Equal(t, mint, got.GetMintAccount().PublicKey)
+	require.Len(t, got.GetSourceAccounts(), 2)
+}
+
+func TestExtensionInstructionData(t *testing.T) {
+	mint := solana.NewWallet().PublicKey()
+	account := solana.NewWallet().PublicKey()
+	owner := solana.NewWallet().PublicKey()
+	hookProgram := solana.NewWallet().PublicKey()
+	zero := make([]byte, 32)
+
+	cases := []struct {
+		name     string
+		inst     *Instruction
+		expected []byte
+	}{
+		{
+			name:     "InitializeDefaultAccountState",
+			inst:     NewInitializeDefaultAccountStateInstruction(Frozen, mint).Build(),
+			expected: []byte{Instruction_DefaultAccountStateExtension, DefaultAccountState_Initialize, byte(Frozen)},
+		},
+		{
+			name:     "EnableRequiredMemoTransfers",
+			inst:     NewEnableRequiredMemoTransfersInstruction(account, owner, nil).Build(),
+			expected: []byte{Instruction_MemoTransferExtension, MemoTransfer_Enable},
+		},
+		{
+			name:     "DisableCpiGuard",
+			inst:     NewDisableCpiGuardInstruction(account, owner, nil).Build(),
+			expected: []byte{Instruction_CpiGuardExtension, CpiGuard_Disable},
+		},
+		{
+			name:     "UpdateInterestBearingMintRate",
+			inst:     NewUpdateInterestBearingMintRateInstruction(-25, mint, owner, nil).Build(),
+			expected: []byte{Instruction_InterestBearingMintExtension, InterestBearingMint_UpdateRate, 0xe7, 0xff},
+		},
+		{
+			name:     "InitializePermanentDelegate",
+			inst:     NewInitializePermanentDelegateInstruction(owner, mint).Build(),
+			expected: append([]byte{Instruction_InitializePermanentDelegate}, owner[:]...),
+		},
+		{
+			name:     "InitializeTransferHook",
+			inst:     NewInitializeTransferHookInstructionBuilder().SetHookProgramID(hookProgram).SetMintAccount(mint).Build(),
+			expected: append(append([]byte{Instruction_TransferHookExtension, TransferHook_Initialize}, zero...), hookProgram[:]...),
+		},
+		{
+			name:     "UpdateMetadataPointer",
+			inst:     NewUpdateMetadataPointerInstruction(mint, mint, owner, nil).Build(),
+			expected: append([]byte{Instruction_MetadataPointerExtension, MetadataPointer_Update}, mint[:]...),
+		},
+		{
+			name:     "InitializeGroupPointer",
+			inst:     NewInitializeGroupPointerInstruction(owner, mint, mint).Build(),
+			expected: append(append([]byte{Instruction_GroupPointerExtension, GroupPointer_Initialize}, owner[:]...), mint[:]...),
+		},
+		{
+			name:     "Reallocate",
+			inst:     NewReallocateInstruction([]ExtensionType{ExtensionMemoTransfer, ExtensionCpiGuard}, account, owner, solana.SystemProgramID, owner, nil).Build(),
+			expected: []byte{Instruction_Reallocate, byte(ExtensionMemoTransfer), 0, byte(ExtensionCpiGuard), 0},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			data, err := c.inst.Data()
+			require.NoError(t, err)
+			require.Equal(t, c.expected, data)
+
+			decoded, err := DecodeInstruction(c.inst.Accounts(), data)
+			require.NoError(t, err)
+			redata, err := decoded.Data()
+			require.NoError(t, err)
+			require.Equal(t, data, redata)
+			require.Equal(t, c.inst.Accounts(), decoded.Accounts())
+		})
+	}
+}
+
+func TestDecodeExtensionInstruction(t *testing.T) {
+	mint := solana.NewWallet().PublicKey()
+	authority := solana.NewWallet().PublicKey()
+	payer := solana.NewWallet().PublicKey()
+
+	tx, err := solana.NewTransaction(
+		[]solana.Instruction{
+			NewSetTransferFeeInstruction(25, 1_000, mint, authority, nil).Build(),
+		},
+		solana.Hash{},
+		solana.TransactionPayer(payer),
+	)
+	require.NoError(t, err)
+
+	got, err := DecodeSetTransferFee(&tx.Message, 0)
+	require.NoError(t, err)
+	require.Equal(t, uint16(25), *got.TransferFeeBasisPoints)
+	require.Equal(t, uint64(1_000), *got.MaximumFee)
+
+	_, err = DecodeTransferCheckedWithFee(&tx.Message, 0)
+	require.Error(t, err)
+}
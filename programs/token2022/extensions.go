@@ -0,0 +1,1040 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package token2022
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"math/bits"
+
+	bin "github.com/gagliardetto/binary"
+	"github.com/gagliardetto/solana-go"
+)
+
+const (
+	// Size of the base Account layout; mints with extensions
+	// are padded to this size as well.
+	ACCOUNT_SIZE = 165
+	// Transfer fees are expressed in basis points of the transfer amount.
+	ONE_IN_BASIS_POINTS = 10_000
+)
+
+// AccountType is the byte written after the base layout of
+// extended accounts, to tell mints and token accounts apart.
+type AccountType uint8
+
+const (
+	AccountTypeUninitialized AccountType = iota
+	AccountTypeMint
+	AccountTypeAccount
+)
+
+// ExtensionType identifies a Token-2022 extension in the TLV data of a mint or account.
+type ExtensionType uint16
+
+const (
+	// Used as padding if the account size would otherwise be 355, same as a multisig.
+	ExtensionUninitialized ExtensionType = iota
+	// Includes transfer fee rate info and accompanying authorities to withdraw and set the fee.
+	ExtensionTransferFeeConfig
+	// Includes withheld transfer fees.
+	ExtensionTransferFeeAmount
+	// Includes an optional mint close authority.
+	ExtensionMintCloseAuthority
+	// Auditor configuration for confidential transfers.
+	ExtensionConfidentialTransferMint
+	// State for confidential transfers.
+	ExtensionConfidentialTransferAccount
+	// Specifies the default Account::state for new Accounts.
+	ExtensionDefaultAccountState
+	// Indicates that the Account owner authority cannot be changed.
+	ExtensionImmutableOwner
+	// Require inbound transfers to have memo.
+	ExtensionMemoTransfer
+	// Indicates that the tokens from this mint can't be transferred.
+	ExtensionNonTransferable
+	// Tokens accrue interest over time.
+	ExtensionInterestBearingConfig
+	// Locks privileged token operations from happening via CPI.
+	ExtensionCpiGuard
+	// Includes an optional permanent delegate.
+	ExtensionPermanentDelegate
+	// Indicates that the tokens in this account belong to a non-transferable mint.
+	ExtensionNonTransferableAccount
+	// Mint requires a CPI to a program implementing the "transfer hook" interface.
+	ExtensionTransferHook
+	// Indicates that the tokens in this account belong to a mint with a transfer hook.
+	ExtensionTransferHookAccount
+	// Includes encrypted withheld fees and the encryption public key they are encrypted under.
+	ExtensionConfidentialTransferFeeConfig
+	// Includes confidential withheld transfer fees.
+	ExtensionConfidentialTransferFeeAmount
+	// Mint contains a pointer to another account (or the same account) that holds metadata.
+	ExtensionMetadataPointer
+	// Mint contains token-metadata.
+	ExtensionTokenMetadata
+	// Mint contains a pointer to another account (or the same account) that holds group configurations.
+	ExtensionGroupPointer
+	// Mint contains token group configurations.
+	ExtensionTokenGroup
+	// Mint contains a pointer to another account (or the same account) that holds group member configurations.
+	ExtensionGroupMemberPointer
+	// Mint contains token group member configurations.
+	ExtensionTokenGroupMember
+	// Mint allowing the minting and burning of confidential tokens.
+	ExtensionConfidentialMintBurn
+	// Tokens whose UI amount is scaled by a given amount.
+	ExtensionScaledUiAmount
+	// Tokens where minting / burning / transferring can be paused.
+	ExtensionPausable
+	// Indicates that the account belongs to a pausable mint.
+	ExtensionPausableAccount
+)
+
+func (t ExtensionType) String() string {
+	switch t {
+	case ExtensionUninitialized:
+		return "Uninitialized"
+	case ExtensionTransferFeeConfig:
+		return "TransferFeeConfig"
+	case ExtensionTransferFeeAmount:
+		return "TransferFeeAmount"
+	case ExtensionMintCloseAuthority:
+		return "MintCloseAuthority"
+	case ExtensionConfidentialTransferMint:
+		return "ConfidentialTransferMint"
+	case ExtensionConfidentialTransferAccount:
+		return "ConfidentialTransferAccount"
+	case ExtensionDefaultAccountState:
+		return "DefaultAccountState"
+	case ExtensionImmutableOwner:
+		return "ImmutableOwner"
+	case ExtensionMemoTransfer:
+		return "MemoTransfer"
+	case ExtensionNonTransferable:
+		return "NonTransferable"
+	case ExtensionInterestBearingConfig:
+		return "InterestBearingConfig"
+	case ExtensionCpiGuard:
+		return "CpiGuard"
+	case ExtensionPermanentDelegate:
+		return "PermanentDelegate"
+	case ExtensionNonTransferableAccount:
+		return "NonTransferableAccount"
+	case ExtensionTransferHook:
+		return "TransferHook"
+	case ExtensionTransferHookAccount:
+		return "TransferHookAccount"
+	case ExtensionConfidentialTransferFeeConfig:
+		return "ConfidentialTransferFeeConfig"
+	case ExtensionConfidentialTransferFeeAmount:
+		return "ConfidentialTransferFeeAmount"
+	case ExtensionMetadataPointer:
+		return "MetadataPointer"
+	case ExtensionTokenMetadata:
+		return "TokenMetadata"
+	case ExtensionGroupPointer:
+		return "GroupPointer"
+	case ExtensionTokenGroup:
+		return "TokenGroup"
+	case ExtensionGroupMemberPointer:
+		return "GroupMemberPointer"
+	case ExtensionTokenGroupMember:
+		return "TokenGroupMember"
+	case ExtensionConfidentialMintBurn:
+		return "ConfidentialMintBurn"
+	case ExtensionScaledUiAmount:
+		return "ScaledUiAmount"
+	case ExtensionPausable:
+		return "Pausable"
+	case ExtensionPausableAccount:
+		return "PausableAccount"
+	default:
+		return fmt.Sprintf("Unknown(%d)", uint16(t))
+	}
+}
+
+// Extension is implemented by all the decoded extension types.
+type Extension interface {
+	bin.EncoderDecoder
+	ExtensionType() ExtensionType
+}
+
+// newExtension returns an empty extension of the provided type;
+// extensions without a typed layout (e.g. the confidential ones)
+// are decoded as a RawExtension.
+func newExtension(typ ExtensionType) Extension {
+	switch typ {
+	case ExtensionTransferFeeConfig:
+		return new(TransferFeeConfig)
+	case ExtensionTransferFeeAmount:
+		return new(TransferFeeAmount)
+	case ExtensionMintCloseAuthority:
+		return new(MintCloseAuthority)
+	case ExtensionDefaultAccountState:
+		return new(DefaultAccountState)
+	case ExtensionImmutableOwner:
+		return new(ImmutableOwner)
+	case ExtensionMemoTransfer:
+		return new(MemoTransfer)
+	case ExtensionNonTransferable:
+		return new(NonTransferable)
+	case ExtensionInterestBearingConfig:
+		return new(InterestBearingConfig)
+	case ExtensionCpiGuard:
+		return new(CpiGuard)
+	case ExtensionPermanentDelegate:
+		return new(PermanentDelegate)
+	case ExtensionNonTransferableAccount:
+		return new(NonTransferableAccount)
+	case ExtensionTransferHook:
+		return new(TransferHook)
+	case ExtensionTransferHookAccount:
+		return new(TransferHookAccount)
+	case ExtensionMetadataPointer:
+		return new(MetadataPointer)
+	case ExtensionTokenMetadata:
+		return new(TokenMetadata)
+	case ExtensionGroupPointer:
+		return new(GroupPointer)
+	case ExtensionTokenGroup:
+		return new(TokenGroup)
+	case ExtensionGroupMemberPointer:
+		return new(GroupMemberPointer)
+	case ExtensionTokenGroupMember:
+		return new(TokenGroupMember)
+	case ExtensionScaledUiAmount:
+		return new(ScaledUiAmountConfig)
+	case ExtensionPausable:
+		return new(PausableConfig)
+	case ExtensionPausableAccount:
+		return new(PausableAccount)
+	default:
+		return &RawExtension{Type: typ}
+	}
+}
+
+// Extensions is the list of extensions of a mint or account,
+// in the order they appear in the account data.
+type Extensions []Extension
+
+// Get returns the extension of the provided type, or nil if not present.
+func (exts Extensions) Get(typ ExtensionType) Extension {
+	for _, ext := range exts {
+		if ext.ExtensionType() == typ {
+			return ext
+		}
+	}
+	return nil
+}
+
+// Types returns the types of the extensions.
+func (exts Extensions) Types() []ExtensionType {
+	out := make([]ExtensionType, len(exts))
+	for i, ext := range exts {
+		out[i] = ext.ExtensionType()
+	}
+	return out
+}
+
+// decodeExtensions reads the account type byte and the TLV entries
+// that follow the base layout; the decoder must be positioned at
+// offset ACCOUNT_SIZE of the account data.
+func decodeExtensions(dec *bin.Decoder, expected AccountType) (Extensions, error) {
+	accountType, err := dec.ReadUint8()
+	if err != nil {
+		return nil, err
+	}
+	if AccountType(accountType) != expected {
+		return nil, fmt.Errorf("invalid account type: expected %d, got %d", expected, accountType)
+	}
+	var exts Extensions
+	for dec.Remaining() >= 4 {
+		typ, err := dec.ReadUint16(binary.LittleEndian)
+		if err != nil {
+			return nil, err
+		}
+		length, err := dec.ReadUint16(binary.LittleEndian)
+		if err != nil {
+			return nil, err
+		}
+		if ExtensionType(typ) == ExtensionUninitialized {
+			// The rest of the account is unused space.
+			break
+		}
+		data, err := dec.ReadNBytes(int(length))
+		if err != nil {
+			return nil, fmt.Errorf("unable to read %s extension: %w", ExtensionType(typ), err)
+		}
+		ext := newExtension(ExtensionType(typ))
+		if err := ext.UnmarshalWithDecoder(bin.NewBinDecoder(data)); err != nil {
+			return nil, fmt.Errorf("unable to decode %s extension: %w", ExtensionType(typ), err)
+		}
+		exts = append(exts, ext)
+	}
+	return exts, nil
+}
+
+// encodeExtensions writes the account type byte and the TLV entries.
+func encodeExtensions(encoder *bin.Encoder, accountType AccountType, exts Extensions) error {
+	if err := encoder.WriteUint8(uint8(accountType)); err != nil {
+		return err
+	}
+	for _, ext := range exts {
+		buf := new(bytes.Buffer)
+		if err := ext.MarshalWithEncoder(bin.NewBinEncoder(buf)); err != nil {
+			return fmt.Errorf("unable to encode %s extension: %w", ext.ExtensionType(), err)
+		}
+		if buf.Len() > math.MaxUint16 {
+			return fmt.Errorf("%s extension is too large: %d bytes", ext.ExtensionType(), buf.Len())
+		}
+		if err := encoder.WriteUint16(uint16(ext.ExtensionType()), binary.LittleEndian); err != nil {
+			return err
+		}
+		if err := encoder.WriteUint16(uint16(buf.Len()), binary.LittleEndian); err != nil {
+			return err
+		}
+		if err := encoder.WriteBytes(buf.Bytes(), false); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RawExtension holds the undecoded data of an extension
+// that has no typed layout in this package.
+type RawExtension struct {
+	Type ExtensionType
+	Data []byte
+}
+
+func (ext *RawExtension) ExtensionType() ExtensionType { return ext.Type }
+
+func (ext *RawExtension) UnmarshalWithDecoder(dec *bin.Decoder) (err error) {
+	ext.Data, err = dec.ReadNBytes(dec.Remaining())
+	return err
+}
+
+func (ext RawExtension) MarshalWithEncoder(encoder *bin.Encoder) error {
+	return encoder.WriteBytes(ext.Data, false)
+}
+
+// TransferFee describes a transfer fee schedule.
+type TransferFee struct {
+	// First epoch where the transfer fee takes effect.
+	Epoch uint64
+	// Maximum fee assessed on transfers, expressed as an amount of tokens.
+	MaximumFee uint64
+	// Amount of transfer collected as fees, expressed as basis points of the transfer amount,
+	// ie. increments of 0.01%.
+	TransferFeeBasisPoints uint16
+}
+
+// Calculate returns the fee for a transfer of the provided amount.
+func (fee TransferFee) Calculate(amount uint64) uint64 {
+	if fee.TransferFeeBasisPoints == 0 || amount == 0 {
+		return 0
+	}
+	// ceil(amount * bps / 10_000), computed in 128 bits to avoid overflows.
+	hi, lo := bits.Mul64(amount, uint64(fee.TransferFeeBasisPoints))
+	lo, carry := bits.Add64(lo, ONE_IN_BASIS_POINTS-1, 0)
+	hi += carry
+	if hi >= ONE_IN_BASIS_POINTS {
+		// The quotient does not fit in 64 bits.
+		return fee.MaximumFee
+	}
+	quo, _ := bits.Div64(hi, lo, ONE_IN_BASIS_POINTS)
+	if quo > fee.MaximumFee {
+		return fee.MaximumFee
+	}
+	return quo
+}
+
+func (fee *TransferFee) UnmarshalWithDecoder(dec *bin.Decoder) (err error) {
+	if fee.Epoch, err = dec.ReadUint64(binary.LittleEndian); err != nil {
+		return err
+	}
+	if fee.MaximumFee, err = dec.ReadUint64(binary.LittleEndian); err != nil {
+		return err
+	}
+	fee.TransferFeeBasisPoints, err = dec.ReadUint16(binary.LittleEndian)
+	return err
+}
+
+func (fee TransferFee) MarshalWithEncoder(encoder *bin.Encoder) (err error) {
+	if err = encoder.WriteUint64(fee.Epoch, binary.LittleEndian); err != nil {
+		return err
+	}
+	if err = encoder.WriteUint64(fee.MaximumFee, binary.LittleEndian); err != nil {
+		return err
+	}
+	return encoder.WriteUint16(fee.TransferFeeBasisPoints, binary.LittleEndian)
+}
+
+// TransferFeeConfig is the transfer fee extension data for mints.
+type TransferFeeConfig struct {
+	// Optional authority to set the fee.
+	TransferFeeConfigAuthority *solana.PublicKey
+	// Withdraw from mint instructions must be signed by this key.
+	WithdrawWithheldAuthority *solana.PublicKey
+	// Withheld transfer fee tokens that have been moved to the mint for withdrawal.
+	WithheldAmount uint64
+	// Older transfer fee, used if the current epoch < NewerTransferFee.Epoch.
+	OlderTransferFee TransferFee
+	// Newer transfer fee, used if the current epoch >= NewerTransferFee.Epoch.
+	NewerTransferFee TransferFee
+}
+
+func (ext *TransferFeeConfig) ExtensionType() ExtensionType { return ExtensionTransferFeeConfig }
+
+// GetEpochFee returns the fee schedule in effect at the provided epoch.
+func (ext *TransferFeeConfig) GetEpochFee(epoch uint64) TransferFee {
+	if epoch >= ext.NewerTransferFee.Epoch {
+		return ext.NewerTransferFee
+	}
+	return ext.OlderTransferFee
+}
+
+func (ext *TransferFeeConfig) UnmarshalWithDecoder(dec *bin.Decoder) (err error) {
+	if ext.TransferFeeConfigAuthority, err = readOptionalNonZeroPubkey(dec); err != nil {
+		return err
+	}
+	if ext.WithdrawWithheldAuthority, err = readOptionalNonZeroPubkey(dec); err != nil {
+		return err
+	}
+	if ext.WithheldAmount, err = dec.ReadUint64(binary.LittleEndian); err != nil {
+		return err
+	}
+	if err = ext.OlderTransferFee.UnmarshalWithDecoder(dec); err != nil {
+		return err
+	}
+	return ext.NewerTransferFee.UnmarshalWithDecoder(dec)
+}
+
+func (ext TransferFeeConfig) MarshalWithEncoder(encoder *bin.Encoder) (err error) {
+	if err = writeOptionalNonZeroPubkey(encoder, ext.TransferFeeConfigAuthority); err != nil {
+		return err
+	}
+	if err = writeOptionalNonZeroPubkey(encoder, ext.WithdrawWithheldAuthority); err != nil {
+		return err
+	}
+	if err = encoder.WriteUint64(ext.WithheldAmount, binary.LittleEndian); err != nil {
+		return err
+	}
+	if err = ext.OlderTransferFee.MarshalWithEncoder(encoder); err != nil {
+		return err
+	}
+	return ext.NewerTransferFee.MarshalWithEncoder(encoder)
+}
+
+// TransferFeeAmount is the transfer fee extension data for accounts.
+type TransferFeeAmount struct {
+	// Amount withheld during transfers, to be harvested to the mint.
+	WithheldAmount uint64
+}
+
+func (ext *TransferFeeAmount) ExtensionType() ExtensionType { return ExtensionTransferFeeAmount }
+
+func (ext *TransferFeeAmount) UnmarshalWithDecoder(dec *bin.Decoder) (err error) {
+	ext.WithheldAmount, err = dec.ReadUint64(binary.LittleEndian)
+	return err
+}
+
+func (ext TransferFeeAmount) MarshalWithEncoder(encoder *bin.Encoder) error {
+	return encoder.WriteUint64(ext.WithheldAmount, binary.LittleEndian)
+}
+
+// MintCloseAuthority holds the authority that can close the mint.
+type MintCloseAuthority struct {
+	// Optional authority to close the mint.
+	CloseAuthority *solana.PublicKey
+}
+
+func (ext *MintCloseAuthority) ExtensionType() ExtensionType { return ExtensionMintCloseAuthority }
+
+func (ext *MintCloseAuthority) UnmarshalWithDecoder(dec *bin.Decoder) (err error) {
+	ext.CloseAuthority, err = readOptionalNonZeroPubkey(dec)
+	return err
+}
+
+func (ext MintCloseAuthority) MarshalWithEncoder(encoder *bin.Encoder) error {
+	return writeOptionalNonZeroPubkey(encoder, ext.CloseAuthority)
+}
+
+// DefaultAccountState holds the state new accounts of the mint are created with.
+type DefaultAccountState struct {
+	// Default Account::state in which new Accounts should be initialized.
+	State AccountState
+}
+
+func (ext *DefaultAccountState) ExtensionType() ExtensionType { return ExtensionDefaultAccountState }
+
+func (ext *DefaultAccountState) UnmarshalWithDecoder(dec *bin.Decoder) error {
+	v, err := dec.ReadUint8()
+	if err != nil {
+		return err
+	}
+	ext.State = AccountState(v)
+	return nil
+}
+
+func (ext DefaultAccountState) MarshalWithEncoder(encoder *bin.Encoder) error {
+	return encoder.WriteUint8(uint8(ext.State))
+}
+
+// ImmutableOwner indicates that the account owner cannot be changed.
+type ImmutableOwner struct{}
+
+func (ext *ImmutableOwner) ExtensionType() ExtensionType                 { return ExtensionImmutableOwner }
+func (ext *ImmutableOwner) UnmarshalWithDecoder(dec *bin.Decoder) error  { return nil }
+func (ext ImmutableOwner) MarshalWithEncoder(encoder *bin.Encoder) error { return nil }
+
+// MemoTransfer indicates whether incoming transfers must be accompanied by a memo.
+type MemoTransfer struct {
+	// Require transfers into this account to be accompanied by a memo.
+	RequireIncomingTransferMemos bool
+}
+
+func (ext *MemoTransfer) ExtensionType() ExtensionType { return ExtensionMemoTransfer }
+
+func (ext *MemoTransfer) UnmarshalWithDecoder(dec *bin.Decoder) (err error) {
+	ext.RequireIncomingTransferMemos, err = dec.ReadBool()
+	return err
+}
+
+func (ext MemoTransfer) MarshalWithEncoder(encoder *bin.Encoder) error {
+	return encoder.WriteBool(ext.RequireIncomingTransferMemos)
+}
+
+// NonTransferable indicates that the tokens of the mint can't be transferred.
+type NonTransferable struct{}
+
+func (ext *NonTransferable) ExtensionType() ExtensionType                 { return ExtensionNonTransferable }
+func (ext *NonTransferable) UnmarshalWithDecoder(dec *bin.Decoder) error  { return nil }
+func (ext NonTransferable) MarshalWithEncoder(encoder *bin.Encoder) error { return nil }
+
+// InterestBearingConfig holds the interest rate configuration of a mint.
+type InterestBearingConfig struct {
+	// Authority that can set the interest rate.
+	RateAuthority *solana.PublicKey
+	// Timestamp of initialization, from which to base interest calculations.
+	InitializationTimestamp int64
+	// Average rate from initialization until the last time it was updated, in basis points.
+	PreUpdateAverageRate int16
+	// Timestamp of the last update, used to calculate the total amount accrued.
+	LastUpdateTimestamp int64
+	// Current rate, since the last update, in basis points.
+	CurrentRate int16
+}
+
+func (ext *InterestBearingConfig) ExtensionType() ExtensionType {
+	return ExtensionInterestBearingConfig
+}
+
+func (ext *InterestBearingConfig) UnmarshalWithDecoder(dec *bin.Decoder) (err error) {
+	if ext.RateAuthority, err = readOptionalNonZeroPubkey(dec); err != nil {
+		return err
+	}
+	if ext.InitializationTimestamp, err = dec.ReadInt64(binary.LittleEndian); err != nil {
+		return err
+	}
+	if ext.PreUpdateAverageRate, err = dec.ReadInt16(binary.LittleEndian); err != nil {
+		return err
+	}
+	if ext.LastUpdateTimestamp, err = dec.ReadInt64(binary.LittleEndian); err != nil {
+		return err
+	}
+	ext.CurrentRate, err = dec.ReadInt16(binary.LittleEndian)
+	return err
+}
+
+func (ext InterestBearingConfig) MarshalWithEncoder(encoder *bin.Encoder) (err error) {
+	if err = writeOptionalNonZeroPubkey(encoder, ext.RateAuthority); err != nil {
+		return err
+	}
+	if err = encoder.WriteInt64(ext.InitializationTimestamp, binary.LittleEndian); err != nil {
+		return err
+	}
+	if err = encoder.WriteInt16(ext.PreUpdateAverageRate, binary.LittleEndian); err != nil {
+		return err
+	}
+	if err = encoder.WriteInt64(ext.LastUpdateTimestamp, binary.LittleEndian); err != nil {
+		return err
+	}
+	return encoder.WriteInt16(ext.CurrentRate, binary.LittleEndian)
+}
+
+// CpiGuard indicates whether privileged token operations via CPI are locked.
+type CpiGuard struct {
+	// Lock privileged token operations from happening via CPI.
+	LockCpi bool
+}
+
+func (ext *CpiGuard) ExtensionType() ExtensionType { return ExtensionCpiGuard }
+
+func (ext *CpiGuard) UnmarshalWithDecoder(dec *bin.Decoder) (err error) {
+	ext.LockCpi, err = dec.ReadBool()
+	return err
+}
+
+func (ext CpiGuard) MarshalWithEncoder(encoder *bin.Encoder) error {
+	return encoder.WriteBool(ext.LockCpi)
+}
+
+// PermanentDelegate holds the permanent delegate of a mint.
+type PermanentDelegate struct {
+	// Optional permanent delegate for transferring or burning tokens.
+	Delegate *solana.PublicKey
+}
+
+func (ext *PermanentDelegate) ExtensionType() ExtensionType { return ExtensionPermanentDelegate }
+
+func (ext *PermanentDelegate) UnmarshalWithDecoder(dec *bin.Decoder) (err error) {
+	ext.Delegate, err = readOptionalNonZeroPubkey(dec)
+	return err
+}
+
+func (ext PermanentDelegate) MarshalWithEncoder(encoder *bin.Encoder) error {
+	return writeOptionalNonZeroPubkey(encoder, ext.Delegate)
+}
+
+// NonTransferableAccount indicates that the account holds tokens of a non-transferable mint.
+type NonTransferableAccount struct{}
+
+func (ext *NonTransferableAccount) ExtensionType() ExtensionType {
+	return ExtensionNonTransferableAccount
+}
+func (ext *NonTransferableAccount) UnmarshalWithDecoder(dec *bin.Decoder) error  { return nil }
+func (ext NonTransferableAccount) MarshalWithEncoder(encoder *bin.Encoder) error { return nil }
+
+// TransferHook holds the program invoked on every transfer of the mint.
+type TransferHook struct {
+	// Authority that can set the transfer hook program id.
+	Authority *solana.PublicKey
+	// Program that authorizes the transfer.
+	ProgramID *solana.PublicKey
+}
+
+func (ext *TransferHook) ExtensionType() ExtensionType { return ExtensionTransferHook }
+
+func (ext *TransferHook) UnmarshalWithDecoder(dec *bin.Decoder) (err error) {
+	if ext.Authority, err = readOptionalNonZeroPubkey(dec); err != nil {
+		return err
+	}
+	ext.ProgramID, err = readOptionalNonZeroPubkey(dec)
+	return err
+}
+
+func (ext TransferHook) MarshalWithEncoder(encoder *bin.Encoder) (err error) {
+	if err = writeOptionalNonZeroPubkey(encoder, ext.Authority); err != nil {
+		return err
+	}
+	return writeOptionalNonZeroPubkey(encoder, ext.ProgramID)
+}
+
+// TransferHookAccount indicates that the account holds tokens of a mint with a transfer hook.
+type TransferHookAccount struct {
+	// Flag to indicate that the account is in the middle of a transfer.
+	Transferring bool
+}
+
+func (ext *TransferHookAccount) ExtensionType() ExtensionType { return ExtensionTransferHookAccount }
+
+func (ext *TransferHookAccount) UnmarshalWithDecoder(dec *bin.Decoder) (err error) {
+	ext.Transferring, err = dec.ReadBool()
+	return err
+}
+
+func (ext TransferHookAccount) MarshalWithEncoder(encoder *bin.Encoder) error {
+	return encoder.WriteBool(ext.Transferring)
+}
+
+// MetadataPointer points to the account that holds the metadata of the mint.
+type MetadataPointer struct {
+	// Authority that can set the metadata address.
+	Authority *solana.PublicKey
+	// Account address that holds the metadata.
+	MetadataAddress *solana.PublicKey
+}
+
+func (ext *MetadataPointer) ExtensionType() ExtensionType { return ExtensionMetadataPointer }
+
+func (ext *MetadataPointer) UnmarshalWithDecoder(dec *bin.Decoder) (err error) {
+	if ext.Authority, err = readOptionalNonZeroPubkey(dec); err != nil {
+		return err
+	}
+	ext.MetadataAddress, err = readOptionalNonZeroPubkey(dec)
+	return err
+}
+
+func (ext MetadataPointer) MarshalWithEncoder(encoder *bin.Encoder) (err error) {
+	if err = writeOptionalNonZeroPubkey(encoder, ext.Authority); err != nil {
+		return err
+	}
+	return writeOptionalNonZeroPubkey(encoder, ext.MetadataAddress)
+}
+
+// MetadataField is a key-value pair of additional token metadata.
+type MetadataField struct {
+	Key   string
+	Value string
+}
+
+// TokenMetadata is the token-metadata interface data stored in the mint.
+type TokenMetadata struct {
+	// The authority that can sign to update the metadata.
+	UpdateAuthority *solana.PublicKey
+	// The associated mint, used to counter spoofing to be sure that metadata
+	// belongs to a particular mint.
+	Mint solana.PublicKey
+	// The longer name of the token.
+	Name string
+	// The shortened symbol for the token.
+	Symbol string
+	// The URI pointing to richer metadata.
+	URI string
+	// Any additional metadata about the token as key-value pairs.
+	AdditionalMetadata []MetadataField
+}
+
+func (ext *TokenMetadata) ExtensionType() ExtensionType { return ExtensionTokenMetadata }
+
+// Get returns the value of the additional metadata field with the provided key.
+func (ext *TokenMetadata) Get(key string) (string, bool) {
+	for _, field := range ext.AdditionalMetadata {
+		if field.Key == key {
+			return field.Value, true
+		}
+	}
+	return "", false
+}
+
+func (ext *TokenMetadata) UnmarshalWithDecoder(dec *bin.Decoder) (err error) {
+	if ext.UpdateAuthority, err = readOptionalNonZeroPubkey(dec); err != nil {
+		return err
+	}
+	if err = dec.Decode(&ext.Mint); err != nil {
+		return err
+	}
+	if ext.Name, err = readBorshString(dec); err != nil {
+		return err
+	}
+	if ext.Symbol, err = readBorshString(dec); err != nil {
+		return err
+	}
+	if ext.URI, err = readBorshString(dec); err != nil {
+		return err
+	}
+	count, err := dec.ReadUint32(binary.LittleEndian)
+	if err != nil {
+		return err
+	}
+	// Each entry takes at least 8 bytes (two empty strings).
+	if uint64(count)*8 > uint64(dec.Remaining()) {
+		return fmt.Errorf("invalid additional metadata length: %d", count)
+	}
+	ext.AdditionalMetadata = make([]MetadataField, count)
+	for i := range ext.AdditionalMetadata {
+		if ext.AdditionalMetadata[i].Key, err = readBorshString(dec); err != nil {
+			return err
+		}
+		if ext.AdditionalMetadata[i].Value, err = readBorshString(dec); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (ext TokenMetadata) MarshalWithEncoder(encoder *bin.Encoder) (err error) {
+	if err = writeOptionalNonZeroPubkey(encoder, ext.UpdateAuthority); err != nil {
+		return err
+	}
+	if err = encoder.WriteBytes(ext.Mint[:], false); err != nil {
+		return err
+	}
+	if err = writeBorshString(encoder, ext.Name); err != nil {
+		return err
+	}
+	if err = writeBorshString(encoder, ext.Symbol); err != nil {
+		return err
+	}
+	if err = writeBorshString(encoder, ext.URI); err != nil {
+		return err
+	}
+	if err = encoder.WriteUint32(uint32(len(ext.AdditionalMetadata)), binary.LittleEndian); err != nil {
+		return err
+	}
+	for _, field := range ext.AdditionalMetadata {
+		if err = writeBorshString(encoder, field.Key); err != nil {
+			return err
+		}
+		if err = writeBorshString(encoder, field.Value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GroupPointer points to the account that holds the group configuration of the mint.
+type GroupPointer struct {
+	// Authority that can set the group address.
+	Authority *solana.PublicKey
+	// Account address that holds the group.
+	GroupAddress *solana.PublicKey
+}
+
+func (ext *GroupPointer) ExtensionType() ExtensionType { return ExtensionGroupPointer }
+
+func (ext *GroupPointer) UnmarshalWithDecoder(dec *bin.Decoder) (err error) {
+	if ext.Authority, err = readOptionalNonZeroPubkey(dec); err != nil {
+		return err
+	}
+	ext.GroupAddress, err = readOptionalNonZeroPubkey(dec)
+	return err
+}
+
+func (ext GroupPointer) MarshalWithEncoder(encoder *bin.Encoder) (err error) {
+	if err = writeOptionalNonZeroPubkey(encoder, ext.Authority); err != nil {
+		return err
+	}
+	return writeOptionalNonZeroPubkey(encoder, ext.GroupAddress)
+}
+
+// TokenGroup is the token-group interface data stored in the mint.
+type TokenGroup struct {
+	// The authority that can sign to update the group.
+	UpdateAuthority *solana.PublicKey
+	// The associated mint, used to counter spoofing to be sure that group
+	// belongs to a particular mint.
+	Mint solana.PublicKey
+	// The current number of group members.
+	Size uint64
+	// The maximum number of group members.
+	MaxSize uint64
+}
+
+func (ext *TokenGroup) ExtensionType() ExtensionType { return ExtensionTokenGroup }
+
+func (ext *TokenGroup) UnmarshalWithDecoder(dec *bin.Decoder) (err error) {
+	if ext.UpdateAuthority, err = readOptionalNonZeroPubkey(dec); err != nil {
+		return err
+	}
+	if err = dec.Decode(&ext.Mint); err != nil {
+		return err
+	}
+	if ext.Size, err = dec.ReadUint64(binary.LittleEndian); err != nil {
+		return err
+	}
+	ext.MaxSize, err = dec.ReadUint64(binary.LittleEndian)
+	return err
+}
+
+func (ext TokenGroup) MarshalWithEncoder(encoder *bin.Encoder) (err error) {
+	if err = writeOptionalNonZeroPubkey(encoder, ext.UpdateAuthority); err != nil {
+		return err
+	}
+	if err = encoder.WriteBytes(ext.Mint[:], false); err != nil {
+		return err
+	}
+	if err = encoder.WriteUint64(ext.Size, binary.LittleEndian); err != nil {
+		return err
+	}
+	return encoder.WriteUint64(ext.MaxSize, binary.LittleEndian)
+}
+
+// GroupMemberPointer points to the account that holds the group member configuration of the mint.
+type GroupMemberPointer struct {
+	// Authority that can set the member address.
+	Authority *solana.PublicKey
+	// Account address that holds the member.
+	MemberAddress *solana.PublicKey
+}
+
+func (ext *GroupMemberPointer) ExtensionType() ExtensionType { return ExtensionGroupMemberPointer }
+
+func (ext *GroupMemberPointer) UnmarshalWithDecoder(dec *bin.Decoder) (err error) {
+	if ext.Authority, err = readOptionalNonZeroPubkey(dec); err != nil {
+		return err
+	}
+	ext.MemberAddress, err = readOptionalNonZeroPubkey(dec)
+	return err
+}
+
+func (ext GroupMemberPointer) MarshalWithEncoder(encoder *bin.Encoder) (err error) {
+	if err = writeOptionalNonZeroPubkey(encoder, ext.Authority); err != nil {
+		return err
+	}
+	return writeOptionalNonZeroPubkey(encoder, ext.MemberAddress)
+}
+
+// TokenGroupMember is the token-group member interface data stored in the mint.
+type TokenGroupMember struct {
+	// The associated mint, used to counter spoofing to be sure that member
+	// belongs to a particular mint.
+	Mint solana.PublicKey
+	// The pubkey of the TokenGroup.
+	Group solana.PublicKey
+	// The member number.
+	MemberNumber uint64
+}
+
+func (ext *TokenGroupMember) ExtensionType() ExtensionType { return ExtensionTokenGroupMember }
+
+func (ext *TokenGroupMember) UnmarshalWithDecoder(dec *bin.Decoder) (err error) {
+	if err = dec.Decode(&ext.Mint); err != nil {
+		return err
+	}
+	if err = dec.Decode(&ext.Group); err != nil {
+		return err
+	}
+	ext.MemberNumber, err = dec.ReadUint64(binary.LittleEndian)
+	return err
+}
+
+func (ext TokenGroupMember) MarshalWithEncoder(encoder *bin.Encoder) (err error) {
+	if err = encoder.WriteBytes(ext.Mint[:], false); err != nil {
+		return err
+	}
+	if err = encoder.WriteBytes(ext.Group[:], false); err != nil {
+		return err
+	}
+	return encoder.WriteUint64(ext.MemberNumber, binary.LittleEndian)
+}
+
+// ScaledUiAmountConfig holds the multiplier applied to the UI amount of the mint.
+type ScaledUiAmountConfig struct {
+	// Authority that can set the scaling amount and authority.
+	Authority *solana.PublicKey
+	// Amount to multiply raw amounts by, outside of the decimal.
+	Multiplier float64
+	// Unix timestamp at which NewMultiplier comes into effect.
+	NewMultiplierEffectiveTimestamp int64
+	// Next multiplier, once NewMultiplierEffectiveTimestamp is reached.
+	NewMultiplier float64
+}
+
+func (ext *ScaledUiAmountConfig) ExtensionType() ExtensionType { return ExtensionScaledUiAmount }
+
+// GetMultiplier returns the multiplier in effect at the provided unix timestamp.
+func (ext *ScaledUiAmountConfig) GetMultiplier(unixTimestamp int64) float64 {
+	if unixTimestamp >= ext.NewMultiplierEffectiveTimestamp {
+		return ext.NewMultiplier
+	}
+	return ext.Multiplier
+}
+
+func (ext *ScaledUiAmountConfig) UnmarshalWithDecoder(dec *bin.Decoder) (err error) {
+	if ext.Authority, err = readOptionalNonZeroPubkey(dec); err != nil {
+		return err
+	}
+	if ext.Multiplier, err = dec.ReadFloat64(binary.LittleEndian); err != nil {
+		return err
+	}
+	if ext.NewMultiplierEffectiveTimestamp, err = dec.ReadInt64(binary.LittleEndian); err != nil {
+		return err
+	}
+	ext.NewMultiplier, err = dec.ReadFloat64(binary.LittleEndian)
+	return err
+}
+
+func (ext ScaledUiAmountConfig) MarshalWithEncoder(encoder *bin.Encoder) (err error) {
+	if err = writeOptionalNonZeroPubkey(encoder, ext.Authority); err != nil {
+		return err
+	}
+	if err = encoder.WriteFloat64(ext.Multiplier, binary.LittleEndian); err != nil {
+		return err
+	}
+	if err = encoder.WriteInt64(ext.NewMultiplierEffectiveTimestamp, binary.LittleEndian); err != nil {
+		return err
+	}
+	return encoder.WriteFloat64(ext.NewMultiplier, binary.LittleEndian)
+}
+
+// PausableConfig holds the pause state of a mint.
+type PausableConfig struct {
+	// Authority that can pause or resume activity on the mint.
+	Authority *solana.PublicKey
+	// Whether minting / transferring / burning tokens is paused.
+	Paused bool
+}
+
+func (ext *PausableConfig) ExtensionType() ExtensionType { return ExtensionPausable }
+
+func (ext *PausableConfig) UnmarshalWithDecoder(dec *bin.Decoder) (err error) {
+	if ext.Authority, err = readOptionalNonZeroPubkey(dec); err != nil {
+		return err
+	}
+	ext.Paused, err = dec.ReadBool()
+	return err
+}
+
+func (ext PausableConfig) MarshalWithEncoder(encoder *bin.Encoder) (err error) {
+	if err = writeOptionalNonZeroPubkey(encoder, ext.Authority); err != nil {
+		return err
+	}
+	return encoder.WriteBool(ext.Paused)
+}
+
+// PausableAccount indicates that the account belongs to a pausable mint.
+type PausableAccount struct{}
+
+func (ext *PausableAccount) ExtensionType() ExtensionType                 { return ExtensionPausableAccount }
+func (ext *PausableAccount) UnmarshalWithDecoder(dec *bin.Decoder) error  { return nil }
+func (ext PausableAccount) MarshalWithEncoder(encoder *bin.Encoder) error { return nil }
+
+// readOptionalNonZeroPubkey reads a pubkey that is considered unset when all zeros.
+func readOptionalNonZeroPubkey(dec *bin.Decoder) (*solana.PublicKey, error) {
+	v, err := dec.ReadNBytes(32)
+	if err != nil {
+		return nil, err
+	}
+	key := solana.PublicKeyFromBytes(v)
+	if key.IsZero() {
+		return nil, nil
+	}
+	return &key, nil
+}
+
+func writeOptionalNonZeroPubkey(encoder *bin.Encoder, key *solana.PublicKey) error {
+	if key == nil {
+		empty := solana.PublicKey{}
+		return encoder.WriteBytes(empty[:], false)
+	}
+	return encoder.WriteBytes(key[:], false)
+}
+
+func readBorshString(dec *bin.Decoder) (string, error) {
+	length, err := dec.ReadUint32(binary.LittleEndian)
+	if err != nil {
+		return "", err
+	}
+	if uint64(length) > uint64(dec.Remaining()) {
+		return "", fmt.Errorf("invalid string length: %d", length)
+	}
+	v, err := dec.ReadNBytes(int(length))
+	if err != nil {
+		return "", err
+	}
+	return string(v), nil
+}
+
+func writeBorshString(encoder *bin.Encoder, s string) error {
+	if err := encoder.WriteUint32(uint32(len(s)), binary.LittleEndian); err != nil {
+		return err
+	}
+	return encoder.WriteBytes([]byte(s), false)
+}
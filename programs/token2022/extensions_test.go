@@ -0,0 +1,180 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package token2022
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"testing"
+
+	bin "github.com/gagliardetto/binary"
+	"github.com/gagliardetto/solana-go"
+	"github.com/stretchr/testify/require"
+)
+
+func tlv(typ ExtensionType, value []byte) []byte {
+	out := make([]byte, 4, 4+len(value))
+	binary.LittleEndian.PutUint16(out[0:2], uint16(typ))
+	binary.LittleEndian.PutUint16(out[2:4], uint16(len(value)))
+	return append(out, value...)
+}
+
+func u64(v uint64) []byte {
+	return binary.LittleEndian.AppendUint64(nil, v)
+}
+
+func borshString(s string) []byte {
+	return append(binary.LittleEndian.AppendUint32(nil, uint32(len(s))), s...)
+}
+
+func TestMintWithExtensions(t *testing.T) {
+	authority := solana.MustPublicKeyFromBase58("Q6XprfkF8RQQKoQVG33xT88H7wi8Uk1B1CC7YAs69Gi")
+	mintKey := solana.MustPublicKeyFromBase58("2b1kV6DkPAnxd5ixfnxCpjxmKwqjjaYmCZfHsFu24GXo")
+
+	var data []byte
+	// Base mint:
+	data = append(data, 1, 0, 0, 0)
+	data = append(data, authority[:]...)
+	data = append(data, u64(1_000_000)...)
+	data = append(data, 6, 1)
+	data = append(data, 0, 0, 0, 0)
+	data = append(data, make([]byte, 32)...)
+	// Padding and account type:
+	data = append(data, make([]byte, ACCOUNT_SIZE-MINT_SIZE)...)
+	data = append(data, byte(AccountTypeMint))
+	// TransferFeeConfig:
+	{
+		var v []byte
+		v = append(v, authority[:]...)
+		v = append(v, make([]byte, 32)...)
+		v = append(v, u64(77)...)
+		v = append(v, u64(10)...)
+		v = append(v, u64(5000)...)
+		v = append(v, 50, 0)
+		v = append(v, u64(11)...)
+		v = append(v, u64(math.MaxUint64)...)
+		v = append(v, 100, 0)
+		data = append(data, tlv(ExtensionTransferFeeConfig, v)...)
+	}
+	// MetadataPointer:
+	data = append(data, tlv(ExtensionMetadataPointer, append(append([]byte{}, authority[:]...), mintKey[:]...))...)
+	// ConfidentialTransferMint (kept raw):
+	data = append(data, tlv(ExtensionConfidentialTransferMint, bytes.Repeat([]byte{7}, 65))...)
+	// TokenMetadata:
+	{
+		var v []byte
+		v = append(v, authority[:]...)
+		v = append(v, mintKey[:]...)
+		v = append(v, borshString("Example")...)
+		v = append(v, borshString("EX")...)
+		v = append(v, borshString("https://example.com/ex.json")...)
+		v = append(v, 1, 0, 0, 0)
+		v = append(v, borshString("color")...)
+		v = append(v, borshString("blue")...)
+		data = append(data, tlv(ExtensionTokenMetadata, v)...)
+	}
+
+	mint := Mint{}
+	require.NoError(t, bin.NewBinDecoder(data).Decode(&mint))
+
+	require.Equal(t, uint64(1_000_000), mint.Supply)
+	require.Nil(t, mint.FreezeAuthority)
+	require.Equal(t,
+		[]ExtensionType{
+			ExtensionTransferFeeConfig,
+			ExtensionMetadataPointer,
+			ExtensionConfidentialTransferMint,
+			ExtensionTokenMetadata,
+		},
+		mint.Extensions.Types(),
+	)
+
+	feeConfig, ok := mint.GetExtension(ExtensionTransferFeeConfig).(*TransferFeeConfig)
+	require.True(t, ok)
+	require.Equal(t, authority, *feeConfig.TransferFeeConfigAuthority)
+	require.Nil(t, feeConfig.WithdrawWithheldAuthority)
+	require.Equal(t, uint64(77), feeConfig.WithheldAmount)
+	require.Equal(t, uint16(50), feeConfig.GetEpochFee(10).TransferFeeBasisPoints)
+	require.Equal(t, uint16(100), feeConfig.GetEpochFee(11).TransferFeeBasisPoints)
+
+	pointer, ok := mint.GetExtension(ExtensionMetadataPointer).(*MetadataPointer)
+	require.True(t, ok)
+	require.Equal(t, mintKey, *pointer.MetadataAddress)
+
+	raw, ok := mint.GetExtension(ExtensionConfidentialTransferMint).(*RawExtension)
+	require.True(t, ok)
+	require.Len(t, raw.Data, 65)
+
+	metadata, ok := mint.GetExtension(ExtensionTokenMetadata).(*TokenMetadata)
+	require.True(t, ok)
+	require.Equal(t, "Example", metadata.Name)
+	require.Equal(t, "EX", metadata.Symbol)
+	require.Equal(t, "https://example.com/ex.json", metadata.URI)
+	color, ok := metadata.Get("color")
+	require.True(t, ok)
+	require.Equal(t, "blue", color)
+
+	require.Nil(t, mint.GetExtension(ExtensionPausable))
+
+	buf := new(bytes.Buffer)
+	require.NoError(t, bin.NewBinEncoder(buf).Encode(mint))
+	require.Equal(t, data, buf.Bytes())
+}
+
+func TestAccountWithExtensions(t *testing.T) {
+	account := Account{
+		Mint:   solana.MustPublicKeyFromBase58("So11111111111111111111111111111111111111112"),
+		Owner:  solana.MustPublicKeyFromBase58("7HZaCWazgTuuFuajxaaxGYbGnyVKwxvsJKue1W4Nvyro"),
+		Amount: 42,
+		State:  Initialized,
+		Extensions: Extensions{
+			&ImmutableOwner{},
+			&TransferFeeAmount{WithheldAmount: 3},
+			&MemoTransfer{RequireIncomingTransferMemos: true},
+			&CpiGuard{LockCpi: true},
+		},
+	}
+
+	buf := new(bytes.Buffer)
+	require.NoError(t, bin.NewBinEncoder(buf).Encode(account))
+	data := buf.Bytes()
+	require.Equal(t, byte(AccountTypeAccount), data[ACCOUNT_SIZE])
+	require.Equal(t, []byte{byte(ExtensionImmutableOwner), 0, 0, 0}, data[ACCOUNT_SIZE+1:ACCOUNT_SIZE+5])
+	require.Len(t, data, ACCOUNT_SIZE+1+4+(4+8)+(4+1)+(4+1))
+
+	// Trailing unused space is ignored.
+	data = append(data, make([]byte, 10)...)
+
+	got := Account{}
+	require.NoError(t, bin.NewBinDecoder(data).Decode(&got))
+	require.Equal(t, account, got)
+
+	memo, ok := got.GetExtension(ExtensionMemoTransfer).(*MemoTransfer)
+	require.True(t, ok)
+	require.True(t, memo.RequireIncomingTransferMemos)
+}
+
+func TestTransferFee_Calculate(t *testing.T) {
+	fee := TransferFee{MaximumFee: 5_000, TransferFeeBasisPoints: 50}
+	require.Equal(t, uint64(0), fee.Calculate(0))
+	require.Equal(t, uint64(1), fee.Calculate(1))
+	require.Equal(t, uint64(5), fee.Calculate(1_000))
+	require.Equal(t, uint64(6), fee.Calculate(1_001))
+	require.Equal(t, uint64(5_000), fee.Calculate(math.MaxUint64))
+
+	fee = TransferFee{MaximumFee: math.MaxUint64, TransferFeeBasisPoints: 10_000}
+	require.Equal(t, uint64(math.MaxUint64), fee.Calculate(math.MaxUint64))
+}
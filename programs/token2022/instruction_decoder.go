@@ -1,31 +1,93 @@
 package token2022
 
 import (
+	"fmt"
+
 	"github.com/gagliardetto/solana-go"
 )
 
 var (
-	DecodeApprove             = decode[*Approve]()
-	DecodeApproveChecked      = decode[*ApproveChecked]()
-	DecodeBurn                = decode[*Burn]()
-	DecodeBurnChecked         = decode[*BurnChecked]()
-	DecodeCloseAccount        = decode[*CloseAccount]()
-	DecodeFreezeAccount       = decode[*FreezeAccount]()
-	DecodeInitializeAccount   = decode[*InitializeAccount]()
-	DecodeInitializeAccount2  = decode[*InitializeAccount2]()
-	DecodeInitializeAccount3  = decode[*InitializeAccount3]()
-	DecodeInitializeMint      = decode[*InitializeMint]()
-	DecodeInitializeMint2     = decode[*InitializeMint2]()
-	DecodeInitializeMultisig  = decode[*InitializeMultisig]()
-	DecodeInitializeMultisig2 = decode[*InitializeMultisig2]()
-	DecodeMintTo              = decode[*MintTo]()
-	DecodeMintToChecked       = decode[*MintToChecked]()
-	DecodeRevoke              = decode[*Revoke]()
-	DecodeSetAuthority        = decode[*SetAuthority]()
-	DecodeSyncNative          = decode[*SyncNative]()
-	DecodeThawAccount         = decode[*ThawAccount]()
-	DecodeTransfer            = decode[*Transfer]()
-	DecodeTransferChecked     = decode[*TransferChecked]()
+	DecodeApprove                            = decode[*Approve]()
+	DecodeApproveChecked                     = decode[*ApproveChecked]()
+	DecodeBurn                               = decode[*Burn]()
+	DecodeBurnChecked                        = decode[*BurnChecked]()
+	DecodeCloseAccount                       = decode[*CloseAccount]()
+	DecodeFreezeAccount                      = decode[*FreezeAccount]()
+	DecodeInitializeAccount                  = decode[*InitializeAccount]()
+	DecodeInitializeAccount2                 = decode[*InitializeAccount2]()
+	DecodeInitializeAccount3                 = decode[*InitializeAccount3]()
+	DecodeInitializeMint                     = decode[*InitializeMint]()
+	DecodeInitializeMint2                    = decode[*InitializeMint2]()
+	DecodeInitializeMultisig                 = decode[*InitializeMultisig]()
+	DecodeInitializeMultisig2                = decode[*InitializeMultisig2]()
+	DecodeMintTo                             = decode[*MintTo]()
+	DecodeMintToChecked                      = decode[*MintToChecked]()
+	DecodeRevoke                             = decode[*Revoke]()
+	DecodeSetAuthority                       = decode[*SetAuthority]()
+	DecodeSyncNative                         = decode[*SyncNative]()
+	DecodeThawAccount                        = decode[*ThawAccount]()
+	DecodeTransfer                           = decode[*Transfer]()
+	DecodeTransferChecked                    = decode[*TransferChecked]()
+	DecodeAmountToUiAmount                   = decode[*AmountToUiAmount]()
+	DecodeCreateNativeMint                   = decode[*CreateNativeMint]()
+	DecodeGetAccountDataSize                 = decode[*GetAccountDataSize]()
+	DecodeInitializeImmutableOwner           = decode[*InitializeImmutableOwner]()
+	DecodeInitializeMintCloseAuthority       = decode[*InitializeMintCloseAuthority]()
+	DecodeInitializeNonTransferableMint      = decode[*InitializeNonTransferableMint]()
+	DecodeInitializePermanentDelegate        = decode[*InitializePermanentDelegate]()
+	DecodeReallocate                         = decode[*Reallocate]()
+	DecodeUiAmountToAmount                   = decode[*UiAmountToAmount]()
+	DecodeWithdrawExcessLamports             = decode[*WithdrawExcessLamports]()
+	DecodeConfidentialTransferExtension      = decode[*ConfidentialTransferExtension]()
+	DecodeConfidentialTransferFeeExtension   = decode[*ConfidentialTransferFeeExtension]()
+	DecodeConfidentialMintBurnExtension      = decode[*ConfidentialMintBurnExtension]()
+	DecodeTransferFeeExtension               = decode[*TransferFeeExtension]()
+	DecodeDefaultAccountStateExtension       = decode[*DefaultAccountStateExtension]()
+	DecodeMemoTransferExtension              = decode[*MemoTransferExtension]()
+	DecodeInterestBearingMintExtension       = decode[*InterestBearingMintExtension]()
+	DecodeCpiGuardExtension                  = decode[*CpiGuardExtension]()
+	DecodeTransferHookExtension              = decode[*TransferHookExtension]()
+	DecodeMetadataPointerExtension           = decode[*MetadataPointerExtension]()
+	DecodeGroupPointerExtension              = decode[*GroupPointerExtension]()
+	DecodeGroupMemberPointerExtension        = decode[*GroupMemberPointerExtension]()
+	DecodeScaledUiAmountExtension            = decode[*ScaledUiAmountExtension]()
+	DecodePausableExtension                  = decode[*PausableExtension]()
+	DecodeInitializeTransferFeeConfig        = decodeExtension[*InitializeTransferFeeConfig](DecodeTransferFeeExtension)
+	DecodeTransferCheckedWithFee             = decodeExtension[*TransferCheckedWithFee](DecodeTransferFeeExtension)
+	DecodeWithdrawWithheldTokensFromMint     = decodeExtension[*WithdrawWithheldTokensFromMint](DecodeTransferFeeExtension)
+	DecodeWithdrawWithheldTokensFromAccounts = decodeExtension[*WithdrawWithheldTokensFromAccounts](DecodeTransferFeeExtension)
+	DecodeHarvestWithheldTokensToMint        = decodeExtension[*HarvestWithheldTokensToMint](DecodeTransferFeeExtension)
+	DecodeSetTransferFee                     = decodeExtension[*SetTransferFee](DecodeTransferFeeExtension)
+	DecodeInitializeDefaultAccountState      = decodeExtension[*InitializeDefaultAccountState](DecodeDefaultAccountStateExtension)
+	DecodeUpdateDefaultAccountState          = decodeExtension[*UpdateDefaultAccountState](DecodeDefaultAccountStateExtension)
+	DecodeEnableRequiredMemoTransfers        = decodeExtension[*EnableRequiredMemoTransfers](DecodeMemoTransferExtension)
+	DecodeDisableRequiredMemoTransfers       = decodeExtension[*DisableRequiredMemoTransfers](DecodeMemoTransferExtension)
+	DecodeInitializeInterestBearingMint      = decodeExtension[*InitializeInterestBearingMint](DecodeInterestBearingMintExtension)
+	DecodeUpdateInterestBearingMintRate      = decodeExtension[*UpdateInterestBearingMintRate](DecodeInterestBearingMintExtension)
+	DecodeEnableCpiGuard                     = decodeExtension[*EnableCpiGuard](DecodeCpiGuardExtension)
+	DecodeDisableCpiGuard                    = decodeExtension[*DisableCpiGuard](DecodeCpiGuardExtension)
+	DecodeInitializeTransferHook             = decodeExtension[*InitializeTransferHook](DecodeTransferHookExtension)
+	DecodeUpdateTransferHook                 = decodeExtension[*UpdateTransferHook](DecodeTransferHookExtension)
+	DecodeInitializeMetadataPointer          = decodeExtension[*InitializeMetadataPointer](DecodeMetadataPointerExtension)
+	DecodeUpdateMetadataPointer              = decodeExtension[*UpdateMetadataPointer](DecodeMetadataPointerExtension)
+	DecodeInitializeGroupPointer             = decodeExtension[*InitializeGroupPointer](DecodeGroupPointerExtension)
+	DecodeUpdateGroupPointer                 = decodeExtension[*UpdateGroupPointer](DecodeGroupPointerExtension)
+	DecodeInitializeGroupMemberPointer       = decodeExtension[*InitializeGroupMemberPointer](DecodeGroupMemberPointerExtension)
+	DecodeUpdateGroupMemberPointer           = decodeExtension[*UpdateGroupMemberPointer](DecodeGroupMemberPointerExtension)
+	DecodeInitializeScaledUiAmount           = decodeExtension[*InitializeScaledUiAmount](DecodeScaledUiAmountExtension)
+	DecodeUpdateScaledUiAmountMultiplier     = decodeExtension[*UpdateScaledUiAmountMultiplier](DecodeScaledUiAmountExtension)
+	DecodeInitializePausable                 = decodeExtension[*InitializePausable](DecodePausableExtension)
+	DecodePause                              = decodeExtension[*Pause](DecodePausableExtension)
+	DecodeResume                             = decodeExtension[*Resume](DecodePausableExtension)
+	DecodeInitializeTokenMetadata            = decodeInterface[*InitializeTokenMetadata]()
+	DecodeUpdateTokenMetadataField           = decodeInterface[*UpdateTokenMetadataField]()
+	DecodeRemoveTokenMetadataKey             = decodeInterface[*RemoveTokenMetadataKey]()
+	DecodeUpdateTokenMetadataAuthority       = decodeInterface[*UpdateTokenMetadataAuthority]()
+	DecodeEmitTokenMetadata                  = decodeInterface[*EmitTokenMetadata]()
+	DecodeInitializeTokenGroup               = decodeInterface[*InitializeTokenGroup]()
+	DecodeUpdateTokenGroupMaxSize            = decodeInterface[*UpdateTokenGroupMaxSize]()
+	DecodeUpdateTokenGroupAuthority          = decodeInterface[*UpdateTokenGroupAuthority]()
+	DecodeInitializeTokenGroupMember         = decodeInterface[*InitializeTokenGroupMember]()
 )
 
 func decode[T any]() func(*solana.Message, int) (T, error) {
@@ -35,3 +97,54 @@ func decode[T any]() func(*solana.Message, int) (T, error) {
 		DecodeInstruction,
 	)
 }
+
+// decodeExtension decodes the instruction of an extension family
+// and returns the family's own instruction.
+func decodeExtension[T any, E interface{ extensionImpl() interface{} }](
+	decodeFamily func(*solana.Message, int) (E, error),
+) func(*solana.Message, int) (T, error) {
+	return func(msg *solana.Message, index int) (T, error) {
+		var t T
+		family, err := decodeFamily(msg, index)
+		if err != nil {
+			return t, err
+		}
+		v, ok := family.extensionImpl().(T)
+		if !ok {
+			return t, fmt.Errorf("instruction '%d': obtained type '%T' doesn't match expected type '%T'", index, family.extensionImpl(), t)
+		}
+		return v, nil
+	}
+}
+
+// decodeInterface decodes an interface instruction, which is not part
+// of InstructionImplDef.
+func decodeInterface[T any]() func(*solana.Message, int) (T, error) {
+	return func(msg *solana.Message, index int) (T, error) {
+		var t T
+		if len(msg.Instructions) <= index {
+			return t, fmt.Errorf("transaction doesn't have an instruction at index '%d'", index)
+		}
+		instruction := msg.Instructions[index]
+		accs, err := instruction.ResolveInstructionAccounts(msg)
+		if err != nil {
+			return t, fmt.Errorf("instruction '%d': failed to resolve accounts: %w", index, err)
+		}
+		programID, err := msg.ResolveProgramIDIndex(instruction.ProgramIDIndex)
+		if err != nil {
+			return t, fmt.Errorf("instruction '%d': failed to resolve program ID: %w", index, err)
+		}
+		if !programID.Equals(ProgramID) {
+			return t, fmt.Errorf("instruction '%d': programID (%s) doesn't match expected value '%s'", index, programID, ProgramID)
+		}
+		decoded, err := DecodeInstruction(accs, instruction.Data)
+		if err != nil {
+			return t, fmt.Errorf("instruction '%d': failed to decode as '%T': %w", index, t, err)
+		}
+		v, ok := decoded.Impl.(T)
+		if !ok {
+			return t, fmt.Errorf("instruction '%d': obtained type '%T' doesn't match expected type '%T'", index, decoded.Impl, t)
+		}
+		return v, nil
+	}
+}
@@ -174,6 +174,107 @@ const (
 
 	// Like InitializeMint, but does not require the Rent sysvar to be provided.
 	Instruction_InitializeMint2
+
+	// Gets the required size of an account for the given mint as a
+	// little-endian `u64`.
+	//
+	// Return data can be fetched using `sol_get_return_data` and deserializing
+	// the return data as a little-endian `u64`.
+	Instruction_GetAccountDataSize
+
+	// Initialize the Immutable Owner extension for the given token account.
+	//
+	// Fails if the account has already been initialized, so must be called
+	// before `InitializeAccount`.
+	Instruction_InitializeImmutableOwner
+
+	// Convert an Amount of tokens to a UiAmount `string`, using the given
+	// mint.
+	Instruction_AmountToUiAmount
+
+	// Convert a UiAmount of tokens to a little-endian `u64` raw Amount, using
+	// the given mint.
+	Instruction_UiAmountToAmount
+
+	// Initialize the close account authority on a new mint.
+	//
+	// Fails if the mint has already been initialized, so must be called before
+	// `InitializeMint`.
+	Instruction_InitializeMintCloseAuthority
+
+	// The common instruction prefix for Transfer Fee extension instructions.
+	Instruction_TransferFeeExtension
+
+	// The common instruction prefix for Confidential Transfer extension
+	// instructions.
+	Instruction_ConfidentialTransferExtension
+
+	// The common instruction prefix for Default Account State extension
+	// instructions.
+	Instruction_DefaultAccountStateExtension
+
+	// Check to see if a token account is large enough for a list of
+	// ExtensionTypes, and if not, use reallocation to increase the data size.
+	Instruction_Reallocate
+
+	// The common instruction prefix for Memo Transfer account extension
+	// instructions.
+	Instruction_MemoTransferExtension
+
+	// Creates the native mint.
+	//
+	// This instruction only needs to be invoked once after deployment and is
+	// permissionless.
+	Instruction_CreateNativeMint
+
+	// Initialize the non transferable extension for the given mint account.
+	Instruction_InitializeNonTransferableMint
+
+	// The common instruction prefix for Interest Bearing extension
+	// instructions.
+	Instruction_InterestBearingMintExtension
+
+	// The common instruction prefix for CPI Guard account extension
+	// instructions.
+	Instruction_CpiGuardExtension
+
+	// Initialize the permanent delegate on a new mint.
+	Instruction_InitializePermanentDelegate
+
+	// The common instruction prefix for transfer hook extension
+	// instructions.
+	Instruction_TransferHookExtension
+
+	// The common instruction prefix for the confidential transfer fee
+	// extension instructions.
+	Instruction_ConfidentialTransferFeeExtension
+
+	// Withdraw lamports in excess of the rent-exempt minimum from a mint,
+	// token account or multisig account.
+	Instruction_WithdrawExcessLamports
+
+	// The common instruction prefix for metadata pointer extension
+	// instructions.
+	Instruction_MetadataPointerExtension
+
+	// The common instruction prefix for group pointer extension
+	// instructions.
+	Instruction_GroupPointerExtension
+
+	// The common instruction prefix for group member pointer extension
+	// instructions.
+	Instruction_GroupMemberPointerExtension
+
+	// The common instruction prefix for confidential mint-burn extension
+	// instructions.
+	Instruction_ConfidentialMintBurnExtension
+
+	// The common instruction prefix for scaled UI amount extension
+	// instructions.
+	Instruction_ScaledUiAmountExtension
+
+	// The common instruction prefix for pausable extension instructions.
+	Instruction_PausableExtension
 )
 
 // InstructionIDToName returns the name of the instruction given its ID.
@@ -221,6 +322,54 @@ func InstructionIDToName(id uint8) string {
 		return "InitializeMultisig2"
 	case Instruction_InitializeMint2:
 		return "InitializeMint2"
+	case Instruction_GetAccountDataSize:
+		return "GetAccountDataSize"
+	case Instruction_InitializeImmutableOwner:
+		return "InitializeImmutableOwner"
+	case Instruction_AmountToUiAmount:
+		return "AmountToUiAmount"
+	case Instruction_UiAmountToAmount:
+		return "UiAmountToAmount"
+	case Instruction_InitializeMintCloseAuthority:
+		return "InitializeMintCloseAuthority"
+	case Instruction_TransferFeeExtension:
+		return "TransferFeeExtension"
+	case Instruction_ConfidentialTransferExtension:
+		return "ConfidentialTransferExtension"
+	case Instruction_DefaultAccountStateExtension:
+		return "DefaultAccountStateExtension"
+	case Instruction_Reallocate:
+		return "Reallocate"
+	case Instruction_MemoTransferExtension:
+		return "MemoTransferExtension"
+	case Instruction_CreateNativeMint:
+		return "CreateNativeMint"
+	case Instruction_InitializeNonTransferableMint:
+		return "InitializeNonTransferableMint"
+	case Instruction_InterestBearingMintExtension:
+		return "InterestBearingMintExtension"
+	case Instruction_CpiGuardExtension:
+		return "CpiGuardExtension"
+	case Instruction_InitializePermanentDelegate:
+		return "InitializePermanentDelegate"
+	case Instruction_TransferHookExtension:
+		return "TransferHookExtension"
+	case Instruction_ConfidentialTransferFeeExtension:
+		return "ConfidentialTransferFeeExtension"
+	case Instruction_WithdrawExcessLamports:
+		return "WithdrawExcessLamports"
+	case Instruction_MetadataPointerExtension:
+		return "MetadataPointerExtension"
+	case Instruction_GroupPointerExtension:
+		return "GroupPointerExtension"
+	case Instruction_GroupMemberPointerExtension:
+		return "GroupMemberPointerExtension"
+	case Instruction_ConfidentialMintBurnExtension:
+		return "ConfidentialMintBurnExtension"
+	case Instruction_ScaledUiAmountExtension:
+		return "ScaledUiAmountExtension"
+	case Instruction_PausableExtension:
+		return "PausableExtension"
 	default:
 		return ""
 	}
@@ -304,6 +453,78 @@ var InstructionImplDef = ag_binary.NewVariantDefinition(
 		{
 			"InitializeMint2", (*InitializeMint2)(nil),
 		},
+		{
+			"GetAccountDataSize", (*GetAccountDataSize)(nil),
+		},
+		{
+			"InitializeImmutableOwner", (*InitializeImmutableOwner)(nil),
+		},
+		{
+			"AmountToUiAmount", (*AmountToUiAmount)(nil),
+		},
+		{
+			"UiAmountToAmount", (*UiAmountToAmount)(nil),
+		},
+		{
+			"InitializeMintCloseAuthority", (*InitializeMintCloseAuthority)(nil),
+		},
+		{
+			"TransferFeeExtension", (*TransferFeeExtension)(nil),
+		},
+		{
+			"ConfidentialTransferExtension", (*ConfidentialTransferExtension)(nil),
+		},
+		{
+			"DefaultAccountStateExtension", (*DefaultAccountStateExtension)(nil),
+		},
+		{
+			"Reallocate", (*Reallocate)(nil),
+		},
+		{
+			"MemoTransferExtension", (*MemoTransferExtension)(nil),
+		},
+		{
+			"CreateNativeMint", (*CreateNativeMint)(nil),
+		},
+		{
+			"InitializeNonTransferableMint", (*InitializeNonTransferableMint)(nil),
+		},
+		{
+			"InterestBearingMintExtension", (*InterestBearingMintExtension)(nil),
+		},
+		{
+			"CpiGuardExtension", (*CpiGuardExtension)(nil),
+		},
+		{
+			"InitializePermanentDelegate", (*InitializePermanentDelegate)(nil),
+		},
+		{
+			"TransferHookExtension", (*TransferHookExtension)(nil),
+		},
+		{
+			"ConfidentialTransferFeeExtension", (*ConfidentialTransferFeeExtension)(nil),
+		},
+		{
+			"WithdrawExcessLamports", (*WithdrawExcessLamports)(nil),
+		},
+		{
+			"MetadataPointerExtension", (*MetadataPointerExtension)(nil),
+		},
+		{
+			"GroupPointerExtension", (*GroupPointerExtension)(nil),
+		},
+		{
+			"GroupMemberPointerExtension", (*GroupMemberPointerExtension)(nil),
+		},
+		{
+			"ConfidentialMintBurnExtension", (*ConfidentialMintBurnExtension)(nil),
+		},
+		{
+			"ScaledUiAmountExtension", (*ScaledUiAmountExtension)(nil),
+		},
+		{
+			"PausableExtension", (*PausableExtension)(nil),
+		},
 	},
 )
 
@@ -340,10 +561,35 @@ func (inst *Instruction) TextEncode(encoder *ag_text.Encoder, option *ag_text.Op
 }
 
 func (inst *Instruction) UnmarshalWithDecoder(decoder *ag_binary.Decoder) error {
+	// The interface instructions are identified by an 8-byte discriminator.
+	if decoder.Remaining() >= 8 {
+		discriminator, err := decoder.PeekDiscriminator()
+		if err != nil {
+			return err
+		}
+		if newImpl, ok := interfaceInstructions[discriminator]; ok {
+			if err := decoder.Discard(8); err != nil {
+				return err
+			}
+			inst.TypeID = discriminator
+			inst.Impl = newImpl()
+			if err := decoder.Decode(inst.Impl); err != nil {
+				return fmt.Errorf("unable to decode interface instruction: %w", err)
+			}
+			return nil
+		}
+	}
 	return inst.BaseVariant.UnmarshalBinaryVariant(decoder, InstructionImplDef)
 }
 
 func (inst Instruction) MarshalWithEncoder(encoder *ag_binary.Encoder) error {
+	if _, ok := interfaceInstructions[inst.TypeID]; ok {
+		err := encoder.WriteBytes(inst.TypeID[:], false)
+		if err != nil {
+			return fmt.Errorf("unable to write interface discriminator: %w", err)
+		}
+		return encoder.Encode(inst.Impl)
+	}
 	err := encoder.WriteUint8(inst.TypeID.Uint8())
 	if err != nil {
 		return fmt.Errorf("unable to write variant type: %w", err)
@@ -351,6 +597,51 @@ func (inst Instruction) MarshalWithEncoder(encoder *ag_binary.Encoder) error {
 	return encoder.Encode(inst.Impl)
 }
 
+// extensionInstruction is embedded by the instructions of an extension
+// family; it holds the extension's own instruction, which is encoded as a
+// one-byte ID followed by its data.
+type extensionInstruction struct {
+	ag_binary.BaseVariant
+}
+
+func (inst *extensionInstruction) EncodeToTree(parent ag_treeout.Branches) {
+	if enToTree, ok := inst.Impl.(ag_text.EncodableToTree); ok {
+		enToTree.EncodeToTree(parent)
+	} else {
+		parent.Child(ag_spew.Sdump(inst))
+	}
+}
+
+func (inst *extensionInstruction) SetAccounts(accounts []*ag_solanago.AccountMeta) error {
+	if v, ok := inst.Impl.(ag_solanago.AccountsSettable); ok {
+		return v.SetAccounts(accounts)
+	}
+	return nil
+}
+
+func (inst extensionInstruction) GetAccounts() []*ag_solanago.AccountMeta {
+	if v, ok := inst.Impl.(ag_solanago.AccountsGettable); ok {
+		return v.GetAccounts()
+	}
+	return nil
+}
+
+func (inst *extensionInstruction) extensionImpl() interface{} {
+	return inst.Impl
+}
+
+func (inst *extensionInstruction) TextEncode(encoder *ag_text.Encoder, option *ag_text.Option) error {
+	return encoder.Encode(inst.Impl, option)
+}
+
+func (inst extensionInstruction) MarshalWithEncoder(encoder *ag_binary.Encoder) error {
+	err := encoder.WriteUint8(inst.TypeID.Uint8())
+	if err != nil {
+		return fmt.Errorf("unable to write extension instruction type: %w", err)
+	}
+	return encoder.Encode(inst.Impl)
+}
+
 func registryDecodeInstruction(accounts []*ag_solanago.AccountMeta, data []byte) (interface{}, error) {
 	inst, err := DecodeInstruction(accounts, data)
 	if err != nil {
@@ -0,0 +1,164 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package token2022
+
+import (
+	"crypto/sha256"
+	"fmt"
+
+	ag_binary "github.com/gagliardetto/binary"
+)
+
+// interfaceDiscriminator returns the discriminator of an instruction of an
+// SPL interface: the first 8 bytes of the hash of its namespaced name.
+func interfaceDiscriminator(name string) ag_binary.TypeID {
+	sum := sha256.Sum256([]byte(name))
+	return ag_binary.TypeIDFromBytes(sum[:8])
+}
+
+// Instructions of the spl-token-metadata-interface, identified by an
+// 8-byte discriminator. The token program handles them for mints that
+// store their metadata on the mint itself.
+var (
+	// Initializes a TLV entry with the basic token-metadata fields.
+	TokenMetadata_Initialize = interfaceDiscriminator("spl_token_metadata_interface:initialize_account")
+
+	// Updates a field in a token-metadata account.
+	TokenMetadata_UpdateField = interfaceDiscriminator("spl_token_metadata_interface:updating_field")
+
+	// Removes a key-value pair in a token-metadata account.
+	TokenMetadata_RemoveKey = interfaceDiscriminator("spl_token_metadata_interface:remove_key_ix")
+
+	// Updates the token-metadata authority.
+	TokenMetadata_UpdateAuthority = interfaceDiscriminator("spl_token_metadata_interface:update_the_authority")
+
+	// Emits the token-metadata as return data.
+	TokenMetadata_Emit = interfaceDiscriminator("spl_token_metadata_interface:emitter")
+)
+
+// TokenMetadataFieldType is the type of a token-metadata field.
+type TokenMetadataFieldType uint8
+
+const (
+	// The name field, corresponding to `TokenMetadata.Name`.
+	TokenMetadataFieldName TokenMetadataFieldType = iota
+	// The symbol field, corresponding to `TokenMetadata.Symbol`.
+	TokenMetadataFieldSymbol
+	// The URI field, corresponding to `TokenMetadata.URI`.
+	TokenMetadataFieldURI
+	// A user field, whose key is given by the associated string.
+	TokenMetadataFieldKey
+)
+
+// TokenMetadataField is a field of the token-metadata that can be updated.
+type TokenMetadataField struct {
+	Type TokenMetadataFieldType
+
+	// Set when Type is TokenMetadataFieldKey.
+	Key string
+}
+
+// NewTokenMetadataKeyField returns the additional metadata field with the given key.
+func NewTokenMetadataKeyField(key string) TokenMetadataField {
+	return TokenMetadataField{Type: TokenMetadataFieldKey, Key: key}
+}
+
+func (field TokenMetadataField) String() string {
+	switch field.Type {
+	case TokenMetadataFieldName:
+		return "name"
+	case TokenMetadataFieldSymbol:
+		return "symbol"
+	case TokenMetadataFieldURI:
+		return "uri"
+	case TokenMetadataFieldKey:
+		return field.Key
+	default:
+		return fmt.Sprintf("Unknown(%d)", uint8(field.Type))
+	}
+}
+
+func (field *TokenMetadataField) UnmarshalWithDecoder(dec *ag_binary.Decoder) (err error) {
+	typ, err := dec.ReadUint8()
+	if err != nil {
+		return err
+	}
+	field.Type = TokenMetadataFieldType(typ)
+	field.Key = ""
+	switch field.Type {
+	case TokenMetadataFieldName, TokenMetadataFieldSymbol, TokenMetadataFieldURI:
+		return nil
+	case TokenMetadataFieldKey:
+		field.Key, err = readBorshString(dec)
+		return err
+	default:
+		return fmt.Errorf("invalid token-metadata field type: %d", typ)
+	}
+}
+
+func (field TokenMetadataField) MarshalWithEncoder(encoder *ag_binary.Encoder) (err error) {
+	if err = encoder.WriteUint8(uint8(field.Type)); err != nil {
+		return err
+	}
+	switch field.Type {
+	case TokenMetadataFieldName, TokenMetadataFieldSymbol, TokenMetadataFieldURI:
+		return nil
+	case TokenMetadataFieldKey:
+		return writeBorshString(encoder, field.Key)
+	default:
+		return fmt.Errorf("invalid token-metadata field type: %d", field.Type)
+	}
+}
+
+// DecodeTokenMetadata decodes token-metadata, as found in the
+// `TokenMetadata` extension of a mint or emitted by `EmitTokenMetadata`.
+func DecodeTokenMetadata(data []byte) (*TokenMetadata, error) {
+	metadata := new(TokenMetadata)
+	if err := metadata.UnmarshalWithDecoder(ag_binary.NewBinDecoder(data)); err != nil {
+		return nil, fmt.Errorf("unable to decode token metadata: %w", err)
+	}
+	return metadata, nil
+}
+
+// Instructions of the spl-token-group-interface, identified by an 8-byte
+// discriminator.
+var (
+	// Initialize a new `Group`.
+	TokenGroup_InitializeGroup = interfaceDiscriminator("spl_token_group_interface:initialize_token_group")
+
+	// Update the max size of a `Group`.
+	TokenGroup_UpdateGroupMaxSize = interfaceDiscriminator("spl_token_group_interface:update_group_max_size")
+
+	// Update the authority of a `Group`.
+	TokenGroup_UpdateGroupAuthority = interfaceDiscriminator("spl_token_group_interface:update_authority")
+
+	// Initialize a new `Member` of a `Group`.
+	TokenGroup_InitializeMember = interfaceDiscriminator("spl_token_group_interface:initialize_member")
+)
+
+// interfaceInstructions maps the discriminators of the interface
+// instructions, which the program recognizes ahead of its own, to
+// constructors of their types.
+var interfaceInstructions = map[ag_binary.TypeID]func() interface{}{
+	TokenMetadata_Initialize:        func() interface{} { return new(InitializeTokenMetadata) },
+	TokenMetadata_UpdateField:       func() interface{} { return new(UpdateTokenMetadataField) },
+	TokenMetadata_RemoveKey:         func() interface{} { return new(RemoveTokenMetadataKey) },
+	TokenMetadata_UpdateAuthority:   func() interface{} { return new(UpdateTokenMetadataAuthority) },
+	TokenMetadata_Emit:              func() interface{} { return new(EmitTokenMetadata) },
+	TokenGroup_InitializeGroup:      func() interface{} { return new(InitializeTokenGroup) },
+	TokenGroup_UpdateGroupMaxSize:   func() interface{} { return new(UpdateTokenGroupMaxSize) },
+	TokenGroup_UpdateGroupAuthority: func() interface{} { return new(UpdateTokenGroupAuthority) },
+	TokenGroup_InitializeMember:     func() interface{} { return new(InitializeTokenGroupMember) },
+}
@@ -0,0 +1,176 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package token2022
+
+import (
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInterfaceDiscriminators(t *testing.T) {
+	require.Equal(t, []byte{210, 225, 30, 162, 88, 184, 77, 141}, TokenMetadata_Initialize[:])
+	require.Equal(t, []byte{250, 166, 180, 250, 13, 12, 184, 70}, TokenMetadata_Emit[:])
+	require.Equal(t, []byte{121, 113, 108, 39, 54, 51, 0, 4}, TokenGroup_InitializeGroup[:])
+}
+
+func TestInitializeTokenMetadata(t *testing.T) {
+	metadata := solana.NewWallet().PublicKey()
+	authority := solana.NewWallet().PublicKey()
+	mint := solana.NewWallet().PublicKey()
+
+	inst, err := NewInitializeTokenMetadataInstruction("Example", "EX", "https://example.com/ex.json", metadata, authority, mint, authority).ValidateAndBuild()
+	require.NoError(t, err)
+
+	data, err := inst.Data()
+	require.NoError(t, err)
+	var expected []byte
+	expected = append(expected, TokenMetadata_Initialize[:]...)
+	expected = append(expected, borshString("Example")...)
+	expected = append(expected, borshString("EX")...)
+	expected = append(expected, borshString("https://example.com/ex.json")...)
+	require.Equal(t, expected, data)
+
+	decoded, err := DecodeInstruction(inst.Accounts(), data)
+	require.NoError(t, err)
+	require.Equal(t, TokenMetadata_Initialize, decoded.TypeID)
+	got, ok := decoded.Impl.(*InitializeTokenMetadata)
+	require.True(t, ok)
+	require.Equal(t, "Example", *got.Name)
+	require.Equal(t, "EX", *got.Symbol)
+	require.Equal(t, "https://example.com/ex.json", *got.URI)
+	require.True(t, got.GetMintAuthorityAccount().IsSigner)
+}
+
+func TestTokenInterfaceInstructionData(t *testing.T) {
+	account := solana.NewWallet().PublicKey()
+	authority := solana.NewWallet().PublicKey()
+	mint := solana.NewWallet().PublicKey()
+
+	cases := []struct {
+		name     string
+		inst     *Instruction
+		expected []byte
+	}{
+		{
+			name:     "UpdateTokenMetadataField (name)",
+			inst:     NewUpdateTokenMetadataFieldInstruction(TokenMetadataField{Type: TokenMetadataFieldName}, "New", account, authority).Build(),
+			expected: append(append(TokenMetadata_UpdateField[:], 0), borshString("New")...),
+		},
+		{
+			name:     "UpdateTokenMetadataField (key)",
+			inst:     NewUpdateTokenMetadataFieldInstruction(NewTokenMetadataKeyField("color"), "blue", account, authority).Build(),
+			expected: append(append(append(TokenMetadata_UpdateField[:], 3), borshString("color")...), borshString("blue")...),
+		},
+		{
+			name:     "RemoveTokenMetadataKey",
+			inst:     NewRemoveTokenMetadataKeyInstruction(true, "color", account, authority).Build(),
+			expected: append(append(TokenMetadata_RemoveKey[:], 1), borshString("color")...),
+		},
+		{
+			name:     "UpdateTokenMetadataAuthority",
+			inst:     NewUpdateTokenMetadataAuthorityInstructionBuilder().SetMetadataAccount(account).SetUpdateAuthorityAccount(authority).Build(),
+			expected: append(TokenMetadata_UpdateAuthority[:], make([]byte, 32)...),
+		},
+		{
+			name:     "EmitTokenMetadata",
+			inst:     NewEmitTokenMetadataInstructionBuilder().SetStart(10).SetMetadataAccount(account).Build(),
+			expected: append(append(TokenMetadata_Emit[:], 1), append(u64(10), 0)...),
+		},
+		{
+			name:     "InitializeTokenGroup",
+			inst:     NewInitializeTokenGroupInstruction(authority, 100, account, mint, authority).Build(),
+			expected: append(append(TokenGroup_InitializeGroup[:], authority[:]...), u64(100)...),
+		},
+		{
+			name:     "UpdateTokenGroupMaxSize",
+			inst:     NewUpdateTokenGroupMaxSizeInstruction(200, account, authority).Build(),
+			expected: append(TokenGroup_UpdateGroupMaxSize[:], u64(200)...),
+		},
+		{
+			name:     "UpdateTokenGroupAuthority",
+			inst:     NewUpdateTokenGroupAuthorityInstruction(mint, account, authority).Build(),
+			expected: append(TokenGroup_UpdateGroupAuthority[:], mint[:]...),
+		},
+		{
+			name:     "InitializeTokenGroupMember",
+			inst:     NewInitializeTokenGroupMemberInstruction(account, mint, authority, account, authority).Build(),
+			expected: TokenGroup_InitializeMember[:],
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			data, err := c.inst.Data()
+			require.NoError(t, err)
+			require.Equal(t, c.expected, data)
+
+			decoded, err := DecodeInstruction(c.inst.Accounts(), data)
+			require.NoError(t, err)
+			require.Equal(t, c.inst.TypeID, decoded.TypeID)
+			redata, err := decoded.Data()
+			require.NoError(t, err)
+			require.Equal(t, data, redata)
+		})
+	}
+}
+
+func TestDecodeTokenMetadata(t *testing.T) {
+	authority := solana.NewWallet().PublicKey()
+	mint := solana.NewWallet().PublicKey()
+
+	var data []byte
+	data = append(data, authority[:]...)
+	data = append(data, mint[:]...)
+	data = append(data, borshString("Example")...)
+	data = append(data, borshString("EX")...)
+	data = append(data, borshString("https://example.com/ex.json")...)
+	data = append(data, 2, 0, 0, 0)
+	data = append(data, borshString("color")...)
+	data = append(data, borshString("blue")...)
+	data = append(data, borshString("size")...)
+	data = append(data, borshString("")...)
+
+	metadata, err := DecodeTokenMetadata(data)
+	require.NoError(t, err)
+	require.Equal(t, authority, *metadata.UpdateAuthority)
+	require.Equal(t, mint, metadata.Mint)
+	require.Equal(t, "EX", metadata.Symbol)
+	require.Equal(t, []MetadataField{{Key: "color", Value: "blue"}, {Key: "size", Value: ""}}, metadata.AdditionalMetadata)
+
+	_, err = DecodeTokenMetadata(data[:len(data)-5])
+	require.Error(t, err)
+}
+
+func TestDecodeInterfaceInstruction(t *testing.T) {
+	group := solana.NewWallet().PublicKey()
+	authority := solana.NewWallet().PublicKey()
+
+	tx, err := solana.NewTransaction(
+		[]solana.Instruction{
+			NewUpdateTokenGroupMaxSizeInstruction(5, group, authority).Build(),
+		},
+		solana.Hash{},
+		solana.TransactionPayer(authority),
+	)
+	require.NoError(t, err)
+
+	got, err := DecodeUpdateTokenGroupMaxSize(&tx.Message, 0)
+	require.NoError(t, err)
+	require.Equal(t, uint64(5), *got.MaxSize)
+
+	_, err = DecodeInitializeTokenGroupMember(&tx.Message, 0)
+	require.Error(t, err)
+}
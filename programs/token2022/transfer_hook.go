@@ -0,0 +1,572 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package token2022
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	bin "github.com/gagliardetto/binary"
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+)
+
+// ExtraAccountMetasSeed is the seed of the account holding the
+// ExtraAccountMetaList of a mint, derived from the transfer hook program.
+const ExtraAccountMetasSeed = "extra-account-metas"
+
+// TransferHookExecuteDiscriminator is the instruction discriminator of the
+// transfer hook interface's `Execute` instruction; it is also the TLV type
+// of the ExtraAccountMetaList used to validate it.
+var TransferHookExecuteDiscriminator = func() [8]byte {
+	var out [8]byte
+	sum := sha256.Sum256([]byte("spl-transfer-hook-interface:execute"))
+	copy(out[:], sum[:8])
+	return out
+}()
+
+// FindExtraAccountMetaListAddress returns the address of the account
+// that holds the extra account metas of the mint for the given
+// transfer hook program.
+func FindExtraAccountMetaListAddress(mint solana.PublicKey, hookProgramID solana.PublicKey) (solana.PublicKey, uint8, error) {
+	return solana.FindProgramAddress(
+		[][]byte{
+			[]byte(ExtraAccountMetasSeed),
+			mint[:],
+		},
+		hookProgramID,
+	)
+}
+
+// AccountDataFetcher returns the data of the account at the given address,
+// or nil if the account does not exist.
+type AccountDataFetcher func(ctx context.Context, address solana.PublicKey) ([]byte, error)
+
+// NewRPCAccountDataFetcher returns an AccountDataFetcher that fetches
+// accounts with the given RPC client.
+func NewRPCAccountDataFetcher(rpcClient *rpc.Client) AccountDataFetcher {
+	return func(ctx context.Context, address solana.PublicKey) ([]byte, error) {
+		account, err := rpcClient.GetAccountInfo(ctx, address)
+		if err != nil {
+			if errors.Is(err, rpc.ErrNotFound) {
+				return nil, nil
+			}
+			return nil, err
+		}
+		if account == nil || account.Value == nil {
+			return nil, nil
+		}
+		return account.GetBinary(), nil
+	}
+}
+
+// SeedType is the type of a seed used to derive the address of an extra account.
+type SeedType uint8
+
+const (
+	// Marks the end of the seeds of an address config.
+	SeedUninitialized SeedType = iota
+	// A literal seed.
+	SeedLiteral
+	// A seed taken from the instruction data.
+	SeedInstructionData
+	// The public key of an account of the instruction.
+	SeedAccountKey
+	// A seed taken from the data of an account of the instruction.
+	SeedAccountData
+)
+
+// Seed is a seed of an extra account address, packed into the
+// address config of an ExtraAccountMeta.
+type Seed struct {
+	Type SeedType
+
+	// Set when Type is SeedLiteral.
+	Bytes []byte
+
+	// The index of the account (SeedAccountKey, SeedAccountData),
+	// or of the first byte in the instruction data (SeedInstructionData).
+	Index uint8
+
+	// The index of the first byte in the account data (SeedAccountData).
+	DataIndex uint8
+
+	// The number of bytes (SeedInstructionData, SeedAccountData).
+	Length uint8
+}
+
+// UnpackSeeds unpacks the seeds of an address config.
+func UnpackSeeds(config [32]byte) ([]Seed, error) {
+	var seeds []Seed
+	for i := 0; i < len(config); {
+		typ := SeedType(config[i])
+		if typ == SeedUninitialized {
+			break
+		}
+		rest := config[i+1:]
+		switch typ {
+		case SeedLiteral:
+			if len(rest) < 1 || len(rest) < 1+int(rest[0]) {
+				return nil, fmt.Errorf("invalid literal seed at offset %d", i)
+			}
+			length := int(rest[0])
+			seeds = append(seeds, Seed{Type: typ, Bytes: append([]byte(nil), rest[1:1+length]...)})
+			i += 2 + length
+		case SeedInstructionData:
+			if len(rest) < 2 {
+				return nil, fmt.Errorf("invalid instruction data seed at offset %d", i)
+			}
+			seeds = append(seeds, Seed{Type: typ, Index: rest[0], Length: rest[1]})
+			i += 3
+		case SeedAccountKey:
+			if len(rest) < 1 {
+				return nil, fmt.Errorf("invalid account key seed at offset %d", i)
+			}
+			seeds = append(seeds, Seed{Type: typ, Index: rest[0]})
+			i += 2
+		case SeedAccountData:
+			if len(rest) < 3 {
+				return nil, fmt.Errorf("invalid account data seed at offset %d", i)
+			}
+			seeds = append(seeds, Seed{Type: typ, Index: rest[0], DataIndex: rest[1], Length: rest[2]})
+			i += 4
+		default:
+			return nil, fmt.Errorf("unknown seed type %d at offset %d", typ, i)
+		}
+	}
+	return seeds, nil
+}
+
+// PackSeeds packs seeds into an address config.
+func PackSeeds(seeds ...Seed) (config [32]byte, err error) {
+	buf := make([]byte, 0, len(config))
+	for _, seed := range seeds {
+		switch seed.Type {
+		case SeedLiteral:
+			if len(seed.Bytes) > 255 {
+				return config, fmt.Errorf("literal seed too long: %d", len(seed.Bytes))
+			}
+			buf = append(buf, byte(seed.Type), byte(len(seed.Bytes)))
+			buf = append(buf, seed.Bytes...)
+		case SeedInstructionData:
+			buf = append(buf, byte(seed.Type), seed.Index, seed.Length)
+		case SeedAccountKey:
+			buf = append(buf, byte(seed.Type), seed.Index)
+		case SeedAccountData:
+			buf = append(buf, byte(seed.Type), seed.Index, seed.DataIndex, seed.Length)
+		default:
+			return config, fmt.Errorf("unknown seed type %d", seed.Type)
+		}
+		if len(buf) > len(config) {
+			return config, fmt.Errorf("seeds do not fit in an address config")
+		}
+	}
+	copy(config[:], buf)
+	return config, nil
+}
+
+const (
+	// The address config holds the public key of the account.
+	ExtraAccountMetaLiteral uint8 = 0
+	// The address config holds the seeds of a PDA of the transfer hook program.
+	ExtraAccountMetaPDA uint8 = 1
+	// The address config locates a public key in instruction or account data.
+	ExtraAccountMetaPubkeyData uint8 = 2
+	// Discriminators from this value onwards mark a PDA of the program
+	// at account index (discriminator - ExtraAccountMetaExternalPDA).
+	ExtraAccountMetaExternalPDA uint8 = 1 << 7
+)
+
+const (
+	// The public key is taken from the instruction data.
+	PubkeyDataInstructionData uint8 = 1
+	// The public key is taken from the data of an account of the instruction.
+	PubkeyDataAccountData uint8 = 2
+)
+
+// ExtraAccountMeta describes how to resolve an extra account required by
+// a transfer hook program.
+type ExtraAccountMeta struct {
+	// How to interpret AddressConfig; see the ExtraAccountMeta* constants.
+	Discriminator uint8
+	AddressConfig [32]byte
+	IsSigner      bool
+	IsWritable    bool
+}
+
+const extraAccountMetaSize = 1 + 32 + 1 + 1
+
+func (meta *ExtraAccountMeta) UnmarshalWithDecoder(dec *bin.Decoder) (err error) {
+	if meta.Discriminator, err = dec.ReadUint8(); err != nil {
+		return err
+	}
+	v, err := dec.ReadNBytes(32)
+	if err != nil {
+		return err
+	}
+	copy(meta.AddressConfig[:], v)
+	if meta.IsSigner, err = dec.ReadBool(); err != nil {
+		return err
+	}
+	meta.IsWritable, err = dec.ReadBool()
+	return err
+}
+
+func (meta ExtraAccountMeta) MarshalWithEncoder(encoder *bin.Encoder) (err error) {
+	if err = encoder.WriteUint8(meta.Discriminator); err != nil {
+		return err
+	}
+	if err = encoder.WriteBytes(meta.AddressConfig[:], false); err != nil {
+		return err
+	}
+	if err = encoder.WriteBool(meta.IsSigner); err != nil {
+		return err
+	}
+	return encoder.WriteBool(meta.IsWritable)
+}
+
+// ExtraAccountMetaList is the list of extra accounts required by the
+// `Execute` instruction of a transfer hook program.
+type ExtraAccountMetaList []ExtraAccountMeta
+
+// DecodeExtraAccountMetaList decodes the `Execute` entry of the TLV data
+// of an extra account metas account.
+func DecodeExtraAccountMetaList(data []byte) (ExtraAccountMetaList, error) {
+	for offset := 0; offset < len(data); {
+		if len(data)-offset < 12 {
+			return nil, fmt.Errorf("invalid TLV entry at offset %d", offset)
+		}
+		discriminator := data[offset : offset+8]
+		length := int(binary.LittleEndian.Uint32(data[offset+8 : offset+12]))
+		start := offset + 12
+		if len(data)-start < length {
+			return nil, fmt.Errorf("TLV entry at offset %d overflows the data", offset)
+		}
+		if string(discriminator) != string(TransferHookExecuteDiscriminator[:]) {
+			offset = start + length
+			continue
+		}
+		value := data[start : start+length]
+		if len(value) < 4 {
+			return nil, fmt.Errorf("invalid extra account metas length")
+		}
+		count := int(binary.LittleEndian.Uint32(value[:4]))
+		if len(value)-4 < count*extraAccountMetaSize {
+			return nil, fmt.Errorf("expected %d extra account metas, but data is too short", count)
+		}
+		dec := bin.NewBinDecoder(value[4:])
+		list := make(ExtraAccountMetaList, count)
+		for i := range list {
+			if err := list[i].UnmarshalWithDecoder(dec); err != nil {
+				return nil, fmt.Errorf("unable to decode extra account meta %d: %w", i, err)
+			}
+		}
+		return list, nil
+	}
+	return nil, fmt.Errorf("no extra account metas found for the execute instruction")
+}
+
+// MarshalWithEncoder encodes the list as the TLV data of an extra account
+// metas account.
+func (list ExtraAccountMetaList) MarshalWithEncoder(encoder *bin.Encoder) (err error) {
+	if err = encoder.WriteBytes(TransferHookExecuteDiscriminator[:], false); err != nil {
+		return err
+	}
+	if err = encoder.WriteUint32(uint32(4+len(list)*extraAccountMetaSize), binary.LittleEndian); err != nil {
+		return err
+	}
+	if err = encoder.WriteUint32(uint32(len(list)), binary.LittleEndian); err != nil {
+		return err
+	}
+	for _, meta := range list {
+		if err = meta.MarshalWithEncoder(encoder); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// extraAccountResolver resolves extra account metas against an instruction,
+// fetching (and caching) account data as needed by the seeds.
+type extraAccountResolver struct {
+	ctx       context.Context
+	fetch     AccountDataFetcher
+	data      []byte
+	programID solana.PublicKey
+	accounts  []*solana.AccountMeta
+	cache     map[solana.PublicKey][]byte
+}
+
+func (r *extraAccountResolver) account(index uint8) (*solana.AccountMeta, error) {
+	if int(index) >= len(r.accounts) {
+		return nil, fmt.Errorf("account index %d out of range", index)
+	}
+	return r.accounts[index], nil
+}
+
+func (r *extraAccountResolver) accountData(index uint8) ([]byte, error) {
+	account, err := r.account(index)
+	if err != nil {
+		return nil, err
+	}
+	if data, ok := r.cache[account.PublicKey]; ok {
+		return data, nil
+	}
+	data, err := r.fetch(r.ctx, account.PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch account %s: %w", account.PublicKey, err)
+	}
+	if data == nil {
+		return nil, fmt.Errorf("account %s not found", account.PublicKey)
+	}
+	r.cache[account.PublicKey] = data
+	return data, nil
+}
+
+func sliceData(data []byte, index, length int) ([]byte, error) {
+	if index+length > len(data) {
+		return nil, fmt.Errorf("data too short: need %d bytes at offset %d, but have %d", length, index, len(data))
+	}
+	return data[index : index+length], nil
+}
+
+func (r *extraAccountResolver) seeds(config [32]byte) ([][]byte, error) {
+	seeds, err := UnpackSeeds(config)
+	if err != nil {
+		return nil, err
+	}
+	out := make([][]byte, 0, len(seeds))
+	for _, seed := range seeds {
+		switch seed.Type {
+		case SeedLiteral:
+			out = append(out, seed.Bytes)
+		case SeedInstructionData:
+			v, err := sliceData(r.data, int(seed.Index), int(seed.Length))
+			if err != nil {
+				return nil, fmt.Errorf("instruction data seed: %w", err)
+			}
+			out = append(out, v)
+		case SeedAccountKey:
+			account, err := r.account(seed.Index)
+			if err != nil {
+				return nil, fmt.Errorf("account key seed: %w", err)
+			}
+			out = append(out, account.PublicKey.Bytes())
+		case SeedAccountData:
+			data, err := r.accountData(seed.Index)
+			if err != nil {
+				return nil, fmt.Errorf("account data seed: %w", err)
+			}
+			v, err := sliceData(data, int(seed.DataIndex), int(seed.Length))
+			if err != nil {
+				return nil, fmt.Errorf("account data seed: %w", err)
+			}
+			out = append(out, v)
+		}
+	}
+	return out, nil
+}
+
+func (r *extraAccountResolver) resolve(meta ExtraAccountMeta) (*solana.AccountMeta, error) {
+	var address solana.PublicKey
+	switch {
+	case meta.Discriminator == ExtraAccountMetaLiteral:
+		address = solana.PublicKeyFromBytes(meta.AddressConfig[:])
+	case meta.Discriminator == ExtraAccountMetaPDA:
+		seeds, err := r.seeds(meta.AddressConfig)
+		if err != nil {
+			return nil, err
+		}
+		if address, _, err = solana.FindProgramAddress(seeds, r.programID); err != nil {
+			return nil, err
+		}
+	case meta.Discriminator == ExtraAccountMetaPubkeyData:
+		var (
+			v   []byte
+			err error
+		)
+		switch meta.AddressConfig[0] {
+		case PubkeyDataInstructionData:
+			v, err = sliceData(r.data, int(meta.AddressConfig[1]), solana.PublicKeyLength)
+		case PubkeyDataAccountData:
+			var data []byte
+			if data, err = r.accountData(meta.AddressConfig[1]); err == nil {
+				v, err = sliceData(data, int(meta.AddressConfig[2]), solana.PublicKeyLength)
+			}
+		default:
+			err = fmt.Errorf("unknown pubkey data type %d", meta.AddressConfig[0])
+		}
+		if err != nil {
+			return nil, fmt.Errorf("pubkey data: %w", err)
+		}
+		address = solana.PublicKeyFromBytes(v)
+	case meta.Discriminator >= ExtraAccountMetaExternalPDA:
+		program, err := r.account(meta.Discriminator - ExtraAccountMetaExternalPDA)
+		if err != nil {
+			return nil, fmt.Errorf("external PDA program: %w", err)
+		}
+		seeds, err := r.seeds(meta.AddressConfig)
+		if err != nil {
+			return nil, err
+		}
+		if address, _, err = solana.FindProgramAddress(seeds, program.PublicKey); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unknown extra account meta discriminator %d", meta.Discriminator)
+	}
+
+	resolved := solana.NewAccountMeta(address, meta.IsWritable, meta.IsSigner)
+	// An extra account never gets more privileges than the same account
+	// already has in the instruction.
+	found, isSigner, isWritable := false, false, false
+	for _, account := range r.accounts {
+		if account.PublicKey.Equals(address) {
+			found = true
+			isSigner = isSigner || account.IsSigner
+			isWritable = isWritable || account.IsWritable
+		}
+	}
+	if found {
+		resolved.IsSigner = resolved.IsSigner && isSigner
+		resolved.IsWritable = resolved.IsWritable && isWritable
+	}
+	return resolved, nil
+}
+
+// Resolve resolves the extra accounts for an instruction with the given
+// data and accounts, sent to the given program. Each resolved account is
+// visible (by index) to the seeds of the following ones.
+func (list ExtraAccountMetaList) Resolve(
+	ctx context.Context,
+	fetch AccountDataFetcher,
+	programID solana.PublicKey,
+	data []byte,
+	accounts []*solana.AccountMeta,
+) ([]*solana.AccountMeta, error) {
+	r := &extraAccountResolver{
+		ctx:       ctx,
+		fetch:     fetch,
+		data:      data,
+		programID: programID,
+		accounts:  append([]*solana.AccountMeta(nil), accounts...),
+		cache:     make(map[solana.PublicKey][]byte),
+	}
+	extra := make([]*solana.AccountMeta, 0, len(list))
+	for i, meta := range list {
+		resolved, err := r.resolve(meta)
+		if err != nil {
+			return nil, fmt.Errorf("unable to resolve extra account %d: %w", i, err)
+		}
+		r.accounts = append(r.accounts, resolved)
+		extra = append(extra, resolved)
+	}
+	return extra, nil
+}
+
+// ResolveTransferHookAccounts returns the accounts to append to a transfer
+// of tokens of a mint with the given transfer hook program: the resolved
+// extra accounts, the hook program and its extra account metas account.
+func ResolveTransferHookAccounts(
+	ctx context.Context,
+	fetch AccountDataFetcher,
+	hookProgramID solana.PublicKey,
+	source solana.PublicKey,
+	mint solana.PublicKey,
+	destination solana.PublicKey,
+	owner solana.PublicKey,
+	amount uint64,
+) ([]*solana.AccountMeta, error) {
+	validation, _, err := FindExtraAccountMetaListAddress(mint, hookProgramID)
+	if err != nil {
+		return nil, err
+	}
+	validationData, err := fetch(ctx, validation)
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch extra account metas %s: %w", validation, err)
+	}
+
+	var out []*solana.AccountMeta
+	if validationData != nil {
+		list, err := DecodeExtraAccountMetaList(validationData)
+		if err != nil {
+			return nil, fmt.Errorf("unable to decode extra account metas %s: %w", validation, err)
+		}
+		// The seeds are evaluated against the `Execute` instruction
+		// that the token program sends to the hook program.
+		data := make([]byte, 0, 16)
+		data = append(data, TransferHookExecuteDiscriminator[:]...)
+		data = binary.LittleEndian.AppendUint64(data, amount)
+		accounts := []*solana.AccountMeta{
+			solana.Meta(source),
+			solana.Meta(mint),
+			solana.Meta(destination),
+			solana.Meta(owner),
+			solana.Meta(validation),
+		}
+		if out, err = list.Resolve(ctx, fetch, hookProgramID, data, accounts); err != nil {
+			return nil, err
+		}
+	}
+	out = append(out,
+		solana.Meta(hookProgramID),
+		solana.Meta(validation),
+	)
+	return out, nil
+}
+
+// NewTransferCheckedInstructionWithExtraMetas declares a new TransferChecked
+// instruction and, if the mint has a transfer hook program, appends the
+// extra accounts required by it.
+func NewTransferCheckedInstructionWithExtraMetas(
+	ctx context.Context,
+	fetch AccountDataFetcher,
+	// Parameters:
+	amount uint64,
+	decimals uint8,
+	// Accounts:
+	source solana.PublicKey,
+	mint solana.PublicKey,
+	destination solana.PublicKey,
+	owner solana.PublicKey,
+	multisigSigners []solana.PublicKey,
+) (*TransferChecked, error) {
+	inst := NewTransferCheckedInstruction(amount, decimals, source, mint, destination, owner, multisigSigners)
+
+	mintData, err := fetch(ctx, mint)
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch mint %s: %w", mint, err)
+	}
+	if mintData == nil {
+		return nil, fmt.Errorf("mint %s not found", mint)
+	}
+	var decoded Mint
+	if err := bin.NewBinDecoder(mintData).Decode(&decoded); err != nil {
+		return nil, fmt.Errorf("unable to decode mint %s: %w", mint, err)
+	}
+	hook, ok := decoded.GetExtension(ExtensionTransferHook).(*TransferHook)
+	if !ok || hook.ProgramID == nil {
+		return inst, nil
+	}
+
+	extra, err := ResolveTransferHookAccounts(ctx, fetch, *hook.ProgramID, source, mint, destination, owner, amount)
+	if err != nil {
+		return nil, err
+	}
+	return inst.AddExtraAccounts(extra...), nil
+}
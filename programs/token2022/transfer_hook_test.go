@@ -0,0 +1,185 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package token2022
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	bin "github.com/gagliardetto/binary"
+	"github.com/gagliardetto/solana-go"
+	"github.com/stretchr/testify/require"
+)
+
+func mapFetcher(accounts map[solana.PublicKey][]byte) AccountDataFetcher {
+	return func(_ context.Context, address solana.PublicKey) ([]byte, error) {
+		return accounts[address], nil
+	}
+}
+
+func mintData(extensions ...[]byte) []byte {
+	data := make([]byte, MINT_SIZE)
+	data[44] = 6 // decimals
+	data[45] = 1 // is_initialized
+	if len(extensions) == 0 {
+		return data
+	}
+	data = append(data, make([]byte, ACCOUNT_SIZE-MINT_SIZE)...)
+	data = append(data, byte(AccountTypeMint))
+	for _, ext := range extensions {
+		data = append(data, ext...)
+	}
+	return data
+}
+
+func TestPackSeeds(t *testing.T) {
+	seeds := []Seed{
+		{Type: SeedLiteral, Bytes: []byte("counter")},
+		{Type: SeedInstructionData, Index: 8, Length: 8},
+		{Type: SeedAccountKey, Index: 1},
+		{Type: SeedAccountData, Index: 0, DataIndex: 32, Length: 32},
+	}
+	config, err := PackSeeds(seeds...)
+	require.NoError(t, err)
+	require.Equal(t, []byte{1, 7, 'c', 'o', 'u', 'n', 't', 'e', 'r', 2, 8, 8, 3, 1, 4, 0, 32, 32, 0}, config[:19])
+
+	got, err := UnpackSeeds(config)
+	require.NoError(t, err)
+	require.Equal(t, seeds, got)
+
+	_, err = PackSeeds(Seed{Type: SeedLiteral, Bytes: make([]byte, 31)})
+	require.Error(t, err)
+}
+
+func TestExtraAccountMetaListEncoding(t *testing.T) {
+	list := ExtraAccountMetaList{
+		{Discriminator: ExtraAccountMetaLiteral, AddressConfig: solana.SystemProgramID, IsWritable: true},
+		{Discriminator: ExtraAccountMetaPDA, IsSigner: true},
+	}
+	buf := new(bytes.Buffer)
+	require.NoError(t, bin.NewBinEncoder(buf).Encode(list))
+	require.Len(t, buf.Bytes(), 8+4+4+2*35)
+	require.Equal(t, TransferHookExecuteDiscriminator[:], buf.Bytes()[:8])
+
+	// Preceded by an unrelated TLV entry:
+	data := append([]byte{1, 2, 3, 4, 5, 6, 7, 8, 2, 0, 0, 0, 9, 9}, buf.Bytes()...)
+	got, err := DecodeExtraAccountMetaList(data)
+	require.NoError(t, err)
+	require.Equal(t, list, got)
+
+	_, err = DecodeExtraAccountMetaList(data[:14])
+	require.Error(t, err)
+}
+
+func TestNewTransferCheckedInstructionWithExtraMetas(t *testing.T) {
+	ctx := context.Background()
+	hookProgram := solana.MustPublicKeyFromBase58("HookX7tfrUjfK5sMKb1ZpJcPuRndxNCt5ZpNCRVmkByA")
+	counterProgram := solana.MustPublicKeyFromBase58("Counter111111111111111111111111111111111111")
+	literal := solana.MustPublicKeyFromBase58("Q6XprfkF8RQQKoQVG33xT88H7wi8Uk1B1CC7YAs69Gi")
+	mint := solana.MustPublicKeyFromBase58("2b1kV6DkPAnxd5ixfnxCpjxmKwqjjaYmCZfHsFu24GXo")
+	source := solana.NewWallet().PublicKey()
+	destination := solana.NewWallet().PublicKey()
+	owner := solana.NewWallet().PublicKey()
+	sourceOwner := solana.NewWallet().PublicKey()
+
+	sourceData := make([]byte, ACCOUNT_SIZE)
+	copy(sourceData[0:32], mint[:])
+	copy(sourceData[32:64], sourceOwner[:])
+
+	pdaSeeds, err := PackSeeds(
+		Seed{Type: SeedLiteral, Bytes: []byte("counter")},
+		Seed{Type: SeedAccountKey, Index: 1},
+	)
+	require.NoError(t, err)
+	externalSeeds, err := PackSeeds(
+		Seed{Type: SeedAccountData, Index: 0, DataIndex: 32, Length: 32},
+		Seed{Type: SeedInstructionData, Index: 8, Length: 8},
+	)
+	require.NoError(t, err)
+	list := ExtraAccountMetaList{
+		{Discriminator: ExtraAccountMetaLiteral, AddressConfig: counterProgram},
+		{Discriminator: ExtraAccountMetaPDA, AddressConfig: pdaSeeds, IsWritable: true},
+		// PDA of the program at index 5, i.e. the first extra account:
+		{Discriminator: ExtraAccountMetaExternalPDA + 5, AddressConfig: externalSeeds, IsWritable: true},
+		// The mint, read from the source account; it is read-only in
+		// the instruction, so it can't be escalated to writable:
+		{Discriminator: ExtraAccountMetaPubkeyData, AddressConfig: [32]byte{PubkeyDataAccountData, 0, 0}, IsWritable: true},
+		{Discriminator: ExtraAccountMetaLiteral, AddressConfig: literal, IsSigner: true},
+	}
+	buf := new(bytes.Buffer)
+	require.NoError(t, bin.NewBinEncoder(buf).Encode(list))
+
+	validation, _, err := FindExtraAccountMetaListAddress(mint, hookProgram)
+	require.NoError(t, err)
+
+	hookExt := append(make([]byte, 32), hookProgram[:]...)
+	fetch := mapFetcher(map[solana.PublicKey][]byte{
+		mint:       mintData(tlv(ExtensionTransferHook, hookExt)),
+		source:     sourceData,
+		validation: buf.Bytes(),
+	})
+
+	inst, err := NewTransferCheckedInstructionWithExtraMetas(ctx, fetch, 1_000, 6, source, mint, destination, owner, nil)
+	require.NoError(t, err)
+	_, err = inst.ValidateAndBuild()
+	require.NoError(t, err)
+
+	counter, _, err := solana.FindProgramAddress([][]byte{[]byte("counter"), mint[:]}, hookProgram)
+	require.NoError(t, err)
+	external, _, err := solana.FindProgramAddress([][]byte{sourceOwner[:], u64(1_000)}, counterProgram)
+	require.NoError(t, err)
+
+	require.Equal(t,
+		[]*solana.AccountMeta{
+			solana.Meta(counterProgram),
+			solana.Meta(counter).WRITE(),
+			solana.Meta(external).WRITE(),
+			solana.Meta(mint),
+			solana.Meta(literal).SIGNER(),
+			solana.Meta(hookProgram),
+			solana.Meta(validation),
+		},
+		[]*solana.AccountMeta(inst.ExtraAccounts),
+	)
+	accounts := inst.Build().Accounts()
+	require.Len(t, accounts, 4+7)
+	require.Equal(t, validation, accounts[len(accounts)-1].PublicKey)
+}
+
+func TestNewTransferCheckedInstructionWithExtraMetas_NoHook(t *testing.T) {
+	ctx := context.Background()
+	mint := solana.NewWallet().PublicKey()
+	source := solana.NewWallet().PublicKey()
+	destination := solana.NewWallet().PublicKey()
+	owner := solana.NewWallet().PublicKey()
+
+	inst, err := NewTransferCheckedInstructionWithExtraMetas(ctx, mapFetcher(map[solana.PublicKey][]byte{
+		mint: mintData(),
+	}), 1, 6, source, mint, destination, owner, nil)
+	require.NoError(t, err)
+	require.Empty(t, inst.ExtraAccounts)
+
+	_, err = NewTransferCheckedInstructionWithExtraMetas(ctx, mapFetcher(nil), 1, 6, source, mint, destination, owner, nil)
+	require.Error(t, err)
+
+	// Without an extra account metas account, only the hook program and
+	// the (missing) validation account are appended:
+	hookProgram := solana.NewWallet().PublicKey()
+	extra, err := ResolveTransferHookAccounts(ctx, mapFetcher(nil), hookProgram, source, mint, destination, owner, 1)
+	require.NoError(t, err)
+	require.Len(t, extra, 2)
+	require.Equal(t, hookProgram, extra[0].PublicKey)
+}
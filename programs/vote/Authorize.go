@@ -15,19 +15,194 @@
 package vote
 
 import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	bin "github.com/gagliardetto/binary"
 	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/text/format"
+	"github.com/gagliardetto/treeout"
 )
 
+// Authorize a key to send votes or issue a withdrawal.
 type Authorize struct {
-	// TODO
+	// New authority
+	NewAuthority *solana.PublicKey
+
+	// Type of authority to change
+	VoteAuthorize *VoteAuthorize
 
 	// [0] = [WRITE] VoteAccount
-	// ··········· Unitialized vote account
+	// ··········· Vote account to be updated with the public key provided for the authority
 	//
-	// [1] = [] SysVarClock
+	// [1] = [] $(SysVarClockPubkey)
 	// ··········· Clock sysvar
 	//
-	// [2] = [SIGNER] Authority
+	// [2] = [SIGNER] AuthorityAccount
 	// ··········· Vote or withdraw authority
 	solana.AccountMetaSlice `bin:"-" borsh_skip:"true"`
 }
+
+// NewAuthorizeInstructionBuilder creates a new `Authorize` instruction builder.
+func NewAuthorizeInstructionBuilder() *Authorize {
+	nd := &Authorize{
+		AccountMetaSlice: make(solana.AccountMetaSlice, 3),
+	}
+	nd.AccountMetaSlice[1] = solana.Meta(solana.SysVarClockPubkey)
+	return nd
+}
+
+// New authority
+func (inst *Authorize) SetNewAuthority(newAuthority solana.PublicKey) *Authorize {
+	inst.NewAuthority = &newAuthority
+	return inst
+}
+
+// Type of authority to change
+func (inst *Authorize) SetVoteAuthorize(voteAuthorize VoteAuthorize) *Authorize {
+	inst.VoteAuthorize = &voteAuthorize
+	return inst
+}
+
+// Vote account to be updated with the public key provided for the authority
+func (inst *Authorize) SetVoteAccount(voteAccount solana.PublicKey) *Authorize {
+	inst.AccountMetaSlice[0] = solana.Meta(voteAccount).WRITE()
+	return inst
+}
+
+func (inst *Authorize) GetVoteAccount() *solana.AccountMeta {
+	return inst.AccountMetaSlice[0]
+}
+
+// Clock sysvar
+func (inst *Authorize) SetSysVarClockPubkeyAccount(sysVarClockPubkeyAccount solana.PublicKey) *Authorize {
+	inst.AccountMetaSlice[1] = solana.Meta(sysVarClockPubkeyAccount)
+	return inst
+}
+
+func (inst *Authorize) GetSysVarClockPubkeyAccount() *solana.AccountMeta {
+	return inst.AccountMetaSlice[1]
+}
+
+// Vote or withdraw authority
+func (inst *Authorize) SetAuthorityAccount(authorityAccount solana.PublicKey) *Authorize {
+	inst.AccountMetaSlice[2] = solana.Meta(authorityAccount).SIGNER()
+	return inst
+}
+
+func (inst *Authorize) GetAuthorityAccount() *solana.AccountMeta {
+	return inst.AccountMetaSlice[2]
+}
+
+func (inst Authorize) Build() *Instruction {
+	return &Instruction{BaseVariant: bin.BaseVariant{
+		Impl:   inst,
+		TypeID: bin.TypeIDFromUint32(Instruction_Authorize, binary.LittleEndian),
+	}}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (inst Authorize) ValidateAndBuild() (*Instruction, error) {
+	if err := inst.Validate(); err != nil {
+		return nil, err
+	}
+	return inst.Build(), nil
+}
+
+func (inst *Authorize) Validate() error {
+	// Check whether all (required) parameters are set:
+	{
+		if inst.NewAuthority == nil {
+			return errors.New("NewAuthority parameter is not set")
+		}
+		if inst.VoteAuthorize == nil {
+			return errors.New("VoteAuthorize parameter is not set")
+		}
+	}
+
+	// Check whether all (required) accounts are set:
+	for accIndex, acc := range inst.AccountMetaSlice {
+		if acc == nil {
+			return fmt.Errorf("ins.AccountMetaSlice[%v] is not set", accIndex)
+		}
+	}
+	return nil
+}
+
+func (inst *Authorize) EncodeToTree(parent treeout.Branches) {
+	parent.Child(format.Program(ProgramName, ProgramID)).
+		//
+		ParentFunc(func(programBranch treeout.Branches) {
+			programBranch.Child(format.Instruction("Authorize")).
+				//
+				ParentFunc(func(instructionBranch treeout.Branches) {
+
+					// Parameters of the instruction:
+					instructionBranch.Child("Params").ParentFunc(func(paramsBranch treeout.Branches) {
+						paramsBranch.Child(format.Param(" NewAuthority", *inst.NewAuthority))
+						paramsBranch.Child(format.Param("VoteAuthorize", *inst.VoteAuthorize))
+					})
+
+					// Accounts of the instruction:
+					instructionBranch.Child("Accounts").ParentFunc(func(accountsBranch treeout.Branches) {
+						accountsBranch.Child(format.Meta("       Vote", inst.AccountMetaSlice.Get(0)))
+						accountsBranch.Child(format.Meta("SysVarClock", inst.AccountMetaSlice.Get(1)))
+						accountsBranch.Child(format.Meta("  Authority", inst.AccountMetaSlice.Get(2)))
+					})
+				})
+		})
+}
+
+func (inst Authorize) MarshalWithEncoder(encoder *bin.Encoder) error {
+	// Serialize `NewAuthority` param:
+	{
+		err := encoder.Encode(*inst.NewAuthority)
+		if err != nil {
+			return err
+		}
+	}
+	// Serialize `VoteAuthorize` param:
+	{
+		err := encoder.Encode(*inst.VoteAuthorize)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (inst *Authorize) UnmarshalWithDecoder(decoder *bin.Decoder) error {
+	// Deserialize `NewAuthority` param:
+	{
+		err := decoder.Decode(&inst.NewAuthority)
+		if err != nil {
+			return err
+		}
+	}
+	// Deserialize `VoteAuthorize` param:
+	{
+		err := decoder.Decode(&inst.VoteAuthorize)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// NewAuthorizeInstruction declares a new Authorize instruction with the provided parameters and accounts.
+func NewAuthorizeInstruction(
+	// Parameters:
+	newAuthority solana.PublicKey,
+	voteAuthorize VoteAuthorize,
+	// Accounts:
+	voteAccount solana.PublicKey,
+	authorityAccount solana.PublicKey) *Authorize {
+	return NewAuthorizeInstructionBuilder().
+		SetNewAuthority(newAuthority).
+		SetVoteAuthorize(voteAuthorize).
+		SetVoteAccount(voteAccount).
+		SetAuthorityAccount(authorityAccount)
+}
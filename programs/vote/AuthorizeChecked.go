@@ -0,0 +1,198 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vote
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	bin "github.com/gagliardetto/binary"
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/text/format"
+	"github.com/gagliardetto/treeout"
+)
+
+// Authorize a key to send votes or issue a withdrawal.
+//
+// This instruction behaves like Authorize with the additional requirement
+// that the new vote or withdraw authority must also be a signer.
+type AuthorizeChecked struct {
+	// Type of authority to change
+	VoteAuthorize *VoteAuthorize
+
+	// [0] = [WRITE] VoteAccount
+	// ··········· Vote account to be updated with the public key provided for the authority
+	//
+	// [1] = [] $(SysVarClockPubkey)
+	// ··········· Clock sysvar
+	//
+	// [2] = [SIGNER] AuthorityAccount
+	// ··········· Vote or withdraw authority
+	//
+	// [3] = [SIGNER] NewAuthorityAccount
+	// ··········· New vote or withdraw authority
+	solana.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+// NewAuthorizeCheckedInstructionBuilder creates a new `AuthorizeChecked` instruction builder.
+func NewAuthorizeCheckedInstructionBuilder() *AuthorizeChecked {
+	nd := &AuthorizeChecked{
+		AccountMetaSlice: make(solana.AccountMetaSlice, 4),
+	}
+	nd.AccountMetaSlice[1] = solana.Meta(solana.SysVarClockPubkey)
+	return nd
+}
+
+// Type of authority to change
+func (inst *AuthorizeChecked) SetVoteAuthorize(voteAuthorize VoteAuthorize) *AuthorizeChecked {
+	inst.VoteAuthorize = &voteAuthorize
+	return inst
+}
+
+// Vote account to be updated with the public key provided for the authority
+func (inst *AuthorizeChecked) SetVoteAccount(voteAccount solana.PublicKey) *AuthorizeChecked {
+	inst.AccountMetaSlice[0] = solana.Meta(voteAccount).WRITE()
+	return inst
+}
+
+func (inst *AuthorizeChecked) GetVoteAccount() *solana.AccountMeta {
+	return inst.AccountMetaSlice[0]
+}
+
+// Clock sysvar
+func (inst *AuthorizeChecked) SetSysVarClockPubkeyAccount(sysVarClockPubkeyAccount solana.PublicKey) *AuthorizeChecked {
+	inst.AccountMetaSlice[1] = solana.Meta(sysVarClockPubkeyAccount)
+	return inst
+}
+
+func (inst *AuthorizeChecked) GetSysVarClockPubkeyAccount() *solana.AccountMeta {
+	return inst.AccountMetaSlice[1]
+}
+
+// Vote or withdraw authority
+func (inst *AuthorizeChecked) SetAuthorityAccount(authorityAccount solana.PublicKey) *AuthorizeChecked {
+	inst.AccountMetaSlice[2] = solana.Meta(authorityAccount).SIGNER()
+	return inst
+}
+
+func (inst *AuthorizeChecked) GetAuthorityAccount() *solana.AccountMeta {
+	return inst.AccountMetaSlice[2]
+}
+
+// New vote or withdraw authority
+func (inst *AuthorizeChecked) SetNewAuthorityAccount(newAuthorityAccount solana.PublicKey) *AuthorizeChecked {
+	inst.AccountMetaSlice[3] = solana.Meta(newAuthorityAccount).SIGNER()
+	return inst
+}
+
+func (inst *AuthorizeChecked) GetNewAuthorityAccount() *solana.AccountMeta {
+	return inst.AccountMetaSlice[3]
+}
+
+func (inst AuthorizeChecked) Build() *Instruction {
+	return &Instruction{BaseVariant: bin.BaseVariant{
+		Impl:   inst,
+		TypeID: bin.TypeIDFromUint32(Instruction_AuthorizeChecked, binary.LittleEndian),
+	}}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (inst AuthorizeChecked) ValidateAndBuild() (*Instruction, error) {
+	if err := inst.Validate(); err != nil {
+		return nil, err
+	}
+	return inst.Build(), nil
+}
+
+func (inst *AuthorizeChecked) Validate() error {
+	// Check whether all (required) parameters are set:
+	{
+		if inst.VoteAuthorize == nil {
+			return errors.New("VoteAuthorize parameter is not set")
+		}
+	}
+
+	// Check whether all (required) accounts are set:
+	for accIndex, acc := range inst.AccountMetaSlice {
+		if acc == nil {
+			return fmt.Errorf("ins.AccountMetaSlice[%v] is not set", accIndex)
+		}
+	}
+	return nil
+}
+
+func (inst *AuthorizeChecked) EncodeToTree(parent treeout.Branches) {
+	parent.Child(format.Program(ProgramName, ProgramID)).
+		//
+		ParentFunc(func(programBranch treeout.Branches) {
+			programBranch.Child(format.Instruction("AuthorizeChecked")).
+				//
+				ParentFunc(func(instructionBranch treeout.Branches) {
+
+					// Parameters of the instruction:
+					instructionBranch.Child("Params").ParentFunc(func(paramsBranch treeout.Branches) {
+						paramsBranch.Child(format.Param("VoteAuthorize", *inst.VoteAuthorize))
+					})
+
+					// Accounts of the instruction:
+					instructionBranch.Child("Accounts").ParentFunc(func(accountsBranch treeout.Branches) {
+						accountsBranch.Child(format.Meta("        Vote", inst.AccountMetaSlice.Get(0)))
+						accountsBranch.Child(format.Meta(" SysVarClock", inst.AccountMetaSlice.Get(1)))
+						accountsBranch.Child(format.Meta("   Authority", inst.AccountMetaSlice.Get(2)))
+						accountsBranch.Child(format.Meta("NewAuthority", inst.AccountMetaSlice.Get(3)))
+					})
+				})
+		})
+}
+
+func (inst AuthorizeChecked) MarshalWithEncoder(encoder *bin.Encoder) error {
+	// Serialize `VoteAuthorize` param:
+	{
+		err := encoder.Encode(*inst.VoteAuthorize)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (inst *AuthorizeChecked) UnmarshalWithDecoder(decoder *bin.Decoder) error {
+	// Deserialize `VoteAuthorize` param:
+	{
+		err := decoder.Decode(&inst.VoteAuthorize)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// NewAuthorizeCheckedInstruction declares a new AuthorizeChecked instruction with the provided parameters and accounts.
+func NewAuthorizeCheckedInstruction(
+	// Parameters:
+	voteAuthorize VoteAuthorize,
+	// Accounts:
+	voteAccount solana.PublicKey,
+	authorityAccount solana.PublicKey,
+	newAuthorityAccount solana.PublicKey) *AuthorizeChecked {
+	return NewAuthorizeCheckedInstructionBuilder().
+		SetVoteAuthorize(voteAuthorize).
+		SetVoteAccount(voteAccount).
+		SetAuthorityAccount(authorityAccount).
+		SetNewAuthorityAccount(newAuthorityAccount)
+}
@@ -0,0 +1,258 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vote
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	bin "github.com/gagliardetto/binary"
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/text/format"
+	"github.com/gagliardetto/treeout"
+)
+
+// Authorize a key to send votes or issue a withdrawal,
+// where the current authority is a derived key.
+//
+// This instruction behaves like AuthorizeWithSeed with the additional requirement
+// that the new vote or withdraw authority must also be a signer.
+type AuthorizeCheckedWithSeed struct {
+	// Type of authority to change
+	VoteAuthorize *VoteAuthorize
+
+	// Owner program used to derive the current authority
+	CurrentAuthorityDerivedKeyOwner *solana.PublicKey
+
+	// Seed used to derive the current authority
+	CurrentAuthorityDerivedKeySeed *string
+
+	// [0] = [WRITE] VoteAccount
+	// ··········· Vote account to be updated
+	//
+	// [1] = [] $(SysVarClockPubkey)
+	// ··········· Clock sysvar
+	//
+	// [2] = [SIGNER] AuthorityBaseAccount
+	// ··········· Base key of the current vote or withdraw authority's derived key
+	//
+	// [3] = [SIGNER] NewAuthorityAccount
+	// ··········· New vote or withdraw authority
+	solana.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+// NewAuthorizeCheckedWithSeedInstructionBuilder creates a new `AuthorizeCheckedWithSeed` instruction builder.
+func NewAuthorizeCheckedWithSeedInstructionBuilder() *AuthorizeCheckedWithSeed {
+	nd := &AuthorizeCheckedWithSeed{
+		AccountMetaSlice: make(solana.AccountMetaSlice, 4),
+	}
+	nd.AccountMetaSlice[1] = solana.Meta(solana.SysVarClockPubkey)
+	return nd
+}
+
+// Type of authority to change
+func (inst *AuthorizeCheckedWithSeed) SetVoteAuthorize(voteAuthorize VoteAuthorize) *AuthorizeCheckedWithSeed {
+	inst.VoteAuthorize = &voteAuthorize
+	return inst
+}
+
+// Owner program used to derive the current authority
+func (inst *AuthorizeCheckedWithSeed) SetCurrentAuthorityDerivedKeyOwner(currentAuthorityDerivedKeyOwner solana.PublicKey) *AuthorizeCheckedWithSeed {
+	inst.CurrentAuthorityDerivedKeyOwner = &currentAuthorityDerivedKeyOwner
+	return inst
+}
+
+// Seed used to derive the current authority
+func (inst *AuthorizeCheckedWithSeed) SetCurrentAuthorityDerivedKeySeed(currentAuthorityDerivedKeySeed string) *AuthorizeCheckedWithSeed {
+	inst.CurrentAuthorityDerivedKeySeed = &currentAuthorityDerivedKeySeed
+	return inst
+}
+
+// Vote account to be updated
+func (inst *AuthorizeCheckedWithSeed) SetVoteAccount(voteAccount solana.PublicKey) *AuthorizeCheckedWithSeed {
+	inst.AccountMetaSlice[0] = solana.Meta(voteAccount).WRITE()
+	return inst
+}
+
+func (inst *AuthorizeCheckedWithSeed) GetVoteAccount() *solana.AccountMeta {
+	return inst.AccountMetaSlice[0]
+}
+
+// Clock sysvar
+func (inst *AuthorizeCheckedWithSeed) SetSysVarClockPubkeyAccount(sysVarClockPubkeyAccount solana.PublicKey) *AuthorizeCheckedWithSeed {
+	inst.AccountMetaSlice[1] = solana.Meta(sysVarClockPubkeyAccount)
+	return inst
+}
+
+func (inst *AuthorizeCheckedWithSeed) GetSysVarClockPubkeyAccount() *solana.AccountMeta {
+	return inst.AccountMetaSlice[1]
+}
+
+// Base key of the current vote or withdraw authority's derived key
+func (inst *AuthorizeCheckedWithSeed) SetAuthorityBaseAccount(authorityBaseAccount solana.PublicKey) *AuthorizeCheckedWithSeed {
+	inst.AccountMetaSlice[2] = solana.Meta(authorityBaseAccount).SIGNER()
+	return inst
+}
+
+func (inst *AuthorizeCheckedWithSeed) GetAuthorityBaseAccount() *solana.AccountMeta {
+	return inst.AccountMetaSlice[2]
+}
+
+// New vote or withdraw authority
+func (inst *AuthorizeCheckedWithSeed) SetNewAuthorityAccount(newAuthorityAccount solana.PublicKey) *AuthorizeCheckedWithSeed {
+	inst.AccountMetaSlice[3] = solana.Meta(newAuthorityAccount).SIGNER()
+	return inst
+}
+
+func (inst *AuthorizeCheckedWithSeed) GetNewAuthorityAccount() *solana.AccountMeta {
+	return inst.AccountMetaSlice[3]
+}
+
+func (inst AuthorizeCheckedWithSeed) Build() *Instruction {
+	return &Instruction{BaseVariant: bin.BaseVariant{
+		Impl:   inst,
+		TypeID: bin.TypeIDFromUint32(Instruction_AuthorizeCheckedWithSeed, binary.LittleEndian),
+	}}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (inst AuthorizeCheckedWithSeed) ValidateAndBuild() (*Instruction, error) {
+	if err := inst.Validate(); err != nil {
+		return nil, err
+	}
+	return inst.Build(), nil
+}
+
+func (inst *AuthorizeCheckedWithSeed) Validate() error {
+	// Check whether all (required) parameters are set:
+	{
+		if inst.VoteAuthorize == nil {
+			return errors.New("VoteAuthorize parameter is not set")
+		}
+		if inst.CurrentAuthorityDerivedKeyOwner == nil {
+			return errors.New("CurrentAuthorityDerivedKeyOwner parameter is not set")
+		}
+		if inst.CurrentAuthorityDerivedKeySeed == nil {
+			return errors.New("CurrentAuthorityDerivedKeySeed parameter is not set")
+		}
+	}
+
+	// Check whether all (required) accounts are set:
+	for accIndex, acc := range inst.AccountMetaSlice {
+		if acc == nil {
+			return fmt.Errorf("ins.AccountMetaSlice[%v] is not set", accIndex)
+		}
+	}
+	return nil
+}
+
+func (inst *AuthorizeCheckedWithSeed) EncodeToTree(parent treeout.Branches) {
+	parent.Child(format.Program(ProgramName, ProgramID)).
+		//
+		ParentFunc(func(programBranch treeout.Branches) {
+			programBranch.Child(format.Instruction("AuthorizeCheckedWithSeed")).
+				//
+				ParentFunc(func(instructionBranch treeout.Branches) {
+
+					// Parameters of the instruction:
+					instructionBranch.Child("Params").ParentFunc(func(paramsBranch treeout.Branches) {
+						paramsBranch.Child(format.Param("                  VoteAuthorize", *inst.VoteAuthorize))
+						paramsBranch.Child(format.Param("CurrentAuthorityDerivedKeyOwner", *inst.CurrentAuthorityDerivedKeyOwner))
+						paramsBranch.Child(format.Param(" CurrentAuthorityDerivedKeySeed", *inst.CurrentAuthorityDerivedKeySeed))
+					})
+
+					// Accounts of the instruction:
+					instructionBranch.Child("Accounts").ParentFunc(func(accountsBranch treeout.Branches) {
+						accountsBranch.Child(format.Meta("         Vote", inst.AccountMetaSlice.Get(0)))
+						accountsBranch.Child(format.Meta("  SysVarClock", inst.AccountMetaSlice.Get(1)))
+						accountsBranch.Child(format.Meta("AuthorityBase", inst.AccountMetaSlice.Get(2)))
+						accountsBranch.Child(format.Meta(" NewAuthority", inst.AccountMetaSlice.Get(3)))
+					})
+				})
+		})
+}
+
+func (inst AuthorizeCheckedWithSeed) MarshalWithEncoder(encoder *bin.Encoder) error {
+	// Serialize `VoteAuthorize` param:
+	{
+		err := encoder.Encode(*inst.VoteAuthorize)
+		if err != nil {
+			return err
+		}
+	}
+	// Serialize `CurrentAuthorityDerivedKeyOwner` param:
+	{
+		err := encoder.Encode(*inst.CurrentAuthorityDerivedKeyOwner)
+		if err != nil {
+			return err
+		}
+	}
+	// Serialize `CurrentAuthorityDerivedKeySeed` param:
+	{
+		err := encoder.WriteRustString(*inst.CurrentAuthorityDerivedKeySeed)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (inst *AuthorizeCheckedWithSeed) UnmarshalWithDecoder(decoder *bin.Decoder) error {
+	// Deserialize `VoteAuthorize` param:
+	{
+		err := decoder.Decode(&inst.VoteAuthorize)
+		if err != nil {
+			return err
+		}
+	}
+	// Deserialize `CurrentAuthorityDerivedKeyOwner` param:
+	{
+		err := decoder.Decode(&inst.CurrentAuthorityDerivedKeyOwner)
+		if err != nil {
+			return err
+		}
+	}
+	// Deserialize `CurrentAuthorityDerivedKeySeed` param:
+	{
+		v, err := decoder.ReadRustString()
+		if err != nil {
+			return err
+		}
+		inst.CurrentAuthorityDerivedKeySeed = &v
+	}
+	return nil
+}
+
+// NewAuthorizeCheckedWithSeedInstruction declares a new AuthorizeCheckedWithSeed instruction with the provided parameters and accounts.
+func NewAuthorizeCheckedWithSeedInstruction(
+	// Parameters:
+	voteAuthorize VoteAuthorize,
+	currentAuthorityDerivedKeyOwner solana.PublicKey,
+	currentAuthorityDerivedKeySeed string,
+	// Accounts:
+	voteAccount solana.PublicKey,
+	authorityBaseAccount solana.PublicKey,
+	newAuthorityAccount solana.PublicKey) *AuthorizeCheckedWithSeed {
+	return NewAuthorizeCheckedWithSeedInstructionBuilder().
+		SetVoteAuthorize(voteAuthorize).
+		SetCurrentAuthorityDerivedKeyOwner(currentAuthorityDerivedKeyOwner).
+		SetCurrentAuthorityDerivedKeySeed(currentAuthorityDerivedKeySeed).
+		SetVoteAccount(voteAccount).
+		SetAuthorityBaseAccount(authorityBaseAccount).
+		SetNewAuthorityAccount(newAuthorityAccount)
+}
@@ -0,0 +1,268 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vote
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	bin "github.com/gagliardetto/binary"
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/text/format"
+	"github.com/gagliardetto/treeout"
+)
+
+// Authorize a key to send votes or issue a withdrawal,
+// where the current authority is a derived key.
+type AuthorizeWithSeed struct {
+	// Type of authority to change
+	VoteAuthorize *VoteAuthorize
+
+	// Owner program used to derive the current authority
+	CurrentAuthorityDerivedKeyOwner *solana.PublicKey
+
+	// Seed used to derive the current authority
+	CurrentAuthorityDerivedKeySeed *string
+
+	// New authority
+	NewAuthority *solana.PublicKey
+
+	// [0] = [WRITE] VoteAccount
+	// ··········· Vote account to be updated
+	//
+	// [1] = [] $(SysVarClockPubkey)
+	// ··········· Clock sysvar
+	//
+	// [2] = [SIGNER] AuthorityBaseAccount
+	// ··········· Base key of the current vote or withdraw authority's derived key
+	solana.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+// NewAuthorizeWithSeedInstructionBuilder creates a new `AuthorizeWithSeed` instruction builder.
+func NewAuthorizeWithSeedInstructionBuilder() *AuthorizeWithSeed {
+	nd := &AuthorizeWithSeed{
+		AccountMetaSlice: make(solana.AccountMetaSlice, 3),
+	}
+	nd.AccountMetaSlice[1] = solana.Meta(solana.SysVarClockPubkey)
+	return nd
+}
+
+// Type of authority to change
+func (inst *AuthorizeWithSeed) SetVoteAuthorize(voteAuthorize VoteAuthorize) *AuthorizeWithSeed {
+	inst.VoteAuthorize = &voteAuthorize
+	return inst
+}
+
+// Owner program used to derive the current authority
+func (inst *AuthorizeWithSeed) SetCurrentAuthorityDerivedKeyOwner(currentAuthorityDerivedKeyOwner solana.PublicKey) *AuthorizeWithSeed {
+	inst.CurrentAuthorityDerivedKeyOwner = &currentAuthorityDerivedKeyOwner
+	return inst
+}
+
+// Seed used to derive the current authority
+func (inst *AuthorizeWithSeed) SetCurrentAuthorityDerivedKeySeed(currentAuthorityDerivedKeySeed string) *AuthorizeWithSeed {
+	inst.CurrentAuthorityDerivedKeySeed = &currentAuthorityDerivedKeySeed
+	return inst
+}
+
+// New authority
+func (inst *AuthorizeWithSeed) SetNewAuthority(newAuthority solana.PublicKey) *AuthorizeWithSeed {
+	inst.NewAuthority = &newAuthority
+	return inst
+}
+
+// Vote account to be updated
+func (inst *AuthorizeWithSeed) SetVoteAccount(voteAccount solana.PublicKey) *AuthorizeWithSeed {
+	inst.AccountMetaSlice[0] = solana.Meta(voteAccount).WRITE()
+	return inst
+}
+
+func (inst *AuthorizeWithSeed) GetVoteAccount() *solana.AccountMeta {
+	return inst.AccountMetaSlice[0]
+}
+
+// Clock sysvar
+func (inst *AuthorizeWithSeed) SetSysVarClockPubkeyAccount(sysVarClockPubkeyAccount solana.PublicKey) *AuthorizeWithSeed {
+	inst.AccountMetaSlice[1] = solana.Meta(sysVarClockPubkeyAccount)
+	return inst
+}
+
+func (inst *AuthorizeWithSeed) GetSysVarClockPubkeyAccount() *solana.AccountMeta {
+	return inst.AccountMetaSlice[1]
+}
+
+// Base key of the current vote or withdraw authority's derived key
+func (inst *AuthorizeWithSeed) SetAuthorityBaseAccount(authorityBaseAccount solana.PublicKey) *AuthorizeWithSeed {
+	inst.AccountMetaSlice[2] = solana.Meta(authorityBaseAccount).SIGNER()
+	return inst
+}
+
+func (inst *AuthorizeWithSeed) GetAuthorityBaseAccount() *solana.AccountMeta {
+	return inst.AccountMetaSlice[2]
+}
+
+func (inst AuthorizeWithSeed) Build() *Instruction {
+	return &Instruction{BaseVariant: bin.BaseVariant{
+		Impl:   inst,
+		TypeID: bin.TypeIDFromUint32(Instruction_AuthorizeWithSeed, binary.LittleEndian),
+	}}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (inst AuthorizeWithSeed) ValidateAndBuild() (*Instruction, error) {
+	if err := inst.Validate(); err != nil {
+		return nil, err
+	}
+	return inst.Build(), nil
+}
+
+func (inst *AuthorizeWithSeed) Validate() error {
+	// Check whether all (required) parameters are set:
+	{
+		if inst.VoteAuthorize == nil {
+			return errors.New("VoteAuthorize parameter is not set")
+		}
+		if inst.CurrentAuthorityDerivedKeyOwner == nil {
+			return errors.New("CurrentAuthorityDerivedKeyOwner parameter is not set")
+		}
+		if inst.CurrentAuthorityDerivedKeySeed == nil {
+			return errors.New("CurrentAuthorityDerivedKeySeed parameter is not set")
+		}
+		if inst.NewAuthority == nil {
+			return errors.New("NewAuthority parameter is not set")
+		}
+	}
+
+	// Check whether all (required) accounts are set:
+	for accIndex, acc := range inst.AccountMetaSlice {
+		if acc == nil {
+			return fmt.Errorf("ins.AccountMetaSlice[%v] is not set", accIndex)
+		}
+	}
+	return nil
+}
+
+func (inst *AuthorizeWithSeed) EncodeToTree(parent treeout.Branches) {
+	parent.Child(format.Program(ProgramName, ProgramID)).
+		//
+		ParentFunc(func(programBranch treeout.Branches) {
+			programBranch.Child(format.Instruction("AuthorizeWithSeed")).
+				//
+				ParentFunc(func(instructionBranch treeout.Branches) {
+
+					// Parameters of the instruction:
+					instructionBranch.Child("Params").ParentFunc(func(paramsBranch treeout.Branches) {
+						paramsBranch.Child(format.Param("                  VoteAuthorize", *inst.VoteAuthorize))
+						paramsBranch.Child(format.Param("CurrentAuthorityDerivedKeyOwner", *inst.CurrentAuthorityDerivedKeyOwner))
+						paramsBranch.Child(format.Param(" CurrentAuthorityDerivedKeySeed", *inst.CurrentAuthorityDerivedKeySeed))
+						paramsBranch.Child(format.Param("                   NewAuthority", *inst.NewAuthority))
+					})
+
+					// Accounts of the instruction:
+					instructionBranch.Child("Accounts").ParentFunc(func(accountsBranch treeout.Branches) {
+						accountsBranch.Child(format.Meta("         Vote", inst.AccountMetaSlice.Get(0)))
+						accountsBranch.Child(format.Meta("  SysVarClock", inst.AccountMetaSlice.Get(1)))
+						accountsBranch.Child(format.Meta("AuthorityBase", inst.AccountMetaSlice.Get(2)))
+					})
+				})
+		})
+}
+
+func (inst AuthorizeWithSeed) MarshalWithEncoder(encoder *bin.Encoder) error {
+	// Serialize `VoteAuthorize` param:
+	{
+		err := encoder.Encode(*inst.VoteAuthorize)
+		if err != nil {
+			return err
+		}
+	}
+	// Serialize `CurrentAuthorityDerivedKeyOwner` param:
+	{
+		err := encoder.Encode(*inst.CurrentAuthorityDerivedKeyOwner)
+		if err != nil {
+			return err
+		}
+	}
+	// Serialize `CurrentAuthorityDerivedKeySeed` param:
+	{
+		err := encoder.WriteRustString(*inst.CurrentAuthorityDerivedKeySeed)
+		if err != nil {
+			return err
+		}
+	}
+	// Serialize `NewAuthority` param:
+	{
+		err := encoder.Encode(*inst.NewAuthority)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (inst *AuthorizeWithSeed) UnmarshalWithDecoder(decoder *bin.Decoder) error {
+	// Deserialize `VoteAuthorize` param:
+	{
+		err := decoder.Decode(&inst.VoteAuthorize)
+		if err != nil {
+			return err
+		}
+	}
+	// Deserialize `CurrentAuthorityDerivedKeyOwner` param:
+	{
+		err := decoder.Decode(&inst.CurrentAuthorityDerivedKeyOwner)
+		if err != nil {
+			return err
+		}
+	}
+	// Deserialize `CurrentAuthorityDerivedKeySeed` param:
+	{
+		v, err := decoder.ReadRustString()
+		if err != nil {
+			return err
+		}
+		inst.CurrentAuthorityDerivedKeySeed = &v
+	}
+	// Deserialize `NewAuthority` param:
+	{
+		err := decoder.Decode(&inst.NewAuthority)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// NewAuthorizeWithSeedInstruction declares a new AuthorizeWithSeed instruction with the provided parameters and accounts.
+func NewAuthorizeWithSeedInstruction(
+	// Parameters:
+	voteAuthorize VoteAuthorize,
+	currentAuthorityDerivedKeyOwner solana.PublicKey,
+	currentAuthorityDerivedKeySeed string,
+	newAuthority solana.PublicKey,
+	// Accounts:
+	voteAccount solana.PublicKey,
+	authorityBaseAccount solana.PublicKey) *AuthorizeWithSeed {
+	return NewAuthorizeWithSeedInstructionBuilder().
+		SetVoteAuthorize(voteAuthorize).
+		SetCurrentAuthorityDerivedKeyOwner(currentAuthorityDerivedKeyOwner).
+		SetCurrentAuthorityDerivedKeySeed(currentAuthorityDerivedKeySeed).
+		SetNewAuthority(newAuthority).
+		SetVoteAccount(voteAccount).
+		SetAuthorityBaseAccount(authorityBaseAccount)
+}
@@ -0,0 +1,166 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vote
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	bin "github.com/gagliardetto/binary"
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/text/format"
+	"github.com/gagliardetto/treeout"
+)
+
+// Update the onchain vote state for the signer,
+// using the compact serialization of the vote state.
+type CompactUpdateVoteState struct {
+	// The proposed vote state
+	VoteStateUpdate *VoteStateUpdate
+
+	// [0] = [WRITE] VoteAccount
+	// ··········· Vote account to vote with
+	//
+	// [1] = [SIGNER] VoteAuthorityAccount
+	// ··········· Vote authority
+	solana.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+// NewCompactUpdateVoteStateInstructionBuilder creates a new `CompactUpdateVoteState` instruction builder.
+func NewCompactUpdateVoteStateInstructionBuilder() *CompactUpdateVoteState {
+	nd := &CompactUpdateVoteState{
+		AccountMetaSlice: make(solana.AccountMetaSlice, 2),
+	}
+	return nd
+}
+
+// The proposed vote state
+func (inst *CompactUpdateVoteState) SetVoteStateUpdate(voteStateUpdate VoteStateUpdate) *CompactUpdateVoteState {
+	inst.VoteStateUpdate = &voteStateUpdate
+	return inst
+}
+
+// Vote account to vote with
+func (inst *CompactUpdateVoteState) SetVoteAccount(voteAccount solana.PublicKey) *CompactUpdateVoteState {
+	inst.AccountMetaSlice[0] = solana.Meta(voteAccount).WRITE()
+	return inst
+}
+
+func (inst *CompactUpdateVoteState) GetVoteAccount() *solana.AccountMeta {
+	return inst.AccountMetaSlice[0]
+}
+
+// Vote authority
+func (inst *CompactUpdateVoteState) SetVoteAuthorityAccount(voteAuthorityAccount solana.PublicKey) *CompactUpdateVoteState {
+	inst.AccountMetaSlice[1] = solana.Meta(voteAuthorityAccount).SIGNER()
+	return inst
+}
+
+func (inst *CompactUpdateVoteState) GetVoteAuthorityAccount() *solana.AccountMeta {
+	return inst.AccountMetaSlice[1]
+}
+
+func (inst CompactUpdateVoteState) Build() *Instruction {
+	return &Instruction{BaseVariant: bin.BaseVariant{
+		Impl:   inst,
+		TypeID: bin.TypeIDFromUint32(Instruction_CompactUpdateVoteState, binary.LittleEndian),
+	}}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (inst CompactUpdateVoteState) ValidateAndBuild() (*Instruction, error) {
+	if err := inst.Validate(); err != nil {
+		return nil, err
+	}
+	return inst.Build(), nil
+}
+
+func (inst *CompactUpdateVoteState) Validate() error {
+	// Check whether all (required) parameters are set:
+	{
+		if inst.VoteStateUpdate == nil {
+			return errors.New("VoteStateUpdate parameter is not set")
+		}
+	}
+
+	// Check whether all (required) accounts are set:
+	for accIndex, acc := range inst.AccountMetaSlice {
+		if acc == nil {
+			return fmt.Errorf("ins.AccountMetaSlice[%v] is not set", accIndex)
+		}
+	}
+	return nil
+}
+
+func (inst *CompactUpdateVoteState) EncodeToTree(parent treeout.Branches) {
+	parent.Child(format.Program(ProgramName, ProgramID)).
+		//
+		ParentFunc(func(programBranch treeout.Branches) {
+			programBranch.Child(format.Instruction("CompactUpdateVoteState")).
+				//
+				ParentFunc(func(instructionBranch treeout.Branches) {
+
+					// Parameters of the instruction:
+					instructionBranch.Child("Params").ParentFunc(func(paramsBranch treeout.Branches) {
+						paramsBranch.Child(format.Param("VoteStateUpdate", *inst.VoteStateUpdate))
+					})
+
+					// Accounts of the instruction:
+					instructionBranch.Child("Accounts").ParentFunc(func(accountsBranch treeout.Branches) {
+						accountsBranch.Child(format.Meta("         Vote", inst.AccountMetaSlice.Get(0)))
+						accountsBranch.Child(format.Meta("VoteAuthority", inst.AccountMetaSlice.Get(1)))
+					})
+				})
+		})
+}
+
+func (inst CompactUpdateVoteState) MarshalWithEncoder(encoder *bin.Encoder) error {
+	// Serialize `VoteStateUpdate` param:
+	{
+		err := inst.VoteStateUpdate.MarshalCompactWithEncoder(encoder)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (inst *CompactUpdateVoteState) UnmarshalWithDecoder(decoder *bin.Decoder) error {
+	// Deserialize `VoteStateUpdate` param:
+	{
+		inst.VoteStateUpdate = new(VoteStateUpdate)
+		err := inst.VoteStateUpdate.UnmarshalCompactWithDecoder(decoder)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// NewCompactUpdateVoteStateInstruction declares a new CompactUpdateVoteState instruction with the provided parameters and accounts.
+func NewCompactUpdateVoteStateInstruction(
+	// Parameters:
+	voteStateUpdate VoteStateUpdate,
+	// Accounts:
+	voteAccount solana.PublicKey,
+	voteAuthorityAccount solana.PublicKey) *CompactUpdateVoteState {
+	return NewCompactUpdateVoteStateInstructionBuilder().
+		SetVoteStateUpdate(voteStateUpdate).
+		SetVoteAccount(voteAccount).
+		SetVoteAuthorityAccount(voteAuthorityAccount)
+}
@@ -15,22 +15,180 @@
 package vote
 
 import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	bin "github.com/gagliardetto/binary"
 	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/text/format"
+	"github.com/gagliardetto/treeout"
 )
 
+// Initialize a vote account.
 type InitializeAccount struct {
-	// TODO
+	// Identity, authorities and commission of the new vote account
+	VoteInit *VoteInit
 
 	// [0] = [WRITE] VoteAccount
-	// ··········· Vote account to vote with
+	// ··········· Uninitialized vote account
 	//
-	// [1] = [] SysVarSlotHashes
-	// ··········· Slot hashes sysvar
+	// [1] = [] $(SysVarRentPubkey)
+	// ··········· Rent sysvar
 	//
-	// [2] = [] SysVarClock
+	// [2] = [] $(SysVarClockPubkey)
 	// ··········· Clock sysvar
 	//
-	// [3] = [SIGNER] VoteAuthority
+	// [3] = [SIGNER] NodeAccount
 	// ··········· New validator identity (node_pubkey)
 	solana.AccountMetaSlice `bin:"-" borsh_skip:"true"`
 }
+
+// NewInitializeAccountInstructionBuilder creates a new `InitializeAccount` instruction builder.
+func NewInitializeAccountInstructionBuilder() *InitializeAccount {
+	nd := &InitializeAccount{
+		AccountMetaSlice: make(solana.AccountMetaSlice, 4),
+	}
+	nd.AccountMetaSlice[1] = solana.Meta(solana.SysVarRentPubkey)
+	nd.AccountMetaSlice[2] = solana.Meta(solana.SysVarClockPubkey)
+	return nd
+}
+
+// Identity, authorities and commission of the new vote account
+func (inst *InitializeAccount) SetVoteInit(voteInit VoteInit) *InitializeAccount {
+	inst.VoteInit = &voteInit
+	return inst
+}
+
+// Uninitialized vote account
+func (inst *InitializeAccount) SetVoteAccount(voteAccount solana.PublicKey) *InitializeAccount {
+	inst.AccountMetaSlice[0] = solana.Meta(voteAccount).WRITE()
+	return inst
+}
+
+func (inst *InitializeAccount) GetVoteAccount() *solana.AccountMeta {
+	return inst.AccountMetaSlice[0]
+}
+
+// Rent sysvar
+func (inst *InitializeAccount) SetSysVarRentPubkeyAccount(sysVarRentPubkeyAccount solana.PublicKey) *InitializeAccount {
+	inst.AccountMetaSlice[1] = solana.Meta(sysVarRentPubkeyAccount)
+	return inst
+}
+
+func (inst *InitializeAccount) GetSysVarRentPubkeyAccount() *solana.AccountMeta {
+	return inst.AccountMetaSlice[1]
+}
+
+// Clock sysvar
+func (inst *InitializeAccount) SetSysVarClockPubkeyAccount(sysVarClockPubkeyAccount solana.PublicKey) *InitializeAccount {
+	inst.AccountMetaSlice[2] = solana.Meta(sysVarClockPubkeyAccount)
+	return inst
+}
+
+func (inst *InitializeAccount) GetSysVarClockPubkeyAccount() *solana.AccountMeta {
+	return inst.AccountMetaSlice[2]
+}
+
+// New validator identity (node_pubkey)
+func (inst *InitializeAccount) SetNodeAccount(nodeAccount solana.PublicKey) *InitializeAccount {
+	inst.AccountMetaSlice[3] = solana.Meta(nodeAccount).SIGNER()
+	return inst
+}
+
+func (inst *InitializeAccount) GetNodeAccount() *solana.AccountMeta {
+	return inst.AccountMetaSlice[3]
+}
+
+func (inst InitializeAccount) Build() *Instruction {
+	return &Instruction{BaseVariant: bin.BaseVariant{
+		Impl:   inst,
+		TypeID: bin.TypeIDFromUint32(Instruction_InitializeAccount, binary.LittleEndian),
+	}}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (inst InitializeAccount) ValidateAndBuild() (*Instruction, error) {
+	if err := inst.Validate(); err != nil {
+		return nil, err
+	}
+	return inst.Build(), nil
+}
+
+func (inst *InitializeAccount) Validate() error {
+	// Check whether all (required) parameters are set:
+	{
+		if inst.VoteInit == nil {
+			return errors.New("VoteInit parameter is not set")
+		}
+	}
+
+	// Check whether all (required) accounts are set:
+	for accIndex, acc := range inst.AccountMetaSlice {
+		if acc == nil {
+			return fmt.Errorf("ins.AccountMetaSlice[%v] is not set", accIndex)
+		}
+	}
+	return nil
+}
+
+func (inst *InitializeAccount) EncodeToTree(parent treeout.Branches) {
+	parent.Child(format.Program(ProgramName, ProgramID)).
+		//
+		ParentFunc(func(programBranch treeout.Branches) {
+			programBranch.Child(format.Instruction("InitializeAccount")).
+				//
+				ParentFunc(func(instructionBranch treeout.Branches) {
+
+					// Parameters of the instruction:
+					instructionBranch.Child("Params").ParentFunc(func(paramsBranch treeout.Branches) {
+						paramsBranch.Child(format.Param("VoteInit", *inst.VoteInit))
+					})
+
+					// Accounts of the instruction:
+					instructionBranch.Child("Accounts").ParentFunc(func(accountsBranch treeout.Branches) {
+						accountsBranch.Child(format.Meta("       Vote", inst.AccountMetaSlice.Get(0)))
+						accountsBranch.Child(format.Meta(" SysVarRent", inst.AccountMetaSlice.Get(1)))
+						accountsBranch.Child(format.Meta("SysVarClock", inst.AccountMetaSlice.Get(2)))
+						accountsBranch.Child(format.Meta("       Node", inst.AccountMetaSlice.Get(3)))
+					})
+				})
+		})
+}
+
+func (inst InitializeAccount) MarshalWithEncoder(encoder *bin.Encoder) error {
+	// Serialize `VoteInit` param:
+	{
+		err := encoder.Encode(*inst.VoteInit)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (inst *InitializeAccount) UnmarshalWithDecoder(decoder *bin.Decoder) error {
+	// Deserialize `VoteInit` param:
+	{
+		err := decoder.Decode(&inst.VoteInit)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// NewInitializeAccountInstruction declares a new InitializeAccount instruction with the provided parameters and accounts.
+func NewInitializeAccountInstruction(
+	// Parameters:
+	voteInit VoteInit,
+	// Accounts:
+	voteAccount solana.PublicKey,
+	nodeAccount solana.PublicKey) *InitializeAccount {
+	return NewInitializeAccountInstructionBuilder().
+		SetVoteInit(voteInit).
+		SetVoteAccount(voteAccount).
+		SetNodeAccount(nodeAccount)
+}
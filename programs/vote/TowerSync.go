@@ -0,0 +1,164 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vote
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	bin "github.com/gagliardetto/binary"
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/text/format"
+	"github.com/gagliardetto/treeout"
+)
+
+// Sync the onchain vote state with the local tower.
+type TowerSync struct {
+	// The local tower
+	TowerSyncUpdate *TowerSyncUpdate
+
+	// [0] = [WRITE] VoteAccount
+	// ··········· Vote account to vote with
+	//
+	// [1] = [SIGNER] VoteAuthorityAccount
+	// ··········· Vote authority
+	solana.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+// NewTowerSyncInstructionBuilder creates a new `TowerSync` instruction builder.
+func NewTowerSyncInstructionBuilder() *TowerSync {
+	nd := &TowerSync{
+		AccountMetaSlice: make(solana.AccountMetaSlice, 2),
+	}
+	return nd
+}
+
+// The local tower
+func (inst *TowerSync) SetTowerSyncUpdate(towerSyncUpdate TowerSyncUpdate) *TowerSync {
+	inst.TowerSyncUpdate = &towerSyncUpdate
+	return inst
+}
+
+// Vote account to vote with
+func (inst *TowerSync) SetVoteAccount(voteAccount solana.PublicKey) *TowerSync {
+	inst.AccountMetaSlice[0] = solana.Meta(voteAccount).WRITE()
+	return inst
+}
+
+func (inst *TowerSync) GetVoteAccount() *solana.AccountMeta {
+	return inst.AccountMetaSlice[0]
+}
+
+// Vote authority
+func (inst *TowerSync) SetVoteAuthorityAccount(voteAuthorityAccount solana.PublicKey) *TowerSync {
+	inst.AccountMetaSlice[1] = solana.Meta(voteAuthorityAccount).SIGNER()
+	return inst
+}
+
+func (inst *TowerSync) GetVoteAuthorityAccount() *solana.AccountMeta {
+	return inst.AccountMetaSlice[1]
+}
+
+func (inst TowerSync) Build() *Instruction {
+	return &Instruction{BaseVariant: bin.BaseVariant{
+		Impl:   inst,
+		TypeID: bin.TypeIDFromUint32(Instruction_TowerSync, binary.LittleEndian),
+	}}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (inst TowerSync) ValidateAndBuild() (*Instruction, error) {
+	if err := inst.Validate(); err != nil {
+		return nil, err
+	}
+	return inst.Build(), nil
+}
+
+func (inst *TowerSync) Validate() error {
+	// Check whether all (required) parameters are set:
+	{
+		if inst.TowerSyncUpdate == nil {
+			return errors.New("TowerSyncUpdate parameter is not set")
+		}
+	}
+
+	// Check whether all (required) accounts are set:
+	for accIndex, acc := range inst.AccountMetaSlice {
+		if acc == nil {
+			return fmt.Errorf("ins.AccountMetaSlice[%v] is not set", accIndex)
+		}
+	}
+	return nil
+}
+
+func (inst *TowerSync) EncodeToTree(parent treeout.Branches) {
+	parent.Child(format.Program(ProgramName, ProgramID)).
+		//
+		ParentFunc(func(programBranch treeout.Branches) {
+			programBranch.Child(format.Instruction("TowerSync")).
+				//
+				ParentFunc(func(instructionBranch treeout.Branches) {
+
+					// Parameters of the instruction:
+					instructionBranch.Child("Params").ParentFunc(func(paramsBranch treeout.Branches) {
+						paramsBranch.Child(format.Param("TowerSyncUpdate", *inst.TowerSyncUpdate))
+					})
+
+					// Accounts of the instruction:
+					instructionBranch.Child("Accounts").ParentFunc(func(accountsBranch treeout.Branches) {
+						accountsBranch.Child(format.Meta("         Vote", inst.AccountMetaSlice.Get(0)))
+						accountsBranch.Child(format.Meta("VoteAuthority", inst.AccountMetaSlice.Get(1)))
+					})
+				})
+		})
+}
+
+func (inst TowerSync) MarshalWithEncoder(encoder *bin.Encoder) error {
+	// Serialize `TowerSyncUpdate` param:
+	{
+		err := encoder.Encode(*inst.TowerSyncUpdate)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (inst *TowerSync) UnmarshalWithDecoder(decoder *bin.Decoder) error {
+	// Deserialize `TowerSyncUpdate` param:
+	{
+		err := decoder.Decode(&inst.TowerSyncUpdate)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// NewTowerSyncInstruction declares a new TowerSync instruction with the provided parameters and accounts.
+func NewTowerSyncInstruction(
+	// Parameters:
+	towerSyncUpdate TowerSyncUpdate,
+	// Accounts:
+	voteAccount solana.PublicKey,
+	voteAuthorityAccount solana.PublicKey) *TowerSync {
+	return NewTowerSyncInstructionBuilder().
+		SetTowerSyncUpdate(towerSyncUpdate).
+		SetVoteAccount(voteAccount).
+		SetVoteAuthorityAccount(voteAuthorityAccount)
+}
@@ -0,0 +1,144 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vote
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	bin "github.com/gagliardetto/binary"
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/text/format"
+	"github.com/gagliardetto/treeout"
+)
+
+// Update the vote account's validator identity (node_pubkey).
+type UpdateValidatorIdentity struct {
+	// [0] = [WRITE] VoteAccount
+	// ··········· Vote account to be updated with the given authority public key
+	//
+	// [1] = [SIGNER] NodeAccount
+	// ··········· New validator identity (node_pubkey)
+	//
+	// [2] = [SIGNER] WithdrawAuthorityAccount
+	// ··········· Withdraw authority
+	solana.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+// NewUpdateValidatorIdentityInstructionBuilder creates a new `UpdateValidatorIdentity` instruction builder.
+func NewUpdateValidatorIdentityInstructionBuilder() *UpdateValidatorIdentity {
+	nd := &UpdateValidatorIdentity{
+		AccountMetaSlice: make(solana.AccountMetaSlice, 3),
+	}
+	return nd
+}
+
+// Vote account to be updated with the given authority public key
+func (inst *UpdateValidatorIdentity) SetVoteAccount(voteAccount solana.PublicKey) *UpdateValidatorIdentity {
+	inst.AccountMetaSlice[0] = solana.Meta(voteAccount).WRITE()
+	return inst
+}
+
+func (inst *UpdateValidatorIdentity) GetVoteAccount() *solana.AccountMeta {
+	return inst.AccountMetaSlice[0]
+}
+
+// New validator identity (node_pubkey)
+func (inst *UpdateValidatorIdentity) SetNodeAccount(nodeAccount solana.PublicKey) *UpdateValidatorIdentity {
+	inst.AccountMetaSlice[1] = solana.Meta(nodeAccount).SIGNER()
+	return inst
+}
+
+func (inst *UpdateValidatorIdentity) GetNodeAccount() *solana.AccountMeta {
+	return inst.AccountMetaSlice[1]
+}
+
+// Withdraw authority
+func (inst *UpdateValidatorIdentity) SetWithdrawAuthorityAccount(withdrawAuthorityAccount solana.PublicKey) *UpdateValidatorIdentity {
+	inst.AccountMetaSlice[2] = solana.Meta(withdrawAuthorityAccount).SIGNER()
+	return inst
+}
+
+func (inst *UpdateValidatorIdentity) GetWithdrawAuthorityAccount() *solana.AccountMeta {
+	return inst.AccountMetaSlice[2]
+}
+
+func (inst UpdateValidatorIdentity) Build() *Instruction {
+	return &Instruction{BaseVariant: bin.BaseVariant{
+		Impl:   inst,
+		TypeID: bin.TypeIDFromUint32(Instruction_UpdateValidatorIdentity, binary.LittleEndian),
+	}}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (inst UpdateValidatorIdentity) ValidateAndBuild() (*Instruction, error) {
+	if err := inst.Validate(); err != nil {
+		return nil, err
+	}
+	return inst.Build(), nil
+}
+
+func (inst *UpdateValidatorIdentity) Validate() error {
+	// Check whether all (required) accounts are set:
+	for accIndex, acc := range inst.AccountMetaSlice {
+		if acc == nil {
+			return fmt.Errorf("ins.AccountMetaSlice[%v] is not set", accIndex)
+		}
+	}
+	return nil
+}
+
+func (inst *UpdateValidatorIdentity) EncodeToTree(parent treeout.Branches) {
+	parent.Child(format.Program(ProgramName, ProgramID)).
+		//
+		ParentFunc(func(programBranch treeout.Branches) {
+			programBranch.Child(format.Instruction("UpdateValidatorIdentity")).
+				//
+				ParentFunc(func(instructionBranch treeout.Branches) {
+
+					// Parameters of the instruction:
+					instructionBranch.Child("Params").ParentFunc(func(paramsBranch treeout.Branches) {})
+
+					// Accounts of the instruction:
+					instructionBranch.Child("Accounts").ParentFunc(func(accountsBranch treeout.Branches) {
+						accountsBranch.Child(format.Meta("             Vote", inst.AccountMetaSlice.Get(0)))
+						accountsBranch.Child(format.Meta("             Node", inst.AccountMetaSlice.Get(1)))
+						accountsBranch.Child(format.Meta("WithdrawAuthority", inst.AccountMetaSlice.Get(2)))
+					})
+				})
+		})
+}
+
+func (inst UpdateValidatorIdentity) MarshalWithEncoder(encoder *bin.Encoder) error {
+	return nil
+}
+
+func (inst *UpdateValidatorIdentity) UnmarshalWithDecoder(decoder *bin.Decoder) error {
+	return nil
+}
+
+// NewUpdateValidatorIdentityInstruction declares a new UpdateValidatorIdentity instruction with the provided parameters and accounts.
+func NewUpdateValidatorIdentityInstruction(
+	// Accounts:
+	voteAccount solana.PublicKey,
+	nodeAccount solana.PublicKey,
+	withdrawAuthorityAccount solana.PublicKey) *UpdateValidatorIdentity {
+	return NewUpdateValidatorIdentityInstructionBuilder().
+		SetVoteAccount(voteAccount).
+		SetNodeAccount(nodeAccount).
+		SetWithdrawAuthorityAccount(withdrawAuthorityAccount)
+}
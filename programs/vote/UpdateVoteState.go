@@ -0,0 +1,164 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vote
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	bin "github.com/gagliardetto/binary"
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/text/format"
+	"github.com/gagliardetto/treeout"
+)
+
+// Update the onchain vote state for the signer.
+type UpdateVoteState struct {
+	// The proposed vote state
+	VoteStateUpdate *VoteStateUpdate
+
+	// [0] = [WRITE] VoteAccount
+	// ··········· Vote account to vote with
+	//
+	// [1] = [SIGNER] VoteAuthorityAccount
+	// ··········· Vote authority
+	solana.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+// NewUpdateVoteStateInstructionBuilder creates a new `UpdateVoteState` instruction builder.
+func NewUpdateVoteStateInstructionBuilder() *UpdateVoteState {
+	nd := &UpdateVoteState{
+		AccountMetaSlice: make(solana.AccountMetaSlice, 2),
+	}
+	return nd
+}
+
+// The proposed vote state
+func (inst *UpdateVoteState) SetVoteStateUpdate(voteStateUpdate VoteStateUpdate) *UpdateVoteState {
+	inst.VoteStateUpdate = &voteStateUpdate
+	return inst
+}
+
+// Vote account to vote with
+func (inst *UpdateVoteState) SetVoteAccount(voteAccount solana.PublicKey) *UpdateVoteState {
+	inst.AccountMetaSlice[0] = solana.Meta(voteAccount).WRITE()
+	return inst
+}
+
+func (inst *UpdateVoteState) GetVoteAccount() *solana.AccountMeta {
+	return inst.AccountMetaSlice[0]
+}
+
+// Vote authority
+func (inst *UpdateVoteState) SetVoteAuthorityAccount(voteAuthorityAccount solana.PublicKey) *UpdateVoteState {
+	inst.AccountMetaSlice[1] = solana.Meta(voteAuthorityAccount).SIGNER()
+	return inst
+}
+
+func (inst *UpdateVoteState) GetVoteAuthorityAccount() *solana.AccountMeta {
+	return inst.AccountMetaSlice[1]
+}
+
+func (inst UpdateVoteState) Build() *Instruction {
+	return &Instruction{BaseVariant: bin.BaseVariant{
+		Impl:   inst,
+		TypeID: bin.TypeIDFromUint32(Instruction_UpdateVoteState, binary.LittleEndian),
+	}}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (inst UpdateVoteState) ValidateAndBuild() (*Instruction, error) {
+	if err := inst.Validate(); err != nil {
+		return nil, err
+	}
+	return inst.Build(), nil
+}
+
+func (inst *UpdateVoteState) Validate() error {
+	// Check whether all (required) parameters are set:
+	{
+		if inst.VoteStateUpdate == nil {
+			return errors.New("VoteStateUpdate parameter is not set")
+		}
+	}
+
+	// Check whether all (required) accounts are set:
+	for accIndex, acc := range inst.AccountMetaSlice {
+		if acc == nil {
+			return fmt.Errorf("ins.AccountMetaSlice[%v] is not set", accIndex)
+		}
+	}
+	return nil
+}
+
+func (inst *UpdateVoteState) EncodeToTree(parent treeout.Branches) {
+	parent.Child(format.Program(ProgramName, ProgramID)).
+		//
+		ParentFunc(func(programBranch treeout.Branches) {
+			programBranch.Child(format.Instruction("UpdateVoteState")).
+				//
+				ParentFunc(func(instructionBranch treeout.Branches) {
+
+					// Parameters of the instruction:
+					instructionBranch.Child("Params").ParentFunc(func(paramsBranch treeout.Branches) {
+						paramsBranch.Child(format.Param("VoteStateUpdate", *inst.VoteStateUpdate))
+					})
+
+					// Accounts of the instruction:
+					instructionBranch.Child("Accounts").ParentFunc(func(accountsBranch treeout.Branches) {
+						accountsBranch.Child(format.Meta("         Vote", inst.AccountMetaSlice.Get(0)))
+						accountsBranch.Child(format.Meta("VoteAuthority", inst.AccountMetaSlice.Get(1)))
+					})
+				})
+		})
+}
+
+func (inst UpdateVoteState) MarshalWithEncoder(encoder *bin.Encoder) error {
+	// Serialize `VoteStateUpdate` param:
+	{
+		err := encoder.Encode(*inst.VoteStateUpdate)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (inst *UpdateVoteState) UnmarshalWithDecoder(decoder *bin.Decoder) error {
+	// Deserialize `VoteStateUpdate` param:
+	{
+		err := decoder.Decode(&inst.VoteStateUpdate)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// NewUpdateVoteStateInstruction declares a new UpdateVoteState instruction with the provided parameters and accounts.
+func NewUpdateVoteStateInstruction(
+	// Parameters:
+	voteStateUpdate VoteStateUpdate,
+	// Accounts:
+	voteAccount solana.PublicKey,
+	voteAuthorityAccount solana.PublicKey) *UpdateVoteState {
+	return NewUpdateVoteStateInstructionBuilder().
+		SetVoteStateUpdate(voteStateUpdate).
+		SetVoteAccount(voteAccount).
+		SetVoteAuthorityAccount(voteAuthorityAccount)
+}
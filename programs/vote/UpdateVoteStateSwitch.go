@@ -0,0 +1,193 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vote
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	bin "github.com/gagliardetto/binary"
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/text/format"
+	"github.com/gagliardetto/treeout"
+)
+
+// Update the onchain vote state for the signer along with a switching proof.
+type UpdateVoteStateSwitch struct {
+	// The proposed vote state
+	VoteStateUpdate *VoteStateUpdate
+
+	// Hash of the switching proof
+	ProofHash *solana.Hash
+
+	// [0] = [WRITE] VoteAccount
+	// ··········· Vote account to vote with
+	//
+	// [1] = [SIGNER] VoteAuthorityAccount
+	// ··········· Vote authority
+	solana.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+// NewUpdateVoteStateSwitchInstructionBuilder creates a new `UpdateVoteStateSwitch` instruction builder.
+func NewUpdateVoteStateSwitchInstructionBuilder() *UpdateVoteStateSwitch {
+	nd := &UpdateVoteStateSwitch{
+		AccountMetaSlice: make(solana.AccountMetaSlice, 2),
+	}
+	return nd
+}
+
+// The proposed vote state
+func (inst *UpdateVoteStateSwitch) SetVoteStateUpdate(voteStateUpdate VoteStateUpdate) *UpdateVoteStateSwitch {
+	inst.VoteStateUpdate = &voteStateUpdate
+	return inst
+}
+
+// Hash of the switching proof
+func (inst *UpdateVoteStateSwitch) SetProofHash(proofHash solana.Hash) *UpdateVoteStateSwitch {
+	inst.ProofHash = &proofHash
+	return inst
+}
+
+// Vote account to vote with
+func (inst *UpdateVoteStateSwitch) SetVoteAccount(voteAccount solana.PublicKey) *UpdateVoteStateSwitch {
+	inst.AccountMetaSlice[0] = solana.Meta(voteAccount).WRITE()
+	return inst
+}
+
+func (inst *UpdateVoteStateSwitch) GetVoteAccount() *solana.AccountMeta {
+	return inst.AccountMetaSlice[0]
+}
+
+// Vote authority
+func (inst *UpdateVoteStateSwitch) SetVoteAuthorityAccount(voteAuthorityAccount solana.PublicKey) *UpdateVoteStateSwitch {
+	inst.AccountMetaSlice[1] = solana.Meta(voteAuthorityAccount).SIGNER()
+	return inst
+}
+
+func (inst *UpdateVoteStateSwitch) GetVoteAuthorityAccount() *solana.AccountMeta {
+	return inst.AccountMetaSlice[1]
+}
+
+func (inst UpdateVoteStateSwitch) Build() *Instruction {
+	return &Instruction{BaseVariant: bin.BaseVariant{
+		Impl:   inst,
+		TypeID: bin.TypeIDFromUint32(Instruction_UpdateVoteStateSwitch, binary.LittleEndian),
+	}}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (inst UpdateVoteStateSwitch) ValidateAndBuild() (*Instruction, error) {
+	if err := inst.Validate(); err != nil {
+		return nil, err
+	}
+	return inst.Build(), nil
+}
+
+func (inst *UpdateVoteStateSwitch) Validate() error {
+	// Check whether all (required) parameters are set:
+	{
+		if inst.VoteStateUpdate == nil {
+			return errors.New("VoteStateUpdate parameter is not set")
+		}
+		if inst.ProofHash == nil {
+			return errors.New("ProofHash parameter is not set")
+		}
+	}
+
+	// Check whether all (required) accounts are set:
+	for accIndex, acc := range inst.AccountMetaSlice {
+		if acc == nil {
+			return fmt.Errorf("ins.AccountMetaSlice[%v] is not set", accIndex)
+		}
+	}
+	return nil
+}
+
+func (inst *UpdateVoteStateSwitch) EncodeToTree(parent treeout.Branches) {
+	parent.Child(format.Program(ProgramName, ProgramID)).
+		//
+		ParentFunc(func(programBranch treeout.Branches) {
+			programBranch.Child(format.Instruction("UpdateVoteStateSwitch")).
+				//
+				ParentFunc(func(instructionBranch treeout.Branches) {
+
+					// Parameters of the instruction:
+					instructionBranch.Child("Params").ParentFunc(func(paramsBranch treeout.Branches) {
+						paramsBranch.Child(format.Param("VoteStateUpdate", *inst.VoteStateUpdate))
+						paramsBranch.Child(format.Param("      ProofHash", *inst.ProofHash))
+					})
+
+					// Accounts of the instruction:
+					instructionBranch.Child("Accounts").ParentFunc(func(accountsBranch treeout.Branches) {
+						accountsBranch.Child(format.Meta("         Vote", inst.AccountMetaSlice.Get(0)))
+						accountsBranch.Child(format.Meta("VoteAuthority", inst.AccountMetaSlice.Get(1)))
+					})
+				})
+		})
+}
+
+func (inst UpdateVoteStateSwitch) MarshalWithEncoder(encoder *bin.Encoder) error {
+	// Serialize `VoteStateUpdate` param:
+	{
+		err := encoder.Encode(*inst.VoteStateUpdate)
+		if err != nil {
+			return err
+		}
+	}
+	// Serialize `ProofHash` param:
+	{
+		err := encoder.Encode(*inst.ProofHash)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (inst *UpdateVoteStateSwitch) UnmarshalWithDecoder(decoder *bin.Decoder) error {
+	// Deserialize `VoteStateUpdate` param:
+	{
+		err := decoder.Decode(&inst.VoteStateUpdate)
+		if err != nil {
+			return err
+		}
+	}
+	// Deserialize `ProofHash` param:
+	{
+		err := decoder.Decode(&inst.ProofHash)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// NewUpdateVoteStateSwitchInstruction declares a new UpdateVoteStateSwitch instruction with the provided parameters and accounts.
+func NewUpdateVoteStateSwitchInstruction(
+	// Parameters:
+	voteStateUpdate VoteStateUpdate,
+	proofHash solana.Hash,
+	// Accounts:
+	voteAccount solana.PublicKey,
+	voteAuthorityAccount solana.PublicKey) *UpdateVoteStateSwitch {
+	return NewUpdateVoteStateSwitchInstructionBuilder().
+		SetVoteStateUpdate(voteStateUpdate).
+		SetProofHash(proofHash).
+		SetVoteAccount(voteAccount).
+		SetVoteAuthorityAccount(voteAuthorityAccount)
+}
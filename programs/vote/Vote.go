@@ -15,6 +15,7 @@
 package vote
 
 import (
+	"encoding/binary"
 	"fmt"
 	"time"
 
@@ -24,6 +25,10 @@ import (
 	"github.com/gagliardetto/treeout"
 )
 
+// Vote with the slots in the provided order.
+//
+// Deprecated by the runtime in favor of TowerSync, but still found in
+// historical blocks.
 type Vote struct {
 	Slots     []uint64
 	Hash      solana.Hash
@@ -43,39 +48,78 @@ type Vote struct {
 	solana.AccountMetaSlice `bin:"-" borsh_skip:"true"`
 }
 
-func (v *Vote) UnmarshalWithDecoder(dec *bin.Decoder) error {
-	v.Slots = nil
-	var numSlots uint64
-	if err := dec.Decode(&numSlots); err != nil {
-		return err
-	}
-	for i := uint64(0); i < numSlots; i++ {
-		var slot uint64
-		if err := dec.Decode(&slot); err != nil {
-			return err
-		}
-		v.Slots = append(v.Slots, slot)
-	}
-	if err := dec.Decode(&v.Hash); err != nil {
-		return err
+// NewVoteInstructionBuilder creates a new `Vote` instruction builder.
+func NewVoteInstructionBuilder() *Vote {
+	nd := &Vote{
+		AccountMetaSlice: make(solana.AccountMetaSlice, 4),
 	}
-	var timestampVariant uint8
-	if err := dec.Decode(&timestampVariant); err != nil {
-		return err
-	}
-	switch timestampVariant {
-	case 0:
-		break
-	case 1:
-		var ts int64
-		if err := dec.Decode(&ts); err != nil {
-			return err
-		}
-		v.Timestamp = &ts
-	default:
-		return fmt.Errorf("invalid vote timestamp variant %#08x", timestampVariant)
+	nd.AccountMetaSlice[1] = solana.Meta(solana.SysVarSlotHashesPubkey)
+	nd.AccountMetaSlice[2] = solana.Meta(solana.SysVarClockPubkey)
+	return nd
+}
+
+// Voted slots
+func (inst *Vote) SetSlots(slots ...uint64) *Vote {
+	inst.Slots = slots
+	return inst
+}
+
+// Bank hash of the last voted slot
+func (inst *Vote) SetHash(hash solana.Hash) *Vote {
+	inst.Hash = hash
+	return inst
+}
+
+// Processing timestamp of the last voted slot
+func (inst *Vote) SetTimestamp(timestamp int64) *Vote {
+	inst.Timestamp = &timestamp
+	return inst
+}
+
+// Vote account to vote with
+func (inst *Vote) SetVoteAccount(voteAccount solana.PublicKey) *Vote {
+	inst.AccountMetaSlice[0] = solana.Meta(voteAccount).WRITE()
+	return inst
+}
+
+func (inst *Vote) GetVoteAccount() *solana.AccountMeta {
+	return inst.AccountMetaSlice[0]
+}
+
+// Vote authority
+func (inst *Vote) SetVoteAuthorityAccount(voteAuthorityAccount solana.PublicKey) *Vote {
+	inst.AccountMetaSlice[3] = solana.Meta(voteAuthorityAccount).SIGNER()
+	return inst
+}
+
+func (inst *Vote) GetVoteAuthorityAccount() *solana.AccountMeta {
+	return inst.AccountMetaSlice[3]
+}
+
+func (inst Vote) Build() *Instruction {
+	return &Instruction{BaseVariant: bin.BaseVariant{
+		Impl:   inst,
+		TypeID: bin.TypeIDFromUint32(Instruction_Vote, binary.LittleEndian),
+	}}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (inst Vote) ValidateAndBuild() (*Instruction, error) {
+	if err := inst.Validate(); err != nil {
+		return nil, err
 	}
-	return nil
+	return inst.Build(), nil
+}
+
+func (v Vote) MarshalWithEncoder(enc *bin.Encoder) error {
+	return marshalVote(enc, v.Slots, v.Hash, v.Timestamp)
+}
+
+func (v *Vote) UnmarshalWithDecoder(dec *bin.Decoder) (err error) {
+	v.Slots, v.Hash, v.Timestamp, err = unmarshalVote(dec)
+	return err
 }
 
 func (inst *Vote) Validate() error {
@@ -106,11 +150,30 @@ func (inst *Vote) EncodeToTree(parent treeout.Branches) {
 
 					// Accounts of the instruction:
 					instructionBranch.Child("Accounts").ParentFunc(func(accountsBranch treeout.Branches) {
-						accountsBranch.Child(format.Meta("Vote Account      ", inst.AccountMetaSlice[0]))
-						accountsBranch.Child(format.Meta("Slot Hashes Sysvar", inst.AccountMetaSlice[1]))
-						accountsBranch.Child(format.Meta("Clock Sysvar      ", inst.AccountMetaSlice[2]))
-						accountsBranch.Child(format.Meta("Vote Authority    ", inst.AccountMetaSlice[3]))
+						accountsBranch.Child(format.Meta("Vote Account      ", inst.AccountMetaSlice.Get(0)))
+						accountsBranch.Child(format.Meta("Slot Hashes Sysvar", inst.AccountMetaSlice.Get(1)))
+						accountsBranch.Child(format.Meta("Clock Sysvar      ", inst.AccountMetaSlice.Get(2)))
+						accountsBranch.Child(format.Meta("Vote Authority    ", inst.AccountMetaSlice.Get(3)))
 					})
 				})
 		})
 }
+
+// NewVoteInstruction declares a new Vote instruction with the provided parameters and accounts.
+func NewVoteInstruction(
+	// Parameters:
+	slots []uint64,
+	hash solana.Hash,
+	timestamp *int64,
+	// Accounts:
+	voteAccount solana.PublicKey,
+	voteAuthorityAccount solana.PublicKey,
+) *Vote {
+	inst := NewVoteInstructionBuilder().
+		SetSlots(slots...).
+		SetHash(hash).
+		SetVoteAccount(voteAccount).
+		SetVoteAuthorityAccount(voteAuthorityAccount)
+	inst.Timestamp = timestamp
+	return inst
+}
@@ -0,0 +1,197 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vote
+
+import (
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	bin "github.com/gagliardetto/binary"
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/text/format"
+	"github.com/gagliardetto/treeout"
+)
+
+// Vote with the slots in the provided order, along with a switching proof.
+//
+// Deprecated by the runtime in favor of TowerSyncSwitch, but still found in
+// historical blocks.
+type VoteSwitch struct {
+	Slots     []uint64
+	Hash      solana.Hash
+	Timestamp *int64
+
+	// Hash of the switching proof
+	ProofHash solana.Hash
+
+	// [0] = [WRITE] VoteAccount
+	// ··········· Vote account to vote with
+	//
+	// [1] = [] SysVarSlotHashes
+	// ··········· Slot hashes sysvar
+	//
+	// [2] = [] SysVarClock
+	// ··········· Clock sysvar
+	//
+	// [3] = [SIGNER] VoteAuthority
+	// ··········· Vote authority
+	solana.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+// NewVoteSwitchInstructionBuilder creates a new `VoteSwitch` instruction builder.
+func NewVoteSwitchInstructionBuilder() *VoteSwitch {
+	nd := &VoteSwitch{
+		AccountMetaSlice: make(solana.AccountMetaSlice, 4),
+	}
+	nd.AccountMetaSlice[1] = solana.Meta(solana.SysVarSlotHashesPubkey)
+	nd.AccountMetaSlice[2] = solana.Meta(solana.SysVarClockPubkey)
+	return nd
+}
+
+// Voted slots
+func (inst *VoteSwitch) SetSlots(slots ...uint64) *VoteSwitch {
+	inst.Slots = slots
+	return inst
+}
+
+// Bank hash of the last voted slot
+func (inst *VoteSwitch) SetHash(hash solana.Hash) *VoteSwitch {
+	inst.Hash = hash
+	return inst
+}
+
+// Processing timestamp of the last voted slot
+func (inst *VoteSwitch) SetTimestamp(timestamp int64) *VoteSwitch {
+	inst.Timestamp = &timestamp
+	return inst
+}
+
+// Hash of the switching proof
+func (inst *VoteSwitch) SetProofHash(proofHash solana.Hash) *VoteSwitch {
+	inst.ProofHash = proofHash
+	return inst
+}
+
+// Vote account to vote with
+func (inst *VoteSwitch) SetVoteAccount(voteAccount solana.PublicKey) *VoteSwitch {
+	inst.AccountMetaSlice[0] = solana.Meta(voteAccount).WRITE()
+	return inst
+}
+
+func (inst *VoteSwitch) GetVoteAccount() *solana.AccountMeta {
+	return inst.AccountMetaSlice[0]
+}
+
+// Vote authority
+func (inst *VoteSwitch) SetVoteAuthorityAccount(voteAuthorityAccount solana.PublicKey) *VoteSwitch {
+	inst.AccountMetaSlice[3] = solana.Meta(voteAuthorityAccount).SIGNER()
+	return inst
+}
+
+func (inst *VoteSwitch) GetVoteAuthorityAccount() *solana.AccountMeta {
+	return inst.AccountMetaSlice[3]
+}
+
+func (inst VoteSwitch) Build() *Instruction {
+	return &Instruction{BaseVariant: bin.BaseVariant{
+		Impl:   inst,
+		TypeID: bin.TypeIDFromUint32(Instruction_VoteSwitch, binary.LittleEndian),
+	}}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (inst VoteSwitch) ValidateAndBuild() (*Instruction, error) {
+	if err := inst.Validate(); err != nil {
+		return nil, err
+	}
+	return inst.Build(), nil
+}
+
+func (v VoteSwitch) MarshalWithEncoder(enc *bin.Encoder) error {
+	if err := marshalVote(enc, v.Slots, v.Hash, v.Timestamp); err != nil {
+		return err
+	}
+	return enc.WriteBytes(v.ProofHash[:], false)
+}
+
+func (v *VoteSwitch) UnmarshalWithDecoder(dec *bin.Decoder) (err error) {
+	if v.Slots, v.Hash, v.Timestamp, err = unmarshalVote(dec); err != nil {
+		return err
+	}
+	v.ProofHash, err = readHash(dec)
+	return err
+}
+
+func (inst *VoteSwitch) Validate() error {
+	// Check whether all accounts are set:
+	for accIndex, acc := range inst.AccountMetaSlice {
+		if acc == nil {
+			return fmt.Errorf("ins.AccountMetaSlice[%v] is not set", accIndex)
+		}
+	}
+	return nil
+}
+
+func (inst *VoteSwitch) EncodeToTree(parent treeout.Branches) {
+	parent.Child(format.Program(ProgramName, ProgramID)).
+		ParentFunc(func(programBranch treeout.Branches) {
+			programBranch.Child(format.Instruction("VoteSwitch")).
+				ParentFunc(func(instructionBranch treeout.Branches) {
+					// Parameters of the instruction:
+					instructionBranch.Child("Params").ParentFunc(func(paramsBranch treeout.Branches) {
+						paramsBranch.Child(format.Param("Slots", inst.Slots))
+						paramsBranch.Child(format.Param("Hash", inst.Hash))
+						var ts time.Time
+						if inst.Timestamp != nil {
+							ts = time.Unix(*inst.Timestamp, 0).UTC()
+						}
+						paramsBranch.Child(format.Param("Timestamp", ts))
+						paramsBranch.Child(format.Param("ProofHash", inst.ProofHash))
+					})
+
+					// Accounts of the instruction:
+					instructionBranch.Child("Accounts").ParentFunc(func(accountsBranch treeout.Branches) {
+						accountsBranch.Child(format.Meta("Vote Account      ", inst.AccountMetaSlice.Get(0)))
+						accountsBranch.Child(format.Meta("Slot Hashes Sysvar", inst.AccountMetaSlice.Get(1)))
+						accountsBranch.Child(format.Meta("Clock Sysvar      ", inst.AccountMetaSlice.Get(2)))
+						accountsBranch.Child(format.Meta("Vote Authority    ", inst.AccountMetaSlice.Get(3)))
+					})
+				})
+		})
+}
+
+// NewVoteSwitchInstruction declares a new VoteSwitch instruction with the provided parameters and accounts.
+func NewVoteSwitchInstruction(
+	// Parameters:
+	slots []uint64,
+	hash solana.Hash,
+	timestamp *int64,
+	proofHash solana.Hash,
+	// Accounts:
+	voteAccount solana.PublicKey,
+	voteAuthorityAccount solana.PublicKey,
+) *VoteSwitch {
+	inst := NewVoteSwitchInstructionBuilder().
+		SetSlots(slots...).
+		SetHash(hash).
+		SetProofHash(proofHash).
+		SetVoteAccount(voteAccount).
+		SetVoteAuthorityAccount(voteAuthorityAccount)
+	inst.Timestamp = timestamp
+	return inst
+}
@@ -16,6 +16,7 @@
 package vote
 
 import (
+	"encoding/binary"
 	"errors"
 	"fmt"
 
@@ -52,7 +53,7 @@ func (v *Withdraw) UnmarshalWithDecoder(dec *bin.Decoder) error {
 	return nil
 }
 
-func (inst *Withdraw) MarshalWithEncoder(encoder *bin.Encoder) error {
+func (inst Withdraw) MarshalWithEncoder(encoder *bin.Encoder) error {
 	// Serialize `Lamports` param:
 	{
 		err := encoder.Encode(*inst.Lamports)
@@ -98,12 +99,41 @@ func (inst *Withdraw) SetWithdrawAuthorityAccount(withdrawAccount solana.PublicK
 	return inst
 }
 
+func (inst *Withdraw) GetVoteAccount() *solana.AccountMeta {
+	return inst.AccountMetaSlice[0]
+}
+
+func (inst *Withdraw) GetRecipientAccount() *solana.AccountMeta {
+	return inst.AccountMetaSlice[1]
+}
+
+func (inst *Withdraw) GetWithdrawAuthorityAccount() *solana.AccountMeta {
+	return inst.AccountMetaSlice[2]
+}
+
 // Number of lamports to transfer to the recipient account
 func (inst *Withdraw) SetLamports(lamports uint64) *Withdraw {
 	inst.Lamports = &lamports
 	return inst
 }
 
+func (inst Withdraw) Build() *Instruction {
+	return &Instruction{BaseVariant: bin.BaseVariant{
+		Impl:   inst,
+		TypeID: bin.TypeIDFromUint32(Instruction_Withdraw, binary.LittleEndian),
+	}}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (inst Withdraw) ValidateAndBuild() (*Instruction, error) {
+	if err := inst.Validate(); err != nil {
+		return nil, err
+	}
+	return inst.Build(), nil
+}
+
 func (inst *Withdraw) EncodeToTree(parent treeout.Branches) {
 	parent.Child(format.Program(ProgramName, ProgramID)).
 		//
@@ -0,0 +1,402 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vote
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	bin "github.com/gagliardetto/binary"
+	"github.com/gagliardetto/solana-go"
+)
+
+const (
+	// Size in bytes of a vote account holding the current VoteState.
+	VoteStateSize = 3762
+
+	// Size in bytes of a vote account holding a V1_14_11 VoteState.
+	VoteState1_14_11Size = 3731
+
+	// Number of entries of the prior voters circular buffer.
+	MaxPriorVoters = 32
+
+	// Maximum number of votes (lockouts) kept in the tower.
+	MaxLockoutHistory = 31
+
+	// Maximum number of epoch credits entries kept in the vote state.
+	MaxEpochCreditsHistory = 64
+)
+
+// VoteStateVersion is the version tag of the data of a vote account.
+type VoteStateVersion uint32
+
+const (
+	VoteStateVersionV0_23_5 VoteStateVersion = iota
+	VoteStateVersionV1_14_11
+	VoteStateVersionCurrent
+)
+
+func (v VoteStateVersion) String() string {
+	switch v {
+	case VoteStateVersionV0_23_5:
+		return "V0_23_5"
+	case VoteStateVersionV1_14_11:
+		return "V1_14_11"
+	case VoteStateVersionCurrent:
+		return "Current"
+	default:
+		return fmt.Sprintf("Unknown(%d)", uint32(v))
+	}
+}
+
+// VoteState is the state of an account owned by the vote program.
+//
+// Both the V1_14_11 and the current versions are decoded into this struct;
+// the versions only differ in the vote latencies, which are always zero for
+// V1_14_11.
+type VoteState struct {
+	Version VoteStateVersion
+
+	// The validator identity that signs the votes in this account.
+	NodePubkey solana.PublicKey
+
+	// The signer for withdrawals.
+	AuthorizedWithdrawer solana.PublicKey
+
+	// Percentage (0-100) that represents what part of a rewards
+	// payout should be given to this VoteAccount.
+	Commission uint8
+
+	// The tower, oldest vote first.
+	Votes []LandedVote
+
+	// This is usually the last Lockout which was popped from Votes.
+	// However, it can be an arbitrary slot, when being used inside the Tower.
+	RootSlot *uint64
+
+	// The authorized voter for each epoch, sorted by epoch.
+	AuthorizedVoters []AuthorizedVoter
+
+	// History of prior authorized voters and the epochs for which
+	// they were set.
+	PriorVoters PriorVoters
+
+	// History of how many credits earned by the end of each epoch,
+	// oldest first.
+	EpochCredits []EpochCredits
+
+	// Most recent timestamp submitted with a vote.
+	LastTimestamp BlockTimestamp
+}
+
+// AuthorizedVoter is the voter authorized starting from an epoch.
+type AuthorizedVoter struct {
+	Epoch  uint64
+	Pubkey solana.PublicKey
+}
+
+// PriorVoter is a voter that was authorized from EpochStart
+// until EpochEnd (exclusive).
+type PriorVoter struct {
+	Pubkey     solana.PublicKey
+	EpochStart uint64
+	EpochEnd   uint64
+}
+
+// PriorVoters is a circular buffer of prior voters;
+// Idx is the index of the most recent entry.
+type PriorVoters struct {
+	Buf     [MaxPriorVoters]PriorVoter
+	Idx     uint64
+	IsEmpty bool
+}
+
+// Last returns the most recent prior voter, or nil if there is none.
+func (p *PriorVoters) Last() *PriorVoter {
+	if p.IsEmpty || p.Idx >= MaxPriorVoters {
+		return nil
+	}
+	return &p.Buf[p.Idx]
+}
+
+// EpochCredits are the credits earned by the end of an epoch,
+// and the credits at the end of the previous entry.
+type EpochCredits struct {
+	Epoch       uint64
+	Credits     uint64
+	PrevCredits uint64
+}
+
+// Earned returns the credits earned during the epoch.
+func (c EpochCredits) Earned() uint64 {
+	return c.Credits - c.PrevCredits
+}
+
+// BlockTimestamp is a timestamp submitted with a vote, and its slot.
+type BlockTimestamp struct {
+	Slot      uint64
+	Timestamp int64
+}
+
+// DecodeVoteState decodes the data of a vote account.
+func DecodeVoteState(data []byte) (*VoteState, error) {
+	state := new(VoteState)
+	if err := state.UnmarshalWithDecoder(bin.NewBinDecoder(data)); err != nil {
+		return nil, fmt.Errorf("unable to decode vote state: %w", err)
+	}
+	return state, nil
+}
+
+// LastVotedSlot returns the slot of the most recent vote,
+// and false if the tower is empty.
+func (state *VoteState) LastVotedSlot() (uint64, bool) {
+	if len(state.Votes) == 0 {
+		return 0, false
+	}
+	return state.Votes[len(state.Votes)-1].Lockout.Slot, true
+}
+
+// Credits returns the total number of credits earned by the account.
+func (state *VoteState) Credits() uint64 {
+	if len(state.EpochCredits) == 0 {
+		return 0
+	}
+	return state.EpochCredits[len(state.EpochCredits)-1].Credits
+}
+
+// GetAuthorizedVoter returns the voter authorized for the provided epoch,
+// i.e. the one with the latest starting epoch that is not after it;
+// it returns false if there is none.
+func (state *VoteState) GetAuthorizedVoter(epoch uint64) (solana.PublicKey, bool) {
+	for i := len(state.AuthorizedVoters) - 1; i >= 0; i-- {
+		if state.AuthorizedVoters[i].Epoch <= epoch {
+			return state.AuthorizedVoters[i].Pubkey, true
+		}
+	}
+	return solana.PublicKey{}, false
+}
+
+func (state *VoteState) UnmarshalWithDecoder(decoder *bin.Decoder) (err error) {
+	version, err := decoder.ReadUint32(binary.LittleEndian)
+	if err != nil {
+		return err
+	}
+	*state = VoteState{Version: VoteStateVersion(version)}
+	switch state.Version {
+	case VoteStateVersionV1_14_11, VoteStateVersionCurrent:
+	default:
+		return fmt.Errorf("unsupported vote state version: %s", state.Version)
+	}
+
+	if state.NodePubkey, err = readPublicKey(decoder); err != nil {
+		return err
+	}
+	if state.AuthorizedWithdrawer, err = readPublicKey(decoder); err != nil {
+		return err
+	}
+	if state.Commission, err = decoder.ReadUint8(); err != nil {
+		return err
+	}
+	// Deserialize `Votes`:
+	{
+		length, err := decoder.ReadUint64(binary.LittleEndian)
+		if err != nil {
+			return err
+		}
+		// Each vote is at least 12 bytes.
+		if length > uint64(decoder.Remaining()/12) {
+			return fmt.Errorf("invalid votes length: %d", length)
+		}
+		state.Votes = make([]LandedVote, length)
+		for i := range state.Votes {
+			if state.Version == VoteStateVersionV1_14_11 {
+				err = state.Votes[i].Lockout.UnmarshalWithDecoder(decoder)
+			} else {
+				err = state.Votes[i].UnmarshalWithDecoder(decoder)
+			}
+			if err != nil {
+				return err
+			}
+		}
+	}
+	if state.RootSlot, err = readOptionUint64(decoder); err != nil {
+		return err
+	}
+	// Deserialize `AuthorizedVoters`:
+	{
+		length, err := decoder.ReadUint64(binary.LittleEndian)
+		if err != nil {
+			return err
+		}
+		// Each entry is 40 bytes.
+		if length > uint64(decoder.Remaining()/40) {
+			return fmt.Errorf("invalid authorized voters length: %d", length)
+		}
+		state.AuthorizedVoters = make([]AuthorizedVoter, length)
+		for i := range state.AuthorizedVoters {
+			if state.AuthorizedVoters[i].Epoch, err = decoder.ReadUint64(binary.LittleEndian); err != nil {
+				return err
+			}
+			if state.AuthorizedVoters[i].Pubkey, err = readPublicKey(decoder); err != nil {
+				return err
+			}
+		}
+	}
+	// Deserialize `PriorVoters`:
+	{
+		for i := range state.PriorVoters.Buf {
+			voter := &state.PriorVoters.Buf[i]
+			if voter.Pubkey, err = readPublicKey(decoder); err != nil {
+				return err
+			}
+			if voter.EpochStart, err = decoder.ReadUint64(binary.LittleEndian); err != nil {
+				return err
+			}
+			if voter.EpochEnd, err = decoder.ReadUint64(binary.LittleEndian); err != nil {
+				return err
+			}
+		}
+		if state.PriorVoters.Idx, err = decoder.ReadUint64(binary.LittleEndian); err != nil {
+			return err
+		}
+		if state.PriorVoters.IsEmpty, err = decoder.ReadBool(); err != nil {
+			return err
+		}
+	}
+	// Deserialize `EpochCredits`:
+	{
+		length, err := decoder.ReadUint64(binary.LittleEndian)
+		if err != nil {
+			return err
+		}
+		// Each entry is 24 bytes.
+		if length > uint64(decoder.Remaining()/24) {
+			return fmt.Errorf("invalid epoch credits length: %d", length)
+		}
+		state.EpochCredits = make([]EpochCredits, length)
+		for i := range state.EpochCredits {
+			credits := &state.EpochCredits[i]
+			if credits.Epoch, err = decoder.ReadUint64(binary.LittleEndian); err != nil {
+				return err
+			}
+			if credits.Credits, err = decoder.ReadUint64(binary.LittleEndian); err != nil {
+				return err
+			}
+			if credits.PrevCredits, err = decoder.ReadUint64(binary.LittleEndian); err != nil {
+				return err
+			}
+		}
+	}
+	if state.LastTimestamp.Slot, err = decoder.ReadUint64(binary.LittleEndian); err != nil {
+		return err
+	}
+	state.LastTimestamp.Timestamp, err = decoder.ReadInt64(binary.LittleEndian)
+	return err
+}
+
+// MarshalWithEncoder encodes the state; the output is zero-padded
+// to VoteStateSize (or VoteState1_14_11Size for that version).
+func (state VoteState) MarshalWithEncoder(encoder *bin.Encoder) (err error) {
+	var size int
+	switch state.Version {
+	case VoteStateVersionV1_14_11:
+		size = VoteState1_14_11Size
+	case VoteStateVersionCurrent:
+		size = VoteStateSize
+	default:
+		return fmt.Errorf("unsupported vote state version: %s", state.Version)
+	}
+	start := encoder.Written()
+	if err = encoder.WriteUint32(uint32(state.Version), binary.LittleEndian); err != nil {
+		return err
+	}
+	if err = encoder.WriteBytes(state.NodePubkey[:], false); err != nil {
+		return err
+	}
+	if err = encoder.WriteBytes(state.AuthorizedWithdrawer[:], false); err != nil {
+		return err
+	}
+	if err = encoder.WriteUint8(state.Commission); err != nil {
+		return err
+	}
+	if err = encoder.WriteUint64(uint64(len(state.Votes)), binary.LittleEndian); err != nil {
+		return err
+	}
+	for _, vote := range state.Votes {
+		if state.Version == VoteStateVersionV1_14_11 {
+			err = vote.Lockout.MarshalWithEncoder(encoder)
+		} else {
+			err = vote.MarshalWithEncoder(encoder)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	if err = writeOptionUint64(encoder, state.RootSlot); err != nil {
+		return err
+	}
+	if err = encoder.WriteUint64(uint64(len(state.AuthorizedVoters)), binary.LittleEndian); err != nil {
+		return err
+	}
+	for _, voter := range state.AuthorizedVoters {
+		if err = encoder.WriteUint64(voter.Epoch, binary.LittleEndian); err != nil {
+			return err
+		}
+		if err = encoder.WriteBytes(voter.Pubkey[:], false); err != nil {
+			return err
+		}
+	}
+	for _, voter := range state.PriorVoters.Buf {
+		if err = encoder.WriteBytes(voter.Pubkey[:], false); err != nil {
+			return err
+		}
+		if err = encoder.WriteUint64(voter.EpochStart, binary.LittleEndian); err != nil {
+			return err
+		}
+		if err = encoder.WriteUint64(voter.EpochEnd, binary.LittleEndian); err != nil {
+			return err
+		}
+	}
+	if err = encoder.WriteUint64(state.PriorVoters.Idx, binary.LittleEndian); err != nil {
+		return err
+	}
+	if err = encoder.WriteBool(state.PriorVoters.IsEmpty); err != nil {
+		return err
+	}
+	if err = encoder.WriteUint64(uint64(len(state.EpochCredits)), binary.LittleEndian); err != nil {
+		return err
+	}
+	for _, credits := range state.EpochCredits {
+		if err = encoder.WriteUint64(credits.Epoch, binary.LittleEndian); err != nil {
+			return err
+		}
+		if err = encoder.WriteUint64(credits.Credits, binary.LittleEndian); err != nil {
+			return err
+		}
+		if err = encoder.WriteUint64(credits.PrevCredits, binary.LittleEndian); err != nil {
+			return err
+		}
+	}
+	if err = encoder.WriteUint64(state.LastTimestamp.Slot, binary.LittleEndian); err != nil {
+		return err
+	}
+	if err = encoder.WriteInt64(state.LastTimestamp.Timestamp, binary.LittleEndian); err != nil {
+		return err
+	}
+	if written := encoder.Written() - start; written < size {
+		return encoder.WriteBytes(make([]byte, size-written), false)
+	}
+	return nil
+}
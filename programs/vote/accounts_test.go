@@ -0,0 +1,220 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vote
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	bin "github.com/gagliardetto/binary"
+	"github.com/gagliardetto/solana-go"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestVoteState(version VoteStateVersion) VoteState {
+	root := uint64(1000)
+	state := VoteState{
+		Version:              version,
+		NodePubkey:           solana.MustPublicKeyFromBase58("7ZhCLV46C9fdnyZBQWkNN8pLUHMYaYPEAWyVL2nmZMfk"),
+		AuthorizedWithdrawer: solana.MustPublicKeyFromBase58("9WzDXwBbmkg8ZTbNMqUxvQRAyrZzDsGYdLVL9zYtAWWM"),
+		Commission:           7,
+		Votes: []LandedVote{
+			{Latency: 1, Lockout: Lockout{Slot: 1001, ConfirmationCount: 3}},
+			{Latency: 2, Lockout: Lockout{Slot: 1002, ConfirmationCount: 2}},
+			{Latency: 1, Lockout: Lockout{Slot: 1004, ConfirmationCount: 1}},
+		},
+		RootSlot: &root,
+		AuthorizedVoters: []AuthorizedVoter{
+			{Epoch: 500, Pubkey: solana.MustPublicKeyFromBase58("CEv7bAukcYMyZxF8jr1iZj9eh3PNYq4hPqTs6AS3tBDD")},
+			{Epoch: 502, Pubkey: solana.MustPublicKeyFromBase58("GJpTBQtpTFAkeSnotG3AG4NxzT3TaZBN9KoDKr1wDfc6")},
+		},
+		EpochCredits: []EpochCredits{
+			{Epoch: 500, Credits: 1000, PrevCredits: 0},
+			{Epoch: 501, Credits: 2500, PrevCredits: 1000},
+		},
+		LastTimestamp: BlockTimestamp{Slot: 1004, Timestamp: 1700000000},
+	}
+	state.PriorVoters.IsEmpty = true
+	if version == VoteStateVersionV1_14_11 {
+		for i := range state.Votes {
+			state.Votes[i].Latency = 0
+		}
+	}
+	return state
+}
+
+func TestVoteState(t *testing.T) {
+	state := newTestVoteState(VoteStateVersionCurrent)
+
+	buf := new(bytes.Buffer)
+	require.NoError(t, bin.NewBinEncoder(buf).Encode(state))
+	data := buf.Bytes()
+	require.Len(t, data, VoteStateSize)
+
+	// Spot-check the bincode layout.
+	require.Equal(t, uint32(VoteStateVersionCurrent), binary.LittleEndian.Uint32(data[0:4]))
+	require.Equal(t, state.NodePubkey[:], data[4:36])
+	require.Equal(t, state.AuthorizedWithdrawer[:], data[36:68])
+	require.Equal(t, byte(7), data[68])
+	require.Equal(t, uint64(3), binary.LittleEndian.Uint64(data[69:77]))
+	require.Equal(t, byte(1), data[77])
+	require.Equal(t, uint64(1001), binary.LittleEndian.Uint64(data[78:86]))
+	require.Equal(t, uint32(3), binary.LittleEndian.Uint32(data[86:90]))
+
+	got, err := DecodeVoteState(data)
+	require.NoError(t, err)
+	require.Equal(t, &state, got)
+
+	slot, ok := got.LastVotedSlot()
+	require.True(t, ok)
+	require.Equal(t, uint64(1004), slot)
+	require.Equal(t, uint64(2500), got.Credits())
+	require.Equal(t, uint64(1500), got.EpochCredits[1].Earned())
+	require.Nil(t, got.PriorVoters.Last())
+
+	voter, ok := got.GetAuthorizedVoter(501)
+	require.True(t, ok)
+	require.Equal(t, state.AuthorizedVoters[0].Pubkey, voter)
+	voter, ok = got.GetAuthorizedVoter(510)
+	require.True(t, ok)
+	require.Equal(t, state.AuthorizedVoters[1].Pubkey, voter)
+	_, ok = got.GetAuthorizedVoter(499)
+	require.False(t, ok)
+}
+
+func TestVoteState_V1_14_11(t *testing.T) {
+	state := newTestVoteState(VoteStateVersionV1_14_11)
+
+	buf := new(bytes.Buffer)
+	require.NoError(t, bin.NewBinEncoder(buf).Encode(state))
+	data := buf.Bytes()
+	require.Len(t, data, VoteState1_14_11Size)
+
+	// Votes are plain lockouts, without the latency.
+	require.Equal(t, uint64(1001), binary.LittleEndian.Uint64(data[77:85]))
+
+	got, err := DecodeVoteState(data)
+	require.NoError(t, err)
+	require.Equal(t, &state, got)
+
+	_, err = DecodeVoteState([]byte{0, 0, 0, 0})
+	require.Error(t, err)
+	_, err = DecodeVoteState([]byte{9, 0, 0, 0})
+	require.Error(t, err)
+}
+
+func TestCompactVoteStateUpdate(t *testing.T) {
+	root := uint64(42)
+	timestamp := int64(1700000000)
+	update := VoteStateUpdate{
+		Lockouts: []Lockout{
+			{Slot: 100, ConfirmationCount: 3},
+			{Slot: 300, ConfirmationCount: 2},
+			{Slot: 301, ConfirmationCount: 1},
+		},
+		Root:      &root,
+		Hash:      solana.Hash{1, 2, 3},
+		Timestamp: &timestamp,
+	}
+	voteAccount := solana.NewWallet().PublicKey()
+	authority := solana.NewWallet().PublicKey()
+
+	inst, err := NewCompactUpdateVoteStateInstruction(update, voteAccount, authority).ValidateAndBuild()
+	require.NoError(t, err)
+	data, err := inst.Data()
+	require.NoError(t, err)
+
+	expected := []byte{12, 0, 0, 0, 42, 0, 0, 0, 0, 0, 0, 0, 3, 58, 3, 0xc8, 0x01, 2, 1, 1}
+	expected = append(expected, update.Hash[:]...)
+	expected = append(expected, 1)
+	expected = binary.LittleEndian.AppendUint64(expected, uint64(timestamp))
+	require.Equal(t, expected, data)
+
+	decoded, err := DecodeInstruction(inst.Accounts(), data)
+	require.NoError(t, err)
+	compact, ok := decoded.Impl.(*CompactUpdateVoteState)
+	require.True(t, ok)
+	require.Equal(t, update, *compact.VoteStateUpdate)
+	require.Equal(t, authority, compact.GetVoteAuthorityAccount().PublicKey)
+
+	// Without a root, the offsets start from slot zero.
+	update.Root = nil
+	tower := TowerSyncUpdate{
+		Lockouts:  update.Lockouts,
+		Hash:      update.Hash,
+		Timestamp: update.Timestamp,
+		BlockID:   solana.Hash{9},
+	}
+	data, err = NewTowerSyncInstruction(tower, voteAccount, authority).Build().Data()
+	require.NoError(t, err)
+	require.Equal(t, []byte{14, 0, 0, 0, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 3, 100, 3}, data[:15])
+
+	decoded, err = DecodeInstruction(inst.Accounts(), data)
+	require.NoError(t, err)
+	require.Equal(t, tower, *decoded.Impl.(*TowerSync).TowerSyncUpdate)
+
+	// Lockouts must be sorted, and the confirmation count must fit in a u8.
+	tower.Lockouts = []Lockout{{Slot: 10, ConfirmationCount: 1}, {Slot: 9, ConfirmationCount: 1}}
+	_, err = NewTowerSyncInstruction(tower, voteAccount, authority).Build().Data()
+	require.Error(t, err)
+	tower.Lockouts = []Lockout{{Slot: 10, ConfirmationCount: 256}}
+	_, err = NewTowerSyncInstruction(tower, voteAccount, authority).Build().Data()
+	require.Error(t, err)
+}
+
+func TestInstructionData(t *testing.T) {
+	voteAccount := solana.NewWallet().PublicKey()
+	authority := solana.NewWallet().PublicKey()
+	recipient := solana.NewWallet().PublicKey()
+
+	inst, err := NewWithdrawInstruction(1000, voteAccount, recipient, authority).ValidateAndBuild()
+	require.NoError(t, err)
+	data, err := inst.Data()
+	require.NoError(t, err)
+	require.Equal(t, []byte{3, 0, 0, 0, 0xe8, 0x03, 0, 0, 0, 0, 0, 0}, data)
+	accounts := inst.Accounts()
+	require.Len(t, accounts, 3)
+	require.True(t, accounts[2].IsSigner)
+	require.True(t, accounts[2].IsWritable)
+
+	inst, err = NewUpdateCommissionInstruction(5, voteAccount, authority).ValidateAndBuild()
+	require.NoError(t, err)
+	data, err = inst.Data()
+	require.NoError(t, err)
+	require.Equal(t, []byte{5, 0, 0, 0, 5}, data)
+
+	newAuthority := solana.NewWallet().PublicKey()
+	inst, err = NewAuthorizeInstruction(newAuthority, VoteAuthorizeWithdrawer, voteAccount, authority).ValidateAndBuild()
+	require.NoError(t, err)
+	data, err = inst.Data()
+	require.NoError(t, err)
+	require.Equal(t, append(append([]byte{1, 0, 0, 0}, newAuthority[:]...), 1, 0, 0, 0), data)
+	require.Equal(t, solana.SysVarClockPubkey, inst.Accounts()[1].PublicKey)
+
+	timestamp := int64(1700000000)
+	inst, err = NewVoteInstruction([]uint64{7, 8}, solana.Hash{1}, &timestamp, voteAccount, authority).ValidateAndBuild()
+	require.NoError(t, err)
+	data, err = inst.Data()
+	require.NoError(t, err)
+	decoded, err := DecodeInstruction(inst.Accounts(), data)
+	require.NoError(t, err)
+	vote, ok := decoded.Impl.(*Vote)
+	require.True(t, ok)
+	require.Equal(t, []uint64{7, 8}, vote.Slots)
+	require.Equal(t, timestamp, *vote.Timestamp)
+	require.Equal(t, solana.SysVarSlotHashesPubkey, vote.AccountMetaSlice[1].PublicKey)
+	require.Equal(t, "Vote", InstructionIDToName(decoded.TypeID.Uint32()))
+}
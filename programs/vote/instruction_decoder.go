@@ -5,9 +5,22 @@ import (
 )
 
 var (
-	DecodeAuthorize         = decode[*Authorize]()
-	DecodeInitializeAccount = decode[*InitializeAccount]()
-	DecodeWithdraw          = decode[*Withdraw]()
+	DecodeAuthorize                    = decode[*Authorize]()
+	DecodeAuthorizeChecked             = decode[*AuthorizeChecked]()
+	DecodeAuthorizeCheckedWithSeed     = decode[*AuthorizeCheckedWithSeed]()
+	DecodeAuthorizeWithSeed            = decode[*AuthorizeWithSeed]()
+	DecodeCompactUpdateVoteState       = decode[*CompactUpdateVoteState]()
+	DecodeCompactUpdateVoteStateSwitch = decode[*CompactUpdateVoteStateSwitch]()
+	DecodeInitializeAccount            = decode[*InitializeAccount]()
+	DecodeTowerSync                    = decode[*TowerSync]()
+	DecodeTowerSyncSwitch              = decode[*TowerSyncSwitch]()
+	DecodeUpdateCommission             = decode[*UpdateCommission]()
+	DecodeUpdateValidatorIdentity      = decode[*UpdateValidatorIdentity]()
+	DecodeUpdateVoteState              = decode[*UpdateVoteState]()
+	DecodeUpdateVoteStateSwitch        = decode[*UpdateVoteStateSwitch]()
+	DecodeVote                         = decode[*Vote]()
+	DecodeVoteSwitch                   = decode[*VoteSwitch]()
+	DecodeWithdraw                     = decode[*Withdraw]()
 )
 
 func decode[T any]() func(*solana.Message, int) (T, error) {
@@ -39,6 +39,96 @@ func init() {
 	solana.RegisterInstructionDecoder(ProgramID, registryDecodeInstruction)
 }
 
+const (
+	// Initialize a vote account
+	Instruction_InitializeAccount uint32 = iota
+
+	// Authorize a key to send votes or issue a withdrawal
+	Instruction_Authorize
+
+	// A Vote instruction with recent votes
+	Instruction_Vote
+
+	// Withdraw some amount of funds
+	Instruction_Withdraw
+
+	// Update the vote account's validator identity (node_pubkey)
+	Instruction_UpdateValidatorIdentity
+
+	// Update the commission for the vote account
+	Instruction_UpdateCommission
+
+	// A Vote instruction with recent votes and a switching proof
+	Instruction_VoteSwitch
+
+	// Authorize a key to send votes or issue a withdrawal, requiring the new authority to sign
+	Instruction_AuthorizeChecked
+
+	// Update the onchain vote state for the signer
+	Instruction_UpdateVoteState
+
+	// Update the onchain vote state for the signer along with a switching proof
+	Instruction_UpdateVoteStateSwitch
+
+	// Authorize a key to send votes or issue a withdrawal with a derived key
+	Instruction_AuthorizeWithSeed
+
+	// Authorize a key to send votes or issue a withdrawal with a derived key, requiring the new authority to sign
+	Instruction_AuthorizeCheckedWithSeed
+
+	// Update the onchain vote state for the signer, using the compact serialization
+	Instruction_CompactUpdateVoteState
+
+	// Update the onchain vote state for the signer along with a switching proof, using the compact serialization
+	Instruction_CompactUpdateVoteStateSwitch
+
+	// Sync the onchain vote state with the local tower
+	Instruction_TowerSync
+
+	// Sync the onchain vote state with the local tower along with a switching proof
+	Instruction_TowerSyncSwitch
+)
+
+// InstructionIDToName returns the name of the instruction given its ID.
+func InstructionIDToName(id uint32) string {
+	switch id {
+	case Instruction_InitializeAccount:
+		return "InitializeAccount"
+	case Instruction_Authorize:
+		return "Authorize"
+	case Instruction_Vote:
+		return "Vote"
+	case Instruction_Withdraw:
+		return "Withdraw"
+	case Instruction_UpdateValidatorIdentity:
+		return "UpdateValidatorIdentity"
+	case Instruction_UpdateCommission:
+		return "UpdateCommission"
+	case Instruction_VoteSwitch:
+		return "VoteSwitch"
+	case Instruction_AuthorizeChecked:
+		return "AuthorizeChecked"
+	case Instruction_UpdateVoteState:
+		return "UpdateVoteState"
+	case Instruction_UpdateVoteStateSwitch:
+		return "UpdateVoteStateSwitch"
+	case Instruction_AuthorizeWithSeed:
+		return "AuthorizeWithSeed"
+	case Instruction_AuthorizeCheckedWithSeed:
+		return "AuthorizeCheckedWithSeed"
+	case Instruction_CompactUpdateVoteState:
+		return "CompactUpdateVoteState"
+	case Instruction_CompactUpdateVoteStateSwitch:
+		return "CompactUpdateVoteStateSwitch"
+	case Instruction_TowerSync:
+		return "TowerSync"
+	case Instruction_TowerSyncSwitch:
+		return "TowerSyncSwitch"
+	default:
+		return ""
+	}
+}
+
 type Instruction struct {
 	bin.BaseVariant
 }
@@ -54,18 +144,22 @@ func (inst *Instruction) EncodeToTree(parent treeout.Branches) {
 var InstructionImplDef = bin.NewVariantDefinition(
 	bin.Uint32TypeIDEncoding,
 	[]bin.VariantType{
-		{
-			"InitializeAccount", (*InitializeAccount)(nil),
-		},
-		{
-			"Authorize", (*Authorize)(nil),
-		},
-		{
-			"Vote", (*Vote)(nil),
-		},
-		{
-			"Withdraw", (*Withdraw)(nil),
-		},
+		{Name: "InitializeAccount", Type: (*InitializeAccount)(nil)},
+		{Name: "Authorize", Type: (*Authorize)(nil)},
+		{Name: "Vote", Type: (*Vote)(nil)},
+		{Name: "Withdraw", Type: (*Withdraw)(nil)},
+		{Name: "UpdateValidatorIdentity", Type: (*UpdateValidatorIdentity)(nil)},
+		{Name: "UpdateCommission", Type: (*UpdateCommission)(nil)},
+		{Name: "VoteSwitch", Type: (*VoteSwitch)(nil)},
+		{Name: "AuthorizeChecked", Type: (*AuthorizeChecked)(nil)},
+		{Name: "UpdateVoteState", Type: (*UpdateVoteState)(nil)},
+		{Name: "UpdateVoteStateSwitch", Type: (*UpdateVoteStateSwitch)(nil)},
+		{Name: "AuthorizeWithSeed", Type: (*AuthorizeWithSeed)(nil)},
+		{Name: "AuthorizeCheckedWithSeed", Type: (*AuthorizeCheckedWithSeed)(nil)},
+		{Name: "CompactUpdateVoteState", Type: (*CompactUpdateVoteState)(nil)},
+		{Name: "CompactUpdateVoteStateSwitch", Type: (*CompactUpdateVoteStateSwitch)(nil)},
+		{Name: "TowerSync", Type: (*TowerSync)(nil)},
+		{Name: "TowerSyncSwitch", Type: (*TowerSyncSwitch)(nil)},
 	},
 )
 
@@ -85,6 +179,18 @@ func (inst *Instruction) Data() ([]byte, error) {
 	return buf.Bytes(), nil
 }
 
+func (a *Instruction) AssertEquivalent(in solana.Instruction) error {
+	b, ok := in.(*Instruction)
+	if !ok {
+		return fmt.Errorf("expected %T, but got %T", a, in)
+	}
+	equiv, ok := a.BaseVariant.Impl.(solana.EquivalenceAssertable[interface{}])
+	if !ok {
+		return solana.CheckInstructionEquivalence(a, b)
+	}
+	return equiv.AssertEquivalent(b.BaseVariant.Impl)
+}
+
 func (inst *Instruction) TextEncode(encoder *text.Encoder, option *text.Option) error {
 	return encoder.Encode(inst.Impl, option)
 }
@@ -0,0 +1,399 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vote
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+
+	bin "github.com/gagliardetto/binary"
+	"github.com/gagliardetto/solana-go"
+)
+
+// VoteAuthorize selects which authority of a vote account is being changed.
+type VoteAuthorize uint32
+
+const (
+	VoteAuthorizeVoter VoteAuthorize = iota
+	VoteAuthorizeWithdrawer
+)
+
+func (a VoteAuthorize) String() string {
+	switch a {
+	case VoteAuthorizeVoter:
+		return "Voter"
+	case VoteAuthorizeWithdrawer:
+		return "Withdrawer"
+	default:
+		return fmt.Sprintf("Unknown(%d)", uint32(a))
+	}
+}
+
+// VoteInit holds the parameters of a new vote account.
+type VoteInit struct {
+	NodePubkey           solana.PublicKey
+	AuthorizedVoter      solana.PublicKey
+	AuthorizedWithdrawer solana.PublicKey
+	Commission           uint8
+}
+
+// Lockout is a voted slot, and the number of votes stacked on top of it.
+type Lockout struct {
+	Slot              uint64
+	ConfirmationCount uint32
+}
+
+// LandedVote is a Lockout along with the latency (in slots) with which
+// the vote landed.
+type LandedVote struct {
+	Latency uint8
+	Lockout Lockout
+}
+
+// VoteStateUpdate is the proposed vote state of the UpdateVoteState
+// family of instructions.
+type VoteStateUpdate struct {
+	// The proposed tower.
+	Lockouts []Lockout
+
+	// The proposed root.
+	Root *uint64
+
+	// Signature of the bank's state at the last slot.
+	Hash solana.Hash
+
+	// Processing timestamp of last slot.
+	Timestamp *int64
+}
+
+func (obj VoteStateUpdate) MarshalWithEncoder(encoder *bin.Encoder) (err error) {
+	if err = encoder.WriteUint64(uint64(len(obj.Lockouts)), binary.LittleEndian); err != nil {
+		return err
+	}
+	for _, lockout := range obj.Lockouts {
+		if err = lockout.MarshalWithEncoder(encoder); err != nil {
+			return err
+		}
+	}
+	if err = writeOptionUint64(encoder, obj.Root); err != nil {
+		return err
+	}
+	if err = encoder.WriteBytes(obj.Hash[:], false); err != nil {
+		return err
+	}
+	return writeOptionInt64(encoder, obj.Timestamp)
+}
+
+func (obj *VoteStateUpdate) UnmarshalWithDecoder(decoder *bin.Decoder) (err error) {
+	if obj.Lockouts, err = readLockouts(decoder); err != nil {
+		return err
+	}
+	if obj.Root, err = readOptionUint64(decoder); err != nil {
+		return err
+	}
+	if obj.Hash, err = readHash(decoder); err != nil {
+		return err
+	}
+	obj.Timestamp, err = readOptionInt64(decoder)
+	return err
+}
+
+// MarshalCompactWithEncoder encodes the vote state update with the compact
+// serialization used by CompactUpdateVoteState: the root is encoded
+// as u64::MAX when not set, and the lockouts as varint slot offsets.
+func (obj VoteStateUpdate) MarshalCompactWithEncoder(encoder *bin.Encoder) (err error) {
+	if err = writeCompactLockouts(encoder, obj.Root, obj.Lockouts); err != nil {
+		return err
+	}
+	if err = encoder.WriteBytes(obj.Hash[:], false); err != nil {
+		return err
+	}
+	return writeOptionInt64(encoder, obj.Timestamp)
+}
+
+// UnmarshalCompactWithDecoder decodes a vote state update encoded with
+// the compact serialization used by CompactUpdateVoteState.
+func (obj *VoteStateUpdate) UnmarshalCompactWithDecoder(decoder *bin.Decoder) (err error) {
+	if obj.Root, obj.Lockouts, err = readCompactLockouts(decoder); err != nil {
+		return err
+	}
+	if obj.Hash, err = readHash(decoder); err != nil {
+		return err
+	}
+	obj.Timestamp, err = readOptionInt64(decoder)
+	return err
+}
+
+// TowerSyncUpdate is the local tower of the TowerSync family of
+// instructions; it is always encoded with the compact serialization.
+type TowerSyncUpdate struct {
+	// The proposed tower.
+	Lockouts []Lockout
+
+	// The proposed root.
+	Root *uint64
+
+	// Signature of the bank's state at the last slot.
+	Hash solana.Hash
+
+	// Processing timestamp of last slot.
+	Timestamp *int64
+
+	// The block id of the last slot.
+	BlockID solana.Hash
+}
+
+func (obj TowerSyncUpdate) MarshalWithEncoder(encoder *bin.Encoder) (err error) {
+	if err = writeCompactLockouts(encoder, obj.Root, obj.Lockouts); err != nil {
+		return err
+	}
+	if err = encoder.WriteBytes(obj.Hash[:], false); err != nil {
+		return err
+	}
+	if err = writeOptionInt64(encoder, obj.Timestamp); err != nil {
+		return err
+	}
+	return encoder.WriteBytes(obj.BlockID[:], false)
+}
+
+func (obj *TowerSyncUpdate) UnmarshalWithDecoder(decoder *bin.Decoder) (err error) {
+	if obj.Root, obj.Lockouts, err = readCompactLockouts(decoder); err != nil {
+		return err
+	}
+	if obj.Hash, err = readHash(decoder); err != nil {
+		return err
+	}
+	if obj.Timestamp, err = readOptionInt64(decoder); err != nil {
+		return err
+	}
+	obj.BlockID, err = readHash(decoder)
+	return err
+}
+
+func (obj Lockout) MarshalWithEncoder(encoder *bin.Encoder) (err error) {
+	if err = encoder.WriteUint64(obj.Slot, binary.LittleEndian); err != nil {
+		return err
+	}
+	return encoder.WriteUint32(obj.ConfirmationCount, binary.LittleEndian)
+}
+
+func (obj *Lockout) UnmarshalWithDecoder(decoder *bin.Decoder) (err error) {
+	if obj.Slot, err = decoder.ReadUint64(binary.LittleEndian); err != nil {
+		return err
+	}
+	obj.ConfirmationCount, err = decoder.ReadUint32(binary.LittleEndian)
+	return err
+}
+
+func (obj LandedVote) MarshalWithEncoder(encoder *bin.Encoder) (err error) {
+	if err = encoder.WriteUint8(obj.Latency); err != nil {
+		return err
+	}
+	return obj.Lockout.MarshalWithEncoder(encoder)
+}
+
+func (obj *LandedVote) UnmarshalWithDecoder(decoder *bin.Decoder) (err error) {
+	if obj.Latency, err = decoder.ReadUint8(); err != nil {
+		return err
+	}
+	return obj.Lockout.UnmarshalWithDecoder(decoder)
+}
+
+func readLockouts(decoder *bin.Decoder) ([]Lockout, error) {
+	length, err := decoder.ReadUint64(binary.LittleEndian)
+	if err != nil {
+		return nil, err
+	}
+	// Each lockout is 12 bytes.
+	if length > uint64(decoder.Remaining()/12) {
+		return nil, fmt.Errorf("invalid lockouts length: %d", length)
+	}
+	lockouts := make([]Lockout, length)
+	for i := range lockouts {
+		if err = lockouts[i].UnmarshalWithDecoder(decoder); err != nil {
+			return nil, err
+		}
+	}
+	return lockouts, nil
+}
+
+// writeCompactLockouts writes the root (u64::MAX if not set), followed
+// by the lockouts as a short-vec of (varint slot offset, u8 confirmation count)
+// pairs; every offset is relative to the previous slot, starting from the root.
+func writeCompactLockouts(encoder *bin.Encoder, root *uint64, lockouts []Lockout) (err error) {
+	var slot uint64
+	if root != nil {
+		slot = *root
+		if err = encoder.WriteUint64(slot, binary.LittleEndian); err != nil {
+			return err
+		}
+	} else {
+		if err = encoder.WriteUint64(math.MaxUint64, binary.LittleEndian); err != nil {
+			return err
+		}
+	}
+	if err = encoder.WriteCompactU16(len(lockouts)); err != nil {
+		return err
+	}
+	for _, lockout := range lockouts {
+		if lockout.Slot < slot {
+			return errors.New("lockout slots must be in increasing order, and greater than the root")
+		}
+		if lockout.ConfirmationCount > math.MaxUint8 {
+			return fmt.Errorf("invalid lockout confirmation count: %d", lockout.ConfirmationCount)
+		}
+		if err = encoder.WriteBytes(binary.AppendUvarint(nil, lockout.Slot-slot), false); err != nil {
+			return err
+		}
+		if err = encoder.WriteUint8(uint8(lockout.ConfirmationCount)); err != nil {
+			return err
+		}
+		slot = lockout.Slot
+	}
+	return nil
+}
+
+func readCompactLockouts(decoder *bin.Decoder) (root *uint64, lockouts []Lockout, err error) {
+	slot, err := decoder.ReadUint64(binary.LittleEndian)
+	if err != nil {
+		return nil, nil, err
+	}
+	if slot == math.MaxUint64 {
+		slot = 0
+	} else {
+		v := slot
+		root = &v
+	}
+	length, err := decoder.ReadCompactU16()
+	if err != nil {
+		return nil, nil, err
+	}
+	lockouts = make([]Lockout, length)
+	for i := range lockouts {
+		offset, err := decoder.ReadUvarint64()
+		if err != nil {
+			return nil, nil, err
+		}
+		if slot+offset < slot {
+			return nil, nil, errors.New("invalid lockout offset: slot overflow")
+		}
+		slot += offset
+		count, err := decoder.ReadUint8()
+		if err != nil {
+			return nil, nil, err
+		}
+		lockouts[i] = Lockout{Slot: slot, ConfirmationCount: uint32(count)}
+	}
+	return root, lockouts, nil
+}
+
+func marshalVote(encoder *bin.Encoder, slots []uint64, hash solana.Hash, timestamp *int64) (err error) {
+	if err = encoder.WriteUint64(uint64(len(slots)), binary.LittleEndian); err != nil {
+		return err
+	}
+	for _, slot := range slots {
+		if err = encoder.WriteUint64(slot, binary.LittleEndian); err != nil {
+			return err
+		}
+	}
+	if err = encoder.WriteBytes(hash[:], false); err != nil {
+		return err
+	}
+	return writeOptionInt64(encoder, timestamp)
+}
+
+func unmarshalVote(decoder *bin.Decoder) (slots []uint64, hash solana.Hash, timestamp *int64, err error) {
+	numSlots, err := decoder.ReadUint64(binary.LittleEndian)
+	if err != nil {
+		return nil, hash, nil, err
+	}
+	if numSlots > uint64(decoder.Remaining()/8) {
+		return nil, hash, nil, fmt.Errorf("invalid vote slots length: %d", numSlots)
+	}
+	for i := uint64(0); i < numSlots; i++ {
+		slot, err := decoder.ReadUint64(binary.LittleEndian)
+		if err != nil {
+			return nil, hash, nil, err
+		}
+		slots = append(slots, slot)
+	}
+	if hash, err = readHash(decoder); err != nil {
+		return nil, hash, nil, err
+	}
+	timestamp, err = readOptionInt64(decoder)
+	return slots, hash, timestamp, err
+}
+
+func readHash(decoder *bin.Decoder) (solana.Hash, error) {
+	v, err := decoder.ReadNBytes(32)
+	if err != nil {
+		return solana.Hash{}, err
+	}
+	return solana.HashFromBytes(v), nil
+}
+
+func readPublicKey(decoder *bin.Decoder) (solana.PublicKey, error) {
+	v, err := decoder.ReadNBytes(32)
+	if err != nil {
+		return solana.PublicKey{}, err
+	}
+	return solana.PublicKeyFromBytes(v), nil
+}
+
+func writeOptionInt64(encoder *bin.Encoder, v *int64) error {
+	if v == nil {
+		return encoder.WriteOption(false)
+	}
+	if err := encoder.WriteOption(true); err != nil {
+		return err
+	}
+	return encoder.WriteInt64(*v, binary.LittleEndian)
+}
+
+func writeOptionUint64(encoder *bin.Encoder, v *uint64) error {
+	if v == nil {
+		return encoder.WriteOption(false)
+	}
+	if err := encoder.WriteOption(true); err != nil {
+		return err
+	}
+	return encoder.WriteUint64(*v, binary.LittleEndian)
+}
+
+func readOptionInt64(decoder *bin.Decoder) (*int64, error) {
+	ok, err := decoder.ReadOption()
+	if err != nil || !ok {
+		return nil, err
+	}
+	v, err := decoder.ReadInt64(binary.LittleEndian)
+	if err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+func readOptionUint64(decoder *bin.Decoder) (*uint64, error) {
+	ok, err := decoder.ReadOption()
+	if err != nil || !ok {
+		return nil, err
+	}
+	v, err := decoder.ReadUint64(binary.LittleEndian)
+	if err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
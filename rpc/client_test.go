@@ -21,6 +21,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/base64"
+	"encoding/binary"
 	stdjson "encoding/json"
 	"fmt"
 	"math/big"
@@ -3123,3 +3124,75 @@ func TestClient_GetRecentPrioritizationFees(t *testing.T) {
 
 	assert.Equal(t, expected, got, "both deserialized values must be equal")
 }
+
+func sysvarAccountResponse(t *testing.T, owner solana.PublicKey, fields ...interface{}) string {
+	buf := new(bytes.Buffer)
+	for _, field := range fields {
+		require.NoError(t, binary.Write(buf, binary.LittleEndian, field))
+	}
+	return fmt.Sprintf(
+		`{"context":{"slot":250000000},"value":{"data":["%s","base64"],"executable":false,"lamports":1169280,"owner":"%s","rentEpoch":18446744073709551615}}`,
+		base64.StdEncoding.EncodeToString(buf.Bytes()),
+		owner,
+	)
+}
+
+func TestClient_GetSysvarClock(t *testing.T) {
+	responseBody := sysvarAccountResponse(t, solana.SysVarOwnerPubkey, uint64(250_000_000), int64(1_700_000_000), uint64(578), uint64(579), int64(1_700_100_000))
+	server, closer := mockJSONRPC(t, stdjson.RawMessage(wrapIntoRPC(responseBody)))
+	defer closer()
+	client := New(server.URL)
+
+	out, err := client.GetSysvarClock(context.Background(), CommitmentFinalized)
+	require.NoError(t, err)
+
+	reqBody := server.RequestBody(t)
+	reqBody["id"] = any(nil)
+	assert.Equal(t,
+		map[string]interface{}{
+			"id":      any(nil),
+			"jsonrpc": "2.0",
+			"method":  "getAccountInfo",
+			"params": []interface{}{
+				solana.SysVarClockPubkey.String(),
+				map[string]interface{}{
+					"encoding":   "base64",
+					"commitment": string(CommitmentFinalized),
+				},
+			},
+		},
+		reqBody,
+	)
+
+	assert.Equal(t,
+		&solana.SysVarClock{
+			Slot:                250_000_000,
+			EpochStartTimestamp: 1_700_000_000,
+			Epoch:               578,
+			LeaderScheduleEpoch: 579,
+			UnixTimestamp:       1_700_100_000,
+		},
+		out,
+	)
+}
+
+func TestClient_GetSysvarRent(t *testing.T) {
+	responseBody := sysvarAccountResponse(t, solana.SysVarOwnerPubkey, uint64(3480), float64(2), uint8(50))
+	server, closer := mockJSONRPC(t, stdjson.RawMessage(wrapIntoRPC(responseBody)))
+	defer closer()
+	client := New(server.URL)
+
+	out, err := client.GetSysvarRent(context.Background(), "")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2_039_280), out.MinimumBalance(165))
+}
+
+func TestClient_GetSysvarWrongOwner(t *testing.T) {
+	responseBody := sysvarAccountResponse(t, solana.SystemProgramID, uint64(3480), float64(2), uint8(50))
+	server, closer := mockJSONRPC(t, stdjson.RawMessage(wrapIntoRPC(responseBody)))
+	defer closer()
+	client := New(server.URL)
+
+	_, err := client.GetSysvarRent(context.Background(), "")
+	require.Error(t, err)
+}
@@ -0,0 +1,108 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rpc
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gagliardetto/solana-go"
+)
+
+// GetSysvarAccountData returns the data of the provided sysvar account,
+// after checking that it is owned by the sysvar program.
+func (cl *Client) GetSysvarAccountData(
+	ctx context.Context,
+	sysvar solana.PublicKey,
+	commitment CommitmentType,
+) ([]byte, error) {
+	out, err := cl.GetAccountInfoWithOpts(ctx, sysvar, &GetAccountInfoOpts{
+		Encoding:   solana.EncodingBase64,
+		Commitment: commitment,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !out.Value.Owner.Equals(solana.SysVarOwnerPubkey) {
+		return nil, fmt.Errorf("account %s is not a sysvar: owned by %s", sysvar, out.Value.Owner)
+	}
+	return out.Value.Data.GetBinary(), nil
+}
+
+func getSysvar[T any](
+	ctx context.Context,
+	cl *Client,
+	sysvar solana.PublicKey,
+	commitment CommitmentType,
+	decode func([]byte) (T, error),
+) (out T, err error) {
+	data, err := cl.GetSysvarAccountData(ctx, sysvar, commitment)
+	if err != nil {
+		return out, err
+	}
+	return decode(data)
+}
+
+// GetSysvarClock returns the Clock sysvar: the current slot, epoch and
+// estimated wall-clock time of the cluster.
+func (cl *Client) GetSysvarClock(ctx context.Context, commitment CommitmentType) (*solana.SysVarClock, error) {
+	return getSysvar(ctx, cl, solana.SysVarClockPubkey, commitment, solana.DecodeSysVarClock)
+}
+
+// GetSysvarRent returns the Rent sysvar, which can compute
+// rent-exemption balances offline.
+func (cl *Client) GetSysvarRent(ctx context.Context, commitment CommitmentType) (*solana.SysVarRent, error) {
+	return getSysvar(ctx, cl, solana.SysVarRentPubkey, commitment, solana.DecodeSysVarRent)
+}
+
+// GetSysvarEpochSchedule returns the EpochSchedule sysvar.
+func (cl *Client) GetSysvarEpochSchedule(ctx context.Context, commitment CommitmentType) (*solana.SysVarEpochSchedule, error) {
+	return getSysvar(ctx, cl, solana.SysVarEpochSchedulePubkey, commitment, solana.DecodeSysVarEpochSchedule)
+}
+
+// GetSysvarFees returns the deprecated Fees sysvar.
+func (cl *Client) GetSysvarFees(ctx context.Context, commitment CommitmentType) (*solana.SysVarFees, error) {
+	return getSysvar(ctx, cl, solana.SysVarFeesPubkey, commitment, solana.DecodeSysVarFees)
+}
+
+// GetSysvarRecentBlockhashes returns the deprecated RecentBlockhashes sysvar.
+func (cl *Client) GetSysvarRecentBlockhashes(ctx context.Context, commitment CommitmentType) (solana.SysVarRecentBlockhashes, error) {
+	return getSysvar(ctx, cl, solana.SysVarRecentBlockHashesPubkey, commitment, solana.DecodeSysVarRecentBlockhashes)
+}
+
+// GetSysvarSlotHashes returns the SlotHashes sysvar.
+func (cl *Client) GetSysvarSlotHashes(ctx context.Context, commitment CommitmentType) (solana.SysVarSlotHashes, error) {
+	return getSysvar(ctx, cl, solana.SysVarSlotHashesPubkey, commitment, solana.DecodeSysVarSlotHashes)
+}
+
+// GetSysvarSlotHistory returns the SlotHistory sysvar.
+func (cl *Client) GetSysvarSlotHistory(ctx context.Context, commitment CommitmentType) (*solana.SysVarSlotHistory, error) {
+	return getSysvar(ctx, cl, solana.SysVarSlotHistoryPubkey, commitment, solana.DecodeSysVarSlotHistory)
+}
+
+// GetSysvarStakeHistory returns the StakeHistory sysvar.
+func (cl *Client) GetSysvarStakeHistory(ctx context.Context, commitment CommitmentType) (solana.SysVarStakeHistory, error) {
+	return getSysvar(ctx, cl, solana.SysVarStakeHistoryPubkey, commitment, solana.DecodeSysVarStakeHistory)
+}
+
+// GetSysvarEpochRewards returns the EpochRewards sysvar.
+func (cl *Client) GetSysvarEpochRewards(ctx context.Context, commitment CommitmentType) (*solana.SysVarEpochRewards, error) {
+	return getSysvar(ctx, cl, solana.SysVarEpochRewardsPubkey, commitment, solana.DecodeSysVarEpochRewards)
+}
+
+// GetSysvarLastRestartSlot returns the LastRestartSlot sysvar.
+func (cl *Client) GetSysvarLastRestartSlot(ctx context.Context, commitment CommitmentType) (*solana.SysVarLastRestartSlot, error) {
+	return getSysvar(ctx, cl, solana.SysVarLastRestartSlotPubkey, commitment, solana.DecodeSysVarLastRestartSlot)
+}
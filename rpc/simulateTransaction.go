@@ -46,6 +46,11 @@ type SimulateTransactionResult struct {
 	UnitsConsumed *uint64 `json:"unitsConsumed,omitempty"`
 }
 
+// ParseLogs parses the simulation logs into an invocation tree.
+func (res *SimulateTransactionResult) ParseLogs() (*solana.ProgramLogs, error) {
+	return solana.ParseProgramLogs(res.Logs)
+}
+
 // SimulateTransaction simulates sending a transaction.
 func (cl *Client) SimulateTransaction(
 	ctx context.Context,
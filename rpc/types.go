@@ -219,6 +219,11 @@ type TransactionMeta struct {
 	ComputeUnitsConsumed *uint64 `json:"computeUnitsConsumed"`
 }
 
+// ParseLogs parses the log messages into an invocation tree.
+func (meta *TransactionMeta) ParseLogs() (*solana.ProgramLogs, error) {
+	return solana.ParseProgramLogs(meta.LogMessages)
+}
+
 type InnerInstruction struct {
 	// TODO: <number> == int64 ???
 	// Index of the transaction instruction from which the inner instruction(s) originated
@@ -480,6 +485,11 @@ type ParsedTransactionMeta struct {
 	LogMessages []string `json:"logMessages"`
 }
 
+// ParseLogs parses the log messages into an invocation tree.
+func (meta *ParsedTransactionMeta) ParseLogs() (*solana.ProgramLogs, error) {
+	return solana.ParseProgramLogs(meta.LogMessages)
+}
+
 type ParsedInnerInstruction struct {
 	Index        uint64               `json:"index"`
 	Instructions []*ParsedInstruction `json:"instructions"`
@@ -113,3 +113,17 @@ func TestData_DataBytesOrJSONFromBytes(t *testing.T) {
 	out := dataBytesOrJSON.GetBinary()
 	assert.Equal(t, in, out)
 }
+
+func TestTransactionMeta_ParseLogs(t *testing.T) {
+	in := `{"err":null,"fee":5000,"logMessages":["Program Vote111111111111111111111111111111111111111 invoke [1]","Program Vote111111111111111111111111111111111111111 success"]}`
+
+	var meta TransactionMeta
+	err := stdjson.Unmarshal([]byte(in), &meta)
+	assert.NoError(t, err)
+
+	logs, err := meta.ParseLogs()
+	assert.NoError(t, err)
+	assert.Len(t, logs.Instructions, 1)
+	assert.Equal(t, solana.VoteProgramID, logs.Instructions[0].ProgramID)
+	assert.True(t, logs.Instructions[0].Success)
+}
@@ -0,0 +1,194 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package solana
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Signer signs messages on behalf of a public key, without exposing the
+// private key: it can be a local key, or a remote service like a KMS or HSM.
+//
+// SignMessage may be called concurrently with other signers of the same
+// transaction, and must honor the context's cancellation.
+type Signer interface {
+	PublicKey() PublicKey
+	SignMessage(ctx context.Context, message []byte) (Signature, error)
+}
+
+var (
+	_ Signer = PrivateKey{}
+	_ Signer = &Wallet{}
+	_ Signer = &signerFunc{}
+)
+
+// SignMessage signs the message with the private key.
+func (k PrivateKey) SignMessage(ctx context.Context, message []byte) (Signature, error) {
+	if err := ctx.Err(); err != nil {
+		return Signature{}, err
+	}
+	return k.Sign(message)
+}
+
+// SignMessage signs the message with the wallet's private key.
+func (a *Wallet) SignMessage(ctx context.Context, message []byte) (Signature, error) {
+	return a.PrivateKey.SignMessage(ctx, message)
+}
+
+type signerFunc struct {
+	publicKey PublicKey
+	sign      func(ctx context.Context, message []byte) (Signature, error)
+}
+
+func (s *signerFunc) PublicKey() PublicKey {
+	return s.publicKey
+}
+
+func (s *signerFunc) SignMessage(ctx context.Context, message []byte) (Signature, error) {
+	return s.sign(ctx, message)
+}
+
+// NewSignerFunc returns a Signer for the public key that signs with the
+// provided function, e.g. a call to a remote signing service.
+func NewSignerFunc(
+	publicKey PublicKey,
+	sign func(ctx context.Context, message []byte) (Signature, error),
+) Signer {
+	return &signerFunc{
+		publicKey: publicKey,
+		sign:      sign,
+	}
+}
+
+// SignerError is the error of a signer.
+type SignerError struct {
+	PublicKey PublicKey
+	Err       error
+}
+
+func (e *SignerError) Error() string {
+	return fmt.Sprintf("signer %s: %s", e.PublicKey, e.Err)
+}
+
+func (e *SignerError) Unwrap() error {
+	return e.Err
+}
+
+// SignerErrors are the errors of all the signers that failed.
+type SignerErrors []*SignerError
+
+func (e SignerErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return fmt.Sprintf("%d signer(s) failed: %s", len(e), strings.Join(msgs, "; "))
+}
+
+func (e SignerErrors) Unwrap() []error {
+	out := make([]error, len(e))
+	for i, err := range e {
+		out[i] = err
+	}
+	return out
+}
+
+// SignWithSigners signs the transaction with the signers, which must include
+// all the signers required by the message; see PartialSignWithSigners.
+func (tx *Transaction) SignWithSigners(ctx context.Context, signers ...Signer) ([]Signature, error) {
+	provided := make(map[PublicKey]struct{}, len(signers))
+	for _, signer := range signers {
+		provided[signer.PublicKey()] = struct{}{}
+	}
+	for _, key := range tx.Message.signerKeys() {
+		if _, ok := provided[key]; !ok {
+			return nil, fmt.Errorf("signer key %q not found. Ensure all the signers are provided", key.String())
+		}
+	}
+	return tx.PartialSignWithSigners(ctx, signers...)
+}
+
+// PartialSignWithSigners signs the transaction with the signers that are
+// required by the message; the other signers are ignored, and the signatures
+// of the required signers that are not provided are left as they are.
+//
+// The signers sign concurrently. Every signature is verified; if any signer
+// fails, no signature is set and the returned error is a SignerErrors with
+// the error of each failed signer.
+func (tx *Transaction) PartialSignWithSigners(ctx context.Context, signers ...Signer) ([]Signature, error) {
+	messageContent, err := tx.Message.MarshalBinary()
+	if err != nil {
+		return nil, fmt.Errorf("unable to encode message for signing: %w", err)
+	}
+	signerKeys := tx.Message.signerKeys()
+	if len(tx.Signatures) != 0 && len(tx.Signatures) != len(signerKeys) {
+		return nil, fmt.Errorf("transaction has %d signatures, but the message requires %d", len(tx.Signatures), len(signerKeys))
+	}
+
+	bySigner := make(map[PublicKey]Signer, len(signers))
+	for _, signer := range signers {
+		if _, ok := bySigner[signer.PublicKey()]; !ok {
+			bySigner[signer.PublicKey()] = signer
+		}
+	}
+
+	signatures := make([]Signature, len(signerKeys))
+	signed := make([]bool, len(signerKeys))
+	errs := make([]*SignerError, len(signerKeys))
+	var wg sync.WaitGroup
+	for i, key := range signerKeys {
+		signer, ok := bySigner[key]
+		if !ok {
+			continue
+		}
+		wg.Add(1)
+		go func(i int, key PublicKey, signer Signer) {
+			defer wg.Done()
+			signature, err := signer.SignMessage(ctx, messageContent)
+			if err == nil && !signature.Verify(key, messageContent) {
+				err = fmt.Errorf("invalid signature %s", signature)
+			}
+			if err != nil {
+				errs[i] = &SignerError{PublicKey: key, Err: err}
+				return
+			}
+			signatures[i] = signature
+			signed[i] = true
+		}(i, key, signer)
+	}
+	wg.Wait()
+
+	var failed SignerErrors
+	for _, err := range errs {
+		if err != nil {
+			failed = append(failed, err)
+		}
+	}
+	if len(failed) > 0 {
+		return nil, failed
+	}
+	if len(tx.Signatures) == 0 {
+		tx.Signatures = make([]Signature, len(signerKeys))
+	}
+	for i := range signerKeys {
+		if signed[i] {
+			tx.Signatures[i] = signatures[i]
+		}
+	}
+	return tx.Signatures, nil
+}
@@ -0,0 +1,110 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package solana
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTestSignerTransaction(t *testing.T, signers ...PublicKey) *Transaction {
+	metas := AccountMetaSlice{}
+	for _, signer := range signers {
+		metas = append(metas, Meta(signer).WRITE().SIGNER())
+	}
+	tx, err := NewTransaction(
+		[]Instruction{NewInstruction(SystemProgramID, metas, []byte{1})},
+		MustHashFromBase58("AnL7vVGidfdxZBFqkxLvVwXgW9pDSZC5kK6d5kyRaXEa"),
+	)
+	require.NoError(t, err)
+	return tx
+}
+
+func TestSignWithSigners(t *testing.T) {
+	payer := NewWallet()
+	local, err := NewRandomPrivateKey()
+	require.NoError(t, err)
+	remoteKey, err := NewRandomPrivateKey()
+	require.NoError(t, err)
+	remote := NewSignerFunc(remoteKey.PublicKey(), func(ctx context.Context, message []byte) (Signature, error) {
+		// e.g. a call to a remote signing service.
+		return remoteKey.Sign(message)
+	})
+
+	tx := newTestSignerTransaction(t, payer.PublicKey(), local.PublicKey(), remoteKey.PublicKey())
+
+	_, err = tx.SignWithSigners(context.Background(), payer, local)
+	require.Error(t, err)
+	require.Empty(t, tx.Signatures)
+
+	unrelated := NewWallet()
+	signatures, err := tx.SignWithSigners(context.Background(), remote, unrelated, local, payer)
+	require.NoError(t, err)
+	require.Len(t, signatures, 3)
+	require.NoError(t, tx.VerifySignatures())
+}
+
+func TestPartialSignWithSigners(t *testing.T) {
+	payer := NewWallet()
+	cosigner := NewWallet()
+	tx := newTestSignerTransaction(t, payer.PublicKey(), cosigner.PublicKey())
+
+	signatures, err := tx.PartialSignWithSigners(context.Background(), payer)
+	require.NoError(t, err)
+	require.Len(t, signatures, 2)
+	require.False(t, signatures[0].IsZero())
+	require.True(t, signatures[1].IsZero())
+
+	signatures, err = tx.PartialSignWithSigners(context.Background(), cosigner)
+	require.NoError(t, err)
+	require.False(t, signatures[0].IsZero())
+	require.False(t, signatures[1].IsZero())
+	require.NoError(t, tx.VerifySignatures())
+}
+
+func TestSignWithSignersErrors(t *testing.T) {
+	payer := NewWallet()
+	failing := NewWallet().PublicKey()
+	lying := NewWallet().PublicKey()
+	errUnavailable := errors.New("signing service unavailable")
+
+	tx := newTestSignerTransaction(t, payer.PublicKey(), failing, lying)
+	_, err := tx.SignWithSigners(
+		context.Background(),
+		payer,
+		NewSignerFunc(failing, func(ctx context.Context, message []byte) (Signature, error) {
+			return Signature{}, errUnavailable
+		}),
+		NewSignerFunc(lying, func(ctx context.Context, message []byte) (Signature, error) {
+			return payer.PrivateKey.Sign(message)
+		}),
+	)
+	require.ErrorIs(t, err, errUnavailable)
+
+	var signerErrs SignerErrors
+	require.ErrorAs(t, err, &signerErrs)
+	require.Len(t, signerErrs, 2)
+	require.Equal(t, failing, signerErrs[0].PublicKey)
+	require.Equal(t, lying, signerErrs[1].PublicKey)
+	require.Empty(t, tx.Signatures)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = newTestSignerTransaction(t, payer.PublicKey()).SignWithSigners(ctx, payer)
+	require.ErrorIs(t, err, context.Canceled)
+}
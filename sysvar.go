@@ -62,4 +62,14 @@ var (
 	// The StakeHistory sysvar contains the history of cluster-wide stake activations and de-activations per epoch.
 	// It is updated at the start of every epoch.
 	SysVarStakeHistoryPubkey = MustPublicKeyFromBase58("SysvarStakeHistory1111111111111111111111111")
+
+	// The EpochRewards sysvar tracks the distribution of the staking rewards of the current epoch.
+	// It is updated at the start of every epoch and while rewards are distributed.
+	SysVarEpochRewardsPubkey = MustPublicKeyFromBase58("SysvarEpochRewards1111111111111111111111111")
+
+	// The LastRestartSlot sysvar contains the slot of the last cluster restart, or zero.
+	SysVarLastRestartSlotPubkey = MustPublicKeyFromBase58("SysvarLastRestartS1ot1111111111111111111111")
+
+	// SysVarOwnerPubkey is the owner of all the sysvar accounts.
+	SysVarOwnerPubkey = MustPublicKeyFromBase58("Sysvar1111111111111111111111111111111111111")
 )
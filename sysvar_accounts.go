@@ -0,0 +1,533 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package solana
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/bits"
+
+	bin "github.com/gagliardetto/binary"
+)
+
+// SysVarClock is the data of the Clock sysvar.
+type SysVarClock struct {
+	// The current slot.
+	Slot uint64
+	// The timestamp of the first slot of the current epoch.
+	EpochStartTimestamp UnixTimeSeconds
+	// The current epoch.
+	Epoch uint64
+	// The future epoch for which the leader schedule has most recently been calculated.
+	LeaderScheduleEpoch uint64
+	// The estimated wall-clock time of the current slot.
+	UnixTimestamp UnixTimeSeconds
+}
+
+func (clock *SysVarClock) UnmarshalWithDecoder(dec *bin.Decoder) (err error) {
+	if clock.Slot, err = dec.ReadUint64(binary.LittleEndian); err != nil {
+		return err
+	}
+	epochStart, err := dec.ReadInt64(binary.LittleEndian)
+	if err != nil {
+		return err
+	}
+	clock.EpochStartTimestamp = UnixTimeSeconds(epochStart)
+	if clock.Epoch, err = dec.ReadUint64(binary.LittleEndian); err != nil {
+		return err
+	}
+	if clock.LeaderScheduleEpoch, err = dec.ReadUint64(binary.LittleEndian); err != nil {
+		return err
+	}
+	timestamp, err := dec.ReadInt64(binary.LittleEndian)
+	if err != nil {
+		return err
+	}
+	clock.UnixTimestamp = UnixTimeSeconds(timestamp)
+	return nil
+}
+
+// DecodeSysVarClock decodes the data of the Clock sysvar account.
+func DecodeSysVarClock(data []byte) (*SysVarClock, error) {
+	out := new(SysVarClock)
+	return out, decodeSysVar("Clock", data, out)
+}
+
+// AccountStorageOverhead is the number of bytes added to the data
+// of every account to compute its rent.
+const AccountStorageOverhead = 128
+
+// SysVarRent is the data of the Rent sysvar.
+type SysVarRent struct {
+	// Rental rate in lamports per byte-year.
+	LamportsPerByteYear uint64
+	// Number of years of rent an account must hold to be rent-exempt.
+	ExemptionThreshold float64
+	// Percentage of collected rent that is burned.
+	BurnPercent uint8
+}
+
+func (rent *SysVarRent) UnmarshalWithDecoder(dec *bin.Decoder) (err error) {
+	if rent.LamportsPerByteYear, err = dec.ReadUint64(binary.LittleEndian); err != nil {
+		return err
+	}
+	if rent.ExemptionThreshold, err = dec.ReadFloat64(binary.LittleEndian); err != nil {
+		return err
+	}
+	rent.BurnPercent, err = dec.ReadUint8()
+	return err
+}
+
+// MinimumBalance returns the minimum balance in lamports for an account
+// with dataLen bytes of data to be rent-exempt;
+// it matches the getMinimumBalanceForRentExemption RPC method.
+func (rent SysVarRent) MinimumBalance(dataLen uint64) uint64 {
+	bytes := AccountStorageOverhead + dataLen
+	return uint64(float64(bytes*rent.LamportsPerByteYear) * rent.ExemptionThreshold)
+}
+
+// IsExempt tells whether an account with the given balance
+// and dataLen bytes of data is rent-exempt.
+func (rent SysVarRent) IsExempt(lamports uint64, dataLen uint64) bool {
+	return lamports >= rent.MinimumBalance(dataLen)
+}
+
+// DecodeSysVarRent decodes the data of the Rent sysvar account.
+func DecodeSysVarRent(data []byte) (*SysVarRent, error) {
+	out := new(SysVarRent)
+	return out, decodeSysVar("Rent", data, out)
+}
+
+// MinimumSlotsPerEpoch is the length of the first epoch when the epoch schedule has a warmup.
+const MinimumSlotsPerEpoch = 32
+
+// SysVarEpochSchedule is the data of the EpochSchedule sysvar.
+type SysVarEpochSchedule struct {
+	// The maximum number of slots in each epoch.
+	SlotsPerEpoch uint64
+	// A number of slots before beginning of an epoch to calculate
+	// a leader schedule for that epoch.
+	LeaderScheduleSlotOffset uint64
+	// Whether epochs start short and grow.
+	Warmup bool
+	// The first epoch after the warmup period.
+	FirstNormalEpoch uint64
+	// The first slot after the warmup period.
+	FirstNormalSlot uint64
+}
+
+func (schedule *SysVarEpochSchedule) UnmarshalWithDecoder(dec *bin.Decoder) (err error) {
+	if schedule.SlotsPerEpoch, err = dec.ReadUint64(binary.LittleEndian); err != nil {
+		return err
+	}
+	if schedule.LeaderScheduleSlotOffset, err = dec.ReadUint64(binary.LittleEndian); err != nil {
+		return err
+	}
+	if schedule.Warmup, err = dec.ReadBool(); err != nil {
+		return err
+	}
+	if schedule.FirstNormalEpoch, err = dec.ReadUint64(binary.LittleEndian); err != nil {
+		return err
+	}
+	schedule.FirstNormalSlot, err = dec.ReadUint64(binary.LittleEndian)
+	return err
+}
+
+// GetSlotsInEpoch returns the number of slots in the given epoch.
+func (schedule SysVarEpochSchedule) GetSlotsInEpoch(epoch uint64) uint64 {
+	if epoch < schedule.FirstNormalEpoch {
+		return 1 << (epoch + uint64(bits.TrailingZeros64(MinimumSlotsPerEpoch)))
+	}
+	return schedule.SlotsPerEpoch
+}
+
+// GetEpochAndSlotIndex returns the epoch of the given slot,
+// and the index of the slot within that epoch.
+func (schedule SysVarEpochSchedule) GetEpochAndSlotIndex(slot uint64) (epoch uint64, slotIndex uint64) {
+	if slot < schedule.FirstNormalSlot {
+		// The warmup epochs double in length, starting from MinimumSlotsPerEpoch.
+		epoch = uint64(bits.Len64(slot+MinimumSlotsPerEpoch)) - uint64(bits.TrailingZeros64(MinimumSlotsPerEpoch)) - 1
+		epochLen := uint64(1) << (epoch + uint64(bits.TrailingZeros64(MinimumSlotsPerEpoch)))
+		return epoch, slot - (epochLen - MinimumSlotsPerEpoch)
+	}
+	normalSlotIndex := slot - schedule.FirstNormalSlot
+	return schedule.FirstNormalEpoch + normalSlotIndex/schedule.SlotsPerEpoch, normalSlotIndex % schedule.SlotsPerEpoch
+}
+
+// GetEpoch returns the epoch of the given slot.
+func (schedule SysVarEpochSchedule) GetEpoch(slot uint64) uint64 {
+	epoch, _ := schedule.GetEpochAndSlotIndex(slot)
+	return epoch
+}
+
+// GetFirstSlotInEpoch returns the first slot of the given epoch.
+func (schedule SysVarEpochSchedule) GetFirstSlotInEpoch(epoch uint64) uint64 {
+	if epoch <= schedule.FirstNormalEpoch {
+		return ((uint64(1) << epoch) - 1) * MinimumSlotsPerEpoch
+	}
+	return (epoch-schedule.FirstNormalEpoch)*schedule.SlotsPerEpoch + schedule.FirstNormalSlot
+}
+
+// GetLastSlotInEpoch returns the last slot of the given epoch.
+func (schedule SysVarEpochSchedule) GetLastSlotInEpoch(epoch uint64) uint64 {
+	return schedule.GetFirstSlotInEpoch(epoch) + schedule.GetSlotsInEpoch(epoch) - 1
+}
+
+// DecodeSysVarEpochSchedule decodes the data of the EpochSchedule sysvar account.
+func DecodeSysVarEpochSchedule(data []byte) (*SysVarEpochSchedule, error) {
+	out := new(SysVarEpochSchedule)
+	return out, decodeSysVar("EpochSchedule", data, out)
+}
+
+// SysVarFees is the data of the deprecated Fees sysvar.
+type SysVarFees struct {
+	LamportsPerSignature uint64
+}
+
+func (fees *SysVarFees) UnmarshalWithDecoder(dec *bin.Decoder) (err error) {
+	fees.LamportsPerSignature, err = dec.ReadUint64(binary.LittleEndian)
+	return err
+}
+
+// DecodeSysVarFees decodes the data of the Fees sysvar account.
+func DecodeSysVarFees(data []byte) (*SysVarFees, error) {
+	out := new(SysVarFees)
+	return out, decodeSysVar("Fees", data, out)
+}
+
+// MaxRecentBlockhashes is the maximum number of entries of the RecentBlockhashes sysvar.
+const MaxRecentBlockhashes = 150
+
+type RecentBlockhashesEntry struct {
+	Blockhash            Hash
+	LamportsPerSignature uint64
+}
+
+// SysVarRecentBlockhashes is the data of the deprecated RecentBlockhashes sysvar,
+// ordered from the most recent blockhash.
+type SysVarRecentBlockhashes []RecentBlockhashesEntry
+
+func (recent *SysVarRecentBlockhashes) UnmarshalWithDecoder(dec *bin.Decoder) (err error) {
+	length, err := readSysVarLength(dec, MaxRecentBlockhashes)
+	if err != nil {
+		return err
+	}
+	*recent = make(SysVarRecentBlockhashes, length)
+	for i := range *recent {
+		entry := &(*recent)[i]
+		if err = readSysVarHash(dec, &entry.Blockhash); err != nil {
+			return err
+		}
+		if entry.LamportsPerSignature, err = dec.ReadUint64(binary.LittleEndian); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DecodeSysVarRecentBlockhashes decodes the data of the RecentBlockhashes sysvar account.
+func DecodeSysVarRecentBlockhashes(data []byte) (SysVarRecentBlockhashes, error) {
+	var out SysVarRecentBlockhashes
+	return out, decodeSysVar("RecentBlockhashes", data, &out)
+}
+
+// MaxSlotHashes is the maximum number of entries of the SlotHashes sysvar.
+const MaxSlotHashes = 512
+
+type SlotHashEntry struct {
+	Slot uint64
+	Hash Hash
+}
+
+// SysVarSlotHashes is the data of the SlotHashes sysvar,
+// ordered from the most recent slot.
+type SysVarSlotHashes []SlotHashEntry
+
+func (slotHashes *SysVarSlotHashes) UnmarshalWithDecoder(dec *bin.Decoder) (err error) {
+	length, err := readSysVarLength(dec, MaxSlotHashes)
+	if err != nil {
+		return err
+	}
+	*slotHashes = make(SysVarSlotHashes, length)
+	for i := range *slotHashes {
+		entry := &(*slotHashes)[i]
+		if entry.Slot, err = dec.ReadUint64(binary.LittleEndian); err != nil {
+			return err
+		}
+		if err = readSysVarHash(dec, &entry.Hash); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Get returns the hash of the given slot, if it is still in the sysvar.
+func (slotHashes SysVarSlotHashes) Get(slot uint64) (Hash, bool) {
+	for _, entry := range slotHashes {
+		if entry.Slot == slot {
+			return entry.Hash, true
+		}
+	}
+	return Hash{}, false
+}
+
+// DecodeSysVarSlotHashes decodes the data of the SlotHashes sysvar account.
+func DecodeSysVarSlotHashes(data []byte) (SysVarSlotHashes, error) {
+	var out SysVarSlotHashes
+	return out, decodeSysVar("SlotHashes", data, &out)
+}
+
+// SlotHistoryMaxEntries is the number of slots tracked by the SlotHistory sysvar.
+const SlotHistoryMaxEntries = 1024 * 1024
+
+type SlotHistoryCheck int
+
+const (
+	SlotHistoryFuture SlotHistoryCheck = iota
+	SlotHistoryTooOld
+	SlotHistoryFound
+	SlotHistoryNotFound
+)
+
+func (check SlotHistoryCheck) String() string {
+	switch check {
+	case SlotHistoryFuture:
+		return "Future"
+	case SlotHistoryTooOld:
+		return "TooOld"
+	case SlotHistoryFound:
+		return "Found"
+	case SlotHistoryNotFound:
+		return "NotFound"
+	default:
+		return fmt.Sprintf("SlotHistoryCheck(%d)", int(check))
+	}
+}
+
+// SysVarSlotHistory is the data of the SlotHistory sysvar:
+// a bitvector of the slots present over the last SlotHistoryMaxEntries slots.
+type SysVarSlotHistory struct {
+	// Bit i%SlotHistoryMaxEntries of the bitvector is set if slot i is present.
+	Bits []uint64
+	// Number of bits in the bitvector.
+	Len      uint64
+	NextSlot uint64
+}
+
+func (history *SysVarSlotHistory) UnmarshalWithDecoder(dec *bin.Decoder) (err error) {
+	hasBits, err := dec.ReadBool()
+	if err != nil {
+		return err
+	}
+	history.Bits = nil
+	if hasBits {
+		length, err := readSysVarLength(dec, SlotHistoryMaxEntries/64)
+		if err != nil {
+			return err
+		}
+		history.Bits = make([]uint64, length)
+		for i := range history.Bits {
+			if history.Bits[i], err = dec.ReadUint64(binary.LittleEndian); err != nil {
+				return err
+			}
+		}
+	}
+	if history.Len, err = dec.ReadUint64(binary.LittleEndian); err != nil {
+		return err
+	}
+	if history.Len > uint64(len(history.Bits))*64 {
+		return fmt.Errorf("slot history length %d exceeds its %d words", history.Len, len(history.Bits))
+	}
+	history.NextSlot, err = dec.ReadUint64(binary.LittleEndian)
+	return err
+}
+
+// NewestSlot returns the most recent slot of the history, or 0 if the history
+// is empty.
+func (history SysVarSlotHistory) NewestSlot() uint64 {
+	if history.NextSlot == 0 {
+		return 0
+	}
+	return history.NextSlot - 1
+}
+
+// OldestSlot returns the oldest slot still tracked by the history.
+func (history SysVarSlotHistory) OldestSlot() uint64 {
+	if history.NextSlot < SlotHistoryMaxEntries {
+		return 0
+	}
+	return history.NextSlot - SlotHistoryMaxEntries
+}
+
+// Check tells whether the given slot is present in the history.
+func (history SysVarSlotHistory) Check(slot uint64) SlotHistoryCheck {
+	switch {
+	case slot >= history.NextSlot:
+		return SlotHistoryFuture
+	case slot < history.OldestSlot():
+		return SlotHistoryTooOld
+	}
+	bit := slot % SlotHistoryMaxEntries
+	if bit < history.Len && history.Bits[bit/64]&(1<<(bit%64)) != 0 {
+		return SlotHistoryFound
+	}
+	return SlotHistoryNotFound
+}
+
+// DecodeSysVarSlotHistory decodes the data of the SlotHistory sysvar account.
+func DecodeSysVarSlotHistory(data []byte) (*SysVarSlotHistory, error) {
+	out := new(SysVarSlotHistory)
+	return out, decodeSysVar("SlotHistory", data, out)
+}
+
+// MaxStakeHistoryEntries is the maximum number of entries of the StakeHistory sysvar.
+const MaxStakeHistoryEntries = 512
+
+type StakeHistoryEntry struct {
+	Epoch uint64
+	// Effective stake at the epoch, in lamports.
+	Effective uint64
+	// Stake being activated at the epoch, in lamports.
+	Activating uint64
+	// Stake being deactivated at the epoch, in lamports.
+	Deactivating uint64
+}
+
+// SysVarStakeHistory is the data of the StakeHistory sysvar,
+// ordered from the most recent epoch.
+type SysVarStakeHistory []StakeHistoryEntry
+
+func (history *SysVarStakeHistory) UnmarshalWithDecoder(dec *bin.Decoder) (err error) {
+	length, err := readSysVarLength(dec, MaxStakeHistoryEntries)
+	if err != nil {
+		return err
+	}
+	*history = make(SysVarStakeHistory, length)
+	for i := range *history {
+		entry := &(*history)[i]
+		for _, field := range []*uint64{&entry.Epoch, &entry.Effective, &entry.Activating, &entry.Deactivating} {
+			if *field, err = dec.ReadUint64(binary.LittleEndian); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Get returns the stake history entry of the given epoch, if any.
+func (history SysVarStakeHistory) Get(epoch uint64) (*StakeHistoryEntry, bool) {
+	for i := range history {
+		if history[i].Epoch == epoch {
+			return &history[i], true
+		}
+	}
+	return nil, false
+}
+
+// DecodeSysVarStakeHistory decodes the data of the StakeHistory sysvar account.
+func DecodeSysVarStakeHistory(data []byte) (SysVarStakeHistory, error) {
+	var out SysVarStakeHistory
+	return out, decodeSysVar("StakeHistory", data, &out)
+}
+
+// SysVarEpochRewards is the data of the EpochRewards sysvar.
+type SysVarEpochRewards struct {
+	// The starting block height of the rewards distribution in the current epoch.
+	DistributionStartingBlockHeight uint64
+	// Number of partitions in the rewards distribution in the current epoch.
+	NumPartitions uint64
+	// The blockhash of the parent block of the first block in the epoch.
+	ParentBlockhash Hash
+	// The total rewards points calculated for the current epoch.
+	TotalPoints bin.Uint128
+	// The total rewards calculated for the current epoch, in lamports.
+	TotalRewards uint64
+	// The rewards currently distributed for the current epoch, in lamports.
+	DistributedRewards uint64
+	// Whether the rewards period is active.
+	Active bool
+}
+
+func (rewards *SysVarEpochRewards) UnmarshalWithDecoder(dec *bin.Decoder) (err error) {
+	if rewards.DistributionStartingBlockHeight, err = dec.ReadUint64(binary.LittleEndian); err != nil {
+		return err
+	}
+	if rewards.NumPartitions, err = dec.ReadUint64(binary.LittleEndian); err != nil {
+		return err
+	}
+	if err = readSysVarHash(dec, &rewards.ParentBlockhash); err != nil {
+		return err
+	}
+	if rewards.TotalPoints, err = dec.ReadUint128(binary.LittleEndian); err != nil {
+		return err
+	}
+	if rewards.TotalRewards, err = dec.ReadUint64(binary.LittleEndian); err != nil {
+		return err
+	}
+	if rewards.DistributedRewards, err = dec.ReadUint64(binary.LittleEndian); err != nil {
+		return err
+	}
+	rewards.Active, err = dec.ReadBool()
+	return err
+}
+
+// DecodeSysVarEpochRewards decodes the data of the EpochRewards sysvar account.
+func DecodeSysVarEpochRewards(data []byte) (*SysVarEpochRewards, error) {
+	out := new(SysVarEpochRewards)
+	return out, decodeSysVar("EpochRewards", data, out)
+}
+
+// SysVarLastRestartSlot is the data of the LastRestartSlot sysvar.
+type SysVarLastRestartSlot struct {
+	LastRestartSlot uint64
+}
+
+func (restart *SysVarLastRestartSlot) UnmarshalWithDecoder(dec *bin.Decoder) (err error) {
+	restart.LastRestartSlot, err = dec.ReadUint64(binary.LittleEndian)
+	return err
+}
+
+// DecodeSysVarLastRestartSlot decodes the data of the LastRestartSlot sysvar account.
+func DecodeSysVarLastRestartSlot(data []byte) (*SysVarLastRestartSlot, error) {
+	out := new(SysVarLastRestartSlot)
+	return out, decodeSysVar("LastRestartSlot", data, out)
+}
+
+func decodeSysVar(name string, data []byte, out bin.BinaryUnmarshaler) error {
+	if err := out.UnmarshalWithDecoder(bin.NewBinDecoder(data)); err != nil {
+		return fmt.Errorf("unable to decode %s sysvar: %w", name, err)
+	}
+	return nil
+}
+
+func readSysVarLength(dec *bin.Decoder, max uint64) (int, error) {
+	length, err := dec.ReadUint64(binary.LittleEndian)
+	if err != nil {
+		return 0, err
+	}
+	if length > max {
+		return 0, fmt.Errorf("too many entries: %d, max is %d", length, max)
+	}
+	return int(length), nil
+}
+
+func readSysVarHash(dec *bin.Decoder, hash *Hash) error {
+	b, err := dec.ReadNBytes(32)
+	if err != nil {
+		return err
+	}
+	copy(hash[:], b)
+	return nil
+}
@@ -0,0 +1,182 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package solana
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func sysVarData(fields ...interface{}) []byte {
+	buf := new(bytes.Buffer)
+	for _, field := range fields {
+		if err := binary.Write(buf, binary.LittleEndian, field); err != nil {
+			panic(err)
+		}
+	}
+	return buf.Bytes()
+}
+
+func TestDecodeSysVarClock(t *testing.T) {
+	clock, err := DecodeSysVarClock(sysVarData(uint64(250_000_000), int64(1_700_000_000), uint64(578), uint64(579), int64(1_700_100_000)))
+	require.NoError(t, err)
+	require.Equal(t, &SysVarClock{
+		Slot:                250_000_000,
+		EpochStartTimestamp: 1_700_000_000,
+		Epoch:               578,
+		LeaderScheduleEpoch: 579,
+		UnixTimestamp:       1_700_100_000,
+	}, clock)
+
+	_, err = DecodeSysVarClock(sysVarData(uint64(1)))
+	require.Error(t, err)
+}
+
+func TestSysVarRent(t *testing.T) {
+	rent, err := DecodeSysVarRent(sysVarData(uint64(3480), float64(2), uint8(50)))
+	require.NoError(t, err)
+	require.Equal(t, &SysVarRent{LamportsPerByteYear: 3480, ExemptionThreshold: 2, BurnPercent: 50}, rent)
+
+	require.Equal(t, uint64(890_880), rent.MinimumBalance(0))
+	// A token account.
+	require.Equal(t, uint64(2_039_280), rent.MinimumBalance(165))
+	require.True(t, rent.IsExempt(2_039_280, 165))
+	require.False(t, rent.IsExempt(2_039_279, 165))
+}
+
+func TestSysVarEpochSchedule(t *testing.T) {
+	schedule, err := DecodeSysVarEpochSchedule(sysVarData(uint64(432_000), uint64(432_000), true, uint64(14), uint64(524_256)))
+	require.NoError(t, err)
+	require.Equal(t, &SysVarEpochSchedule{
+		SlotsPerEpoch:            432_000,
+		LeaderScheduleSlotOffset: 432_000,
+		Warmup:                   true,
+		FirstNormalEpoch:         14,
+		FirstNormalSlot:          524_256,
+	}, schedule)
+
+	require.Equal(t, uint64(32), schedule.GetSlotsInEpoch(0))
+	require.Equal(t, uint64(64), schedule.GetSlotsInEpoch(1))
+	require.Equal(t, uint64(432_000), schedule.GetSlotsInEpoch(14))
+
+	for _, test := range []struct {
+		slot, epoch, index uint64
+	}{
+		{0, 0, 0},
+		{31, 0, 31},
+		{32, 1, 0},
+		{95, 1, 63},
+		{96, 2, 0},
+		{524_255, 13, 262_143},
+		{524_256, 14, 0},
+		{524_256 + 432_000 + 5, 15, 5},
+	} {
+		epoch, index := schedule.GetEpochAndSlotIndex(test.slot)
+		require.Equal(t, test.epoch, epoch, "slot %d", test.slot)
+		require.Equal(t, test.index, index, "slot %d", test.slot)
+		require.Equal(t, test.slot-test.index, schedule.GetFirstSlotInEpoch(epoch), "slot %d", test.slot)
+	}
+	require.Equal(t, uint64(95), schedule.GetLastSlotInEpoch(1))
+	require.Equal(t, uint64(524_256+432_000-1), schedule.GetLastSlotInEpoch(14))
+}
+
+func TestDecodeSysVarLists(t *testing.T) {
+	keys := newTestKeys(2)
+	hash1 := Hash(keys[0])
+	hash2 := Hash(keys[1])
+
+	fees, err := DecodeSysVarFees(sysVarData(uint64(5000)))
+	require.NoError(t, err)
+	require.Equal(t, uint64(5000), fees.LamportsPerSignature)
+
+	recent, err := DecodeSysVarRecentBlockhashes(sysVarData(uint64(2), hash1, uint64(5000), hash2, uint64(10000)))
+	require.NoError(t, err)
+	require.Equal(t, SysVarRecentBlockhashes{{hash1, 5000}, {hash2, 10000}}, recent)
+
+	slotHashes, err := DecodeSysVarSlotHashes(sysVarData(uint64(2), uint64(101), hash1, uint64(100), hash2))
+	require.NoError(t, err)
+	require.Equal(t, SysVarSlotHashes{{101, hash1}, {100, hash2}}, slotHashes)
+	got, ok := slotHashes.Get(100)
+	require.True(t, ok)
+	require.Equal(t, hash2, got)
+	_, ok = slotHashes.Get(99)
+	require.False(t, ok)
+
+	stakeHistory, err := DecodeSysVarStakeHistory(sysVarData(uint64(1), uint64(577), uint64(1_000), uint64(20), uint64(30)))
+	require.NoError(t, err)
+	entry, ok := stakeHistory.Get(577)
+	require.True(t, ok)
+	require.Equal(t, StakeHistoryEntry{Epoch: 577, Effective: 1_000, Activating: 20, Deactivating: 30}, *entry)
+	_, ok = stakeHistory.Get(576)
+	require.False(t, ok)
+
+	// Lengths are bounded.
+	_, err = DecodeSysVarSlotHashes(sysVarData(uint64(MaxSlotHashes + 1)))
+	require.Error(t, err)
+	_, err = DecodeSysVarStakeHistory(sysVarData(uint64(math.MaxUint64)))
+	require.Error(t, err)
+}
+
+func TestSysVarSlotHistory(t *testing.T) {
+	words := make([]uint64, SlotHistoryMaxEntries/64)
+	words[0] = 1<<0 | 1<<5
+	words[1] = 1 << 0 // slot 64
+	data := sysVarData(uint8(1), uint64(len(words)), words, uint64(SlotHistoryMaxEntries), uint64(100))
+
+	history, err := DecodeSysVarSlotHistory(data)
+	require.NoError(t, err)
+	require.Equal(t, uint64(99), history.NewestSlot())
+	require.Equal(t, uint64(0), history.OldestSlot())
+	require.Equal(t, SlotHistoryFound, history.Check(0))
+	require.Equal(t, SlotHistoryFound, history.Check(5))
+	require.Equal(t, SlotHistoryFound, history.Check(64))
+	require.Equal(t, SlotHistoryNotFound, history.Check(6))
+	require.Equal(t, SlotHistoryFuture, history.Check(100))
+
+	history.NextSlot = SlotHistoryMaxEntries + 10
+	require.Equal(t, SlotHistoryTooOld, history.Check(9))
+	require.Equal(t, SlotHistoryNotFound, history.Check(10))
+	require.Equal(t, SlotHistoryFound, history.Check(SlotHistoryMaxEntries+5))
+
+	// An empty history has no slot yet.
+	empty := SysVarSlotHistory{}
+	require.Equal(t, uint64(0), empty.NewestSlot())
+	require.Equal(t, uint64(0), empty.OldestSlot())
+	require.Equal(t, SlotHistoryFuture, empty.Check(0))
+
+	_, err = DecodeSysVarSlotHistory(sysVarData(uint8(1), uint64(1), uint64(0), uint64(65), uint64(1)))
+	require.Error(t, err)
+}
+
+func TestDecodeSysVarEpochRewards(t *testing.T) {
+	hash := Hash(newTestKeys(1)[0])
+	rewards, err := DecodeSysVarEpochRewards(sysVarData(uint64(300_000_000), uint64(10), hash, uint64(7), uint64(1), uint64(1_000_000), uint64(250_000), true))
+	require.NoError(t, err)
+	require.Equal(t, uint64(300_000_000), rewards.DistributionStartingBlockHeight)
+	require.Equal(t, uint64(10), rewards.NumPartitions)
+	require.Equal(t, hash, rewards.ParentBlockhash)
+	require.Equal(t, "18446744073709551623", rewards.TotalPoints.BigInt().String())
+	require.Equal(t, uint64(1_000_000), rewards.TotalRewards)
+	require.Equal(t, uint64(250_000), rewards.DistributedRewards)
+	require.True(t, rewards.Active)
+
+	restart, err := DecodeSysVarLastRestartSlot(sysVarData(uint64(123)))
+	require.NoError(t, err)
+	require.Equal(t, uint64(123), restart.LastRestartSlot)
+}
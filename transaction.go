@@ -20,6 +20,7 @@ package solana
 import (
 	"bytes"
 	"encoding/base64"
+	"encoding/binary"
 	"fmt"
 	"sort"
 
@@ -138,6 +139,13 @@ type TransactionOption interface {
 type transactionOptions struct {
 	payer         PublicKey
 	addressTables map[PublicKey]PublicKeySlice // [tablePubkey]addresses
+	durableNonce  *durableNonce
+}
+
+type durableNonce struct {
+	nonceAccount   PublicKey
+	nonceAuthority PublicKey
+	nonce          Hash
 }
 
 type transactionOptionFunc func(opts *transactionOptions)
@@ -154,6 +162,55 @@ func TransactionAddressTables(tables map[PublicKey]PublicKeySlice) TransactionOp
 	return transactionOptionFunc(func(opts *transactionOptions) { opts.addressTables = tables })
 }
 
+// TransactionDurableNonce makes the transaction use a durable nonce instead
+// of a recent blockhash: the nonce replaces the recent blockhash, and an
+// AdvanceNonceAccount instruction for the nonce account, signed by the nonce
+// authority, is put first (unless the first instruction already is one).
+//
+// The nonce is the value stored in the nonce account; see
+// system.FetchDurableNonce to fetch it and build this option in one call.
+func TransactionDurableNonce(nonceAccount PublicKey, nonceAuthority PublicKey, nonce Hash) TransactionOption {
+	return transactionOptionFunc(func(opts *transactionOptions) {
+		opts.durableNonce = &durableNonce{
+			nonceAccount:   nonceAccount,
+			nonceAuthority: nonceAuthority,
+			nonce:          nonce,
+		}
+	})
+}
+
+// systemInstructionAdvanceNonceAccount is the ID of the system program's
+// AdvanceNonceAccount instruction.
+const systemInstructionAdvanceNonceAccount uint32 = 4
+
+func newAdvanceNonceAccountInstruction(nonceAccount PublicKey, nonceAuthority PublicKey) Instruction {
+	data := make([]byte, 4)
+	binary.LittleEndian.PutUint32(data, systemInstructionAdvanceNonceAccount)
+	return NewInstruction(
+		SystemProgramID,
+		AccountMetaSlice{
+			Meta(nonceAccount).WRITE(),
+			Meta(SysVarRecentBlockHashesPubkey),
+			Meta(nonceAuthority).SIGNER(),
+		},
+		data,
+	)
+}
+
+// isAdvanceNonceAccountInstruction reports whether the instruction advances
+// the given nonce account.
+func isAdvanceNonceAccountInstruction(instruction Instruction, nonceAccount PublicKey) bool {
+	if !instruction.ProgramID().Equals(SystemProgramID) {
+		return false
+	}
+	data, err := instruction.Data()
+	if err != nil || len(data) < 4 || binary.LittleEndian.Uint32(data) != systemInstructionAdvanceNonceAccount {
+		return false
+	}
+	accounts := instruction.Accounts()
+	return len(accounts) > 0 && accounts[0].PublicKey.Equals(nonceAccount)
+}
+
 var debugNewTransaction = false
 
 type TransactionBuilder struct {
@@ -231,8 +288,30 @@ func NewTransaction(instructions []Instruction, recentBlockHash Hash, opts ...Tr
 		}
 	}
 
+	if nonce := options.durableNonce; nonce != nil {
+		if nonce.nonceAccount.IsZero() || nonce.nonceAuthority.IsZero() {
+			return nil, fmt.Errorf("durable nonce requires the nonce account and the nonce authority")
+		}
+		if !isAdvanceNonceAccountInstruction(instructions[0], nonce.nonceAccount) {
+			instructions = append(
+				[]Instruction{newAdvanceNonceAccountInstruction(nonce.nonceAccount, nonce.nonceAuthority)},
+				instructions...,
+			)
+		}
+		recentBlockHash = nonce.nonce
+	}
+
+	// Iterate the tables in a fixed order, so that an address present in
+	// several tables is always looked up in the same one.
+	addressTablePubKeys := make(PublicKeySlice, 0, len(options.addressTables))
+	for addressTablePubKey := range options.addressTables {
+		addressTablePubKeys = append(addressTablePubKeys, addressTablePubKey)
+	}
+	addressTablePubKeys.Sort()
+
 	addressLookupKeysMap := make(map[PublicKey]addressTablePubkeyWithIndex) // all accounts from tables as map
-	for addressTablePubKey, addressTable := range options.addressTables {
+	for _, addressTablePubKey := range addressTablePubKeys {
+		addressTable := options.addressTables[addressTablePubKey]
 		if len(addressTable) > 256 {
 			return nil, fmt.Errorf("max lookup table index exceeded for %s table", addressTablePubKey)
 		}
@@ -0,0 +1,380 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package solana
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+
+	bin "github.com/gagliardetto/binary"
+)
+
+// TransactionEnvelopeVersion is the version of the envelope encodings.
+const TransactionEnvelopeVersion = 1
+
+// TransactionEnvelope carries a partially-signed transaction between the
+// parties that sign it: the message, the required signers, the signatures
+// collected so far, and optional human-readable labels of the signers.
+//
+// It encodes to JSON, and to a compact base64 string (see ToBase64).
+type TransactionEnvelope struct {
+	Message Message
+
+	// Signers are the signers required by the message, in order.
+	Signers PublicKeySlice
+
+	// Signatures has a signature for each signer;
+	// the signatures not collected yet are zero.
+	Signatures []Signature
+
+	// Labels are optional human-readable labels of the signers.
+	Labels map[PublicKey]string
+}
+
+// NewTransactionEnvelope creates an envelope for the transaction, with the
+// signatures it already has.
+func NewTransactionEnvelope(tx *Transaction) (*TransactionEnvelope, error) {
+	signers := tx.Message.Signers()
+	env := &TransactionEnvelope{
+		Message:    tx.Message,
+		Signers:    signers,
+		Signatures: make([]Signature, len(signers)),
+		Labels:     make(map[PublicKey]string),
+	}
+	switch len(tx.Signatures) {
+	case 0:
+	case len(signers):
+		copy(env.Signatures, tx.Signatures)
+	default:
+		return nil, fmt.Errorf("got %v signers, but %v signatures", len(signers), len(tx.Signatures))
+	}
+	return env, nil
+}
+
+// SetLabel sets the human-readable label of a signer.
+func (env *TransactionEnvelope) SetLabel(signer PublicKey, label string) error {
+	if !env.Signers.Has(signer) {
+		return fmt.Errorf("%s is not a signer of the message", signer)
+	}
+	if env.Labels == nil {
+		env.Labels = make(map[PublicKey]string)
+	}
+	env.Labels[signer] = label
+	return nil
+}
+
+// Transaction returns the transaction, with the signatures collected so far.
+func (env *TransactionEnvelope) Transaction() *Transaction {
+	return &Transaction{
+		Signatures: append([]Signature(nil), env.Signatures...),
+		Message:    env.Message,
+	}
+}
+
+// MissingSigners returns the signers whose signature is not collected yet.
+func (env *TransactionEnvelope) MissingSigners() PublicKeySlice {
+	out := make(PublicKeySlice, 0)
+	for i, signer := range env.Signers {
+		if i >= len(env.Signatures) || env.Signatures[i].IsZero() {
+			out = append(out, signer)
+		}
+	}
+	return out
+}
+
+// IsComplete reports whether all the signatures are collected.
+func (env *TransactionEnvelope) IsComplete() bool {
+	return len(env.MissingSigners()) == 0
+}
+
+// VerifyPartial checks the envelope against its message, and verifies the
+// signatures collected so far; the missing ones are not an error.
+func (env *TransactionEnvelope) VerifyPartial() error {
+	if err := env.check(); err != nil {
+		return err
+	}
+	if env.IsComplete() {
+		return env.Transaction().VerifySignatures()
+	}
+	msg, err := env.Message.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	for i, sig := range env.Signatures {
+		if !sig.IsZero() && !sig.Verify(env.Signers[i], msg) {
+			return fmt.Errorf("invalid signature by %s", env.Signers[i].String())
+		}
+	}
+	return nil
+}
+
+// check verifies that the signers are the ones required by the message.
+func (env *TransactionEnvelope) check() error {
+	signers := env.Message.Signers()
+	if !signers.Equals(env.Signers) {
+		return fmt.Errorf("envelope signers don't match the signers of the message")
+	}
+	if len(env.Signatures) != len(signers) {
+		return fmt.Errorf("got %v signers, but %v signatures", len(signers), len(env.Signatures))
+	}
+	return nil
+}
+
+// Sign adds the signatures of the provided signers that are required by the
+// message; see Transaction.PartialSignWithSigners.
+func (env *TransactionEnvelope) Sign(ctx context.Context, signers ...Signer) error {
+	if err := env.check(); err != nil {
+		return err
+	}
+	tx := env.Transaction()
+	signatures, err := tx.PartialSignWithSigners(ctx, signers...)
+	if err != nil {
+		return err
+	}
+	env.Signatures = signatures
+	return nil
+}
+
+// Merge adds to the envelope the signatures and labels collected in the
+// other envelope, which must be for the same message. Conflicting signatures
+// are an error; for conflicting labels, the envelope's own are kept.
+func (env *TransactionEnvelope) Merge(other *TransactionEnvelope) error {
+	if err := env.check(); err != nil {
+		return err
+	}
+	if err := other.check(); err != nil {
+		return fmt.Errorf("other envelope: %w", err)
+	}
+	msg, err := env.Message.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	otherMsg, err := other.Message.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(msg, otherMsg) {
+		return fmt.Errorf("envelopes are for different messages")
+	}
+
+	merged := append([]Signature(nil), env.Signatures...)
+	for i, sig := range other.Signatures {
+		switch {
+		case sig.IsZero():
+		case merged[i].IsZero():
+			if !sig.Verify(env.Signers[i], msg) {
+				return fmt.Errorf("invalid signature by %s", env.Signers[i].String())
+			}
+			merged[i] = sig
+		case !merged[i].Equals(sig):
+			return fmt.Errorf("conflicting signatures by %s", env.Signers[i].String())
+		}
+	}
+	env.Signatures = merged
+
+	for signer, label := range other.Labels {
+		if _, ok := env.Labels[signer]; !ok {
+			if err := env.SetLabel(signer, label); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+type transactionEnvelopeJSON struct {
+	Version int                             `json:"version"`
+	Message string                          `json:"message"`
+	Signers []transactionEnvelopeSignerJSON `json:"signers"`
+}
+
+type transactionEnvelopeSignerJSON struct {
+	PublicKey PublicKey  `json:"publicKey"`
+	Label     string     `json:"label,omitempty"`
+	Signature *Signature `json:"signature,omitempty"`
+}
+
+func (env *TransactionEnvelope) MarshalJSON() ([]byte, error) {
+	if err := env.check(); err != nil {
+		return nil, err
+	}
+	msg, err := env.Message.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	out := transactionEnvelopeJSON{
+		Version: TransactionEnvelopeVersion,
+		Message: base64.StdEncoding.EncodeToString(msg),
+		Signers: make([]transactionEnvelopeSignerJSON, len(env.Signers)),
+	}
+	for i, signer := range env.Signers {
+		out.Signers[i] = transactionEnvelopeSignerJSON{
+			PublicKey: signer,
+			Label:     env.Labels[signer],
+		}
+		if !env.Signatures[i].IsZero() {
+			sig := env.Signatures[i]
+			out.Signers[i].Signature = &sig
+		}
+	}
+	return json.Marshal(out)
+}
+
+func (env *TransactionEnvelope) UnmarshalJSON(data []byte) error {
+	var in transactionEnvelopeJSON
+	if err := json.Unmarshal(data, &in); err != nil {
+		return err
+	}
+	if in.Version != TransactionEnvelopeVersion {
+		return fmt.Errorf("unsupported envelope version: %d", in.Version)
+	}
+	msg, err := base64.StdEncoding.DecodeString(in.Message)
+	if err != nil {
+		return fmt.Errorf("unable to decode message: %w", err)
+	}
+	decoded := TransactionEnvelope{
+		Signers:    make(PublicKeySlice, len(in.Signers)),
+		Signatures: make([]Signature, len(in.Signers)),
+		Labels:     make(map[PublicKey]string),
+	}
+	if err := decoded.Message.UnmarshalWithDecoder(bin.NewBinDecoder(msg)); err != nil {
+		return fmt.Errorf("unable to decode message: %w", err)
+	}
+	for i, signer := range in.Signers {
+		decoded.Signers[i] = signer.PublicKey
+		if signer.Signature != nil {
+			decoded.Signatures[i] = *signer.Signature
+		}
+		if signer.Label != "" {
+			decoded.Labels[signer.PublicKey] = signer.Label
+		}
+	}
+	if err := decoded.check(); err != nil {
+		return err
+	}
+	*env = decoded
+	return nil
+}
+
+// MarshalBinary encodes the envelope compactly: the version, the transaction
+// in wire format (with zero signatures for the missing ones), then the labels
+// as pairs of signer index and string.
+func (env *TransactionEnvelope) MarshalBinary() ([]byte, error) {
+	if err := env.check(); err != nil {
+		return nil, err
+	}
+	txData, err := env.Transaction().MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	buf := new(bytes.Buffer)
+	encoder := bin.NewBinEncoder(buf)
+	if err := encoder.WriteUint8(TransactionEnvelopeVersion); err != nil {
+		return nil, err
+	}
+	if err := encoder.WriteBytes(txData, false); err != nil {
+		return nil, err
+	}
+	labeled := make([]int, 0, len(env.Labels))
+	for i, signer := range env.Signers {
+		if label, ok := env.Labels[signer]; ok && label != "" {
+			labeled = append(labeled, i)
+		}
+	}
+	if err := encoder.WriteCompactU16(len(labeled)); err != nil {
+		return nil, err
+	}
+	for _, i := range labeled {
+		if err := encoder.WriteUint8(uint8(i)); err != nil {
+			return nil, err
+		}
+		label := env.Labels[env.Signers[i]]
+		if err := encoder.WriteCompactU16(len(label)); err != nil {
+			return nil, err
+		}
+		if err := encoder.WriteBytes([]byte(label), false); err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary decodes an envelope encoded with MarshalBinary.
+func (env *TransactionEnvelope) UnmarshalBinary(data []byte) error {
+	decoder := bin.NewBinDecoder(data)
+	version, err := decoder.ReadUint8()
+	if err != nil {
+		return err
+	}
+	if version != TransactionEnvelopeVersion {
+		return fmt.Errorf("unsupported envelope version: %d", version)
+	}
+	tx := new(Transaction)
+	if err := tx.UnmarshalWithDecoder(decoder); err != nil {
+		return fmt.Errorf("unable to decode transaction: %w", err)
+	}
+	decoded, err := NewTransactionEnvelope(tx)
+	if err != nil {
+		return err
+	}
+	numLabels, err := decoder.ReadCompactU16()
+	if err != nil {
+		return fmt.Errorf("unable to read labels count: %w", err)
+	}
+	for l := 0; l < numLabels; l++ {
+		i, err := decoder.ReadUint8()
+		if err != nil {
+			return err
+		}
+		if int(i) >= len(decoded.Signers) {
+			return fmt.Errorf("label signer index %d out of range", i)
+		}
+		size, err := decoder.ReadCompactU16()
+		if err != nil {
+			return err
+		}
+		label, err := decoder.ReadNBytes(size)
+		if err != nil {
+			return err
+		}
+		decoded.Labels[decoded.Signers[i]] = string(label)
+	}
+	*env = *decoded
+	return nil
+}
+
+// ToBase64 returns the compact encoding of the envelope in base64.
+func (env *TransactionEnvelope) ToBase64() (string, error) {
+	data, err := env.MarshalBinary()
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(data), nil
+}
+
+// TransactionEnvelopeFromBase64 decodes an envelope encoded with ToBase64.
+func TransactionEnvelopeFromBase64(b64 string) (*TransactionEnvelope, error) {
+	data, err := base64.StdEncoding.DecodeString(b64)
+	if err != nil {
+		return nil, err
+	}
+	env := new(TransactionEnvelope)
+	if err := env.UnmarshalBinary(data); err != nil {
+		return nil, err
+	}
+	return env, nil
+}
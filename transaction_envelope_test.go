@@ -0,0 +1,103 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package solana
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransactionEnvelope(t *testing.T) {
+	payer := NewWallet()
+	alice := NewWallet()
+	bob := NewWallet()
+	tx := newTestSignerTransaction(t, payer.PublicKey(), alice.PublicKey(), bob.PublicKey())
+
+	env, err := NewTransactionEnvelope(tx)
+	require.NoError(t, err)
+	require.NoError(t, env.SetLabel(alice.PublicKey(), "alice"))
+	require.NoError(t, env.SetLabel(bob.PublicKey(), "bob"))
+	require.Error(t, env.SetLabel(NewWallet().PublicKey(), "eve"))
+	require.NoError(t, env.Sign(context.Background(), payer))
+	require.Equal(t, PublicKeySlice{alice.PublicKey(), bob.PublicKey()}, env.MissingSigners())
+	require.NoError(t, env.VerifyPartial())
+
+	// Alice gets the envelope as JSON, Bob as base64.
+	data, err := json.Marshal(env)
+	require.NoError(t, err)
+	aliceEnv := new(TransactionEnvelope)
+	require.NoError(t, json.Unmarshal(data, aliceEnv))
+	require.Equal(t, env.Signatures, aliceEnv.Signatures)
+	require.Equal(t, env.Labels, aliceEnv.Labels)
+	require.NoError(t, aliceEnv.Sign(context.Background(), alice))
+
+	b64, err := env.ToBase64()
+	require.NoError(t, err)
+	bobEnv, err := TransactionEnvelopeFromBase64(b64)
+	require.NoError(t, err)
+	require.Equal(t, env.Signers, bobEnv.Signers)
+	require.Equal(t, env.Labels, bobEnv.Labels)
+	require.NoError(t, bobEnv.Sign(context.Background(), bob))
+	require.Equal(t, PublicKeySlice{alice.PublicKey()}, bobEnv.MissingSigners())
+
+	require.NoError(t, env.Merge(aliceEnv))
+	require.NoError(t, env.Merge(bobEnv))
+	require.True(t, env.IsComplete())
+	require.NoError(t, env.VerifyPartial())
+	require.NoError(t, env.Transaction().VerifySignatures())
+}
+
+func TestTransactionEnvelopeMergeErrors(t *testing.T) {
+	payer := NewWallet()
+	alice := NewWallet()
+	tx := newTestSignerTransaction(t, payer.PublicKey(), alice.PublicKey())
+
+	env, err := NewTransactionEnvelope(tx)
+	require.NoError(t, err)
+
+	// A different message.
+	other, err := NewTransactionEnvelope(newTestSignerTransaction(t, payer.PublicKey(), alice.PublicKey()))
+	require.NoError(t, err)
+	other.Message.RecentBlockhash = Hash{1}
+	require.Error(t, env.Merge(other))
+
+	// A forged signature.
+	forged, err := NewTransactionEnvelope(tx)
+	require.NoError(t, err)
+	forged.Signatures[1], err = payer.PrivateKey.Sign([]byte("something else"))
+	require.NoError(t, err)
+	require.Error(t, forged.VerifyPartial())
+	require.Error(t, env.Merge(forged))
+	require.False(t, env.IsComplete())
+	require.Len(t, env.MissingSigners(), 2)
+}
+
+func TestTransactionEnvelopeWithoutSignatures(t *testing.T) {
+	payer := NewWallet()
+	alice := NewWallet()
+	tx := newTestSignerTransaction(t, payer.PublicKey(), alice.PublicKey())
+
+	env := &TransactionEnvelope{
+		Message: tx.Message,
+		Signers: tx.Message.Signers(),
+	}
+	require.Equal(t, PublicKeySlice{payer.PublicKey(), alice.PublicKey()}, env.MissingSigners())
+	require.False(t, env.IsComplete())
+	require.Error(t, env.VerifyPartial())
+	_, err := json.Marshal(env)
+	require.Error(t, err)
+}
@@ -0,0 +1,277 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package solana
+
+import (
+	"errors"
+	"fmt"
+)
+
+const (
+	// PacketDataSize is the maximum size of a serialized transaction:
+	// the IPv6 MTU minus the IP and UDP headers.
+	PacketDataSize = 1280 - 40 - 8
+
+	// MaxTransactionAccountLocks is the maximum number of accounts
+	// (including programs) a transaction can reference.
+	MaxTransactionAccountLocks = 64
+
+	// MaxTransactionComputeUnits is the maximum compute unit limit
+	// of a transaction.
+	MaxTransactionComputeUnits = 1_400_000
+)
+
+var (
+	ErrTransactionTooLarge      = errors.New("transaction too large")
+	ErrTooManyAccountLocks      = errors.New("too many account locks")
+	ErrComputeUnitLimitExceeded = errors.New("compute unit limit exceeded")
+	ErrEmptyInstructionGroup    = errors.New("instruction group is empty")
+)
+
+// InstructionGroup is a list of instructions that must be executed
+// atomically: they are always packed in the same transaction, in order.
+type InstructionGroup struct {
+	Instructions []Instruction
+
+	// ComputeUnits is the estimated compute units used by all the
+	// instructions of the group; zero if unknown, in which case the group
+	// does not count towards the compute unit limit.
+	ComputeUnits uint32
+}
+
+// NewInstructionGroup creates a group of instructions that must be packed
+// in the same transaction.
+func NewInstructionGroup(computeUnits uint32, instructions ...Instruction) InstructionGroup {
+	return InstructionGroup{
+		Instructions: instructions,
+		ComputeUnits: computeUnits,
+	}
+}
+
+// InstructionGroups wraps each instruction in its own group,
+// with an unknown compute units cost.
+func InstructionGroups(instructions ...Instruction) []InstructionGroup {
+	groups := make([]InstructionGroup, len(instructions))
+	for i, instruction := range instructions {
+		groups[i] = NewInstructionGroup(0, instruction)
+	}
+	return groups
+}
+
+// PackOptions configures PackInstructions.
+type PackOptions struct {
+	// Payer is the fee payer of all the transactions.
+	// If not set, defaults to the first signer of the first instruction
+	// of each transaction's first group; the Prefix is not considered.
+	Payer PublicKey
+
+	// AddressTables are the lookup tables used to compile the transactions.
+	AddressTables map[PublicKey]PublicKeySlice
+
+	// Prefix are instructions to put first in every transaction,
+	// e.g. the compute budget instructions.
+	Prefix []Instruction
+
+	// PrefixComputeUnits is the estimated compute units used by the Prefix.
+	PrefixComputeUnits uint32
+
+	// MaxSize defaults to PacketDataSize.
+	MaxSize int
+
+	// MaxAccountLocks defaults to MaxTransactionAccountLocks.
+	MaxAccountLocks int
+
+	// MaxComputeUnits defaults to MaxTransactionComputeUnits.
+	MaxComputeUnits uint32
+}
+
+// PackedTransaction is a transaction of a TransactionPackPlan.
+type PackedTransaction struct {
+	// Groups are the indexes of the instruction groups in the transaction.
+	Groups []int
+
+	// Instructions are the instructions of the transaction,
+	// the prefix included.
+	Instructions []Instruction
+
+	// ComputeUnits is the sum of the known compute units of the instructions.
+	ComputeUnits uint32
+
+	// Size is the size of the serialized transaction, signatures included.
+	Size int
+
+	// AccountLocks is the number of accounts referenced by the transaction.
+	AccountLocks int
+
+	// Payer is the fee payer of the transaction.
+	Payer PublicKey
+}
+
+// UnpackableGroup is an instruction group that doesn't fit in a transaction
+// even on its own.
+type UnpackableGroup struct {
+	// Index is the index of the instruction group.
+	Index int
+	Err   error
+}
+
+// TransactionPackPlan is the result of PackInstructions.
+type TransactionPackPlan struct {
+	Transactions []*PackedTransaction
+	Unpackable   []UnpackableGroup
+
+	options []TransactionOption
+}
+
+// BuildTransactions creates the planned transactions with the provided
+// recent blockhash.
+func (plan *TransactionPackPlan) BuildTransactions(recentBlockHash Hash) ([]*Transaction, error) {
+	out := make([]*Transaction, 0, len(plan.Transactions))
+	for i, packed := range plan.Transactions {
+		options := append([]TransactionOption{TransactionPayer(packed.Payer)}, plan.options...)
+		tx, err := NewTransaction(packed.Instructions, recentBlockHash, options...)
+		if err != nil {
+			return nil, fmt.Errorf("unable to build transaction %d: %w", i, err)
+		}
+		out = append(out, tx)
+	}
+	return out, nil
+}
+
+// PackInstructions packs the instruction groups into as few transactions as
+// possible, keeping them in order: every transaction stays within the packet
+// size, account locks and compute unit limits. As the groups keep their
+// order, filling each transaction before starting the next one gives the
+// fewest transactions.
+//
+// Groups that cannot fit in a transaction even on their own are reported
+// in the plan's Unpackable list, and left out of the transactions.
+func PackInstructions(groups []InstructionGroup, opts *PackOptions) *TransactionPackPlan {
+	if opts == nil {
+		opts = &PackOptions{}
+	}
+	packer := transactionPacker{
+		opts:            opts,
+		maxSize:         opts.MaxSize,
+		maxAccountLocks: opts.MaxAccountLocks,
+		maxComputeUnits: opts.MaxComputeUnits,
+	}
+	if packer.maxSize <= 0 {
+		packer.maxSize = PacketDataSize
+	}
+	if packer.maxAccountLocks <= 0 {
+		packer.maxAccountLocks = MaxTransactionAccountLocks
+	}
+	if packer.maxComputeUnits == 0 {
+		packer.maxComputeUnits = MaxTransactionComputeUnits
+	}
+	if len(opts.AddressTables) > 0 {
+		packer.txOpts = append(packer.txOpts, TransactionAddressTables(opts.AddressTables))
+	}
+
+	plan := &TransactionPackPlan{
+		options: packer.txOpts,
+	}
+	var current *PackedTransaction
+	for index, group := range groups {
+		if len(group.Instructions) == 0 {
+			plan.Unpackable = append(plan.Unpackable, UnpackableGroup{Index: index, Err: ErrEmptyInstructionGroup})
+			continue
+		}
+		if current != nil {
+			next, err := packer.add(current, index, group)
+			if err == nil {
+				current = next
+				continue
+			}
+		}
+		// Only close the current transaction if the group fits on its own:
+		// an unpackable group must not cut the following groups off.
+		next, err := packer.add(packer.empty(), index, group)
+		if err != nil {
+			plan.Unpackable = append(plan.Unpackable, UnpackableGroup{Index: index, Err: err})
+			continue
+		}
+		if current != nil {
+			plan.Transactions = append(plan.Transactions, current)
+		}
+		current = next
+	}
+	if current != nil {
+		plan.Transactions = append(plan.Transactions, current)
+	}
+	return plan
+}
+
+type transactionPacker struct {
+	opts            *PackOptions
+	txOpts          []TransactionOption
+	maxSize         int
+	maxAccountLocks int
+	maxComputeUnits uint32
+}
+
+func (packer *transactionPacker) empty() *PackedTransaction {
+	return &PackedTransaction{
+		Instructions: append([]Instruction(nil), packer.opts.Prefix...),
+		ComputeUnits: packer.opts.PrefixComputeUnits,
+		Payer:        packer.opts.Payer,
+	}
+}
+
+// add returns a copy of the packed transaction with the group added,
+// or an error if the result doesn't fit in a transaction.
+func (packer *transactionPacker) add(packed *PackedTransaction, index int, group InstructionGroup) (*PackedTransaction, error) {
+	next := &PackedTransaction{
+		Groups:       append(append([]int(nil), packed.Groups...), index),
+		Instructions: append(append([]Instruction(nil), packed.Instructions...), group.Instructions...),
+		ComputeUnits: packed.ComputeUnits + group.ComputeUnits,
+		Payer:        packed.Payer,
+	}
+	if next.Payer.IsZero() {
+		// The first group of the transaction provides the fee payer:
+		// the prefix, e.g. compute budget instructions, may have no signers.
+		for _, account := range group.Instructions[0].Accounts() {
+			if account.IsSigner {
+				next.Payer = account.PublicKey
+				break
+			}
+		}
+		if next.Payer.IsZero() {
+			return nil, errors.New("cannot determine fee payer: the first instruction of the group has no signer")
+		}
+	}
+	if next.ComputeUnits < packed.ComputeUnits || next.ComputeUnits > packer.maxComputeUnits {
+		return nil, ErrComputeUnitLimitExceeded
+	}
+
+	options := append([]TransactionOption{TransactionPayer(next.Payer)}, packer.txOpts...)
+	tx, err := NewTransaction(next.Instructions, Hash{}, options...)
+	if err != nil {
+		return nil, err
+	}
+	next.AccountLocks = len(tx.Message.AccountKeys) + tx.Message.NumLookups()
+	if next.AccountLocks > packer.maxAccountLocks {
+		return nil, fmt.Errorf("%w: %d accounts", ErrTooManyAccountLocks, next.AccountLocks)
+	}
+	next.Size, err = serializedTransactionSize(&tx.Message)
+	if err != nil {
+		return nil, err
+	}
+	if next.Size > packer.maxSize {
+		return nil, fmt.Errorf("%w: %d bytes", ErrTransactionTooLarge, next.Size)
+	}
+	return next, nil
+}
@@ -0,0 +1,171 @@
+// Copyright 2021 github.com/gagliardetto
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package solana
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTestTransfer(from PublicKey) Instruction {
+	return &testTransactionInstructions{
+		accounts: []*AccountMeta{
+			Meta(from).WRITE().SIGNER(),
+			Meta(NewWallet().PublicKey()).WRITE(),
+		},
+		data:      []byte{2, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0},
+		programID: SystemProgramID,
+	}
+}
+
+func TestPackInstructions(t *testing.T) {
+	payer := NewWallet().PublicKey()
+	instructions := make([]Instruction, 100)
+	for i := range instructions {
+		instructions[i] = newTestTransfer(payer)
+	}
+
+	plan := PackInstructions(InstructionGroups(instructions...), &PackOptions{Payer: payer})
+	require.Empty(t, plan.Unpackable)
+	require.Greater(t, len(plan.Transactions), 1)
+
+	next := 0
+	for _, packed := range plan.Transactions {
+		require.LessOrEqual(t, packed.Size, PacketDataSize)
+		require.LessOrEqual(t, packed.AccountLocks, MaxTransactionAccountLocks)
+		for _, index := range packed.Groups {
+			require.Equal(t, next, index)
+			next++
+		}
+	}
+	require.Equal(t, len(instructions), next)
+
+	// Each transaction is full: the first group of the next one doesn't fit.
+	for i := 0; i < len(plan.Transactions)-1; i++ {
+		packed := plan.Transactions[i]
+		withNext := append(append([]Instruction(nil), packed.Instructions...), instructions[plan.Transactions[i+1].Groups[0]])
+		tx, err := NewTransaction(withNext, Hash{}, TransactionPayer(payer))
+		require.NoError(t, err)
+		data, err := tx.Message.MarshalBinary()
+		require.NoError(t, err)
+		size := 1 + SignatureLength + len(data)
+		require.True(t, size > PacketDataSize || len(tx.Message.AccountKeys) > MaxTransactionAccountLocks)
+	}
+
+	txs, err := plan.BuildTransactions(Hash{})
+	require.NoError(t, err)
+	require.Len(t, txs, len(plan.Transactions))
+	for i, tx := range txs {
+		require.Len(t, tx.Message.Instructions, len(plan.Transactions[i].Instructions))
+		require.Equal(t, payer, tx.Message.AccountKeys[0])
+	}
+}
+
+func TestPackInstructionsGroupsAndLimits(t *testing.T) {
+	payer := NewWallet().PublicKey()
+	tooLarge := &testTransactionInstructions{
+		accounts:  []*AccountMeta{Meta(payer).WRITE().SIGNER()},
+		data:      make([]byte, PacketDataSize),
+		programID: SystemProgramID,
+	}
+	prefix := &testTransactionInstructions{
+		accounts:  []*AccountMeta{},
+		data:      []byte{2, 0, 0, 0, 0},
+		programID: MustPublicKeyFromBase58("ComputeBudget111111111111111111111111111111"),
+	}
+
+	groups := []InstructionGroup{
+		NewInstructionGroup(600_000, newTestTransfer(payer), newTestTransfer(payer)),
+		NewInstructionGroup(600_000, newTestTransfer(payer)),
+		NewInstructionGroup(0, tooLarge),
+		NewInstructionGroup(1_500_000, newTestTransfer(payer)),
+		NewInstructionGroup(300_000, newTestTransfer(payer), newTestTransfer(payer)),
+		{},
+	}
+	plan := PackInstructions(groups, &PackOptions{
+		Payer:              payer,
+		Prefix:             []Instruction{prefix},
+		PrefixComputeUnits: 150,
+	})
+
+	require.Len(t, plan.Unpackable, 3)
+	require.Equal(t, 2, plan.Unpackable[0].Index)
+	require.ErrorIs(t, plan.Unpackable[0].Err, ErrTransactionTooLarge)
+	require.Equal(t, 3, plan.Unpackable[1].Index)
+	require.ErrorIs(t, plan.Unpackable[1].Err, ErrComputeUnitLimitExceeded)
+	require.Equal(t, 5, plan.Unpackable[2].Index)
+	require.ErrorIs(t, plan.Unpackable[2].Err, ErrEmptyInstructionGroup)
+
+	require.Len(t, plan.Transactions, 2)
+	require.Equal(t, []int{0, 1}, plan.Transactions[0].Groups)
+	require.Equal(t, uint32(1_200_150), plan.Transactions[0].ComputeUnits)
+	require.Len(t, plan.Transactions[0].Instructions, 4)
+	require.Equal(t, Instruction(prefix), plan.Transactions[0].Instructions[0])
+	require.Equal(t, []int{4}, plan.Transactions[1].Groups)
+	require.Len(t, plan.Transactions[1].Instructions, 3)
+}
+
+func TestPackInstructionsPrefixWithoutPayer(t *testing.T) {
+	payers := newTestKeys(2)
+	setComputeUnitLimit := NewInstruction(ComputeBudget, nil, []byte{2, 0x40, 0x0d, 0x03, 0x00})
+	noSigner := NewInstruction(MemoProgramID, AccountMetaSlice{Meta(payers[0])}, []byte("memo"))
+
+	groups := []InstructionGroup{
+		// Nothing can pay for a transaction starting with this group.
+		NewInstructionGroup(0, noSigner),
+		NewInstructionGroup(800_000, newTestTransfer(payers[0])),
+		NewInstructionGroup(800_000, newTestTransfer(payers[1])),
+		// Paid by the payer of the transaction it joins.
+		NewInstructionGroup(0, noSigner),
+	}
+	plan := PackInstructions(groups, &PackOptions{
+		Prefix: []Instruction{setComputeUnitLimit},
+	})
+
+	// Each transaction is paid by the first signer of its first group, not of the prefix.
+	require.Len(t, plan.Transactions, 2)
+	require.Equal(t, payers[0], plan.Transactions[0].Payer)
+	require.Equal(t, payers[1], plan.Transactions[1].Payer)
+	require.Equal(t, []int{2, 3}, plan.Transactions[1].Groups)
+	require.Len(t, plan.Unpackable, 1)
+	require.Equal(t, 0, plan.Unpackable[0].Index)
+
+	txs, err := plan.BuildTransactions(Hash{})
+	require.NoError(t, err)
+	require.Len(t, txs, 2)
+	for i, tx := range txs {
+		require.Equal(t, payers[i], tx.Message.AccountKeys[0])
+		require.Equal(t, ComputeBudget, tx.Message.AccountKeys[tx.Message.Instructions[0].ProgramIDIndex])
+	}
+}
+
+func TestPackInstructionsUnpackableKeepsTransactionOpen(t *testing.T) {
+	payer := NewWallet().PublicKey()
+	tooLarge := NewInstruction(MemoProgramID, AccountMetaSlice{Meta(payer).SIGNER()}, make([]byte, 2000))
+
+	groups := []InstructionGroup{
+		NewInstructionGroup(0, newTestTransfer(payer)),
+		NewInstructionGroup(0, tooLarge),
+		NewInstructionGroup(0, newTestTransfer(payer)),
+	}
+	plan := PackInstructions(groups, &PackOptions{Payer: payer})
+
+	require.Len(t, plan.Unpackable, 1)
+	require.Equal(t, 1, plan.Unpackable[0].Index)
+	require.ErrorIs(t, plan.Unpackable[0].Err, ErrTransactionTooLarge)
+	require.Len(t, plan.Transactions, 1)
+	require.Equal(t, []int{0, 2}, plan.Transactions[0].Groups)
+}
@@ -111,6 +111,53 @@ func TestNewTransaction(t *testing.T) {
 	})
 }
 
+func TestNewTransactionWithDurableNonce(t *testing.T) {
+	payer := MustPublicKeyFromBase58("9hFtYBYmBJCVguRYs9pBTWKYAFoKfjYR7zBPpEkVsmD")
+	nonceAccount := MustPublicKeyFromBase58("6FzXPEhCJoBx7Zw3SN9qhekHemd6E2b8kVguitmVAngW")
+	nonce := MustHashFromBase58("A9QnpgfhCkmiBSjgBuWk76Wo3HxzxvDopUq9x6UUMmjn")
+	blockhash := MustHashFromBase58("AnL7vVGidfdxZBFqkxLvVwXgW9pDSZC5kK6d5kyRaXEa")
+
+	instructions := []Instruction{
+		&testTransactionInstructions{
+			accounts: []*AccountMeta{
+				{PublicKey: payer, IsSigner: true, IsWritable: true},
+			},
+			data:      []byte{0xaa, 0xbb},
+			programID: MustPublicKeyFromBase58("Vote111111111111111111111111111111111111111"),
+		},
+	}
+
+	trx, err := NewTransaction(instructions, blockhash, TransactionDurableNonce(nonceAccount, payer, nonce))
+	require.NoError(t, err)
+
+	assert.Equal(t, nonce, trx.Message.RecentBlockhash)
+	assert.Equal(t, payer, trx.Message.AccountKeys[0])
+	require.Len(t, trx.Message.Instructions, 2)
+
+	advance := trx.Message.Instructions[0]
+	assert.Equal(t, SystemProgramID, trx.Message.AccountKeys[advance.ProgramIDIndex])
+	assert.Equal(t, Base58([]byte{4, 0, 0, 0}), advance.Data)
+	require.Len(t, advance.Accounts, 3)
+	assert.Equal(t, nonceAccount, trx.Message.AccountKeys[advance.Accounts[0]])
+	assert.Equal(t, SysVarRecentBlockHashesPubkey, trx.Message.AccountKeys[advance.Accounts[1]])
+	assert.Equal(t, payer, trx.Message.AccountKeys[advance.Accounts[2]])
+	writable, err := trx.Message.IsWritable(nonceAccount)
+	require.NoError(t, err)
+	assert.True(t, writable)
+
+	// An existing AdvanceNonceAccount instruction is not duplicated.
+	again, err := NewTransaction(
+		[]Instruction{newAdvanceNonceAccountInstruction(nonceAccount, payer), instructions[0]},
+		blockhash,
+		TransactionDurableNonce(nonceAccount, payer, nonce),
+	)
+	require.NoError(t, err)
+	assert.Len(t, again.Message.Instructions, 2)
+
+	_, err = NewTransaction(instructions, blockhash, TransactionDurableNonce(PublicKey{}, payer, nonce))
+	require.Error(t, err)
+}
+
 func TestPartialSignTransaction(t *testing.T) {
 	signers := []PrivateKey{
 		NewWallet().PrivateKey,
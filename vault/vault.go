@@ -104,6 +104,26 @@ func (v *Vault) AddPrivateKey(privateKey solana.PrivateKey) solana.PublicKey {
 	return privateKey.PublicKey()
 }
 
+// Signers returns a solana.Signer for each PrivateKey in the Vault's KeyBag.
+func (v *Vault) Signers() []solana.Signer {
+	out := make([]solana.Signer, len(v.KeyBag))
+	for i, privateKey := range v.KeyBag {
+		out[i] = privateKey
+	}
+	return out
+}
+
+// Signer returns the solana.Signer of the provided public key, if its
+// PrivateKey is in the Vault's KeyBag.
+func (v *Vault) Signer(pubkey solana.PublicKey) (solana.Signer, bool) {
+	for _, privateKey := range v.KeyBag {
+		if privateKey.PublicKey().Equals(pubkey) {
+			return privateKey, true
+		}
+	}
+	return nil, false
+}
+
 // PrintPublicKeys prints a PublicKey corresponding to each PrivateKey in the Vault's
 // KeyBag.
 func (v *Vault) PrintPublicKeys() {